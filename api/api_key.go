@@ -0,0 +1,20 @@
+package api
+
+import "time"
+
+// APIKey authenticates requests to the api. Only a hash of the key is stored so Key is only set when the key is minted
+type APIKey struct {
+	ID   string  `json:"id"`
+	Name string  `json:"name"`
+	Key  *string `json:"key,omitempty"`
+	// Prefix is the start of the key to recognize it by
+	Prefix string   `json:"prefix"`
+	Scopes []string `json:"scopes"`
+	// RateLimit is how many requests per second the key is allowed on average, Burst is how many it can make at once
+	RateLimit  float64    `json:"rate_limit"`
+	Burst      int        `json:"burst"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  *time.Time `json:"updated_at"`
+}
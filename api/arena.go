@@ -8,6 +8,9 @@ type Arena struct {
 	City       *string    `json:"city"`
 	State      *string    `json:"state"`
 	Country    string     `json:"country"`
+	Timezone   *string    `json:"timezone"`
+	Latitude   *float64   `json:"latitude"`
+	Longitude  *float64   `json:"longitude"`
 	NBAArenaID int        `json:"nba_arena_id"`
 	CreatedAt  time.Time  `json:"created_at"`
 	UpdatedAt  *time.Time `json:"updated_at"`
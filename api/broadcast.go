@@ -0,0 +1,24 @@
+package api
+
+import "time"
+
+type Broadcaster struct {
+	ID               string     `json:"id"`
+	Display          string     `json:"display"`
+	Abbreviation     string     `json:"abbreviation"`
+	RegionID         *int       `json:"region_id"`
+	NBABroadcasterID int        `json:"nba_broadcaster_id"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        *time.Time `json:"updated_at"`
+}
+
+type GameBroadcast struct {
+	ID                string      `json:"id"`
+	GameID            string      `json:"game_id"`
+	Broadcaster       Broadcaster `json:"broadcaster"`
+	Scope             string      `json:"scope"`
+	Media             string      `json:"media"`
+	TapeDelayComments *string     `json:"tape_delay_comments"`
+	CreatedAt         time.Time   `json:"created_at"`
+	UpdatedAt         *time.Time  `json:"updated_at"`
+}
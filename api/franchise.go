@@ -3,25 +3,57 @@ package api
 import "time"
 
 type Franchise struct {
-	ID                 string
-	CreatedAt          time.Time
-	UpdatedAt          *time.Time
-	LeagueID           string
-	NBATeamID          int
-	City               string
-	State              string
-	Country            string
-	Name               string
-	Nickname           string
-	StartYear          int
-	EndYear            int
-	Years              int
-	Games              int
-	Wins               int
-	Losses             int
-	PlayoffAppearances int
-	DivisionTitles     int
-	ConferenceTitles   int
-	LeagueTitles       int
-	Active             bool
+	ID                 string     `json:"id"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          *time.Time `json:"updated_at"`
+	LeagueID           string     `json:"league_id"`
+	NBATeamID          int        `json:"nba_team_id"`
+	City               string     `json:"city"`
+	State              string     `json:"state"`
+	Country            string     `json:"country"`
+	Name               string     `json:"name"`
+	Nickname           string     `json:"nickname"`
+	StartYear          int        `json:"start_year"`
+	EndYear            int        `json:"end_year"`
+	Years              int        `json:"years"`
+	Games              int        `json:"games"`
+	Wins               int        `json:"wins"`
+	Losses             int        `json:"losses"`
+	PlayoffAppearances int        `json:"playoff_appearances"`
+	DivisionTitles     int        `json:"division_titles"`
+	ConferenceTitles   int        `json:"conference_titles"`
+	LeagueTitles       int        `json:"league_titles"`
+	Active             bool       `json:"active"`
+}
+
+type FranchiseTimeline struct {
+	Franchise Franchise         `json:"franchise"`
+	Eras      []FranchiseEra    `json:"eras"`
+	Seasons   []FranchiseSeason `json:"seasons"`
+	// Titles are the start years of the seasons the franchise won the finals
+	Titles []int `json:"titles"`
+}
+
+// FranchiseEra is a stretch of consecutive seasons a franchise played under the same city and name
+type FranchiseEra struct {
+	City      string `json:"city"`
+	Name      string `json:"name"`
+	StartYear int    `json:"start_year"`
+	EndYear   int    `json:"end_year"`
+	Seasons   int    `json:"seasons"`
+}
+
+type FranchiseSeason struct {
+	SeasonStartYear int    `json:"season_start_year"`
+	TeamID          string `json:"team_id"`
+	City            string `json:"city"`
+	Name            string `json:"name"`
+	Wins            int    `json:"wins"`
+	Losses          int    `json:"losses"`
+	PlayoffWins     int    `json:"playoff_wins"`
+	PlayoffLosses   int    `json:"playoff_losses"`
+	// PlayoffRound is the deepest playoff round reached, nil when the franchise missed the playoffs
+	PlayoffRound  *int    `json:"playoff_round"`
+	PlayoffResult *string `json:"playoff_result"`
+	Champion      bool    `json:"champion"`
 }
@@ -21,6 +21,10 @@ type Game struct {
 	StartTime           time.Time  `json:"start_time"`
 	EndTime             *time.Time `json:"end_time"`
 	NBAGameID           string     `json:"nba_game_id"`
-	CreatedAt           time.Time  `json:"created_at"`
-	UpdatedAt           *time.Time `json:"updated_at"`
+	// Source is where the game's data came from, games backfilled from stats.nba.com game logs have no arena, officials or period details
+	Source              string           `json:"source"`
+	HomeScheduleContext *ScheduleContext `json:"home_schedule_context"`
+	AwayScheduleContext *ScheduleContext `json:"away_schedule_context"`
+	CreatedAt           time.Time        `json:"created_at"`
+	UpdatedAt           *time.Time       `json:"updated_at"`
 }
@@ -0,0 +1,81 @@
+package api
+
+import "time"
+
+type GameRuns struct {
+	GameID         string           `json:"game_id"`
+	ScoringRuns    []ScoringRun     `json:"scoring_runs"`
+	LeadChanges    []GameScoreEvent `json:"lead_changes"`
+	Ties           []GameScoreEvent `json:"ties"`
+	ClutchSegments []ClutchSegment  `json:"clutch_segments"`
+}
+
+type ScoringRun struct {
+	ID                     string     `json:"id"`
+	GameID                 string     `json:"game_id"`
+	TeamID                 *string    `json:"team_id"`
+	Points                 int        `json:"points"`
+	StartActionNumber      int        `json:"start_action_number"`
+	StartPeriod            int        `json:"start_period"`
+	StartClockTenthSeconds *int       `json:"start_clock_tenth_seconds"`
+	EndActionNumber        int        `json:"end_action_number"`
+	EndPeriod              int        `json:"end_period"`
+	EndClockTenthSeconds   *int       `json:"end_clock_tenth_seconds"`
+	CreatedAt              time.Time  `json:"created_at"`
+	UpdatedAt              *time.Time `json:"updated_at"`
+}
+
+type GameScoreEvent struct {
+	ID                string     `json:"id"`
+	GameID            string     `json:"game_id"`
+	Type              string     `json:"type"`
+	ActionNumber      int        `json:"action_number"`
+	Period            int        `json:"period"`
+	ClockTenthSeconds *int       `json:"clock_tenth_seconds"`
+	HomeScore         int        `json:"home_score"`
+	AwayScore         int        `json:"away_score"`
+	LeadingTeamID     *string    `json:"leading_team_id"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         *time.Time `json:"updated_at"`
+}
+
+type ClutchSegment struct {
+	ID                     string     `json:"id"`
+	GameID                 string     `json:"game_id"`
+	Period                 int        `json:"period"`
+	StartActionNumber      int        `json:"start_action_number"`
+	StartClockTenthSeconds *int       `json:"start_clock_tenth_seconds"`
+	EndActionNumber        int        `json:"end_action_number"`
+	EndClockTenthSeconds   *int       `json:"end_clock_tenth_seconds"`
+	CreatedAt              time.Time  `json:"created_at"`
+	UpdatedAt              *time.Time `json:"updated_at"`
+}
+
+type PlayerClutchStats struct {
+	PlayerID string                  `json:"player_id"`
+	Games    int                     `json:"games"`
+	Totals   ClutchStatTotals        `json:"totals"`
+	GameLogs []PlayerGameClutchStats `json:"game_logs"`
+}
+
+type ClutchStatTotals struct {
+	Points                 int `json:"points"`
+	FieldGoalsMade         int `json:"field_goals_made"`
+	FieldGoalsAttempted    int `json:"field_goals_attempted"`
+	ThreePointersMade      int `json:"three_pointers_made"`
+	ThreePointersAttempted int `json:"three_pointers_attempted"`
+	FreeThrowsMade         int `json:"free_throws_made"`
+	FreeThrowsAttempted    int `json:"free_throws_attempted"`
+	Rebounds               int `json:"rebounds"`
+	Turnovers              int `json:"turnovers"`
+}
+
+type PlayerGameClutchStats struct {
+	ID        string     `json:"id"`
+	GameID    string     `json:"game_id"`
+	PlayerID  string     `json:"player_id"`
+	TeamID    *string    `json:"team_id"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+	ClutchStatTotals
+}
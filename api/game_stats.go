@@ -0,0 +1,52 @@
+package api
+
+// PlayerGameStats is a player's line in a game
+type PlayerGameStats struct {
+	GameID                 string `json:"game_id"`
+	TeamID                 string `json:"team_id"`
+	PlayerID               string `json:"player_id"`
+	TimePlayedSeconds      *int   `json:"time_played_seconds"`
+	Points                 *int   `json:"points"`
+	Assists                *int   `json:"assists"`
+	Turnovers              *int   `json:"turnovers"`
+	Steals                 *int   `json:"steals"`
+	Blocks                 *int   `json:"blocks"`
+	ThreePointersAttempted *int   `json:"three_pointers_attempted"`
+	ThreePointersMade      *int   `json:"three_pointers_made"`
+	FieldGoalsAttempted    *int   `json:"field_goals_attempted"`
+	FieldGoalsMade         *int   `json:"field_goals_made"`
+	FreeThrowsAttempted    *int   `json:"free_throws_attempted"`
+	FreeThrowsMade         *int   `json:"free_throws_made"`
+	ReboundsOffensive      *int   `json:"rebounds_offensive"`
+	ReboundsDefensive      *int   `json:"rebounds_defensive"`
+	ReboundsTotal          *int   `json:"rebounds_total"`
+	FoulsPersonal          *int   `json:"fouls_personal"`
+	PlusMinus              *int   `json:"plus_minus"`
+}
+
+// TeamGameStats is a team's totals in a game
+type TeamGameStats struct {
+	GameID                 string `json:"game_id"`
+	TeamID                 string `json:"team_id"`
+	Points                 *int   `json:"points"`
+	PointsAgainst          *int   `json:"points_against"`
+	Assists                *int   `json:"assists"`
+	Turnovers              *int   `json:"turnovers"`
+	Steals                 *int   `json:"steals"`
+	Blocks                 *int   `json:"blocks"`
+	ThreePointersAttempted *int   `json:"three_pointers_attempted"`
+	ThreePointersMade      *int   `json:"three_pointers_made"`
+	FieldGoalsAttempted    *int   `json:"field_goals_attempted"`
+	FieldGoalsMade         *int   `json:"field_goals_made"`
+	FreeThrowsAttempted    *int   `json:"free_throws_attempted"`
+	FreeThrowsMade         *int   `json:"free_throws_made"`
+	ReboundsOffensive      *int   `json:"rebounds_offensive"`
+	ReboundsDefensive      *int   `json:"rebounds_defensive"`
+	ReboundsTotal          *int   `json:"rebounds_total"`
+	FoulsPersonal          *int   `json:"fouls_personal"`
+	FastBreakPoints        *int   `json:"fast_break_points"`
+	PointsInPaint          *int   `json:"points_in_paint"`
+	SecondChancePoints     *int   `json:"second_chance_points"`
+	PointsOffTurnovers     *int   `json:"points_off_turnovers"`
+	BiggestLead            *int   `json:"biggest_lead"`
+}
@@ -0,0 +1,62 @@
+package api
+
+import "time"
+
+// HeadToHead is a team's history against an opponent, games played by earlier incarnations of either franchise are included
+type HeadToHead struct {
+	TeamID          string                     `json:"team_id"`
+	OpponentID      string                     `json:"opponent_id"`
+	AllTime         HeadToHeadRecord           `json:"all_time"`
+	RegularSeason   HeadToHeadRecord           `json:"regular_season"`
+	Playoffs        HeadToHeadRecord           `json:"playoffs"`
+	Seasons         []HeadToHeadSeason         `json:"seasons"`
+	PlayoffMeetings []HeadToHeadPlayoffMeeting `json:"playoff_meetings"`
+	CurrentSeason   *HeadToHeadSeasonSeries    `json:"current_season"`
+}
+
+// HeadToHeadRecord is from the perspective of the team, margins are the team's points minus the opponent's
+type HeadToHeadRecord struct {
+	Games             int      `json:"games"`
+	Wins              int      `json:"wins"`
+	Losses            int      `json:"losses"`
+	HomeWins          int      `json:"home_wins"`
+	HomeLosses        int      `json:"home_losses"`
+	AwayWins          int      `json:"away_wins"`
+	AwayLosses        int      `json:"away_losses"`
+	AverageMargin     *float64 `json:"average_margin"`
+	HomeAverageMargin *float64 `json:"home_average_margin"`
+	AwayAverageMargin *float64 `json:"away_average_margin"`
+}
+
+type HeadToHeadSeason struct {
+	SeasonStartYear int              `json:"season_start_year"`
+	RegularSeason   HeadToHeadRecord `json:"regular_season"`
+	Playoffs        HeadToHeadRecord `json:"playoffs"`
+}
+
+type HeadToHeadPlayoffMeeting struct {
+	SeasonStartYear int `json:"season_start_year"`
+	Round           int `json:"round"`
+	Wins            int `json:"wins"`
+	Losses          int `json:"losses"`
+	// WonSeries is nil while the series is still being played
+	WonSeries *bool `json:"won_series"`
+}
+
+// HeadToHeadSeasonSeries is the regular season series between the teams in a season including games not played yet
+type HeadToHeadSeasonSeries struct {
+	SeasonStartYear int              `json:"season_start_year"`
+	Record          HeadToHeadRecord `json:"record"`
+	Played          []HeadToHeadGame `json:"played"`
+	Remaining       []HeadToHeadGame `json:"remaining"`
+}
+
+type HeadToHeadGame struct {
+	GameID         string    `json:"game_id"`
+	NBAGameID      string    `json:"nba_game_id"`
+	StartTime      time.Time `json:"start_time"`
+	Home           bool      `json:"home"`
+	Status         string    `json:"status"`
+	TeamPoints     *int      `json:"team_points"`
+	OpponentPoints *int      `json:"opponent_points"`
+}
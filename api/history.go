@@ -0,0 +1,20 @@
+package api
+
+import "time"
+
+// History is a change to a stored row, recorded every time an ingest inserts or changes the row
+type History struct {
+	ID        string  `json:"id"`
+	TableName string  `json:"table_name"`
+	Operation string  `json:"operation"`
+	RowID     string  `json:"row_id"`
+	GameID    *string `json:"game_id"`
+	TeamID    *string `json:"team_id"`
+	PlayerID  *string `json:"player_id"`
+	// OldValues are the values of the columns that changed from before the change, inserts only have NewValues
+	OldValues   map[string]any `json:"old_values"`
+	NewValues   map[string]any `json:"new_values"`
+	SourceKey   *string        `json:"source_key"`
+	IngestRunID *string        `json:"ingest_run_id"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
@@ -0,0 +1,42 @@
+package api
+
+import "time"
+
+type InjuryReportEntry struct {
+	ID             string     `json:"id"`
+	InjuryReportID string     `json:"injury_report_id"`
+	ReportTime     time.Time  `json:"report_time"`
+	GameID         *string    `json:"game_id"`
+	TeamID         *string    `json:"team_id"`
+	PlayerID       *string    `json:"player_id"`
+	GameDate       time.Time  `json:"game_date"`
+	Matchup        string     `json:"matchup"`
+	TeamName       string     `json:"team_name"`
+	PlayerName     string     `json:"player_name"`
+	Status         string     `json:"status"`
+	Reason         *string    `json:"reason"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+}
+
+type GameAvailability struct {
+	GameID           string               `json:"game_id"`
+	LatestReportTime *time.Time           `json:"latest_report_time"`
+	Players          []PlayerAvailability `json:"players"`
+}
+
+type PlayerAvailability struct {
+	PlayerID   *string              `json:"player_id"`
+	PlayerName string               `json:"player_name"`
+	TeamID     *string              `json:"team_id"`
+	TeamName   string               `json:"team_name"`
+	Status     string               `json:"status"`
+	Reason     *string              `json:"reason"`
+	Timeline   []AvailabilityChange `json:"timeline"`
+}
+
+type AvailabilityChange struct {
+	ReportTime time.Time `json:"report_time"`
+	Status     string    `json:"status"`
+	Reason     *string   `json:"reason"`
+}
@@ -0,0 +1,44 @@
+package api
+
+import "time"
+
+// LiveGameEvent is pushed to live game stream subscribers, Data is one of the LiveGame types for the event's Type or a
+// PlayByPlay for play events
+type LiveGameEvent struct {
+	// ID can be sent back as the last event id to resume a stream after reconnecting
+	ID     string    `json:"id"`
+	Type   string    `json:"type"`
+	GameID string    `json:"game_id"`
+	Time   time.Time `json:"time"`
+	Data   any       `json:"data"`
+}
+
+// LiveGameState is the game as of the event it is sent with, it starts streams that aren't resuming
+type LiveGameState struct {
+	Status              string `json:"status"`
+	Period              *int   `json:"period"`
+	PeriodTimeRemaining *int   `json:"period_time_remaining"`
+	HomeTeamPoints      *int   `json:"home_team_points"`
+	AwayTeamPoints      *int   `json:"away_team_points"`
+}
+
+type LiveGameScore struct {
+	HomeTeamPoints int `json:"home_team_points"`
+	AwayTeamPoints int `json:"away_team_points"`
+}
+
+type LiveGamePeriod struct {
+	Period         int  `json:"period"`
+	PreviousPeriod *int `json:"previous_period"`
+}
+
+type LiveGameStatus struct {
+	Status         string `json:"status"`
+	PreviousStatus string `json:"previous_status"`
+}
+
+type LiveGameFinal struct {
+	HomeTeamPoints *int       `json:"home_team_points"`
+	AwayTeamPoints *int       `json:"away_team_points"`
+	EndTime        *time.Time `json:"end_time"`
+}
@@ -0,0 +1,20 @@
+package api
+
+import "time"
+
+type Milestone struct {
+	ID       string  `json:"id"`
+	GameID   string  `json:"game_id"`
+	TeamID   *string `json:"team_id"`
+	PlayerID *string `json:"player_id"`
+	// PlayByPlayID is the play the milestone was reached on when it can be pinned to one
+	PlayByPlayID  *string    `json:"play_by_play_id"`
+	Type          string     `json:"type"`
+	Stat          string     `json:"stat"`
+	Value         int        `json:"value"`
+	PreviousValue *int       `json:"previous_value"`
+	Description   string     `json:"description"`
+	GameStartTime time.Time  `json:"game_start_time"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     *time.Time `json:"updated_at"`
+}
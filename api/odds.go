@@ -0,0 +1,47 @@
+package api
+
+import "time"
+
+type GameOdds struct {
+	GameID           string                   `json:"game_id"`
+	Lines            []GameOddsLine           `json:"lines"`
+	AgainstTheSpread []AgainstTheSpreadResult `json:"against_the_spread"`
+}
+
+// GameOddsLine is one bookmaker's odds for one outcome of a market, ex. FanDuel's home spread
+type GameOddsLine struct {
+	NBABookmakerID string             `json:"nba_bookmaker_id"`
+	Bookmaker      string             `json:"bookmaker"`
+	Market         string             `json:"market"`
+	Outcome        string             `json:"outcome"`
+	OpeningOdds    *float64           `json:"opening_odds"`
+	OpeningLine    *float64           `json:"opening_line"`
+	ClosingOdds    *float64           `json:"closing_odds"`
+	ClosingLine    *float64           `json:"closing_line"`
+	History        []GameOddsSnapshot `json:"history"`
+}
+
+type GameOddsSnapshot struct {
+	ID             string     `json:"id"`
+	GameID         string     `json:"game_id"`
+	NBABookmakerID string     `json:"nba_bookmaker_id"`
+	Bookmaker      string     `json:"bookmaker"`
+	Market         string     `json:"market"`
+	Outcome        string     `json:"outcome"`
+	Odds           *float64   `json:"odds"`
+	Line           *float64   `json:"line"`
+	OpeningOdds    *float64   `json:"opening_odds"`
+	OpeningLine    *float64   `json:"opening_line"`
+	CapturedAt     time.Time  `json:"captured_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+}
+
+type AgainstTheSpreadResult struct {
+	NBABookmakerID string  `json:"nba_bookmaker_id"`
+	Bookmaker      string  `json:"bookmaker"`
+	HomeSpread     float64 `json:"home_spread"`
+	HomeMargin     int     `json:"home_margin"`
+	Result         string  `json:"result"`
+	CoverTeamID    *string `json:"cover_team_id"`
+}
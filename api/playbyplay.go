@@ -1,4 +1,23 @@
 package api
 
+import "time"
+
 type PlayByPlay struct {
-}
\ No newline at end of file
+	ID                 string     `json:"id"`
+	GameID             string     `json:"game_id"`
+	TeamID             *string    `json:"team_id"`
+	PlayerID           *string    `json:"player_id"`
+	Period             int        `json:"period"`
+	ActionNumber       int        `json:"action_number"`
+	ClockTenthSeconds  *int       `json:"clock_tenth_seconds"`
+	ActionType         *string    `json:"action_type"`
+	SubType            *string    `json:"sub_type"`
+	Description        *string    `json:"description"`
+	ShotResult         *string    `json:"shot_result"`
+	HomeScore          *int       `json:"home_score"`
+	AwayScore          *int       `json:"away_score"`
+	PossessionTeamID   *string    `json:"possession_team_id"`
+	HomeWinProbability *float64   `json:"home_win_probability"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          *time.Time `json:"updated_at"`
+}
@@ -0,0 +1,38 @@
+package api
+
+import "time"
+
+type PlayoffBracket struct {
+	SeasonStartYear int             `json:"season_start_year"`
+	PlayIn          []PlayoffSeries `json:"play_in"`
+	Rounds          []PlayoffRound  `json:"rounds"`
+}
+
+type PlayoffRound struct {
+	Round  int             `json:"round"`
+	Name   string          `json:"name"`
+	Series []PlayoffSeries `json:"series"`
+}
+
+// PlayoffSeries is a playoff series or a single play-in game, the high seed is the team with home court advantage
+type PlayoffSeries struct {
+	ID             string  `json:"id"`
+	SeasonID       string  `json:"season_id"`
+	PlayIn         bool    `json:"play_in"`
+	Round          int     `json:"round"`
+	SeriesNumber   int     `json:"series_number"`
+	Conference     *string `json:"conference"`
+	HighSeedTeamID *string `json:"high_seed_team_id"`
+	LowSeedTeamID  *string `json:"low_seed_team_id"`
+	HighSeed       *int    `json:"high_seed"`
+	LowSeed        *int    `json:"low_seed"`
+	HighSeedWins   int     `json:"high_seed_wins"`
+	LowSeedWins    int     `json:"low_seed_wins"`
+	WinsNeeded     int     `json:"wins_needed"`
+	// GamesScheduled includes the if necessary games that are still on the schedule
+	GamesScheduled int        `json:"games_scheduled"`
+	Status         string     `json:"status"`
+	WinnerTeamID   *string    `json:"winner_team_id"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+}
@@ -0,0 +1,13 @@
+package api
+
+// Problem is an RFC 7807 problem details error response. Problems aren't given their own types so the type is
+// about:blank and the title is the status's text
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// TraceID is the id of the request's trace to find what went wrong by
+	TraceID string `json:"trace_id,omitempty"`
+}
@@ -11,3 +11,50 @@ type Referee struct {
 	CreatedAt    time.Time  `json:"created_at"`
 	UpdatedAt    *time.Time `json:"updated_at"`
 }
+
+type RefereeDetails struct {
+	Referee
+	Seasons         []RefereeSeason   `json:"seasons"`
+	Tendencies      RefereeTendencies `json:"tendencies"`
+	CrewChiefRecord TeamRecord        `json:"crew_chief_record"`
+}
+
+type RefereeSeason struct {
+	Season         string              `json:"season"`
+	Games          int                 `json:"games"`
+	CrewChiefGames int                 `json:"crew_chief_games"`
+	Assignments    []RefereeAssignment `json:"assignments"`
+}
+
+type RefereeAssignment struct {
+	GameID     string    `json:"game_id"`
+	NBAGameID  string    `json:"nba_game_id"`
+	Season     string    `json:"season"`
+	StartTime  time.Time `json:"start_time"`
+	HomeTeamID *string   `json:"home_team_id"`
+	AwayTeamID *string   `json:"away_team_id"`
+	Assignment *string   `json:"assignment"`
+}
+
+type RefereeTendencies struct {
+	Games                    int     `json:"games"`
+	FoulsPerGame             float64 `json:"fouls_per_game"`
+	HomeFoulsPerGame         float64 `json:"home_fouls_per_game"`
+	AwayFoulsPerGame         float64 `json:"away_fouls_per_game"`
+	HomeAwayFoulDifferential float64 `json:"home_away_foul_differential"`
+	FreeThrowRate            float64 `json:"free_throw_rate"`
+	TechnicalsPerGame        float64 `json:"technicals_per_game"`
+}
+
+type TeamRecord struct {
+	TeamID *string `json:"team_id"`
+	Wins   int     `json:"wins"`
+	Losses int     `json:"losses"`
+}
+
+// GameOfficial is a referee working a game
+type GameOfficial struct {
+	GameID     string  `json:"game_id"`
+	RefereeID  string  `json:"referee_id"`
+	Assignment *string `json:"assignment"`
+}
@@ -0,0 +1,18 @@
+package api
+
+import "time"
+
+type ScheduleContext struct {
+	GameID         string    `json:"game_id"`
+	TeamID         string    `json:"team_id"`
+	StartTime      time.Time `json:"start_time"`
+	Home           bool      `json:"home"`
+	ArenaID        *string   `json:"arena_id"`
+	PreviousGameID *string   `json:"previous_game_id"`
+	// RestDays is the number of full days off between the team's previous game and this one in the arenas' local time
+	RestDays      *int     `json:"rest_days"`
+	BackToBack    bool     `json:"back_to_back"`
+	MilesTraveled *float64 `json:"miles_traveled"`
+	// TimeZonesCrossed is positive when the team traveled east since its previous game and negative when it went west
+	TimeZonesCrossed *int `json:"time_zones_crossed"`
+}
@@ -0,0 +1,8 @@
+package api
+
+type Season struct {
+	// Name is the start and end year ex. 2024-2025
+	Name      string `json:"name"`
+	StartYear int    `json:"start_year"`
+	EndYear   int    `json:"end_year"`
+}
@@ -0,0 +1,19 @@
+package api
+
+import "time"
+
+type StatCorrection struct {
+	ID     string `json:"id"`
+	GameID string `json:"game_id"`
+	// Entity is what was corrected ex. team_game_stats_total, player_game_stats_total or play_by_play
+	Entity   string  `json:"entity"`
+	TeamID   *string `json:"team_id"`
+	PlayerID *string `json:"player_id"`
+	// ActionNumber is the play that was corrected for play by play corrections
+	ActionNumber *int       `json:"action_number"`
+	Field        string     `json:"field"`
+	OldValue     *string    `json:"old_value"`
+	NewValue     *string    `json:"new_value"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    *time.Time `json:"updated_at"`
+}
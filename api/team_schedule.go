@@ -0,0 +1,59 @@
+package api
+
+import "time"
+
+// TeamSchedule is a team's games for a season in start time order
+type TeamSchedule struct {
+	TeamID          string             `json:"team_id"`
+	SeasonStartYear int                `json:"season_start_year"`
+	Games           []TeamScheduleGame `json:"games"`
+}
+
+// TeamScheduleGame is a game from the perspective of the team, points and results are only set once the game has them
+type TeamScheduleGame struct {
+	GameID          string               `json:"game_id"`
+	NBAGameID       string               `json:"nba_game_id"`
+	StartTime       time.Time            `json:"start_time"`
+	SeasonStageName string               `json:"season_stage_name"`
+	GameStatusName  string               `json:"game_status_name"`
+	SeasonWeek      *int                 `json:"season_week"`
+	Home            bool                 `json:"home"`
+	Opponent        TeamScheduleOpponent `json:"opponent"`
+	Arena           *TeamScheduleArena   `json:"arena"`
+	TeamPoints      *int                 `json:"team_points"`
+	OpponentPoints  *int                 `json:"opponent_points"`
+	// Result is W or L for completed games
+	Result *string `json:"result"`
+	// Wins and Losses are the team's running record through this game within its season stage
+	Wins   int `json:"wins"`
+	Losses int `json:"losses"`
+	// Streak is the team's current run of results within the season stage ex. W3
+	Streak *string `json:"streak"`
+	// RestDays is the number of full days off since the team's previous game in the arenas' local time
+	RestDays     *int                    `json:"rest_days"`
+	Broadcasters []TeamScheduleBroadcast `json:"broadcasters"`
+}
+
+type TeamScheduleOpponent struct {
+	ID           string  `json:"id"`
+	Name         string  `json:"name"`
+	Nickname     string  `json:"nickname"`
+	City         string  `json:"city"`
+	NBAShortName *string `json:"nba_short_name"`
+}
+
+type TeamScheduleArena struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	City     *string `json:"city"`
+	State    *string `json:"state"`
+	Timezone *string `json:"timezone"`
+}
+
+// TeamScheduleBroadcast only includes broadcasts the team's fans can see, national ones and those for the team's side
+type TeamScheduleBroadcast struct {
+	Display      string `json:"display"`
+	Abbreviation string `json:"abbreviation"`
+	Scope        string `json:"scope"`
+	Media        string `json:"media"`
+}
@@ -0,0 +1,61 @@
+package api
+
+import "time"
+
+type WebhookSubscription struct {
+	ID         string   `json:"id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	// TeamIDs and LeagueIDs limit deliveries to games involving one of the teams or in one of the leagues, empty matches all
+	TeamIDs   []string `json:"team_ids"`
+	LeagueIDs []string `json:"league_ids"`
+	Active    bool     `json:"active"`
+	// Secret signs deliveries, it is only returned when the subscription is created
+	Secret    *string    `json:"secret"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+type WebhookDelivery struct {
+	ID                 string       `json:"id"`
+	SubscriptionID     string       `json:"subscription_id"`
+	URL                string       `json:"url"`
+	EventID            string       `json:"event_id"`
+	EventType          string       `json:"event_type"`
+	GameID             *string      `json:"game_id"`
+	Status             string       `json:"status"`
+	Attempts           int          `json:"attempts"`
+	NextAttemptAt      *time.Time   `json:"next_attempt_at"`
+	LastAttemptAt      *time.Time   `json:"last_attempt_at"`
+	LastResponseStatus *int         `json:"last_response_status"`
+	LastError          *string      `json:"last_error"`
+	DeliveredAt        *time.Time   `json:"delivered_at"`
+	Payload            WebhookEvent `json:"payload"`
+	CreatedAt          time.Time    `json:"created_at"`
+}
+
+// WebhookEvent is the body of a delivery. its ID is the same for every subscriber and every attempt so receivers can
+// drop duplicates
+type WebhookEvent struct {
+	ID   string      `json:"id"`
+	Type string      `json:"type"`
+	Time time.Time   `json:"time"`
+	Game WebhookGame `json:"game"`
+	// Period is the period that ended for period_end events
+	Period    *int       `json:"period"`
+	Milestone *Milestone `json:"milestone"`
+}
+
+type WebhookGame struct {
+	ID             string     `json:"id"`
+	NBAGameID      string     `json:"nba_game_id"`
+	HomeTeamID     *string    `json:"home_team_id"`
+	AwayTeamID     *string    `json:"away_team_id"`
+	HomeTeamPoints *int       `json:"home_team_points"`
+	AwayTeamPoints *int       `json:"away_team_points"`
+	Status         string     `json:"status"`
+	Period         *int       `json:"period"`
+	SeasonStage    string     `json:"season_stage"`
+	StartTime      time.Time  `json:"start_time"`
+	EndTime        *time.Time `json:"end_time"`
+}
@@ -0,0 +1,22 @@
+package api
+
+type WinProbability struct {
+	GameID        string               `json:"game_id"`
+	HomeTeamID    *string              `json:"home_team_id"`
+	AwayTeamID    *string              `json:"away_team_id"`
+	Plays         []WinProbabilityPlay `json:"plays"`
+	BiggestSwings []WinProbabilityPlay `json:"biggest_swings"`
+}
+
+type WinProbabilityPlay struct {
+	PlayByPlayID       string  `json:"play_by_play_id"`
+	ActionNumber       int     `json:"action_number"`
+	Period             int     `json:"period"`
+	ClockTenthSeconds  *int    `json:"clock_tenth_seconds"`
+	Description        *string `json:"description"`
+	HomeScore          *int    `json:"home_score"`
+	AwayScore          *int    `json:"away_score"`
+	HomeWinProbability float64 `json:"home_win_probability"`
+	Swing              float64 `json:"swing"`
+	BigSwing           bool    `json:"big_swing"`
+}
@@ -52,3 +52,22 @@ func (c Client) GetObject(ctx context.Context, bucket, objectKey string) ([]byte
 
 	return b, nil
 }
+
+// ListObjectKeys returns the keys of every object in the R2 bucket that starts with prefix
+func (c Client) ListObjectKeys(ctx context.Context, bucket, prefix string) ([]string, error) {
+	var keys []string
+
+	paginator := s3.NewListObjectsV2Paginator(c.client, &s3.ListObjectsV2Input{Bucket: &bucket, Prefix: &prefix})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list r2 objects: %w", err)
+		}
+
+		for _, object := range page.Contents {
+			keys = append(keys, *object.Key)
+		}
+	}
+
+	return keys, nil
+}
@@ -293,13 +293,13 @@ type TeamLeaders struct {
 		PlayersNode []struct {
 			PlayerID string `json:"personId"`
 		} `json:"players"`
-	} `json:"points"`
+	} `json:"rebounds"`
 	AssistsNode struct {
 		Assists     string `json:"value"`
 		AssistsNode []struct {
 			PlayerID string `json:"personId"`
 		} `json:"players"`
-	} `json:"points"`
+	} `json:"assists"`
 
 	teamID          int
 	PointsLeaders   []string
@@ -495,7 +495,7 @@ func (c Client) GetBoxscoreDetailed(ctx context.Context, gameID string, objectKe
 		return Boxscore{}, fmt.Errorf("failed to create request to get boxscore: %w", err)
 	}
 
-	response, err := c.client.Do(req)
+	response, err := do(c.client, req)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
@@ -561,7 +561,7 @@ func (c Client) GetBoxscoreSummary(ctx context.Context, gameID string, objectKey
 			return BoxscoreSummary{}, fmt.Errorf("failed to create request to get boxscore summary: %w", err)
 		}
 
-		response, err := c.statsClient.Do(req)
+		response, err := do(c.statsClient, req)
 		if err != nil {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
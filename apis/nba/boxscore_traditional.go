@@ -0,0 +1,211 @@
+package nba
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const boxscoreTraditionalV2URL = "https://stats.nba.com/stats/boxscoretraditionalv2?EndPeriod=10&EndRange=0&GameID=%s&RangeType=0&StartPeriod=1&StartRange=0"
+
+// BoxscoreTraditional is the stats.nba.com boxscore which unlike the cdn boxscore exists for every season
+type BoxscoreTraditional struct {
+	GameID  string
+	Players []BoxscoreTraditionalPlayer
+}
+
+type BoxscoreTraditionalPlayer struct {
+	PlayerID      int
+	PlayerName    string
+	TeamID        int
+	StartPosition string
+	// Comment is the reason a player didn't play ex. DNP - Coach's Decision
+	Comment           string
+	TimePlayedSeconds *int
+	GameLogStatLine
+}
+
+// Played is false for players that were listed for the game but didn't get in. Minutes weren't tracked for the earliest
+// seasons so those players only have a comment when they didn't play
+func (p BoxscoreTraditionalPlayer) Played() bool {
+	if p.TimePlayedSeconds != nil {
+		return *p.TimePlayedSeconds > 0
+	}
+	return p.Comment == ""
+}
+
+func (c Client) GetBoxscoreTraditional(ctx context.Context, gameID string, objectKey string) (BoxscoreTraditional, error) {
+	ctx, span := otel.Tracer("nba").Start(ctx, "nba.Client.GetBoxscoreTraditional")
+	defer span.End()
+
+	var data []byte
+
+	if c.Cache != nil {
+		obj, err := c.Cache.GetObject(ctx, objectKey)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return BoxscoreTraditional{}, fmt.Errorf("failed to get boxscore traditional from cache: %w", err)
+		}
+		data = obj
+	}
+
+	if data == nil {
+		req, err := retryablehttp.NewRequest(http.MethodGet, fmt.Sprintf(boxscoreTraditionalV2URL, gameID), nil)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return BoxscoreTraditional{}, fmt.Errorf("failed to create request to get boxscore traditional: %w", err)
+		}
+
+		response, err := do(c.statsClient, req)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return BoxscoreTraditional{}, fmt.Errorf("failed to get BoxscoreTraditional object: %w", err)
+		}
+		defer response.Body.Close()
+
+		if response.StatusCode != 200 {
+			err = fmt.Errorf("failed to successfully get BoxscoreTraditional object: status %d", response.StatusCode)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			if slices.Contains([]int{http.StatusNotFound}, response.StatusCode) {
+				return BoxscoreTraditional{}, ErrNotFound
+			}
+			return BoxscoreTraditional{}, err
+		}
+
+		if response.Header.Get("Content-Encoding") == "gzip" {
+			response.Body, err = gzip.NewReader(response.Body)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return BoxscoreTraditional{}, fmt.Errorf("failed to create gzip reader when getting nba boxscore traditional: %w", err)
+			}
+		}
+
+		respBody, err := io.ReadAll(response.Body)
+		if err != nil {
+			return BoxscoreTraditional{}, fmt.Errorf("failed to read response body when getting nba boxscore traditional: %w", err)
+		}
+
+		if c.Cache != nil {
+			if err := c.Cache.PutObject(ctx, objectKey, bytes.NewReader(respBody)); err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return BoxscoreTraditional{}, fmt.Errorf("failed to cache boxscore traditional object: %w", err)
+			}
+		}
+
+		data = respBody
+	}
+
+	boxscoreResult, err := unmarshalNBAHttpResponseToJSON[statsBaseResponse](bytes.NewReader(data))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return BoxscoreTraditional{}, fmt.Errorf("failed to get boxscore traditional response: %w", err)
+	}
+
+	boxscore := BoxscoreTraditional{GameID: gameID, Players: []BoxscoreTraditionalPlayer{}}
+
+	for _, resultSet := range boxscoreResult.ResultSets {
+		headersMap := make(map[string]int, len(resultSet.Headers))
+		for i, header := range resultSet.Headers {
+			headersMap[header] = i
+		}
+
+		switch resultSet.Name {
+		case "PlayerStats":
+			for _, rowSet := range resultSet.RowSet {
+				playerID, err := parseRowSetValue[int](headersMap, rowSet, "PLAYER_ID")
+				if err != nil {
+					return BoxscoreTraditional{}, fmt.Errorf("failed to parse player id from stats %s endpoint: %w", endpointNameBoxscoreTraditionalV2, err)
+				}
+
+				playerName, err := parseRowSetValue[string](headersMap, rowSet, "PLAYER_NAME")
+				if err != nil {
+					return BoxscoreTraditional{}, fmt.Errorf("failed to parse player name from stats %s endpoint: %w", endpointNameBoxscoreTraditionalV2, err)
+				}
+
+				teamID, err := parseRowSetValue[int](headersMap, rowSet, "TEAM_ID")
+				if err != nil {
+					return BoxscoreTraditional{}, fmt.Errorf("failed to parse team id from stats %s endpoint: %w", endpointNameBoxscoreTraditionalV2, err)
+				}
+
+				startPosition, err := parseRowSetValue[string](headersMap, rowSet, "START_POSITION")
+				if err != nil {
+					return BoxscoreTraditional{}, fmt.Errorf("failed to parse start position from stats %s endpoint: %w", endpointNameBoxscoreTraditionalV2, err)
+				}
+
+				comment, err := parseRowSetValue[string](headersMap, rowSet, "COMMENT")
+				if err != nil {
+					return BoxscoreTraditional{}, fmt.Errorf("failed to parse comment from stats %s endpoint: %w", endpointNameBoxscoreTraditionalV2, err)
+				}
+
+				minutes, err := parseRowSetValue[*string](headersMap, rowSet, "MIN")
+				if err != nil {
+					return BoxscoreTraditional{}, fmt.Errorf("failed to parse minutes from stats %s endpoint: %w", endpointNameBoxscoreTraditionalV2, err)
+				}
+
+				timePlayedSeconds, err := parseBoxscoreTraditionalMinutes(minutes)
+				if err != nil {
+					return BoxscoreTraditional{}, fmt.Errorf("failed to parse minutes from stats %s endpoint: %w", endpointNameBoxscoreTraditionalV2, err)
+				}
+
+				statLine, err := parseGameLogStatLine(headersMap, rowSet)
+				if err != nil {
+					return BoxscoreTraditional{}, err
+				}
+
+				boxscore.Players = append(boxscore.Players, BoxscoreTraditionalPlayer{
+					PlayerID:          playerID,
+					PlayerName:        playerName,
+					TeamID:            teamID,
+					StartPosition:     strings.TrimSpace(startPosition),
+					Comment:           strings.TrimSpace(comment),
+					TimePlayedSeconds: timePlayedSeconds,
+					GameLogStatLine:   statLine,
+				})
+			}
+		}
+	}
+
+	return boxscore, nil
+}
+
+// parseBoxscoreTraditionalMinutes parses minutes played which come as MM:SS and for some seasons as a decimal number of
+// minutes followed by seconds ex. 34.000000:12
+func parseBoxscoreTraditionalMinutes(minutes *string) (*int, error) {
+	if minutes == nil || *minutes == "" {
+		return nil, nil
+	}
+
+	minutesStr, secondsStr, _ := strings.Cut(*minutes, ":")
+
+	m, err := strconv.ParseFloat(minutesStr, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse minutes %s: %w", *minutes, err)
+	}
+
+	s := 0
+	if secondsStr != "" {
+		s, err = strconv.Atoi(secondsStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse seconds %s: %w", *minutes, err)
+		}
+	}
+
+	timePlayedSeconds := int(m)*60 + s
+	return &timePlayedSeconds, nil
+}
@@ -1,10 +1,12 @@
 package nba
 
 import (
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/drewthor/wolves_reddit_bot/pkg/rlhttp"
+	"github.com/hashicorp/go-retryablehttp"
 	"golang.org/x/time/rate"
 )
 
@@ -37,6 +39,21 @@ func NewClient(cache ObjectCacher, options ...rlhttp.ClientOption) Client {
 	return Client{client: c, statsClient: statsC, Cache: cache}
 }
 
+// do sends a request with the client, failing to reach the nba and server errors after retrying are ErrUnavailable
+func do(client *rlhttp.Client, req *retryablehttp.Request) (*http.Response, error) {
+	response, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+
+	if response.StatusCode >= http.StatusInternalServerError || response.StatusCode == http.StatusTooManyRequests {
+		response.Body.Close()
+		return nil, fmt.Errorf("%w: status %d", ErrUnavailable, response.StatusCode)
+	}
+
+	return response, nil
+}
+
 type nbaRoundTripper struct {
 	r http.RoundTripper
 }
@@ -3,3 +3,6 @@ package nba
 import "errors"
 
 var ErrNotFound = errors.New("not found")
+
+// ErrUnavailable is returned when the nba's api can't be reached or fails to respond
+var ErrUnavailable = errors.New("nba api unavailable")
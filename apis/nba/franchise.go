@@ -57,7 +57,7 @@ func (c Client) FranchiseHistory(ctx context.Context, leagueID string) ([]Franch
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request to get franchise history: %w", err)
 	}
-	response, err := c.statsClient.Do(req)
+	response, err := do(c.statsClient, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get franchise history from nba from url %s: %w", franchiseURL, err)
 	}
@@ -39,46 +39,14 @@ type Game struct {
 	PostponedStatus  string     `json:"postponedStatus"`
 	BranchLink       string     `json:"branchLink"`
 	Broadcasters     struct {
-		NationalTvBroadcasters []struct {
-			BroadcasterScope        string `json:"broadcasterScope"`
-			BroadcasterMedia        string `json:"broadcasterMedia"`
-			BroadcasterID           int    `json:"broadcasterId"`
-			BroadcasterDisplay      string `json:"broadcasterDisplay"`
-			BroadcasterAbbreviation string `json:"broadcasterAbbreviation"`
-			TapeDelayComments       string `json:"tapeDelayComments"`
-			RegionID                int    `json:"regionId"`
-		} `json:"nationalTvBroadcasters"`
-		NationalRadioBroadcasters []interface{} `json:"nationalRadioBroadcasters"`
-		HomeTvBroadcasters        []struct {
-			BroadcasterScope        string `json:"broadcasterScope"`
-			BroadcasterMedia        string `json:"broadcasterMedia"`
-			BroadcasterID           int    `json:"broadcasterId"`
-			BroadcasterDisplay      string `json:"broadcasterDisplay"`
-			BroadcasterAbbreviation string `json:"broadcasterAbbreviation"`
-			TapeDelayComments       string `json:"tapeDelayComments"`
-			RegionID                int    `json:"regionId"`
-		} `json:"homeTvBroadcasters"`
-		HomeRadioBroadcasters []struct {
-			BroadcasterScope        string `json:"broadcasterScope"`
-			BroadcasterMedia        string `json:"broadcasterMedia"`
-			BroadcasterID           int    `json:"broadcasterId"`
-			BroadcasterDisplay      string `json:"broadcasterDisplay"`
-			BroadcasterAbbreviation string `json:"broadcasterAbbreviation"`
-			TapeDelayComments       string `json:"tapeDelayComments"`
-			RegionID                int    `json:"regionId"`
-		} `json:"homeRadioBroadcasters"`
-		AwayTvBroadcasters    []interface{} `json:"awayTvBroadcasters"`
-		AwayRadioBroadcasters []struct {
-			BroadcasterScope        string `json:"broadcasterScope"`
-			BroadcasterMedia        string `json:"broadcasterMedia"`
-			BroadcasterID           int    `json:"broadcasterId"`
-			BroadcasterDisplay      string `json:"broadcasterDisplay"`
-			BroadcasterAbbreviation string `json:"broadcasterAbbreviation"`
-			TapeDelayComments       string `json:"tapeDelayComments"`
-			RegionID                int    `json:"regionId"`
-		} `json:"awayRadioBroadcasters"`
-		IntlRadioBroadcasters []interface{} `json:"intlRadioBroadcasters"`
-		IntlTvBroadcasters    []interface{} `json:"intlTvBroadcasters"`
+		NationalTvBroadcasters    []GameBroadcaster `json:"nationalTvBroadcasters"`
+		NationalRadioBroadcasters []GameBroadcaster `json:"nationalRadioBroadcasters"`
+		HomeTvBroadcasters        []GameBroadcaster `json:"homeTvBroadcasters"`
+		HomeRadioBroadcasters     []GameBroadcaster `json:"homeRadioBroadcasters"`
+		AwayTvBroadcasters        []GameBroadcaster `json:"awayTvBroadcasters"`
+		AwayRadioBroadcasters     []GameBroadcaster `json:"awayRadioBroadcasters"`
+		IntlRadioBroadcasters     []GameBroadcaster `json:"intlRadioBroadcasters"`
+		IntlTvBroadcasters        []GameBroadcaster `json:"intlTvBroadcasters"`
 	} `json:"broadcasters"`
 	HomeTeam struct {
 		TeamID      int    `json:"teamId"`
@@ -113,3 +81,13 @@ type Game struct {
 		Points      float64 `json:"points"`
 	} `json:"pointsLeaders"`
 }
+
+type GameBroadcaster struct {
+	BroadcasterScope        string `json:"broadcasterScope"` // ex. natl
+	BroadcasterMedia        string `json:"broadcasterMedia"` // ex. tv
+	BroadcasterID           int    `json:"broadcasterId"`
+	BroadcasterDisplay      string `json:"broadcasterDisplay"`
+	BroadcasterAbbreviation string `json:"broadcasterAbbreviation"`
+	TapeDelayComments       string `json:"tapeDelayComments"`
+	RegionID                int    `json:"regionId"`
+}
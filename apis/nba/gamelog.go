@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 	"go.opentelemetry.io/otel"
@@ -18,6 +20,9 @@ import (
 
 const leagueGameLogURL = "https://stats.nba.com/stats/leaguegamelog?"
 
+// gameLogDateLayout is the layout of GAME_DATE in the league game log, only the date of the game is available
+const gameLogDateLayout = "2006-01-02"
+
 type SeasonType string
 
 const (
@@ -30,13 +35,15 @@ const (
 
 type GameLog struct {
 	GameID               string
+	GameDate             time.Time
 	HomeTeam             TeamGameLog
-	AwayTeamID           TeamGameLog
+	AwayTeam             TeamGameLog
 	TotalDurationMinutes int
 }
 
-type TeamGameLog struct {
-	TeamID                 int
+// GameLogStatLine is the box score line shared by team and player game logs. Stats the league didn't track yet for a
+// season ex. three pointers before 1979-80 or steals and blocks before 1973-74 are nil
+type GameLogStatLine struct {
 	FieldGoalsMade         int
 	FieldGoalsAttempted    *int
 	ThreePointersMade      *int
@@ -52,78 +59,39 @@ type TeamGameLog struct {
 	Turnovers              *int
 	PersonalFouls          *int
 	Points                 int
-	PlusMinus              int
+	PlusMinus              *int
+}
+
+type TeamGameLog struct {
+	TeamID           int
+	TeamAbbreviation string
+	TeamName         string
+	GameLogStatLine
+}
+
+type PlayerGameLog struct {
+	GameID     string
+	GameDate   time.Time
+	PlayerID   int
+	PlayerName string
+	TeamID     int
+	Minutes    *int
+	GameLogStatLine
 }
 
 func (c *Client) LeagueGameLog(ctx context.Context, seasonStartYear int, seasonType SeasonType) ([]GameLog, error) {
 	ctx, span := otel.Tracer("nba").Start(ctx, "nba.Client.GameLog")
 	defer span.End()
 
-	//if c.Cache != nil {
-	//	obj, err := c.Cache.GetObject(ctx, objectKey)
-	//	if err != nil && !errors.Is(err, ErrNotFound) {
-	//		return nil, fmt.Errorf("failed to get boxscore summary from cache: %w", err)
-	//	}
-	//	data = obj
-	//	slog.Info("found boxscore summary in cache")
-	//}
-	urlValues := url.Values{
-		"Counter":      {"0"},
-		"Direction":    {"ASC"},
-		"LeagueID":     {"00"},
-		"PlayerOrTeam": {"T"},
-		"Season":       {strconv.Itoa(seasonStartYear)},
-		"SeasonType":   {string(seasonType)},
-		"Sorter":       {"DATE"},
-	}
-
-	u := leagueGameLogURL + urlValues.Encode()
-	req, err := retryablehttp.NewRequest(http.MethodGet, u, nil)
+	gameLogsData, err := c.getLeagueGameLog(ctx, seasonStartYear, seasonType, "T")
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		return nil, fmt.Errorf("failed to create request to get league game log: %w", err)
-	}
-
-	response, err := c.statsClient.Do(req)
-	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		return nil, fmt.Errorf("failed to get GameLog object: %w", err)
-	}
-	defer response.Body.Close()
-
-	if response.StatusCode != 200 {
-		err = fmt.Errorf("failed to successfully get league game log: status %d: url: %s", response.StatusCode, req.URL.String())
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 		return nil, err
 	}
 
-	if response.Header.Get("Content-Encoding") == "gzip" {
-		response.Body, err = gzip.NewReader(response.Body)
-		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, err.Error())
-			return nil, fmt.Errorf("failed to create gzip reader when getting nba league game log: %w", err)
-		}
-	}
-
-	data, err := io.ReadAll(response.Body)
-	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		return nil, fmt.Errorf("failed to read all response data when getting nba league game log: %w", err)
-	}
-
-	gameLogsData, err := unmarshalNBAHttpResponseToJSON[statsBaseResponse](bytes.NewReader(data))
-	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		return nil, fmt.Errorf("failed to get league game log response: %w", err)
-	}
-
 	gameLogs := make(map[string]GameLog)
+	var gameIDs []string
 	for _, resultSet := range gameLogsData.ResultSets {
 		headersMap := make(map[string]int, len(resultSet.Headers))
 		for i, header := range resultSet.Headers {
@@ -141,6 +109,16 @@ func (c *Client) LeagueGameLog(ctx context.Context, seasonStartYear int, seasonT
 					return nil, fmt.Errorf("failed to parse response from stats %s endpoint: %w", endpointNameLeagueGameLog, err)
 				}
 
+				teamAbbreviation, err := parseRowSetValue[string](headersMap, rowSet, "TEAM_ABBREVIATION")
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse team abbreviation from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
+				}
+
+				teamName, err := parseRowSetValue[string](headersMap, rowSet, "TEAM_NAME")
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse team name from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
+				}
+
 				gameID, err := parseRowSetValue[string](headersMap, rowSet, "GAME_ID")
 				if err != nil {
 					span.RecordError(err)
@@ -148,6 +126,11 @@ func (c *Client) LeagueGameLog(ctx context.Context, seasonStartYear int, seasonT
 					return nil, fmt.Errorf("failed to parse gameID from stats %s endpoint: %w", endpointNameLeagueGameLog, err)
 				}
 
+				gameDate, err := parseGameLogDate(headersMap, rowSet)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse game date from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
+				}
+
 				matchup, err := parseRowSetValue[string](headersMap, rowSet, "MATCHUP")
 				if err != nil {
 					span.RecordError(err)
@@ -162,133 +145,279 @@ func (c *Client) LeagueGameLog(ctx context.Context, seasonStartYear int, seasonT
 					return nil, fmt.Errorf("failed to parse total duration minutes from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
 				}
 
-				fieldGoalsMade, err := parseRowSetValue[int](headersMap, rowSet, "FGM")
+				statLine, err := parseGameLogStatLine(headersMap, rowSet)
 				if err != nil {
-					span.RecordError(err)
-					span.SetStatus(codes.Error, err.Error())
-					return nil, fmt.Errorf("failed to parse field goals made from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
+					return nil, err
 				}
 
-				fieldGoalsAttempted, err := parseRowSetValue[*int](headersMap, rowSet, "FGA")
-				if err != nil {
-					return nil, fmt.Errorf("failed to parse game code from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
+				gameLog, ok := gameLogs[gameID]
+				if !ok {
+					gameIDs = append(gameIDs, gameID)
+					gameLog = GameLog{
+						GameID:               gameID,
+						GameDate:             gameDate,
+						TotalDurationMinutes: totalDurationMinutes,
+					}
 				}
 
-				threePointersMade, err := parseRowSetValue[*int](headersMap, rowSet, "FG3M")
-				if err != nil {
-					return nil, fmt.Errorf("failed to parse three pointers made from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
+				teamGameLog := TeamGameLog{
+					TeamID:           teamID,
+					TeamAbbreviation: teamAbbreviation,
+					TeamName:         teamName,
+					GameLogStatLine:  statLine,
 				}
 
-				threePointersAttempted, err := parseRowSetValue[*int](headersMap, rowSet, "FG3A")
-				if err != nil {
-					return nil, fmt.Errorf("failed to parse three pointers attempted from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
-				}
+				awayTeam := strings.Contains(matchup, "@")
 
-				freeThrowsMade, err := parseRowSetValue[int](headersMap, rowSet, "FTM")
-				if err != nil {
-					return nil, fmt.Errorf("failed to parse free throws made from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
+				if awayTeam {
+					gameLog.AwayTeam = teamGameLog
+				} else {
+					gameLog.HomeTeam = teamGameLog
 				}
 
-				freeThrowsAttempted, err := parseRowSetValue[int](headersMap, rowSet, "FTA")
-				if err != nil {
-					return nil, fmt.Errorf("failed to parse free throws attempted from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
-				}
+				gameLogs[gameID] = gameLog
+			}
+		}
+	}
 
-				offensiveRebounds, err := parseRowSetValue[*int](headersMap, rowSet, "OREB")
-				if err != nil {
-					return nil, fmt.Errorf("failed to parse offensive rebounds from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
-				}
+	leagueGameLogs := make([]GameLog, 0, len(gameLogs))
+	for _, gameID := range gameIDs {
+		leagueGameLogs = append(leagueGameLogs, gameLogs[gameID])
+	}
 
-				defensiveRebounds, err := parseRowSetValue[*int](headersMap, rowSet, "DREB")
-				if err != nil {
-					return nil, fmt.Errorf("failed to parse defensive rebounds from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
-				}
+	return leagueGameLogs, nil
+}
 
-				totalRebounds, err := parseRowSetValue[*int](headersMap, rowSet, "REB")
-				if err != nil {
-					return nil, fmt.Errorf("failed to parse total rebounds from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
-				}
+// LeaguePlayerGameLog is every player's line for every game of the season type. Player lines for the earliest seasons
+// are incomplete so games may be missing some or all of their players
+func (c *Client) LeaguePlayerGameLog(ctx context.Context, seasonStartYear int, seasonType SeasonType) ([]PlayerGameLog, error) {
+	ctx, span := otel.Tracer("nba").Start(ctx, "nba.Client.LeaguePlayerGameLog")
+	defer span.End()
+
+	gameLogsData, err := c.getLeagueGameLog(ctx, seasonStartYear, seasonType, "P")
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
 
-				assists, err := parseRowSetValue[*int](headersMap, rowSet, "AST")
+	playerGameLogs := []PlayerGameLog{}
+	for _, resultSet := range gameLogsData.ResultSets {
+		headersMap := make(map[string]int, len(resultSet.Headers))
+		for i, header := range resultSet.Headers {
+			headersMap[header] = i
+		}
+
+		switch resultSet.Name {
+		case "LeagueGameLog":
+			for _, rowSet := range resultSet.RowSet {
+				playerID, err := parseRowSetValue[int](headersMap, rowSet, "PLAYER_ID")
 				if err != nil {
-					return nil, fmt.Errorf("failed to parse assists from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
+					return nil, fmt.Errorf("failed to parse player id from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
 				}
 
-				steals, err := parseRowSetValue[*int](headersMap, rowSet, "STL")
+				playerName, err := parseRowSetValue[string](headersMap, rowSet, "PLAYER_NAME")
 				if err != nil {
-					return nil, fmt.Errorf("failed to parse steals rebounds from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
+					return nil, fmt.Errorf("failed to parse player name from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
 				}
 
-				blocks, err := parseRowSetValue[*int](headersMap, rowSet, "BLK")
+				teamID, err := parseRowSetValue[int](headersMap, rowSet, "TEAM_ID")
 				if err != nil {
-					return nil, fmt.Errorf("failed to parse blocks from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
+					return nil, fmt.Errorf("failed to parse team id from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
 				}
 
-				turnovers, err := parseRowSetValue[*int](headersMap, rowSet, "TOV")
+				gameID, err := parseRowSetValue[string](headersMap, rowSet, "GAME_ID")
 				if err != nil {
-					return nil, fmt.Errorf("failed to parse turnovers from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
+					return nil, fmt.Errorf("failed to parse game id from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
 				}
 
-				personalFouls, err := parseRowSetValue[*int](headersMap, rowSet, "PF")
+				gameDate, err := parseGameLogDate(headersMap, rowSet)
 				if err != nil {
-					return nil, fmt.Errorf("failed to parse personal fouls from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
+					return nil, fmt.Errorf("failed to parse game date from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
 				}
 
-				points, err := parseRowSetValue[int](headersMap, rowSet, "PTS")
+				minutes, err := parseRowSetValue[*int](headersMap, rowSet, "MIN")
 				if err != nil {
-					return nil, fmt.Errorf("failed to parse points from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
+					return nil, fmt.Errorf("failed to parse minutes from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
 				}
 
-				plusMinus, err := parseRowSetValue[int](headersMap, rowSet, "PLUS_MINUS")
+				statLine, err := parseGameLogStatLine(headersMap, rowSet)
 				if err != nil {
-					return nil, fmt.Errorf("failed to parse plus minus from nba stats %s endpoint: %w", endpointNameLeagueGameLog, err)
+					return nil, err
 				}
 
-				gameLog, ok := gameLogs[gameID]
-				if !ok {
-					gameLog = GameLog{
-						GameID:               gameID,
-						TotalDurationMinutes: totalDurationMinutes,
-					}
-				}
+				playerGameLogs = append(playerGameLogs, PlayerGameLog{
+					GameID:          gameID,
+					GameDate:        gameDate,
+					PlayerID:        playerID,
+					PlayerName:      playerName,
+					TeamID:          teamID,
+					Minutes:         minutes,
+					GameLogStatLine: statLine,
+				})
+			}
+		}
+	}
 
-				teamGameLog := TeamGameLog{
-					TeamID:                 teamID,
-					FieldGoalsMade:         fieldGoalsMade,
-					FieldGoalsAttempted:    fieldGoalsAttempted,
-					ThreePointersMade:      threePointersMade,
-					ThreePointersAttempted: threePointersAttempted,
-					FreeThrowsMade:         freeThrowsMade,
-					FreeThrowsAttempted:    freeThrowsAttempted,
-					OffensiveRebounds:      offensiveRebounds,
-					DefensiveRebounds:      defensiveRebounds,
-					TotalRebounds:          totalRebounds,
-					Assists:                assists,
-					Steals:                 steals,
-					Blocks:                 blocks,
-					Turnovers:              turnovers,
-					PersonalFouls:          personalFouls,
-					Points:                 points,
-					PlusMinus:              plusMinus,
-				}
+	return playerGameLogs, nil
+}
 
-				awayTeam := strings.Contains(matchup, "@")
+func (c *Client) getLeagueGameLog(ctx context.Context, seasonStartYear int, seasonType SeasonType, playerOrTeam string) (statsBaseResponse, error) {
+	urlValues := url.Values{
+		"Counter":      {"0"},
+		"Direction":    {"ASC"},
+		"LeagueID":     {"00"},
+		"PlayerOrTeam": {playerOrTeam},
+		"Season":       {strconv.Itoa(seasonStartYear)},
+		"SeasonType":   {string(seasonType)},
+		"Sorter":       {"DATE"},
+	}
 
-				if awayTeam {
-					gameLog.AwayTeamID = teamGameLog
-				} else {
-					gameLog.HomeTeam = teamGameLog
-				}
+	u := leagueGameLogURL + urlValues.Encode()
+	req, err := retryablehttp.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return statsBaseResponse{}, fmt.Errorf("failed to create request to get league game log: %w", err)
+	}
 
-				gameLogs[gameID] = gameLog
-			}
+	response, err := do(c.statsClient, req)
+	if err != nil {
+		return statsBaseResponse{}, fmt.Errorf("failed to get GameLog object: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return statsBaseResponse{}, fmt.Errorf("failed to successfully get league game log: status %d: url: %s", response.StatusCode, req.URL.String())
+	}
+
+	if response.Header.Get("Content-Encoding") == "gzip" {
+		response.Body, err = gzip.NewReader(response.Body)
+		if err != nil {
+			return statsBaseResponse{}, fmt.Errorf("failed to create gzip reader when getting nba league game log: %w", err)
 		}
 	}
 
-	leagueGameLogs := make([]GameLog, 0, len(gameLogs))
-	for _, gameLog := range gameLogs {
-		leagueGameLogs = append(leagueGameLogs, gameLog)
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return statsBaseResponse{}, fmt.Errorf("failed to read all response data when getting nba league game log: %w", err)
 	}
 
-	return leagueGameLogs, nil
+	gameLogsData, err := unmarshalNBAHttpResponseToJSON[statsBaseResponse](bytes.NewReader(data))
+	if err != nil {
+		return statsBaseResponse{}, fmt.Errorf("failed to get league game log response: %w", err)
+	}
+
+	return gameLogsData, nil
+}
+
+func parseGameLogDate(headersMap map[string]int, rowSet []json.RawMessage) (time.Time, error) {
+	gameDateStr, err := parseRowSetValue[string](headersMap, rowSet, "GAME_DATE")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// some endpoints include a time of day ex. 2023-10-24T00:00:00
+	gameDateStr, _, _ = strings.Cut(gameDateStr, "T")
+
+	return time.Parse(gameLogDateLayout, gameDateStr)
+}
+
+func parseGameLogStatLine(headersMap map[string]int, rowSet []json.RawMessage) (GameLogStatLine, error) {
+	fieldGoalsMade, err := parseRowSetValue[int](headersMap, rowSet, "FGM")
+	if err != nil {
+		return GameLogStatLine{}, fmt.Errorf("failed to parse field goals made from nba stats game log: %w", err)
+	}
+
+	fieldGoalsAttempted, err := parseRowSetValue[*int](headersMap, rowSet, "FGA")
+	if err != nil {
+		return GameLogStatLine{}, fmt.Errorf("failed to parse field goals attempted from nba stats game log: %w", err)
+	}
+
+	threePointersMade, err := parseRowSetValue[*int](headersMap, rowSet, "FG3M")
+	if err != nil {
+		return GameLogStatLine{}, fmt.Errorf("failed to parse three pointers made from nba stats game log: %w", err)
+	}
+
+	threePointersAttempted, err := parseRowSetValue[*int](headersMap, rowSet, "FG3A")
+	if err != nil {
+		return GameLogStatLine{}, fmt.Errorf("failed to parse three pointers attempted from nba stats game log: %w", err)
+	}
+
+	freeThrowsMade, err := parseRowSetValue[int](headersMap, rowSet, "FTM")
+	if err != nil {
+		return GameLogStatLine{}, fmt.Errorf("failed to parse free throws made from nba stats game log: %w", err)
+	}
+
+	freeThrowsAttempted, err := parseRowSetValue[int](headersMap, rowSet, "FTA")
+	if err != nil {
+		return GameLogStatLine{}, fmt.Errorf("failed to parse free throws attempted from nba stats game log: %w", err)
+	}
+
+	offensiveRebounds, err := parseRowSetValue[*int](headersMap, rowSet, "OREB")
+	if err != nil {
+		return GameLogStatLine{}, fmt.Errorf("failed to parse offensive rebounds from nba stats game log: %w", err)
+	}
+
+	defensiveRebounds, err := parseRowSetValue[*int](headersMap, rowSet, "DREB")
+	if err != nil {
+		return GameLogStatLine{}, fmt.Errorf("failed to parse defensive rebounds from nba stats game log: %w", err)
+	}
+
+	totalRebounds, err := parseRowSetValue[*int](headersMap, rowSet, "REB")
+	if err != nil {
+		return GameLogStatLine{}, fmt.Errorf("failed to parse total rebounds from nba stats game log: %w", err)
+	}
+
+	assists, err := parseRowSetValue[*int](headersMap, rowSet, "AST")
+	if err != nil {
+		return GameLogStatLine{}, fmt.Errorf("failed to parse assists from nba stats game log: %w", err)
+	}
+
+	steals, err := parseRowSetValue[*int](headersMap, rowSet, "STL")
+	if err != nil {
+		return GameLogStatLine{}, fmt.Errorf("failed to parse steals from nba stats game log: %w", err)
+	}
+
+	blocks, err := parseRowSetValue[*int](headersMap, rowSet, "BLK")
+	if err != nil {
+		return GameLogStatLine{}, fmt.Errorf("failed to parse blocks from nba stats game log: %w", err)
+	}
+
+	turnovers, err := parseRowSetValue[*int](headersMap, rowSet, "TOV")
+	if err != nil {
+		return GameLogStatLine{}, fmt.Errorf("failed to parse turnovers from nba stats game log: %w", err)
+	}
+
+	personalFouls, err := parseRowSetValue[*int](headersMap, rowSet, "PF")
+	if err != nil {
+		return GameLogStatLine{}, fmt.Errorf("failed to parse personal fouls from nba stats game log: %w", err)
+	}
+
+	points, err := parseRowSetValue[int](headersMap, rowSet, "PTS")
+	if err != nil {
+		return GameLogStatLine{}, fmt.Errorf("failed to parse points from nba stats game log: %w", err)
+	}
+
+	plusMinus, err := parseRowSetValue[*int](headersMap, rowSet, "PLUS_MINUS")
+	if err != nil {
+		return GameLogStatLine{}, fmt.Errorf("failed to parse plus minus from nba stats game log: %w", err)
+	}
+
+	return GameLogStatLine{
+		FieldGoalsMade:         fieldGoalsMade,
+		FieldGoalsAttempted:    fieldGoalsAttempted,
+		ThreePointersMade:      threePointersMade,
+		ThreePointersAttempted: threePointersAttempted,
+		FreeThrowsMade:         freeThrowsMade,
+		FreeThrowsAttempted:    freeThrowsAttempted,
+		OffensiveRebounds:      offensiveRebounds,
+		DefensiveRebounds:      defensiveRebounds,
+		TotalRebounds:          totalRebounds,
+		Assists:                assists,
+		Steals:                 steals,
+		Blocks:                 blocks,
+		Turnovers:              turnovers,
+		PersonalFouls:          personalFouls,
+		Points:                 points,
+		PlusMinus:              plusMinus,
+	}, nil
 }
@@ -0,0 +1,365 @@
+package nba
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/ledongthuc/pdf"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// the league publishes the official injury report as a pdf every hour on game days
+const injuryReportURL = "https://ak-static.cms.nba.com/referee/injury/Injury-Report_%s.pdf" // %s is the report time in ET ex. 2024-01-15_05PM
+
+const injuryReportTimeFormat = "2006-01-02_03PM"
+
+type InjuryStatus string
+
+const (
+	InjuryStatusOut          InjuryStatus = "out"
+	InjuryStatusDoubtful     InjuryStatus = "doubtful"
+	InjuryStatusQuestionable InjuryStatus = "questionable"
+	InjuryStatusProbable     InjuryStatus = "probable"
+	InjuryStatusAvailable    InjuryStatus = "available"
+)
+
+// injury report columns in the order they are in the pdf
+const (
+	injuryReportColumnGameDate = iota
+	injuryReportColumnGameTime
+	injuryReportColumnMatchup
+	injuryReportColumnTeam
+	injuryReportColumnPlayerName
+	injuryReportColumnCurrentStatus
+	injuryReportColumnReason
+	injuryReportColumnCount
+)
+
+var injuryReportHeaders = [injuryReportColumnCount]string{"Game Date", "Game Time", "Matchup", "Team", "Player Name", "Current Status", "Reason"}
+
+const injuryReportNotYetSubmitted = "NOT YET SUBMITTED"
+
+type InjuryReport struct {
+	ReportTime time.Time
+	Entries    []InjuryReportEntry
+}
+
+type InjuryReportEntry struct {
+	GameDate        time.Time // midnight ET of the day the game is played
+	GameTime        string    // ex. 07:00 (ET)
+	Matchup         string    // ex. MIN@DEN
+	AwayTricode     string
+	HomeTricode     string
+	Team            string // ex. Minnesota Timberwolves
+	PlayerName      string // ex. Edwards, Anthony
+	PlayerFirstName string
+	PlayerLastName  string
+	Status          InjuryStatus
+	Reason          string // ex. Injury/Illness - Left Ankle; Sprain
+}
+
+// InjuryReportTime is the time of the latest report published at or before t since reports are published on the hour
+// in ET
+func InjuryReportTime(t time.Time) (time.Time, error) {
+	eastCoastLoc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to load east coast location for injury report time: %w", err)
+	}
+
+	return t.In(eastCoastLoc).Truncate(time.Hour), nil
+}
+
+func (c Client) GetInjuryReport(ctx context.Context, reportTime time.Time, objectKey string) (InjuryReport, error) {
+	ctx, span := otel.Tracer("nba").Start(ctx, "nba.Client.GetInjuryReport")
+	defer span.End()
+
+	reportTime, err := InjuryReportTime(reportTime)
+	if err != nil {
+		return InjuryReport{}, err
+	}
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(injuryReportURL, reportTime.Format(injuryReportTimeFormat)), nil)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return InjuryReport{}, fmt.Errorf("failed to create request to get injury report: %w", err)
+	}
+
+	response, err := do(c.client, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return InjuryReport{}, fmt.Errorf("failed to get injury report: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		err = fmt.Errorf("failed to successfully get injury report with status code: %d", response.StatusCode)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if slices.Contains([]int{http.StatusNotFound, http.StatusForbidden}, response.StatusCode) {
+			return InjuryReport{}, ErrNotFound
+		}
+		return InjuryReport{}, err
+	}
+
+	respBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return InjuryReport{}, fmt.Errorf("failed to read injury report response: %w", err)
+	}
+
+	if c.Cache != nil {
+		if err := c.Cache.PutObject(ctx, objectKey, bytes.NewReader(respBody)); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return InjuryReport{}, fmt.Errorf("failed to cache injury report object: %w", err)
+		}
+	}
+
+	rows, err := InjuryReportRowsFromPDF(bytes.NewReader(respBody), int64(len(respBody)))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return InjuryReport{}, err
+	}
+
+	return ParseInjuryReportRows(reportTime, rows)
+}
+
+type injuryReportTextSegment struct {
+	x    float64
+	text string
+}
+
+// InjuryReportRowsFromPDF lays the text of the injury report pdf out into rows of the report's columns. The pdf has no
+// table structure so the text is grouped into lines by its y coordinate and each piece of text is put in the column
+// whose header starts at or before it.
+func InjuryReportRowsFromPDF(r io.ReaderAt, size int64) ([][]string, error) {
+	reader, err := pdf.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read injury report pdf: %w", err)
+	}
+
+	var rows [][]string
+	var columnStarts []float64
+
+	for pageNum := 1; pageNum <= reader.NumPage(); pageNum++ {
+		page := reader.Page(pageNum)
+		if page.V.IsNull() {
+			continue
+		}
+
+		for _, line := range injuryReportLines(page.Content().Text) {
+			if starts, ok := injuryReportColumnStarts(line); ok {
+				columnStarts = starts
+				continue
+			}
+			if columnStarts == nil {
+				// title and report time above the first header
+				continue
+			}
+
+			row := make([]string, injuryReportColumnCount)
+			for _, segment := range line {
+				column := 0
+				for i, start := range columnStarts {
+					// allow a little slack since centered or bolded text can start just left of its header
+					if segment.x >= start-2 {
+						column = i
+					}
+				}
+				row[column] = strings.TrimSpace(row[column] + " " + segment.text)
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, nil
+}
+
+// injuryReportLines groups the text into lines from the top of the page down where each line is made up of segments
+// of text separated by whitespace
+func injuryReportLines(texts []pdf.Text) [][]injuryReportTextSegment {
+	sorted := slices.Clone(texts)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if math.Abs(sorted[i].Y-sorted[j].Y) > 1 {
+			return sorted[i].Y > sorted[j].Y
+		}
+		return sorted[i].X < sorted[j].X
+	})
+
+	var lines [][]injuryReportTextSegment
+	var line []injuryReportTextSegment
+	lineY := math.Inf(1)
+	lastEnd := 0.0
+	breakSegment := true
+
+	for _, text := range sorted {
+		if math.Abs(text.Y-lineY) > 1 {
+			if len(line) > 0 {
+				lines = append(lines, line)
+			}
+			line = nil
+			lineY = text.Y
+			breakSegment = true
+		}
+
+		if strings.TrimSpace(text.S) == "" {
+			breakSegment = true
+			continue
+		}
+
+		// text is often split into individual characters so only start a new segment on a visible gap
+		if breakSegment || text.X-lastEnd > text.FontSize*0.2 {
+			line = append(line, injuryReportTextSegment{x: text.X, text: text.S})
+		} else {
+			line[len(line)-1].text += text.S
+		}
+		lastEnd = text.X + text.W
+		breakSegment = false
+	}
+	if len(line) > 0 {
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// injuryReportColumnStarts returns the x coordinate each column starts at if the line is the header of the report
+func injuryReportColumnStarts(line []injuryReportTextSegment) ([]float64, bool) {
+	var starts []float64
+
+	segmentIndex := 0
+	for _, header := range injuryReportHeaders {
+		headerWords := strings.Fields(header)
+
+		found := false
+		for ; segmentIndex+len(headerWords) <= len(line); segmentIndex++ {
+			matches := true
+			for i, headerWord := range headerWords {
+				if line[segmentIndex+i].text != headerWord {
+					matches = false
+					break
+				}
+			}
+			if matches {
+				starts = append(starts, line[segmentIndex].x)
+				segmentIndex += len(headerWords)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+
+	return starts, true
+}
+
+// ParseInjuryReportRows turns the rows of the injury report into entries. The report only lists the game and team on
+// the first row for them, and reasons too long for their column wrap onto the following row, so both are carried
+// between rows.
+func ParseInjuryReportRows(reportTime time.Time, rows [][]string) (InjuryReport, error) {
+	eastCoastLoc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return InjuryReport{}, fmt.Errorf("failed to load east coast location for injury report: %w", err)
+	}
+
+	report := InjuryReport{ReportTime: reportTime, Entries: []InjuryReportEntry{}}
+
+	var current InjuryReportEntry
+	lastRowWasEntry := false
+
+	for rowNum, row := range rows {
+		if len(row) != injuryReportColumnCount {
+			return InjuryReport{}, fmt.Errorf("injury report row %d has %d columns and expected %d", rowNum, len(row), injuryReportColumnCount)
+		}
+
+		// the title and page numbers repeat on every page and can land in any column
+		joined := strings.TrimSpace(strings.Join(row, " "))
+		if row[injuryReportColumnGameDate] == injuryReportHeaders[injuryReportColumnGameDate] || strings.HasPrefix(joined, "Injury Report:") || strings.HasPrefix(joined, "Page ") {
+			lastRowWasEntry = false
+			continue
+		}
+
+		if gameDate := row[injuryReportColumnGameDate]; gameDate != "" {
+			d, err := time.ParseInLocation("01/02/2006", gameDate, eastCoastLoc)
+			if err != nil {
+				return InjuryReport{}, fmt.Errorf("injury report row %d has invalid game date %s: %w", rowNum, gameDate, err)
+			}
+			current.GameDate = d
+		}
+		if gameTime := row[injuryReportColumnGameTime]; gameTime != "" {
+			current.GameTime = gameTime
+		}
+		if matchup := row[injuryReportColumnMatchup]; matchup != "" {
+			away, home, found := strings.Cut(matchup, "@")
+			if !found {
+				return InjuryReport{}, fmt.Errorf("injury report row %d has invalid matchup %s", rowNum, matchup)
+			}
+			current.Matchup = matchup
+			current.AwayTricode = away
+			current.HomeTricode = home
+		}
+		if team := row[injuryReportColumnTeam]; team != "" {
+			current.Team = team
+		}
+
+		playerName := row[injuryReportColumnPlayerName]
+		status := row[injuryReportColumnCurrentStatus]
+		reason := row[injuryReportColumnReason]
+
+		if playerName == "" && status == "" {
+			if reason == injuryReportNotYetSubmitted || reason == "" {
+				lastRowWasEntry = false
+				continue
+			}
+			if !lastRowWasEntry {
+				return InjuryReport{}, fmt.Errorf("injury report row %d has a reason without a player", rowNum)
+			}
+			last := &report.Entries[len(report.Entries)-1]
+			last.Reason = strings.TrimSpace(last.Reason + " " + reason)
+			continue
+		}
+
+		if current.GameDate.IsZero() || current.Matchup == "" || current.Team == "" {
+			return InjuryReport{}, fmt.Errorf("injury report row %d for player %s is missing its game or team", rowNum, playerName)
+		}
+
+		injuryStatus := InjuryStatus(strings.ToLower(status))
+		if !slices.Contains([]InjuryStatus{InjuryStatusOut, InjuryStatusDoubtful, InjuryStatusQuestionable, InjuryStatusProbable, InjuryStatusAvailable}, injuryStatus) {
+			return InjuryReport{}, fmt.Errorf("injury report row %d has invalid status %s for player %s", rowNum, status, playerName)
+		}
+
+		entry := current
+		entry.PlayerName = playerName
+		entry.Status = injuryStatus
+		entry.Reason = reason
+
+		lastName, firstName, found := strings.Cut(playerName, ",")
+		if found {
+			entry.PlayerLastName = strings.TrimSpace(lastName)
+			entry.PlayerFirstName = strings.TrimSpace(firstName)
+		} else {
+			entry.PlayerLastName = playerName
+		}
+
+		report.Entries = append(report.Entries, entry)
+		lastRowWasEntry = true
+	}
+
+	return report, nil
+}
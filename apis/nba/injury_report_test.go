@@ -0,0 +1,85 @@
+package nba
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseInjuryReportRows(t *testing.T) {
+	eastCoastLoc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("failed to load east coast location with err: %v", err)
+	}
+	reportTime := time.Date(2024, 1, 15, 17, 0, 0, 0, eastCoastLoc)
+	gameDate := time.Date(2024, 1, 15, 0, 0, 0, 0, eastCoastLoc)
+	nextGameDate := time.Date(2024, 1, 16, 0, 0, 0, 0, eastCoastLoc)
+
+	minDen := InjuryReportEntry{GameDate: gameDate, GameTime: "03:00 (ET)", Matchup: "MIN@DEN", AwayTricode: "MIN", HomeTricode: "DEN"}
+
+	entry := func(game InjuryReportEntry, team string, firstName string, lastName string, status InjuryStatus, reason string) InjuryReportEntry {
+		game.Team = team
+		game.PlayerName = lastName + ", " + firstName
+		game.PlayerFirstName = firstName
+		game.PlayerLastName = lastName
+		game.Status = status
+		game.Reason = reason
+		return game
+	}
+
+	tests := []struct {
+		name    string
+		fixture string
+		want    []InjuryReportEntry
+		wantErr bool
+	}{
+		{
+			name:    "carries games and teams between rows and joins wrapped reasons",
+			fixture: "injury_report_2024-01-15_05PM.json",
+			want: []InjuryReportEntry{
+				entry(minDen, "Minnesota Timberwolves", "Anthony", "Edwards", InjuryStatusQuestionable, "Injury/Illness - Left Ankle; Sprain"),
+				entry(minDen, "Minnesota Timberwolves", "Rudy", "Gobert", InjuryStatusOut, "Injury/Illness - Right Hamstring; Strain"),
+				entry(minDen, "Minnesota Timberwolves", "Wendell", "Moore", InjuryStatusOut, "G League - Two-Way"),
+				entry(minDen, "Denver Nuggets", "Jamal", "Murray", InjuryStatusProbable, "Injury/Illness - Right Ankle; Sprain"),
+				entry(minDen, "Denver Nuggets", "Jaren", "Jackson Jr.", InjuryStatusDoubtful, "Injury/Illness - Left Knee; Soreness"),
+				entry(InjuryReportEntry{GameDate: gameDate, GameTime: "07:30 (ET)", Matchup: "LAL@LAC", AwayTricode: "LAL", HomeTricode: "LAC"}, "LA Clippers", "Kawhi", "Leonard", InjuryStatusAvailable, "Injury/Illness - Right Knee; Injury Management"),
+				entry(InjuryReportEntry{GameDate: nextGameDate, GameTime: "08:00 (ET)", Matchup: "BOS@MIL", AwayTricode: "BOS", HomeTricode: "MIL"}, "Boston Celtics", "Kristaps", "Porzingis", InjuryStatusOut, "Injury/Illness - Left Calf; Strain"),
+			},
+		},
+		{
+			name:    "unknown status fails",
+			fixture: "injury_report_invalid_status.json",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture, err := os.ReadFile(filepath.Join("testdata", tt.fixture))
+			if err != nil {
+				t.Fatalf("failed to read fixture with err: %v", err)
+			}
+			var rows [][]string
+			if err := json.Unmarshal(fixture, &rows); err != nil {
+				t.Fatalf("failed to unmarshal fixture with err: %v", err)
+			}
+
+			got, err := ParseInjuryReportRows(reportTime, rows)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseInjuryReportRows() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !got.ReportTime.Equal(reportTime) {
+				t.Errorf("ParseInjuryReportRows() report time = %v, want %v", got.ReportTime, reportTime)
+			}
+			if !reflect.DeepEqual(got.Entries, tt.want) {
+				t.Errorf("ParseInjuryReportRows() entries = %+v, want %+v", got.Entries, tt.want)
+			}
+		})
+	}
+}
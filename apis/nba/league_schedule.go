@@ -57,7 +57,7 @@ func (c Client) CurrentLeagueSchedule(ctx context.Context, objectKey string) (Le
 		return LeagueSchedule{}, fmt.Errorf("failed to create request to get league schedule: %w", err)
 	}
 
-	response, err := c.client.Do(req)
+	response, err := do(c.client, req)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
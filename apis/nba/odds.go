@@ -1,3 +1,179 @@
 package nba
 
-//https://cdn.nba.com/static/json/liveData/odds/odds_todaysGames.json
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const todaysGamesOddsURL = "https://cdn.nba.com/static/json/liveData/odds/odds_todaysGames.json"
+
+type OddsMarketType string
+
+const (
+	OddsMarketTypeMoneyline OddsMarketType = "moneyline"
+	OddsMarketTypeSpread    OddsMarketType = "spread"
+	OddsMarketTypeTotal     OddsMarketType = "total"
+	OddsMarketTypeUnknown   OddsMarketType = "unknown"
+)
+
+type TodaysGamesOdds struct {
+	Games []GameOdds `json:"games"`
+}
+
+type GameOdds struct {
+	GameID    string       `json:"gameId"`
+	SRID      string       `json:"sr_id"` // ex. sr:match:41633293
+	SRMatchID string       `json:"srMatchId"`
+	Markets   []OddsMarket `json:"markets"`
+}
+
+type OddsMarket struct {
+	Name       string          `json:"name"` // ex. 2way, spread
+	OddsTypeID int             `json:"odds_type_id"`
+	GroupName  string          `json:"group_name"` // ex. regular
+	Books      []OddsBookmaker `json:"books"`
+}
+
+// Type normalizes the market name since the feed has used a few names for the same markets
+func (m OddsMarket) Type() OddsMarketType {
+	switch m.Name {
+	case "2way", "moneyline":
+		return OddsMarketTypeMoneyline
+	case "spread", "handicap":
+		return OddsMarketTypeSpread
+	case "total", "totals", "over_under":
+		return OddsMarketTypeTotal
+	default:
+		return OddsMarketTypeUnknown
+	}
+}
+
+type OddsBookmaker struct {
+	ID          string        `json:"id"`   // ex. sr:book:18186
+	Name        string        `json:"name"` // ex. FanDuel
+	Outcomes    []OddsOutcome `json:"outcomes"`
+	URL         string        `json:"url"`
+	CountryCode string        `json:"countryCode"` // ex. US
+}
+
+type OddsOutcome struct {
+	OddsFieldID   int         `json:"odds_field_id"`
+	Type          string      `json:"type"`         // ex. home, away, over, under
+	Odds          oddsDecimal `json:"odds"`         // decimal odds ex. 1.909
+	OpeningOdds   oddsDecimal `json:"opening_odds"` // decimal odds ex. 1.870
+	OddsTrend     string      `json:"odds_trend"`   // ex. up, down, neutral
+	Spread        oddsDecimal `json:"spread"`       // ex. -3.5 for the home outcome of a spread market
+	OpeningSpread oddsDecimal `json:"opening_spread"`
+	Total         oddsDecimal `json:"total"` // ex. 224.5 for a total market
+	OpeningTotal  oddsDecimal `json:"opening_total"`
+}
+
+// Line returns the spread or total the odds are for; moneylines don't have one
+func (o OddsOutcome) Line() (float64, bool) {
+	if o.Spread.Valid {
+		return o.Spread.Float64, true
+	}
+	return o.Total.Float64, o.Total.Valid
+}
+
+func (o OddsOutcome) OpeningLine() (float64, bool) {
+	if o.OpeningSpread.Valid {
+		return o.OpeningSpread.Float64, true
+	}
+	return o.OpeningTotal.Float64, o.OpeningTotal.Valid
+}
+
+// oddsDecimal handles the odds feed returning numbers as either json numbers or strings and leaving out missing values
+type oddsDecimal struct {
+	Float64 float64
+	Valid   bool
+}
+
+func (o *oddsDecimal) UnmarshalJSON(data []byte) error {
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		raw := ""
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("could not unmarshal nba odds value: %s error: %w", string(data), err)
+		}
+		n = json.Number(raw)
+	}
+
+	if n == "" {
+		*o = oddsDecimal{}
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil {
+		return fmt.Errorf("could not parse nba odds value: %s error: %w", string(data), err)
+	}
+
+	*o = oddsDecimal{Float64: f, Valid: true}
+
+	return nil
+}
+
+func (c Client) GetTodaysGamesOdds(ctx context.Context, objectKey string) (TodaysGamesOdds, error) {
+	ctx, span := otel.Tracer("nba").Start(ctx, "nba.Client.GetTodaysGamesOdds")
+	defer span.End()
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodGet, todaysGamesOddsURL, nil)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return TodaysGamesOdds{}, fmt.Errorf("failed to create request to get todays games odds: %w", err)
+	}
+
+	response, err := do(c.client, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return TodaysGamesOdds{}, fmt.Errorf("failed to get TodaysGamesOdds object: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		err = fmt.Errorf("failed to successfully get TodaysGamesOdds object")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if slices.Contains([]int{http.StatusNotFound, http.StatusForbidden}, response.StatusCode) {
+			return TodaysGamesOdds{}, ErrNotFound
+		}
+		return TodaysGamesOdds{}, err
+	}
+
+	respBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return TodaysGamesOdds{}, fmt.Errorf("failed to read todays games odds response: %w", err)
+	}
+
+	if c.Cache != nil {
+		if err := c.Cache.PutObject(ctx, objectKey, bytes.NewReader(respBody)); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return TodaysGamesOdds{}, fmt.Errorf("failed to cache todays games odds object: %w", err)
+		}
+	}
+
+	var odds TodaysGamesOdds
+	if err := json.Unmarshal(respBody, &odds); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return TodaysGamesOdds{}, fmt.Errorf("failed to unmarshal todays games odds json: %w", err)
+	}
+
+	return odds, nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
@@ -22,7 +23,7 @@ func (c Client) PlayByPlayForGame(ctx context.Context, gameID string, outputWrit
 		return PlayByPlay{}, fmt.Errorf("failed to create request to get play by play for game: %w", err)
 	}
 
-	response, err := c.client.Do(req)
+	response, err := do(c.client, req)
 	if err != nil {
 		return PlayByPlay{}, fmt.Errorf("failed to make call to get playbyplay: %w", err)
 	}
@@ -61,66 +62,168 @@ type PlayByPlay struct {
 		Time    string `json:"time"`
 	} `json:"meta"`
 	Game struct {
-		GameID  string `json:"gameId"`
-		Actions []struct {
-			ActionNumber             int       `json:"actionNumber"`
-			Clock                    duration  `json:"clock"`
-			TimeActual               time.Time `json:"timeActual"`
-			Period                   int       `json:"period"`
-			PeriodType               string    `json:"periodType"`
-			ActionType               string    `json:"actionType"`
-			SubType                  string    `json:"subType,omitempty"`
-			Qualifiers               []string  `json:"qualifiers"` // ex. ["2ndchance"], ["pointsinthepaint"], ["pointsinthepaint", "2ndchance"], ["fromturnover"]
-			PersonID                 int       `json:"personId"`
-			X                        *float64  `json:"x"`
-			Y                        *float64  `json:"y"`
-			Possession               int       `json:"possession"`
-			ScoreHome                string    `json:"scoreHome"`
-			ScoreAway                string    `json:"scoreAway"`
-			Edited                   time.Time `json:"edited"`
-			OrderNumber              int       `json:"orderNumber"`
-			XLegacy                  *int      `json:"xLegacy"`
-			YLegacy                  *int      `json:"yLegacy"`
-			IsFieldGoal              int       `json:"isFieldGoal"`
-			Side                     *string   `json:"side"` // ex. "left", "right"
-			Description              string    `json:"description,omitempty"`
-			PersonIdsFilter          []int     `json:"personIdsFilter"`
-			TeamID                   int       `json:"teamId,omitempty"`
-			TeamTricode              string    `json:"teamTricode,omitempty"`
-			Descriptor               string    `json:"descriptor,omitempty"`
-			JumpBallRecoveredName    string    `json:"jumpBallRecoveredName,omitempty"`
-			JumpBallRecoverdPersonID int       `json:"jumpBallRecoverdPersonId,omitempty"`
-			PlayerName               string    `json:"playerName,omitempty"`
-			PlayerNameI              string    `json:"playerNameI,omitempty"`
-			JumpBallWonPlayerName    *string   `json:"jumpBallWonPlayerName,omitempty"`
-			JumpBallWonPersonID      *int      `json:"jumpBallWonPersonId,omitempty"`
-			JumpBallLostPlayerName   *string   `json:"jumpBallLostPlayerName,omitempty"`
-			JumpBallLostPersonID     *int      `json:"jumpBallLostPersonId,omitempty"`
-			ShotDistance             float64   `json:"shotDistance,omitempty"`
-			ShotResult               string    `json:"shotResult,omitempty"`
-			PointsTotal              int       `json:"pointsTotal,omitempty"`
-			AssistPlayerNameInitial  *string   `json:"assistPlayerNameInitial,omitempty"`
-			AssistPersonID           *int      `json:"assistPersonId,omitempty"`
-			AssistTotal              *int      `json:"assistTotal,omitempty"`
-			OfficialID               int       `json:"officialId,omitempty"`
-			ShotActionNumber         int       `json:"shotActionNumber,omitempty"`
-			ReboundTotal             int       `json:"reboundTotal,omitempty"`
-			ReboundDefensiveTotal    int       `json:"reboundDefensiveTotal,omitempty"`
-			ReboundOffensiveTotal    int       `json:"reboundOffensiveTotal,omitempty"`
-			FoulPersonalTotal        int       `json:"foulPersonalTotal,omitempty"`
-			FoulTechnicalTotal       int       `json:"foulTechnicalTotal,omitempty"`
-			FoulDrawnPlayerName      *string   `json:"foulDrawnPlayerName,omitempty"`
-			FoulDrawnPersonID        *int      `json:"foulDrawnPersonId,omitempty"`
-			TurnoverTotal            int       `json:"turnoverTotal,omitempty"`
-			StealPlayerName          *string   `json:"stealPlayerName,omitempty"`
-			StealPersonID            *int      `json:"stealPersonId,omitempty"`
-			Value                    string    `json:"value,omitempty"`
-			BlockPlayerName          *string   `json:"blockPlayerName,omitempty"`
-			BlockPersonID            *int      `json:"blockPersonId,omitempty"`
-		} `json:"actions"`
+		GameID  string             `json:"gameId"`
+		Actions []PlayByPlayAction `json:"actions"`
 	} `json:"game"`
 }
 
+type PlayByPlayAction struct {
+	ActionNumber             int       `json:"actionNumber"`
+	Clock                    duration  `json:"clock"`
+	TimeActual               time.Time `json:"timeActual"`
+	Period                   int       `json:"period"`
+	PeriodType               string    `json:"periodType"`
+	ActionType               string    `json:"actionType"`
+	SubType                  string    `json:"subType,omitempty"`
+	Qualifiers               []string  `json:"qualifiers"` // ex. ["2ndchance"], ["pointsinthepaint"], ["pointsinthepaint", "2ndchance"], ["fromturnover"]
+	PersonID                 int       `json:"personId"`
+	X                        *float64  `json:"x"`
+	Y                        *float64  `json:"y"`
+	Possession               int       `json:"possession"`
+	ScoreHome                string    `json:"scoreHome"`
+	ScoreAway                string    `json:"scoreAway"`
+	Edited                   time.Time `json:"edited"`
+	OrderNumber              int       `json:"orderNumber"`
+	XLegacy                  *int      `json:"xLegacy"`
+	YLegacy                  *int      `json:"yLegacy"`
+	IsFieldGoal              int       `json:"isFieldGoal"`
+	Side                     *string   `json:"side"` // ex. "left", "right"
+	Description              string    `json:"description,omitempty"`
+	PersonIdsFilter          []int     `json:"personIdsFilter"`
+	TeamID                   int       `json:"teamId,omitempty"`
+	TeamTricode              string    `json:"teamTricode,omitempty"`
+	Descriptor               string    `json:"descriptor,omitempty"`
+	JumpBallRecoveredName    string    `json:"jumpBallRecoveredName,omitempty"`
+	JumpBallRecoverdPersonID int       `json:"jumpBallRecoverdPersonId,omitempty"`
+	PlayerName               string    `json:"playerName,omitempty"`
+	PlayerNameI              string    `json:"playerNameI,omitempty"`
+	JumpBallWonPlayerName    *string   `json:"jumpBallWonPlayerName,omitempty"`
+	JumpBallWonPersonID      *int      `json:"jumpBallWonPersonId,omitempty"`
+	JumpBallLostPlayerName   *string   `json:"jumpBallLostPlayerName,omitempty"`
+	JumpBallLostPersonID     *int      `json:"jumpBallLostPersonId,omitempty"`
+	ShotDistance             float64   `json:"shotDistance,omitempty"`
+	ShotResult               string    `json:"shotResult,omitempty"`
+	PointsTotal              int       `json:"pointsTotal,omitempty"`
+	AssistPlayerNameInitial  *string   `json:"assistPlayerNameInitial,omitempty"`
+	AssistPersonID           *int      `json:"assistPersonId,omitempty"`
+	AssistTotal              *int      `json:"assistTotal,omitempty"`
+	OfficialID               int       `json:"officialId,omitempty"`
+	ShotActionNumber         int       `json:"shotActionNumber,omitempty"`
+	ReboundTotal             int       `json:"reboundTotal,omitempty"`
+	ReboundDefensiveTotal    int       `json:"reboundDefensiveTotal,omitempty"`
+	ReboundOffensiveTotal    int       `json:"reboundOffensiveTotal,omitempty"`
+	FoulPersonalTotal        int       `json:"foulPersonalTotal,omitempty"`
+	FoulTechnicalTotal       int       `json:"foulTechnicalTotal,omitempty"`
+	FoulDrawnPlayerName      *string   `json:"foulDrawnPlayerName,omitempty"`
+	FoulDrawnPersonID        *int      `json:"foulDrawnPersonId,omitempty"`
+	TurnoverTotal            int       `json:"turnoverTotal,omitempty"`
+	StealPlayerName          *string   `json:"stealPlayerName,omitempty"`
+	StealPersonID            *int      `json:"stealPersonId,omitempty"`
+	Value                    string    `json:"value,omitempty"`
+	BlockPlayerName          *string   `json:"blockPlayerName,omitempty"`
+	BlockPersonID            *int      `json:"blockPersonId,omitempty"`
+}
+
+const (
+	// DefaultRegulationPeriods is the number of regulation periods to fall back to for games that don't say
+	DefaultRegulationPeriods = 4
+
+	regulationPeriodLength = 12 * time.Minute
+	overtimePeriodLength   = 5 * time.Minute
+)
+
+// Score returns the running home and away score at the time of the action
+func (a PlayByPlayAction) Score() (int, int, error) {
+	homeScore, err := strconv.Atoi(a.ScoreHome)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse home score %q for play by play action %d: %w", a.ScoreHome, a.ActionNumber, err)
+	}
+	awayScore, err := strconv.Atoi(a.ScoreAway)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse away score %q for play by play action %d: %w", a.ScoreAway, a.ActionNumber, err)
+	}
+
+	return homeScore, awayScore, nil
+}
+
+// PeriodLength returns the length of the period the action happened in
+func (a PlayByPlayAction) PeriodLength(regulationPeriods int) time.Duration {
+	return PeriodLength(a.Period, regulationPeriods)
+}
+
+// ClockRemaining returns the time left in the period at the time of the action
+func (a PlayByPlayAction) ClockRemaining() time.Duration {
+	return time.Duration(a.Clock.DurationTenthSeconds) * 100 * time.Millisecond
+}
+
+// GameElapsed returns the game time that has elapsed from tipoff to the action
+func (a PlayByPlayAction) GameElapsed(regulationPeriods int) time.Duration {
+	return GameElapsed(a.Period, regulationPeriods, a.ClockRemaining())
+}
+
+// PeriodLength returns the length of the given period; periods past the regulation periods are overtime periods
+func PeriodLength(period int, regulationPeriods int) time.Duration {
+	if period > regulationPeriods {
+		return overtimePeriodLength
+	}
+	return regulationPeriodLength
+}
+
+// GameElapsed returns the game time elapsed from tipoff to the given point in the given period
+func GameElapsed(period int, regulationPeriods int, clockRemaining time.Duration) time.Duration {
+	elapsed := time.Duration(0)
+	for p := 1; p < period; p++ {
+		elapsed += PeriodLength(p, regulationPeriods)
+	}
+
+	return elapsed + PeriodLength(period, regulationPeriods) - clockRemaining
+}
+
+// GameRemaining returns the game time left from the given point in the given period until the end of regulation, or
+// until the end of the current overtime period once the game is in overtime
+func GameRemaining(period int, regulationPeriods int, clockRemaining time.Duration) time.Duration {
+	remaining := clockRemaining
+	for p := period + 1; p <= regulationPeriods; p++ {
+		remaining += PeriodLength(p, regulationPeriods)
+	}
+
+	return remaining
+}
+
+type PlayByPlayTeams struct {
+	HomeTeamID  int
+	HomeTricode string
+	AwayTeamID  int
+	AwayTricode string
+}
+
+// Teams works out which team is home and which is away from the running score since the play by play feed doesn't
+// otherwise say which side a team is on
+func (p PlayByPlay) Teams() PlayByPlayTeams {
+	teams := PlayByPlayTeams{}
+	prevHome, prevAway := 0, 0
+	for _, action := range p.Game.Actions {
+		home, away, err := action.Score()
+		if err != nil {
+			continue
+		}
+		if home > prevHome && teams.HomeTeamID == 0 {
+			teams.HomeTeamID = action.TeamID
+			teams.HomeTricode = action.TeamTricode
+		}
+		if away > prevAway && teams.AwayTeamID == 0 {
+			teams.AwayTeamID = action.TeamID
+			teams.AwayTricode = action.TeamTricode
+		}
+		prevHome, prevAway = home, away
+		if teams.HomeTeamID != 0 && teams.AwayTeamID != 0 {
+			break
+		}
+	}
+
+	return teams
+}
+
 type PlayByPlayV3 struct {
 	Meta struct {
 		Version int       `json:"version"`
@@ -166,7 +269,7 @@ func (c Client) PlayByPlayV3ForGame(ctx context.Context, gameID string, outputWr
 		return PlayByPlayV3{}, fmt.Errorf("failed to get play by play v3 for game: %w", err)
 	}
 
-	response, err := c.statsClient.Do(req)
+	response, err := do(c.statsClient, req)
 	if err != nil {
 		return PlayByPlayV3{}, err
 	}
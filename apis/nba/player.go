@@ -39,7 +39,12 @@ func GetPlayers(seasonStartYear int) ([]Player, error) {
 	url := fmt.Sprintf(seasonPlayersURL, seasonStartYear)
 	response, err := http.Get(url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get players for year %d from url %s %w", seasonStartYear, url, err)
+		return nil, fmt.Errorf("failed to get players for year %d from url %s %w: %w", seasonStartYear, url, ErrUnavailable, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusInternalServerError {
+		return nil, fmt.Errorf("failed to get players for year %d from url %s %w: status %d", seasonStartYear, url, ErrUnavailable, response.StatusCode)
 	}
 
 	playersResult, err := unmarshalNBAHttpResponseToJSON[Players](response.Body)
@@ -0,0 +1,66 @@
+package nba
+
+import (
+	"strconv"
+)
+
+const (
+	gameIDSeasonStagePost   = 4
+	gameIDSeasonStagePlayIn = 5
+)
+
+// PlayoffGameID is the series information encoded in a playoff or play-in game id
+// ex. 0042300405 is game 5 of the series 0 in round 4 (the finals) of the 2023 playoffs
+type PlayoffGameID struct {
+	PlayIn       bool
+	Round        int
+	SeriesNumber int
+	GameNumber   int
+}
+
+// GameIDSeasonStage returns the season stage encoded in a game id
+// ex. 1 for preseason, 2 for regular season, 3 for all-star, 4 for playoffs and 5 for play-in
+func GameIDSeasonStage(gameID string) (int, bool) {
+	if len(gameID) != 10 {
+		return 0, false
+	}
+
+	stage, err := strconv.Atoi(gameID[2:3])
+	if err != nil {
+		return 0, false
+	}
+
+	return stage, true
+}
+
+// ParsePlayoffGameID parses the round, series and game number out of a playoff or play-in game id
+// game ids are formatted as 00TYY00RSG where T is the season stage, YY the season start year, R the round,
+// S the series number and G the game number in the series
+func ParsePlayoffGameID(gameID string) (PlayoffGameID, bool) {
+	stage, ok := GameIDSeasonStage(gameID)
+	if !ok || (stage != gameIDSeasonStagePost && stage != gameIDSeasonStagePlayIn) {
+		return PlayoffGameID{}, false
+	}
+
+	round, err := strconv.Atoi(gameID[7:8])
+	if err != nil {
+		return PlayoffGameID{}, false
+	}
+
+	seriesNumber, err := strconv.Atoi(gameID[8:9])
+	if err != nil {
+		return PlayoffGameID{}, false
+	}
+
+	gameNumber, err := strconv.Atoi(gameID[9:10])
+	if err != nil {
+		return PlayoffGameID{}, false
+	}
+
+	return PlayoffGameID{
+		PlayIn:       stage == gameIDSeasonStagePlayIn,
+		Round:        round,
+		SeriesNumber: seriesNumber,
+		GameNumber:   gameNumber,
+	}, true
+}
@@ -132,7 +132,7 @@ func (c Client) GetTodaysScoreboard(ctx context.Context, objectKey string) (Toda
 		return TodaysScoreboard{}, fmt.Errorf("failed to create request to get todays scoreboard: %w", err)
 	}
 
-	response, err := c.client.Do(req)
+	response, err := do(c.client, req)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
@@ -14,6 +14,8 @@ const (
 	endpointNameLeagueGameLog     endpointName = "leaguegamelog"
 	endpointNameFranchiseHistory  endpointName = "franchisehistory"
 	endpointNameLeagueStandingsV3 endpointName = "leaguestandingsv3"
+
+	endpointNameBoxscoreTraditionalV2 endpointName = "boxscoretraditionalv2"
 )
 
 type statsBaseResponse struct {
@@ -14,8 +14,9 @@ import (
 	"go.opentelemetry.io/otel/codes"
 )
 
+const TeamLogoURL = "https://cdn.nba.com/logos/nba/%d/primary/L/logo.svg"
+
 const (
-	teamLogoUrl           = "https://cdn.nba.com/logos/nba/%d/primary/L/logo.svg"
 	teamCommonInfoBaseURL = "https://stats.nba.com/stats/teaminfocommon?"
 	teamStandingsURL      = "https://stats.nba.com/stats/leaguestandingsv3?"
 )
@@ -48,7 +49,7 @@ func (c Client) CommonTeamInfo(ctx context.Context, leagueID string, teamID int)
 	if err != nil {
 		return TeamCommonInfo{}, fmt.Errorf("failed to create request to get common team info: %w", err)
 	}
-	response, err := c.statsClient.Do(req)
+	response, err := do(c.statsClient, req)
 	if err != nil {
 		return TeamCommonInfo{}, fmt.Errorf("failed to get current teams from nba from url %s: %w", teamURL, err)
 	}
@@ -197,7 +198,7 @@ func (c Client) TeamStandings(ctx context.Context, leagueID string, seasonStartY
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request to get team standings: %w", err)
 	}
-	response, err := c.statsClient.Do(req)
+	response, err := do(c.statsClient, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get team standings from nba from url %s: %w", u, err)
 	}
@@ -5,27 +5,48 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 	_ "time/tzdata"
 
 	"github.com/drewthor/wolves_reddit_bot/apis/cloudflare"
 	"github.com/drewthor/wolves_reddit_bot/apis/nba"
+	"github.com/drewthor/wolves_reddit_bot/internal/api_key"
 	"github.com/drewthor/wolves_reddit_bot/internal/arena"
 	"github.com/drewthor/wolves_reddit_bot/internal/boxscore"
+	"github.com/drewthor/wolves_reddit_bot/internal/broadcast"
+	"github.com/drewthor/wolves_reddit_bot/internal/chart"
 	"github.com/drewthor/wolves_reddit_bot/internal/franchise"
 	"github.com/drewthor/wolves_reddit_bot/internal/game"
+	"github.com/drewthor/wolves_reddit_bot/internal/game_analysis"
 	"github.com/drewthor/wolves_reddit_bot/internal/game_referee"
+	"github.com/drewthor/wolves_reddit_bot/internal/graph"
+	"github.com/drewthor/wolves_reddit_bot/internal/head_to_head"
+	"github.com/drewthor/wolves_reddit_bot/internal/history"
+	"github.com/drewthor/wolves_reddit_bot/internal/injury_report"
 	"github.com/drewthor/wolves_reddit_bot/internal/league"
+	"github.com/drewthor/wolves_reddit_bot/internal/live"
+	"github.com/drewthor/wolves_reddit_bot/internal/milestone"
+	"github.com/drewthor/wolves_reddit_bot/internal/notification"
+	"github.com/drewthor/wolves_reddit_bot/internal/odds"
 	"github.com/drewthor/wolves_reddit_bot/internal/playbyplay"
 	"github.com/drewthor/wolves_reddit_bot/internal/player"
+	"github.com/drewthor/wolves_reddit_bot/internal/player_game_stats"
+	"github.com/drewthor/wolves_reddit_bot/internal/playoff"
 	"github.com/drewthor/wolves_reddit_bot/internal/r2"
 	"github.com/drewthor/wolves_reddit_bot/internal/referee"
+	"github.com/drewthor/wolves_reddit_bot/internal/response_cache"
+	"github.com/drewthor/wolves_reddit_bot/internal/schedule_context"
 	"github.com/drewthor/wolves_reddit_bot/internal/scheduler"
 	"github.com/drewthor/wolves_reddit_bot/internal/season"
+	"github.com/drewthor/wolves_reddit_bot/internal/stat_correction"
 	"github.com/drewthor/wolves_reddit_bot/internal/store/postgres"
 	"github.com/drewthor/wolves_reddit_bot/internal/team"
 	"github.com/drewthor/wolves_reddit_bot/internal/team_game_stats"
+	"github.com/drewthor/wolves_reddit_bot/internal/team_schedule"
 	"github.com/drewthor/wolves_reddit_bot/internal/team_season"
+	"github.com/drewthor/wolves_reddit_bot/internal/webhook"
+	"github.com/drewthor/wolves_reddit_bot/internal/win_probability"
 	"github.com/drewthor/wolves_reddit_bot/pkg/chimiddleware"
 	"github.com/drewthor/wolves_reddit_bot/pkg/pgxutil"
 	"github.com/drewthor/wolves_reddit_bot/pkg/rlhttp"
@@ -142,35 +163,114 @@ func main() {
 
 	postgresStore := postgres.NewDB(dbpool)
 
-	arenaService := arena.NewService(postgresStore)
+	arenaLocations, err := arena.DefaultLocations()
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to load arena locations", slog.Any("error", err))
+		os.Exit(1)
+	}
+	apiKeyService := api_key.NewService(postgresStore)
+	go apiKeyService.WriteLastUsed(ctx, logger)
+	arenaService := arena.NewService(postgresStore, arenaLocations)
 	boxscoreService := boxscore.NewService()
+	broadcastService := broadcast.NewService(postgresStore)
 	teamSeasonService := team_season.NewService(postgresStore, nbaClient)
 	teamService := team.NewService(postgresStore, teamSeasonService, nbaClient)
-	franchiseService := franchise.NewService(postgresStore, teamService, teamSeasonService, nbaClient)
+	scoringRunMinPoints := game_analysis.DefaultMinRunPoints
+	if minPoints := os.Getenv("SCORING_RUN_MIN_POINTS"); minPoints != "" {
+		scoringRunMinPoints, err = strconv.Atoi(minPoints)
+		if err != nil || scoringRunMinPoints <= 0 {
+			logger.ErrorContext(ctx, "invalid SCORING_RUN_MIN_POINTS, expected a positive number of points", slog.String("points", minPoints))
+			os.Exit(1)
+		}
+	}
+	gameAnalysisService := game_analysis.NewService(postgresStore, scoringRunMinPoints)
 	gameRefereeService := game_referee.NewService(postgresStore)
 	leagueService := league.NewService(postgresStore)
-	playByPlayService := playbyplay.NewService(nbaClient, r2Client, postgresStore)
+	liveService := live.NewService(postgresStore)
+	winProbabilityModel, err := win_probability.DefaultModel()
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to load win probability model", slog.Any("error", err))
+		os.Exit(1)
+	}
+	winProbabilityService := win_probability.NewService(postgresStore, winProbabilityModel)
+	playByPlayService := playbyplay.NewService(nbaClient, r2Client, postgresStore, winProbabilityService)
+	playoffService := playoff.NewService(postgresStore)
 	refereeService := referee.NewService(postgresStore)
 	seasonService := season.NewService(postgresStore, nbaClient)
+	franchiseService := franchise.NewService(postgresStore, seasonService, teamService, teamSeasonService, nbaClient)
+	scheduleContextService := schedule_context.NewService(postgresStore, seasonService)
+	headToHeadService := head_to_head.NewService(postgresStore, seasonService)
+	teamScheduleService := team_schedule.NewService(postgresStore, teamService, seasonService)
 	teamGameStatsService := team_game_stats.NewService(postgresStore)
+	playerGameStatsService := player_game_stats.NewService(postgresStore)
+	milestoneService := milestone.NewService(postgresStore)
+	playerService := player.NewService(postgresStore)
+	webhookService := webhook.NewService(postgresStore, &http.Client{})
 	gameService := game.NewService(
 		postgresStore,
 		arenaService,
+		broadcastService,
+		gameAnalysisService,
 		gameRefereeService,
 		leagueService,
+		liveService,
+		milestoneService,
 		playByPlayService,
+		playerService,
+		playerGameStatsService,
+		playoffService,
 		refereeService,
+		scheduleContextService,
 		seasonService,
 		teamService,
 		teamGameStatsService,
+		webhookService,
 		nbaClient,
 		r2Client,
 	)
-	playerService := player.NewService(postgresStore)
-	schedulerService := scheduler.NewService(gameService, seasonService, nbaClient)
+	graphService, err := graph.NewService(postgresStore, gameService)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to build graphql schema", slog.Any("error", err))
+		os.Exit(1)
+	}
+	chartService := chart.NewService(postgresStore, r2Client)
+	oddsService := odds.NewService(postgresStore, nbaClient)
+	injuryReportService := injury_report.NewService(postgresStore, nbaClient)
+	historyService := history.NewService(postgresStore)
+	statCorrectionService := stat_correction.NewService(postgresStore, gameService)
+	statCorrectionWindow := stat_correction.DefaultWindow
+	if window := os.Getenv("STAT_CORRECTION_WINDOW"); window != "" {
+		statCorrectionWindow, err = time.ParseDuration(window)
+		if err != nil {
+			logger.ErrorContext(ctx, "invalid STAT_CORRECTION_WINDOW, expected a duration ex. 72h", slog.Any("error", err))
+			os.Exit(1)
+		}
+	}
+	notificationChannels := []notification.Channel{}
+	if channelsFile := os.Getenv("NOTIFICATION_CHANNELS_FILE"); channelsFile != "" {
+		notificationChannels, err = notification.LoadChannels(channelsFile)
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to load notification channels", slog.String("file", channelsFile), slog.Any("error", err))
+			os.Exit(1)
+		}
+	}
+	notificationService := notification.NewService(notificationChannels, teamService, &http.Client{})
+	schedulerService := scheduler.NewService(gameService, seasonService, chartService, injuryReportService, milestoneService, oddsService, statCorrectionService, statCorrectionWindow, webhookService, notificationService, nbaClient)
 	schedulerService.Start(logger)
 	defer schedulerService.Stop()
 
+	// responses are only cached when a size is configured
+	var responseCacheService response_cache.Service
+	if cacheSize := os.Getenv("RESPONSE_CACHE_SIZE"); cacheSize != "" {
+		size, err := strconv.Atoi(cacheSize)
+		if err != nil || size <= 0 {
+			logger.ErrorContext(ctx, "invalid RESPONSE_CACHE_SIZE, expected a positive number of responses", slog.String("size", cacheSize))
+			os.Exit(1)
+		}
+		responseCacheService = response_cache.NewService(postgresStore, size)
+		go responseCacheService.Listen(ctx, logger)
+	}
+
 	sentryMiddleware := sentryhttp.New(sentryhttp.Options{
 		Repanic: true,
 	})
@@ -182,11 +282,32 @@ func main() {
 	r.Use(sentryMiddleware.Handle)
 	r.Use(otelchi.Middleware("nba", otelchi.WithChiRoutes(r)))
 
-	r.Mount("/games", game.NewHandler(logger, gameService).Routes())
-	r.Mount("/players", player.NewHandler(logger, playerService).Routes())
-	r.Mount("/teams", team.NewHandler(logger, teamService).Routes())
-	r.Mount("/boxscores", boxscore.NewHandler(logger, boxscoreService).Routes())
-	r.Mount("/franchises", franchise.NewHandler(logger, franchiseService).Routes())
+	mountRoutes(r, logger, services{
+		apiKey:          apiKeyService,
+		boxscore:        boxscoreService,
+		broadcast:       broadcastService,
+		chart:           chartService,
+		franchise:       franchiseService,
+		game:            gameService,
+		gameAnalysis:    gameAnalysisService,
+		graph:           graphService,
+		headToHead:      headToHeadService,
+		history:         historyService,
+		injuryReport:    injuryReportService,
+		live:            liveService,
+		milestone:       milestoneService,
+		odds:            oddsService,
+		player:          playerService,
+		playoff:         playoffService,
+		referee:         refereeService,
+		responseCache:   responseCacheService,
+		scheduleContext: scheduleContextService,
+		statCorrection:  statCorrectionService,
+		team:            teamService,
+		teamSchedule:    teamScheduleService,
+		webhook:         webhookService,
+		winProbability:  winProbabilityService,
+	})
 
 	logger.InfoContext(ctx, "starting http server")
 
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/drewthor/wolves_reddit_bot/internal/api_key"
+	"github.com/drewthor/wolves_reddit_bot/internal/store/postgres"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+)
+
+const usage = `usage:
+  api_key mint -name <name> [-scopes read,ingest,admin] [-rate <requests per second>] [-burst <requests>]
+  api_key revoke <id>
+  api_key list`
+
+// api_key mints, revokes and lists the keys used to authenticate to the api. A minted key is only printed once since
+// only its hash is stored
+func main() {
+	ctx := context.Background()
+
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	err := godotenv.Load()
+	if err != nil {
+		slog.Debug("Error loading .env file")
+	}
+
+	dbpool, err := pgxpool.New(ctx, os.Getenv("DATABASE_URL"))
+	if err != nil {
+		slog.Error("unable to connect to database", slog.Any("error", err))
+		os.Exit(1)
+	}
+	defer dbpool.Close()
+
+	apiKeyService := api_key.NewService(postgres.NewDB(dbpool))
+
+	switch os.Args[1] {
+	case "mint":
+		flags := flag.NewFlagSet("mint", flag.ExitOnError)
+		name := flags.String("name", "", "who or what the key is for")
+		scopes := flags.String("scopes", string(api_key.ScopeRead), "comma separated scopes, a scope includes the ones before it in read, ingest, admin")
+		rateLimit := flags.Float64("rate", api_key.DefaultRateLimit, "requests per second the key is allowed on average")
+		burst := flags.Int("burst", api_key.DefaultBurst, "requests the key can make at once")
+		flags.Parse(os.Args[2:])
+
+		apiKey, err := apiKeyService.Mint(ctx, api_key.KeyCreate{
+			Name:      *name,
+			Scopes:    strings.Split(*scopes, ","),
+			RateLimit: *rateLimit,
+			Burst:     *burst,
+		})
+		if err != nil {
+			slog.Error("failed to mint api key", slog.Any("error", err))
+			os.Exit(1)
+		}
+
+		printJSON(apiKey)
+		fmt.Fprintln(os.Stderr, "store the key now, it can't be shown again")
+	case "revoke":
+		if len(os.Args) != 3 {
+			fmt.Fprintln(os.Stderr, usage)
+			os.Exit(2)
+		}
+
+		apiKey, err := apiKeyService.Revoke(ctx, os.Args[2])
+		if err != nil {
+			slog.Error("failed to revoke api key", slog.String("id", os.Args[2]), slog.Any("error", err))
+			os.Exit(1)
+		}
+
+		printJSON(apiKey)
+	case "list":
+		apiKeys, err := apiKeyService.List(ctx)
+		if err != nil {
+			slog.Error("failed to list api keys", slog.Any("error", err))
+			os.Exit(1)
+		}
+
+		printJSON(apiKeys)
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func printJSON(v any) {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		slog.Error("failed to write output", slog.Any("error", err))
+		os.Exit(1)
+	}
+}
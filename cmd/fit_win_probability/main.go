@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log/slog"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/drewthor/wolves_reddit_bot/apis/cloudflare"
+	"github.com/drewthor/wolves_reddit_bot/apis/nba"
+	"github.com/drewthor/wolves_reddit_bot/internal/win_probability"
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"github.com/joho/godotenv"
+)
+
+// fitOutput is the model file, the metrics are ignored when the model is embedded but show how good the fit was
+type fitOutput struct {
+	win_probability.Model
+	Games int                     `json:"games"`
+	Train win_probability.Metrics `json:"train"`
+	// HeldOut is left out when there were too few games to hold any out
+	HeldOut *win_probability.Metrics `json:"held_out,omitempty"`
+}
+
+// fit_win_probability fits the win probability model to the play by play archived in r2 and writes the coefficients
+// along with how well they fit to the model file embedded in the win_probability package
+func main() {
+	ctx := context.Background()
+
+	outPath := flag.String("out", "internal/win_probability/model.json", "path to write the fitted model to")
+	iterations := flag.Int("iterations", 25, "max newton iterations")
+	holdout := flag.Float64("holdout", 0.2, "fraction of the most recent games held out of the fit to measure it on")
+	flag.Parse()
+
+	if *holdout < 0 || *holdout >= 1 {
+		slog.Error("holdout must be at least 0 and less than 1", slog.Float64("holdout", *holdout))
+		os.Exit(1)
+	}
+
+	err := godotenv.Load()
+	if err != nil {
+		slog.Debug("Error loading .env file")
+	}
+
+	r2Client := cloudflare.NewClient(os.Getenv("CLOUDFLARE_ACCOUNT_ID"), os.Getenv("CLOUDFLARE_ACCESS_KEY_ID"), os.Getenv("CLOUDFLARE_ACCESS_KEY_SECRET"))
+
+	keys, err := r2Client.ListObjectKeys(ctx, util.NBAR2Bucket, "playbyplay/")
+	if err != nil {
+		slog.Error("failed to list archived play by play", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	var games []nba.PlayByPlay
+	for _, key := range keys {
+		// skip the charts archived next to the play by play
+		if path.Ext(key) != ".json" || strings.Contains(path.Base(key), "_") {
+			continue
+		}
+
+		b, err := r2Client.GetObject(ctx, util.NBAR2Bucket, key)
+		if err != nil {
+			slog.Error("failed to get archived play by play", slog.String("key", key), slog.Any("error", err))
+			os.Exit(1)
+		}
+
+		pbp := nba.PlayByPlay{}
+		if err := json.Unmarshal(b, &pbp); err != nil {
+			slog.Warn("skipping unparsable archived play by play", slog.String("key", key), slog.Any("error", err))
+			continue
+		}
+		if len(pbp.Game.Actions) == 0 {
+			continue
+		}
+
+		games = append(games, pbp)
+	}
+
+	// play the games in order so each team's record only includes the games before it, like it does when modeling
+	// live games
+	slices.SortFunc(games, func(a, b nba.PlayByPlay) int {
+		return a.Game.Actions[0].TimeActual.Compare(b.Game.Actions[0].TimeActual)
+	})
+
+	// each game's samples are kept together so the held out games are whole games
+	gameSamples := [][]win_probability.Sample{}
+	type record struct{ wins, losses int }
+	records := map[int]record{}
+	season := ""
+	for _, pbp := range games {
+		// game ids look like 0022300061 where 23 is the season start year
+		if gameSeason := pbp.Game.GameID[3:5]; gameSeason != season {
+			season = gameSeason
+			records = map[int]record{}
+		}
+
+		teams := pbp.Teams()
+		home, away := records[teams.HomeTeamID], records[teams.AwayTeamID]
+		strengthDiff := win_probability.WinPercentage(home.wins, home.losses) - win_probability.WinPercentage(away.wins, away.losses)
+
+		// the archived play by play doesn't say how many regulation periods the game had
+		states := win_probability.GameStates(pbp.Game.Actions, teams.HomeTeamID, teams.AwayTeamID, strengthDiff, nba.DefaultRegulationPeriods)
+		finalMargin := states[len(states)-1].Margin
+		if finalMargin == 0 {
+			continue
+		}
+
+		homeWon := finalMargin > 0
+		samples := make([]win_probability.Sample, 0, len(states))
+		for _, state := range states {
+			samples = append(samples, win_probability.Sample{State: state, HomeWon: homeWon})
+		}
+		gameSamples = append(gameSamples, samples)
+
+		if homeWon {
+			home.wins++
+			away.losses++
+		} else {
+			home.losses++
+			away.wins++
+		}
+		records[teams.HomeTeamID], records[teams.AwayTeamID] = home, away
+	}
+
+	// the held out games are the most recent so they're measured the way the model is used, on games after the ones it
+	// was fit to
+	trainGames := len(gameSamples) - int(float64(len(gameSamples))**holdout)
+	train, heldOut := slices.Concat(gameSamples[:trainGames]...), slices.Concat(gameSamples[trainGames:]...)
+
+	slog.Info("fitting win probability model", slog.Int("games", len(gameSamples)), slog.Int("held_out_games", len(gameSamples)-trainGames))
+
+	out := fitOutput{}
+	if len(heldOut) > 0 {
+		heldOutModel, err := win_probability.Fit(train, *iterations)
+		if err != nil {
+			slog.Error("failed to fit win probability model without the held out games", slog.Any("error", err))
+			os.Exit(1)
+		}
+		metrics := win_probability.Evaluate(heldOutModel, heldOut)
+		out.HeldOut = &metrics
+	}
+
+	// the embedded model is fit to every game once the held out games have measured it
+	all := slices.Concat(gameSamples...)
+	model, err := win_probability.Fit(all, *iterations)
+	if err != nil {
+		slog.Error("failed to fit win probability model", slog.Any("error", err))
+		os.Exit(1)
+	}
+	out.Model = model
+	out.Games = len(gameSamples)
+	out.Train = win_probability.Evaluate(model, all)
+
+	b, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		slog.Error("failed to marshal win probability model", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(*outPath, append(b, '\n'), 0644); err != nil {
+		slog.Error("failed to write win probability model", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	slog.Info("wrote win probability model", slog.String("path", *outPath), slog.Any("model", model), slog.Any("train", out.Train), slog.Any("held_out", out.HeldOut))
+}
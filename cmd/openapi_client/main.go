@@ -0,0 +1,27 @@
+package main
+
+import (
+	"flag"
+	"log/slog"
+	"os"
+
+	"github.com/drewthor/wolves_reddit_bot/internal/openapi"
+)
+
+// generates the api's Go client from the openapi spec, run by go generate in pkg/client
+func main() {
+	out := flag.String("out", "client.gen.go", "the file to write the client to")
+	packageName := flag.String("package", "client", "the client's package name")
+	flag.Parse()
+
+	source, err := openapi.GenerateClient(openapi.Spec(), *packageName)
+	if err != nil {
+		slog.Error("failed to generate client", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(*out, source, 0o644); err != nil {
+		slog.Error("failed to write client", slog.String("file", *out), slog.Any("error", err))
+		os.Exit(1)
+	}
+}
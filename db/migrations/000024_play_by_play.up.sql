@@ -11,7 +11,7 @@ create table play_by_play
     period integer not null,
     action_number integer not null,
 
-    unique (game_id, action_number),
+    unique (game_id, action_number)
 );
 
 create or replace trigger set_timestamp
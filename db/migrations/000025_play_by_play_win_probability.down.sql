@@ -0,0 +1,11 @@
+begin;
+
+alter table play_by_play drop column if exists home_win_probability;
+alter table play_by_play drop column if exists possession_team_id;
+alter table play_by_play drop column if exists away_score;
+alter table play_by_play drop column if exists home_score;
+alter table play_by_play drop column if exists description;
+alter table play_by_play drop column if exists action_type;
+alter table play_by_play drop column if exists clock_tenth_seconds;
+
+commit;
@@ -0,0 +1,11 @@
+begin;
+
+alter table play_by_play add column clock_tenth_seconds integer;
+alter table play_by_play add column action_type text;
+alter table play_by_play add column description text;
+alter table play_by_play add column home_score integer;
+alter table play_by_play add column away_score integer;
+alter table play_by_play add column possession_team_id uuid references team (id);
+alter table play_by_play add column home_win_probability double precision;
+
+commit;
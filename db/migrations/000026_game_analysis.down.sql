@@ -0,0 +1,10 @@
+begin;
+
+drop table if exists player_game_clutch_stats;
+drop table if exists game_clutch_segment;
+drop table if exists game_score_event;
+drop table if exists game_scoring_run;
+
+alter table play_by_play drop column if exists shot_result;
+
+commit;
@@ -0,0 +1,100 @@
+begin;
+
+alter table play_by_play add column shot_result text;
+
+create table game_scoring_run
+(
+    id                        uuid                     default gen_random_uuid() not null primary key,
+    created_at                timestamp with time zone default now()             not null,
+    updated_at                timestamp with time zone,
+    game_id                   uuid references game (id)                          not null,
+    team_id                   uuid references team (id),
+    points                    integer                                            not null,
+    start_action_number       integer                                            not null,
+    start_period              integer                                            not null,
+    start_clock_tenth_seconds integer,
+    end_action_number         integer                                            not null,
+    end_period                integer                                            not null,
+    end_clock_tenth_seconds   integer,
+
+    unique (game_id, start_action_number)
+);
+
+create or replace trigger set_timestamp
+    before update
+    on game_scoring_run
+    for each row
+execute procedure trigger_set_timestamp();
+
+create table game_score_event
+(
+    id                  uuid                     default gen_random_uuid() not null primary key,
+    created_at          timestamp with time zone default now()             not null,
+    updated_at          timestamp with time zone,
+    game_id             uuid references game (id)                          not null,
+    type                text                                               not null,
+    action_number       integer                                            not null,
+    period              integer                                            not null,
+    clock_tenth_seconds integer,
+    home_score          integer                                            not null,
+    away_score          integer                                            not null,
+    leading_team_id     uuid references team (id),
+
+    unique (game_id, action_number)
+);
+
+create or replace trigger set_timestamp
+    before update
+    on game_score_event
+    for each row
+execute procedure trigger_set_timestamp();
+
+create table game_clutch_segment
+(
+    id                        uuid                     default gen_random_uuid() not null primary key,
+    created_at                timestamp with time zone default now()             not null,
+    updated_at                timestamp with time zone,
+    game_id                   uuid references game (id)                          not null,
+    period                    integer                                            not null,
+    start_action_number       integer                                            not null,
+    start_clock_tenth_seconds integer,
+    end_action_number         integer                                            not null,
+    end_clock_tenth_seconds   integer,
+
+    unique (game_id, start_action_number)
+);
+
+create or replace trigger set_timestamp
+    before update
+    on game_clutch_segment
+    for each row
+execute procedure trigger_set_timestamp();
+
+create table player_game_clutch_stats
+(
+    id                        uuid                     default gen_random_uuid() not null primary key,
+    created_at                timestamp with time zone default now()             not null,
+    updated_at                timestamp with time zone,
+    game_id                   uuid references game (id)                          not null,
+    player_id                 uuid references player (id)                        not null,
+    team_id                   uuid references team (id),
+    points                    integer                                            not null,
+    field_goals_made          integer                                            not null,
+    field_goals_attempted     integer                                            not null,
+    three_pointers_made       integer                                            not null,
+    three_pointers_attempted  integer                                            not null,
+    free_throws_made          integer                                            not null,
+    free_throws_attempted     integer                                            not null,
+    rebounds                  integer                                            not null,
+    turnovers                 integer                                            not null,
+
+    unique (game_id, player_id)
+);
+
+create or replace trigger set_timestamp
+    before update
+    on player_game_clutch_stats
+    for each row
+execute procedure trigger_set_timestamp();
+
+commit;
@@ -0,0 +1,7 @@
+begin;
+
+drop index if exists play_by_play_game_id_action_type_index;
+
+alter table play_by_play drop column if exists sub_type;
+
+commit;
@@ -0,0 +1,8 @@
+begin;
+
+alter table play_by_play add column sub_type text;
+
+create index play_by_play_game_id_action_type_index
+    on play_by_play (game_id, action_type);
+
+commit;
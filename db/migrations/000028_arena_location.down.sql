@@ -0,0 +1,9 @@
+begin;
+
+drop view if exists team_game_schedule_context;
+
+alter table arena drop column longitude;
+alter table arena drop column latitude;
+alter table arena drop column timezone;
+
+commit;
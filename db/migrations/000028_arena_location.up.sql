@@ -0,0 +1,58 @@
+begin;
+
+alter table arena add column timezone text;
+alter table arena add column latitude double precision;
+alter table arena add column longitude double precision;
+
+-- one row per team per game with how far and how recently the team last played that season
+create or replace view team_game_schedule_context as
+with team_game as (select g.id           as game_id,
+                          g.season_id,
+                          g.start_time,
+                          g.home_team_id as team_id,
+                          true           as home,
+                          a.id           as arena_id,
+                          a.timezone,
+                          a.latitude,
+                          a.longitude
+                   from game g
+                            left join arena a on a.id = g.arena_id
+                   union all
+                   select g.id,
+                          g.season_id,
+                          g.start_time,
+                          g.away_team_id,
+                          false,
+                          a.id,
+                          a.timezone,
+                          a.latitude,
+                          a.longitude
+                   from game g
+                            left join arena a on a.id = g.arena_id),
+     previous_game as (select tg.*,
+                              lag(tg.game_id) over w    as previous_game_id,
+                              lag(tg.start_time) over w as previous_start_time,
+                              lag(tg.timezone) over w   as previous_timezone,
+                              lag(tg.latitude) over w   as previous_latitude,
+                              lag(tg.longitude) over w  as previous_longitude
+                       from team_game tg
+                       where tg.team_id is not null
+                       window w as (partition by tg.team_id, tg.season_id order by tg.start_time))
+select game_id,
+       team_id,
+       season_id,
+       start_time,
+       home,
+       arena_id,
+       previous_game_id,
+       (start_time at time zone coalesce(timezone, 'UTC'))::date -
+       (previous_start_time at time zone coalesce(previous_timezone, 'UTC'))::date - 1 as rest_days,
+       3958.8 * 2 * asin(sqrt(
+                   power(sin(radians(latitude - previous_latitude) / 2), 2) +
+                   cos(radians(previous_latitude)) * cos(radians(latitude)) *
+                   power(sin(radians(longitude - previous_longitude) / 2), 2)))            as miles_traveled,
+       (extract(epoch from (start_time at time zone timezone) -
+                           (start_time at time zone previous_timezone)) / 3600)::integer    as time_zones_crossed
+from previous_game;
+
+commit;
@@ -0,0 +1,6 @@
+begin;
+
+drop table if exists game_broadcast;
+drop table if exists broadcaster;
+
+commit;
@@ -0,0 +1,46 @@
+begin;
+
+create table broadcaster
+(
+    id                 uuid                     default gen_random_uuid() not null primary key,
+    created_at         timestamp with time zone default now()             not null,
+    updated_at         timestamp with time zone,
+    display            text                                               not null,
+    abbreviation       text                                               not null,
+    region_id          integer,
+    nba_broadcaster_id integer                                            not null unique
+);
+
+create or replace trigger set_timestamp
+    before update
+    on broadcaster
+    for each row
+execute procedure trigger_set_timestamp();
+
+create index broadcaster_abbreviation_index
+    on broadcaster (upper(abbreviation));
+
+create table game_broadcast
+(
+    id                  uuid                     default gen_random_uuid() not null primary key,
+    created_at          timestamp with time zone default now()             not null,
+    updated_at          timestamp with time zone,
+    game_id             uuid references game (id)                          not null,
+    broadcaster_id      uuid references broadcaster (id)                   not null,
+    scope               text                                               not null,
+    media               text                                               not null,
+    tape_delay_comments text,
+
+    unique (game_id, broadcaster_id, scope, media)
+);
+
+create or replace trigger set_timestamp
+    before update
+    on game_broadcast
+    for each row
+execute procedure trigger_set_timestamp();
+
+create index game_broadcast_broadcaster_id_index
+    on game_broadcast (broadcaster_id);
+
+commit;
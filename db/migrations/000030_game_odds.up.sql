@@ -0,0 +1,29 @@
+begin;
+
+-- a row is only added when a bookmaker's odds or line for an outcome changes so the rows are the line history
+create table game_odds
+(
+    id                uuid                     default gen_random_uuid() not null primary key,
+    created_at        timestamp with time zone default now()             not null,
+    updated_at        timestamp with time zone,
+    game_id           uuid references game (id)                          not null,
+    nba_bookmaker_id  text                                               not null,
+    bookmaker         text                                               not null,
+    market            text                                               not null,
+    outcome           text                                               not null,
+    odds              double precision,
+    line              double precision,
+    opening_odds      double precision,
+    opening_line      double precision,
+    captured_at       timestamp with time zone                           not null,
+
+    unique (game_id, nba_bookmaker_id, market, outcome, captured_at)
+);
+
+create or replace trigger set_timestamp
+    before update
+    on game_odds
+    for each row
+execute procedure trigger_set_timestamp();
+
+commit;
@@ -0,0 +1,6 @@
+begin;
+
+drop table if exists injury_report_entry;
+drop table if exists injury_report;
+
+commit;
@@ -0,0 +1,45 @@
+begin;
+
+create table injury_report
+(
+    id          uuid                     default gen_random_uuid() not null primary key,
+    created_at  timestamp with time zone default now()             not null,
+    updated_at  timestamp with time zone,
+    report_time timestamp with time zone                           not null unique
+);
+
+create or replace trigger set_timestamp
+    before update
+    on injury_report
+    for each row
+execute procedure trigger_set_timestamp();
+
+create table injury_report_entry
+(
+    id               uuid                     default gen_random_uuid() not null primary key,
+    created_at       timestamp with time zone default now()             not null,
+    updated_at       timestamp with time zone,
+    injury_report_id uuid references injury_report (id)                 not null,
+    game_id          uuid references game (id),
+    team_id          uuid references team (id),
+    player_id        uuid references player (id),
+    game_date        date                                               not null,
+    matchup          text                                               not null,
+    team_name        text                                               not null,
+    player_name      text                                               not null,
+    status           text                                               not null,
+    reason           text,
+
+    unique (injury_report_id, team_name, player_name)
+);
+
+create or replace trigger set_timestamp
+    before update
+    on injury_report_entry
+    for each row
+execute procedure trigger_set_timestamp();
+
+create index injury_report_entry_game_id_index
+    on injury_report_entry (game_id);
+
+commit;
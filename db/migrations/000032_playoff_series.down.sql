@@ -0,0 +1,9 @@
+begin;
+
+drop table if exists playoff_series;
+
+alter table game drop column if_necessary;
+alter table game drop column away_team_seed;
+alter table game drop column home_team_seed;
+
+commit;
@@ -0,0 +1,37 @@
+begin;
+
+alter table game add column home_team_seed integer;
+alter table game add column away_team_seed integer;
+alter table game add column if_necessary   boolean default false not null;
+
+create table playoff_series
+(
+    id                uuid                     default gen_random_uuid() not null primary key,
+    created_at        timestamp with time zone default now()             not null,
+    updated_at        timestamp with time zone,
+    season_id         uuid references season (id)                        not null,
+    play_in           boolean                                            not null,
+    round             integer                                            not null,
+    series_number     integer                                            not null,
+    conference        text,
+    high_seed_team_id uuid references team (id),
+    low_seed_team_id  uuid references team (id),
+    high_seed         integer,
+    low_seed          integer,
+    high_seed_wins    integer                  default 0                 not null,
+    low_seed_wins     integer                  default 0                 not null,
+    wins_needed       integer                                            not null,
+    games_scheduled   integer                  default 0                 not null,
+    status            text                                               not null,
+    winner_team_id    uuid references team (id),
+
+    unique (season_id, play_in, round, series_number)
+);
+
+create or replace trigger set_timestamp
+    before update
+    on playoff_series
+    for each row
+execute procedure trigger_set_timestamp();
+
+commit;
@@ -0,0 +1,11 @@
+begin;
+
+drop index if exists team_franchise_id_index;
+
+alter table team_season drop column if exists city;
+alter table team_season drop column if exists name;
+
+alter table franchise drop column if exists end_year;
+alter table franchise drop column if exists start_year;
+
+commit;
@@ -0,0 +1,13 @@
+begin;
+
+-- written by the franchise history ingest but never added by a migration
+alter table franchise add column if not exists start_year integer;
+alter table franchise add column if not exists end_year   integer;
+
+alter table team_season add column if not exists name text;
+alter table team_season add column if not exists city text;
+
+create index if not exists team_franchise_id_index
+    on team (franchise_id);
+
+commit;
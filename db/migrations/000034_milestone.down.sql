@@ -0,0 +1,7 @@
+begin;
+
+drop table if exists milestone;
+
+drop index if exists player_team_game_stats_total_player_id_index;
+
+commit;
@@ -0,0 +1,35 @@
+begin;
+
+create index if not exists player_team_game_stats_total_player_id_index
+    on player_team_game_stats_total (player_id);
+
+create table milestone
+(
+    id              uuid                     default gen_random_uuid() not null primary key,
+    created_at      timestamp with time zone default now()             not null,
+    updated_at      timestamp with time zone,
+    game_id         uuid references game (id)                          not null,
+    team_id         uuid references team (id),
+    player_id       uuid references player (id),
+    play_by_play_id uuid references play_by_play (id),
+    type            text                                               not null,
+    stat            text                                               not null,
+    value           integer                                            not null,
+    previous_value  integer,
+    description     text                                               not null
+);
+
+create or replace trigger set_timestamp
+    before update
+    on milestone
+    for each row
+execute procedure trigger_set_timestamp();
+
+-- a milestone is only recorded once per game no matter how many times the game is ingested
+create unique index milestone_game_id_type_stat_team_id_player_id_uindex
+    on milestone (game_id, type, stat, coalesce(team_id, '00000000-0000-0000-0000-000000000000'), coalesce(player_id, '00000000-0000-0000-0000-000000000000'));
+
+create index milestone_player_id_index
+    on milestone (player_id);
+
+commit;
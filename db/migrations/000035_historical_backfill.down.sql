@@ -0,0 +1,7 @@
+begin;
+
+alter table player_team_game_stats_total drop column if exists source;
+alter table team_game_stats_total drop column if exists source;
+alter table game drop column if exists source;
+
+commit;
@@ -0,0 +1,9 @@
+begin;
+
+-- where each row came from, seasons before the cdn boxscores are backfilled from the stats.nba.com game logs and
+-- traditional boxscores which don't have arenas, officials or most advanced stats
+alter table game add column source                         text default 'cdn' not null;
+alter table team_game_stats_total add column source        text default 'cdn' not null;
+alter table player_team_game_stats_total add column source text default 'cdn' not null;
+
+commit;
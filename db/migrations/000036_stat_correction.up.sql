@@ -0,0 +1,31 @@
+begin;
+
+-- one row per field that changed when a completed game was re-ingested
+create table stat_correction
+(
+    id            uuid                     default gen_random_uuid() not null primary key,
+    created_at    timestamp with time zone default now()             not null,
+    updated_at    timestamp with time zone,
+    game_id       uuid references game (id)                          not null,
+    entity        text                                               not null,
+    team_id       uuid references team (id),
+    player_id     uuid references player (id),
+    action_number integer,
+    field         text                                               not null,
+    old_value     text,
+    new_value     text
+);
+
+create or replace trigger set_timestamp
+    before update
+    on stat_correction
+    for each row
+execute procedure trigger_set_timestamp();
+
+create index stat_correction_game_id_index
+    on stat_correction (game_id);
+
+create index stat_correction_created_at_index
+    on stat_correction (created_at);
+
+commit;
@@ -0,0 +1,18 @@
+begin;
+
+drop trigger if exists record_history on game_referee;
+drop trigger if exists record_history on player_team_game_stats_total;
+drop trigger if exists record_history on team_game_stats_total;
+drop trigger if exists record_history on team_season;
+drop trigger if exists record_history on franchise;
+drop trigger if exists record_history on referee;
+drop trigger if exists record_history on arena;
+drop trigger if exists record_history on player;
+drop trigger if exists record_history on team;
+drop trigger if exists record_history on game;
+
+drop function if exists record_history();
+
+drop table if exists history;
+
+commit;
@@ -0,0 +1,142 @@
+begin;
+
+-- every insert or update of an ingested row, written by the record_history trigger
+create table history
+(
+    id            uuid                     default gen_random_uuid() not null primary key,
+    created_at    timestamp with time zone default now()             not null,
+    table_name    text                                               not null,
+    operation     text                                               not null,
+    row_id        uuid                                               not null,
+    -- the game, team and player the row belongs to so the history of each can be found without knowing every table
+    game_id       uuid,
+    team_id       uuid,
+    player_id     uuid,
+    -- only the columns that changed for updates, the whole row for inserts
+    old_values    jsonb,
+    new_values    jsonb,
+    -- set per transaction by the store from the ingest run the change was made by
+    source_key    text,
+    ingest_run_id uuid
+);
+
+create index history_game_id_index
+    on history (game_id);
+
+create index history_team_id_index
+    on history (team_id);
+
+create index history_player_id_index
+    on history (player_id);
+
+create index history_ingest_run_id_index
+    on history (ingest_run_id);
+
+-- the search path is pinned to the one the migration runs with since the store qualifies tables instead of setting it
+create or replace function record_history() returns trigger
+    language plpgsql
+    set search_path from current
+as
+$$
+DECLARE
+    old_row    jsonb;
+    new_row    jsonb := to_jsonb(NEW) - 'created_at' - 'updated_at';
+    old_values jsonb;
+    new_values jsonb;
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        old_row := to_jsonb(OLD) - 'created_at' - 'updated_at';
+
+        SELECT jsonb_object_agg(o.key, o.value), jsonb_object_agg(n.key, n.value)
+        INTO old_values, new_values
+        FROM jsonb_each(old_row) o
+        JOIN jsonb_each(new_row) n ON n.key = o.key
+        WHERE o.value IS DISTINCT FROM n.value;
+
+        -- upserts that don't change anything aren't history
+        IF new_values IS NULL THEN
+            RETURN NULL;
+        END IF;
+    ELSE
+        new_values := new_row;
+    END IF;
+
+    INSERT INTO history (table_name, operation, row_id, game_id, team_id, player_id, old_values, new_values, source_key, ingest_run_id)
+    VALUES (
+        TG_TABLE_NAME,
+        lower(TG_OP),
+        (new_row ->> 'id')::uuid,
+        CASE WHEN TG_TABLE_NAME = 'game' THEN new_row ->> 'id' ELSE new_row ->> 'game_id' END::uuid,
+        CASE WHEN TG_TABLE_NAME = 'team' THEN new_row ->> 'id' ELSE new_row ->> 'team_id' END::uuid,
+        CASE WHEN TG_TABLE_NAME = 'player' THEN new_row ->> 'id' ELSE new_row ->> 'player_id' END::uuid,
+        old_values,
+        new_values,
+        nullif(current_setting('nba.source_key', true), ''),
+        nullif(current_setting('nba.ingest_run_id', true), '')::uuid
+    );
+
+    RETURN NULL;
+END;
+$$;
+
+create or replace trigger record_history
+    after insert or update
+    on game
+    for each row
+execute procedure record_history();
+
+create or replace trigger record_history
+    after insert or update
+    on team
+    for each row
+execute procedure record_history();
+
+create or replace trigger record_history
+    after insert or update
+    on player
+    for each row
+execute procedure record_history();
+
+create or replace trigger record_history
+    after insert or update
+    on arena
+    for each row
+execute procedure record_history();
+
+create or replace trigger record_history
+    after insert or update
+    on referee
+    for each row
+execute procedure record_history();
+
+create or replace trigger record_history
+    after insert or update
+    on franchise
+    for each row
+execute procedure record_history();
+
+create or replace trigger record_history
+    after insert or update
+    on team_season
+    for each row
+execute procedure record_history();
+
+create or replace trigger record_history
+    after insert or update
+    on team_game_stats_total
+    for each row
+execute procedure record_history();
+
+create or replace trigger record_history
+    after insert or update
+    on player_team_game_stats_total
+    for each row
+execute procedure record_history();
+
+create or replace trigger record_history
+    after insert or update
+    on game_referee
+    for each row
+execute procedure record_history();
+
+commit;
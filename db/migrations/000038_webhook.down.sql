@@ -0,0 +1,11 @@
+begin;
+
+drop table if exists webhook_game_state;
+
+drop view if exists webhook_dead_letter;
+
+drop table if exists webhook_delivery;
+
+drop table if exists webhook_subscription;
+
+commit;
@@ -0,0 +1,92 @@
+begin;
+
+create table webhook_subscription
+(
+    id          uuid                     default gen_random_uuid() not null primary key,
+    created_at  timestamp with time zone default now()             not null,
+    updated_at  timestamp with time zone,
+    url         text                                               not null,
+    -- secret signs deliveries so it has to be kept in plain text
+    secret      text                                               not null,
+    event_types text[]                                             not null,
+    -- empty filters match every team or league
+    team_ids    uuid[]                   default '{}'              not null,
+    league_ids  uuid[]                   default '{}'              not null,
+    active      boolean                  default true              not null
+);
+
+create or replace trigger set_timestamp
+    before update
+    on webhook_subscription
+    for each row
+execute procedure trigger_set_timestamp();
+
+create table webhook_delivery
+(
+    id                   uuid                     default gen_random_uuid() not null primary key,
+    created_at           timestamp with time zone default now()             not null,
+    updated_at           timestamp with time zone,
+    subscription_id      uuid references webhook_subscription (id) on delete cascade not null,
+    -- event_key identifies the event so a transition seen by overlapping ingests is only delivered once
+    event_key            text                                               not null,
+    event_id             uuid                                               not null,
+    event_type           text                                               not null,
+    game_id              uuid references game (id),
+    payload              jsonb                                              not null,
+    status               text                     default 'pending'         not null,
+    attempts             integer                  default 0                 not null,
+    next_attempt_at      timestamp with time zone default now()             not null,
+    last_attempt_at      timestamp with time zone,
+    last_response_status integer,
+    last_error           text,
+    delivered_at         timestamp with time zone,
+    unique (subscription_id, event_key)
+);
+
+create or replace trigger set_timestamp
+    before update
+    on webhook_delivery
+    for each row
+execute procedure trigger_set_timestamp();
+
+create index webhook_delivery_pending_index
+    on webhook_delivery (next_attempt_at)
+    where status = 'pending';
+
+create index webhook_delivery_subscription_id_index
+    on webhook_delivery (subscription_id, created_at);
+
+-- deliveries that ran out of attempts, they stay here until they are retried or their subscription is deleted
+create view webhook_dead_letter as
+select d.id,
+       d.subscription_id,
+       s.url,
+       d.event_id,
+       d.event_type,
+       d.game_id,
+       d.payload,
+       d.attempts,
+       d.last_attempt_at,
+       d.last_response_status,
+       d.last_error,
+       d.created_at
+from webhook_delivery d
+         join webhook_subscription s on s.id = d.subscription_id
+where d.status = 'dead';
+
+-- the last state of each game that webhook events were generated from
+create table webhook_game_state
+(
+    game_id    uuid references game (id) not null primary key,
+    updated_at timestamp with time zone default now() not null,
+    status     text                      not null,
+    period     integer
+);
+
+-- existing games are the baseline so subscribers aren't sent events for games that were ingested before webhooks existed
+insert into webhook_game_state (game_id, status, period)
+select g.id, gs.name, g.period
+from game g
+         join game_status gs on gs.id = g.game_status_id;
+
+commit;
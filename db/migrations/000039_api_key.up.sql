@@ -0,0 +1,27 @@
+begin;
+
+create table api_key
+(
+    id           uuid                     default gen_random_uuid() not null primary key,
+    created_at   timestamp with time zone default now()             not null,
+    updated_at   timestamp with time zone,
+    name         text                                               not null,
+    -- prefix is the start of the key so a key can be recognized without storing it
+    prefix       text                                               not null,
+    -- key_hash is the sha256 of the key, keys are random so they don't need a slow hash
+    key_hash     text                                               not null unique,
+    scopes       text[]                                             not null,
+    -- rate_limit is the requests per second the key refills, burst is how many it can make at once
+    rate_limit   double precision                                   not null,
+    burst        integer                                            not null,
+    last_used_at timestamp with time zone,
+    revoked_at   timestamp with time zone
+);
+
+create or replace trigger set_timestamp
+    before update
+    on api_key
+    for each row
+execute procedure trigger_set_timestamp();
+
+commit;
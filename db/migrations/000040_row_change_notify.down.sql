@@ -0,0 +1,41 @@
+begin;
+
+drop trigger if exists notify_row_change on webhook_subscription;
+drop trigger if exists notify_row_change on webhook_delivery;
+drop trigger if exists notify_row_change on team_season;
+drop trigger if exists notify_row_change on team_game_stats_total;
+drop trigger if exists notify_row_change on team;
+drop trigger if exists notify_row_change on stat_correction;
+drop trigger if exists notify_row_change on season_week;
+drop trigger if exists notify_row_change on season_stage;
+drop trigger if exists notify_row_change on season;
+drop trigger if exists notify_row_change on referee;
+drop trigger if exists notify_row_change on position;
+drop trigger if exists notify_row_change on playoff_series;
+drop trigger if exists notify_row_change on player_team_game_stats_total;
+drop trigger if exists notify_row_change on player_position;
+drop trigger if exists notify_row_change on player_game_clutch_stats;
+drop trigger if exists notify_row_change on player;
+drop trigger if exists notify_row_change on play_by_play;
+drop trigger if exists notify_row_change on milestone;
+drop trigger if exists notify_row_change on league;
+drop trigger if exists notify_row_change on injury_report_entry;
+drop trigger if exists notify_row_change on injury_report;
+drop trigger if exists notify_row_change on history;
+drop trigger if exists notify_row_change on game_status;
+drop trigger if exists notify_row_change on game_scoring_run;
+drop trigger if exists notify_row_change on game_score_event;
+drop trigger if exists notify_row_change on game_referee;
+drop trigger if exists notify_row_change on game_odds;
+drop trigger if exists notify_row_change on game_clutch_segment;
+drop trigger if exists notify_row_change on game_broadcast;
+drop trigger if exists notify_row_change on game;
+drop trigger if exists notify_row_change on franchise;
+drop trigger if exists notify_row_change on division;
+drop trigger if exists notify_row_change on conference;
+drop trigger if exists notify_row_change on broadcaster;
+drop trigger if exists notify_row_change on arena;
+
+drop function if exists notify_row_change();
+
+commit;
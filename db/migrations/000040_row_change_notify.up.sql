@@ -0,0 +1,227 @@
+begin;
+
+-- notifies listeners of row_change with the table's name after every statement that changes one of the tables the api
+-- caches responses of, notifications are sent when the transaction commits and duplicates in one transaction are sent once.
+-- api_key is left out since every authenticated request updates its last_used_at, as is webhook_game_state which only
+-- the webhook dispatcher reads
+create or replace function notify_row_change() returns trigger
+    language plpgsql
+as
+$$
+BEGIN
+    PERFORM pg_notify('row_change', TG_TABLE_NAME);
+    RETURN NULL;
+END;
+$$;
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on arena
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on broadcaster
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on conference
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on division
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on franchise
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on game
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on game_broadcast
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on game_clutch_segment
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on game_odds
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on game_referee
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on game_score_event
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on game_scoring_run
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on game_status
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on history
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on injury_report
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on injury_report_entry
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on league
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on milestone
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on play_by_play
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on player
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on player_game_clutch_stats
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on player_position
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on player_team_game_stats_total
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on playoff_series
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on position
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on referee
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on season
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on season_stage
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on season_week
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on stat_correction
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on team
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on team_game_stats_total
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on team_season
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on webhook_delivery
+    for each statement
+execute procedure notify_row_change();
+
+create or replace trigger notify_row_change
+    after insert or update or delete or truncate
+    on webhook_subscription
+    for each statement
+execute procedure notify_row_change();
+
+commit;
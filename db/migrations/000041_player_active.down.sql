@@ -0,0 +1,16 @@
+begin;
+
+do
+$$
+    begin
+        if col_description('player'::regclass, (select attnum
+                                                 from pg_attribute
+                                                 where attrelid = 'player'::regclass
+                                                   and attname = 'active')) = 'renamed from currently_in_nba' then
+            comment on column player.active is null;
+            alter table player rename column active to currently_in_nba;
+        end if;
+    end
+$$;
+
+commit;
@@ -0,0 +1,19 @@
+begin;
+
+-- the player ingest writes active but the player table was created with currently_in_nba, the comment marks the
+-- column as renamed so the down migration only reverts a rename this migration made
+do
+$$
+    begin
+        if exists (select 1
+                   from information_schema.columns
+                   where table_schema = current_schema()
+                     and table_name = 'player'
+                     and column_name = 'currently_in_nba') then
+            alter table player rename column currently_in_nba to active;
+            comment on column player.active is 'renamed from currently_in_nba';
+        end if;
+    end
+$$;
+
+commit;
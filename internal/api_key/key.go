@@ -0,0 +1,60 @@
+package api_key
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+)
+
+type Scope string
+
+// scopes grant the scopes before them ex. an ingest key can read and an admin key can do anything
+const (
+	ScopeRead   Scope = "read"
+	ScopeIngest Scope = "ingest"
+	ScopeAdmin  Scope = "admin"
+)
+
+var Scopes = []Scope{ScopeRead, ScopeIngest, ScopeAdmin}
+
+const (
+	keyPrefix = "nba_"
+	// keyBytes is how much randomness a key has, plenty to not be guessable
+	keyBytes = 32
+	// prefixLength is how much of the key is kept to recognize it by
+	prefixLength = len(keyPrefix) + 8
+)
+
+// HasScope reports whether the key has the scope or one that grants it
+func HasScope(key api.APIKey, scope Scope) bool {
+	required := slices.Index(Scopes, scope)
+	if required == -1 {
+		return false
+	}
+	for _, keyScope := range key.Scopes {
+		if i := slices.Index(Scopes, Scope(keyScope)); i != -1 && i >= required {
+			return true
+		}
+	}
+	return false
+}
+
+func generateKey() (string, error) {
+	b := make([]byte, keyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	return keyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashKey hashes a key for storing and looking it up. Keys are random so a fast hash is as safe as a slow one and
+// keeps authenticating cheap
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,87 @@
+package api_key
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/util"
+)
+
+// HeaderAPIKey is the header a key can be sent in instead of as an Authorization bearer token
+const HeaderAPIKey = "X-API-Key"
+
+type contextKey struct{}
+
+// FromContext returns the key a request was authenticated with
+func FromContext(ctx context.Context) (api.APIKey, bool) {
+	key, ok := ctx.Value(contextKey{}).(api.APIKey)
+	return key, ok
+}
+
+// Authenticate is a middleware that requires requests to have a valid key and be within the key's rate limit. The key
+// is put in the request's context for RequireScope
+func Authenticate(logger *slog.Logger, apiKeyService Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			key := r.Header.Get(HeaderAPIKey)
+			if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+				key = strings.TrimSpace(bearer)
+			}
+			if key == "" {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				util.WriteProblem(w, r, http.StatusUnauthorized, "missing api key, send it as a bearer token or in the X-API-Key header")
+				return
+			}
+
+			apiKey, err := apiKeyService.Authenticate(ctx, key)
+			if err != nil {
+				if errors.Is(err, ErrUnauthorized) {
+					w.Header().Set("WWW-Authenticate", "Bearer")
+					util.WriteProblem(w, r, http.StatusUnauthorized, err.Error())
+					return
+				}
+				logger.ErrorContext(ctx, "failed to authenticate api key", slog.Any("error", err))
+				util.WriteError(w, r, err)
+				return
+			}
+
+			if ok, retryAfter := apiKeyService.Allow(apiKey); !ok {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				util.WriteProblem(w, r, http.StatusTooManyRequests, fmt.Sprintf("rate limit of %g requests per second exceeded", apiKey.RateLimit))
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, contextKey{}, apiKey)))
+		})
+	}
+}
+
+// RequireScope is a middleware that only lets through requests authenticated with a key that has the scope
+func RequireScope(scope Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, ok := FromContext(r.Context())
+			if !ok {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				util.WriteProblem(w, r, http.StatusUnauthorized, "missing api key")
+				return
+			}
+
+			if !HasScope(key, scope) {
+				util.WriteProblem(w, r, http.StatusForbidden, fmt.Sprintf("api key is missing the %s scope", scope))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package api_key
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/pkg/lru"
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"golang.org/x/time/rate"
+)
+
+const (
+	DefaultRateLimit = 10
+	DefaultBurst     = 20
+
+	// keyCacheTTL is how long an authenticated key is trusted before it's looked up again, a revoked key keeps working
+	// for up to this long
+	keyCacheTTL = time.Minute
+
+	// unknownKeyTTL is how long a key that doesn't exist is rejected without looking it up again, a newly minted key
+	// can't be rejected this way since it was never looked up before it existed
+	unknownKeyTTL = 10 * time.Second
+	// unknownKeyCacheSize bounds the unknown keys remembered so a client sending random keys can't grow it
+	unknownKeyCacheSize = 10_000
+
+	// lastUsedInterval is how often the last use of each key is written instead of on every request
+	lastUsedInterval = time.Minute
+)
+
+var (
+	ErrInvalidKey = util.NewError(util.ErrValidation, "invalid api key")
+	// ErrUnauthorized is returned when authenticating a key that doesn't exist or was revoked
+	ErrUnauthorized = errors.New("unknown or revoked api key")
+)
+
+type Service interface {
+	// Mint creates a key, the returned key's Key is the only time the key is known
+	Mint(ctx context.Context, keyCreate KeyCreate) (api.APIKey, error)
+	List(ctx context.Context) ([]api.APIKey, error)
+	Revoke(ctx context.Context, id string) (api.APIKey, error)
+	Authenticate(ctx context.Context, key string) (api.APIKey, error)
+	// Allow takes a request from the key's token bucket, when it's empty it returns false and how long until the next
+	// request is allowed
+	Allow(key api.APIKey) (bool, time.Duration)
+	// WriteLastUsed writes when each authenticated key was last used every lastUsedInterval until ctx is done
+	WriteLastUsed(ctx context.Context, logger *slog.Logger)
+}
+
+func NewService(apiKeyStore Store) Service {
+	return &service{
+		apiKeyStore: apiKeyStore,
+		keys:        map[string]cachedKey{},
+		unknownKeys: lru.New[string, time.Time](unknownKeyCacheSize),
+		limiters:    map[string]*rate.Limiter{},
+		lastUsed:    map[string]time.Time{},
+	}
+}
+
+type cachedKey struct {
+	key       api.APIKey
+	expiresAt time.Time
+}
+
+type service struct {
+	apiKeyStore Store
+
+	mu sync.Mutex
+	// keys are the recently authenticated keys by hash
+	keys map[string]cachedKey
+	// unknownKeys are when each recently rejected key hash can be looked up again
+	unknownKeys *lru.Cache[string, time.Time]
+	limiters    map[string]*rate.Limiter
+	// lastUsed are when each key id was last authenticated since the last uses were written
+	lastUsed map[string]time.Time
+}
+
+func (s *service) Mint(ctx context.Context, keyCreate KeyCreate) (api.APIKey, error) {
+	ctx, span := otel.Tracer("api_key").Start(ctx, "api_key.service.Mint")
+	defer span.End()
+
+	if keyCreate.RateLimit == 0 {
+		keyCreate.RateLimit = DefaultRateLimit
+	}
+	if keyCreate.Burst == 0 {
+		keyCreate.Burst = DefaultBurst
+	}
+	if err := validateKeyCreate(keyCreate); err != nil {
+		return api.APIKey{}, err
+	}
+
+	key, err := generateKey()
+	if err != nil {
+		return api.APIKey{}, err
+	}
+	keyCreate.Prefix = key[:prefixLength]
+	keyCreate.KeyHash = hashKey(key)
+
+	apiKey, err := s.apiKeyStore.CreateAPIKey(ctx, keyCreate)
+	if err != nil {
+		return api.APIKey{}, fmt.Errorf("failed to create api key: %w", err)
+	}
+	apiKey.Key = &key
+
+	return apiKey, nil
+}
+
+func validateKeyCreate(keyCreate KeyCreate) error {
+	if strings.TrimSpace(keyCreate.Name) == "" {
+		return fmt.Errorf("%w: a name is required", ErrInvalidKey)
+	}
+	if len(keyCreate.Scopes) == 0 {
+		return fmt.Errorf("%w: at least one scope is required", ErrInvalidKey)
+	}
+	for _, scope := range keyCreate.Scopes {
+		if !slices.Contains(Scopes, Scope(scope)) {
+			return fmt.Errorf("%w: unknown scope %s, expected read, ingest or admin", ErrInvalidKey, scope)
+		}
+	}
+	if keyCreate.RateLimit < 0 || math.IsInf(keyCreate.RateLimit, 0) || math.IsNaN(keyCreate.RateLimit) {
+		return fmt.Errorf("%w: rate limit must be a positive number of requests per second", ErrInvalidKey)
+	}
+	if keyCreate.Burst < 1 {
+		return fmt.Errorf("%w: burst must be at least 1", ErrInvalidKey)
+	}
+	return nil
+}
+
+func (s *service) List(ctx context.Context) ([]api.APIKey, error) {
+	ctx, span := otel.Tracer("api_key").Start(ctx, "api_key.service.List")
+	defer span.End()
+
+	return s.apiKeyStore.ListAPIKeys(ctx)
+}
+
+func (s *service) Revoke(ctx context.Context, id string) (api.APIKey, error) {
+	ctx, span := otel.Tracer("api_key").Start(ctx, "api_key.service.Revoke")
+	defer span.End()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return api.APIKey{}, util.NewError(util.ErrNotFound, "api key not found")
+	}
+
+	apiKey, err := s.apiKeyStore.RevokeAPIKey(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.APIKey{}, util.NewError(util.ErrNotFound, "api key not found")
+		}
+		return api.APIKey{}, fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	// keys revoked by this process stop working right away, other processes notice when their cache expires
+	s.mu.Lock()
+	for hash, cached := range s.keys {
+		if cached.key.ID == apiKey.ID {
+			delete(s.keys, hash)
+		}
+	}
+	delete(s.limiters, apiKey.ID)
+	s.mu.Unlock()
+
+	return apiKey, nil
+}
+
+func (s *service) Authenticate(ctx context.Context, key string) (api.APIKey, error) {
+	ctx, span := otel.Tracer("api_key").Start(ctx, "api_key.service.Authenticate")
+	defer span.End()
+
+	if !strings.HasPrefix(key, keyPrefix) {
+		return api.APIKey{}, ErrUnauthorized
+	}
+
+	keyHash := hashKey(key)
+
+	if retryAt, ok := s.unknownKeys.Get(keyHash); ok && time.Now().Before(retryAt) {
+		return api.APIKey{}, ErrUnauthorized
+	}
+
+	s.mu.Lock()
+	cached, ok := s.keys[keyHash]
+	s.mu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		s.used(cached.key)
+		return cached.key, nil
+	}
+
+	apiKey, err := s.apiKeyStore.GetAPIKeyWithHash(ctx, keyHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.mu.Lock()
+			delete(s.keys, keyHash)
+			s.mu.Unlock()
+			s.unknownKeys.Add(keyHash, time.Now().Add(unknownKeyTTL))
+			return api.APIKey{}, ErrUnauthorized
+		}
+		return api.APIKey{}, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	s.mu.Lock()
+	s.keys[keyHash] = cachedKey{key: apiKey, expiresAt: time.Now().Add(keyCacheTTL)}
+	s.mu.Unlock()
+	s.used(apiKey)
+
+	return apiKey, nil
+}
+
+func (s *service) used(key api.APIKey) {
+	s.mu.Lock()
+	s.lastUsed[key.ID] = time.Now()
+	s.mu.Unlock()
+}
+
+func (s *service) WriteLastUsed(ctx context.Context, logger *slog.Logger) {
+	ticker := time.NewTicker(lastUsedInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// write the uses since the last tick before stopping
+			s.writeLastUsed(context.WithoutCancel(ctx), logger)
+			return
+		case <-ticker.C:
+			s.writeLastUsed(ctx, logger)
+		}
+	}
+}
+
+func (s *service) writeLastUsed(ctx context.Context, logger *slog.Logger) {
+	ctx, span := otel.Tracer("api_key").Start(ctx, "api_key.service.writeLastUsed")
+	defer span.End()
+
+	s.mu.Lock()
+	lastUsed := s.lastUsed
+	s.lastUsed = map[string]time.Time{}
+	s.mu.Unlock()
+
+	if len(lastUsed) == 0 {
+		return
+	}
+
+	if err := s.apiKeyStore.UpdateAPIKeysLastUsed(ctx, lastUsed); err != nil {
+		logger.ErrorContext(ctx, "failed to write api keys last used", slog.Int("keys", len(lastUsed)), slog.Any("error", err))
+	}
+}
+
+func (s *service) Allow(key api.APIKey) (bool, time.Duration) {
+	s.mu.Lock()
+	limiter, ok := s.limiters[key.ID]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(key.RateLimit), key.Burst)
+		s.limiters[key.ID] = limiter
+	}
+	s.mu.Unlock()
+
+	// keys updated since the limiter was created keep their bucket with the new limits
+	if limiter.Limit() != rate.Limit(key.RateLimit) {
+		limiter.SetLimit(rate.Limit(key.RateLimit))
+	}
+	if limiter.Burst() != key.Burst {
+		limiter.SetBurst(key.Burst)
+	}
+
+	reservation := limiter.Reserve()
+	if !reservation.OK() {
+		// the bucket can't hold a token, the soonest a request could be allowed is once one token refills
+		return false, time.Duration(float64(time.Second) / float64(limiter.Limit()))
+	}
+	if delay := reservation.Delay(); delay > 0 {
+		reservation.Cancel()
+		return false, delay
+	}
+
+	return true, 0
+}
@@ -0,0 +1,29 @@
+package api_key
+
+import (
+	"context"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+)
+
+// KeyCreate is a key to mint, the rate limit and burst default when they're zero
+type KeyCreate struct {
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	RateLimit float64  `json:"rate_limit"`
+	Burst     int      `json:"burst"`
+	// Prefix and KeyHash are set when the key is minted
+	Prefix  string `json:"-"`
+	KeyHash string `json:"-"`
+}
+
+type Store interface {
+	CreateAPIKey(ctx context.Context, keyCreate KeyCreate) (api.APIKey, error)
+	ListAPIKeys(ctx context.Context) ([]api.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id string) (api.APIKey, error)
+	// GetAPIKeyWithHash gets the unrevoked key with the hash, it returns pgx.ErrNoRows when there isn't one
+	GetAPIKeyWithHash(ctx context.Context, keyHash string) (api.APIKey, error)
+	// UpdateAPIKeysLastUsed sets when each key id was last used unless it was already used later
+	UpdateAPIKeysLastUsed(ctx context.Context, lastUsed map[string]time.Time) error
+}
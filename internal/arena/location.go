@@ -0,0 +1,59 @@
+package arena
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// arenas.json is a static dataset of the arenas the nba plays in since the nba apis don't include where they are
+//
+//go:embed arenas.json
+var arenaLocationsJSON []byte
+
+type Location struct {
+	Name      string   `json:"name"`
+	Aliases   []string `json:"aliases"`
+	City      string   `json:"city"`
+	State     string   `json:"state"`
+	Timezone  string   `json:"timezone"`
+	Latitude  float64  `json:"latitude"`
+	Longitude float64  `json:"longitude"`
+}
+
+type Locations struct {
+	byName []Location
+}
+
+func DefaultLocations() (Locations, error) {
+	l := Locations{}
+	if err := json.Unmarshal(arenaLocationsJSON, &l.byName); err != nil {
+		return Locations{}, fmt.Errorf("failed to unmarshal embedded arena locations: %w", err)
+	}
+
+	return l, nil
+}
+
+// find matches on the arena name or one of its former names first since arenas are renamed often, then falls back to
+// the city and state
+func (l Locations) find(name string, city string, state string) (Location, bool) {
+	for _, location := range l.byName {
+		if strings.EqualFold(location.Name, name) {
+			return location, true
+		}
+		for _, alias := range location.Aliases {
+			if strings.EqualFold(alias, name) {
+				return location, true
+			}
+		}
+	}
+
+	for _, location := range l.byName {
+		if city != "" && strings.EqualFold(location.City, city) && (state == "" || strings.EqualFold(location.State, state)) {
+			return location, true
+		}
+	}
+
+	return Location{}, false
+}
@@ -11,17 +11,37 @@ type Service interface {
 	UpdateArenas(ctx context.Context, arenas []ArenaUpdate) ([]api.Arena, error)
 }
 
-func NewService(arenaStore Store) Service {
-	return &service{ArenaStore: arenaStore}
+func NewService(arenaStore Store, locations Locations) Service {
+	return &service{ArenaStore: arenaStore, locations: locations}
 }
 
 type service struct {
 	ArenaStore Store
+
+	locations Locations
 }
 
 func (s *service) UpdateArenas(ctx context.Context, arenas []ArenaUpdate) ([]api.Arena, error) {
 	ctx, span := otel.Tracer("arena").Start(ctx, "arena.service.UpdateArenas")
 	defer span.End()
 
+	for i, arena := range arenas {
+		location, found := s.locations.find(arena.Name, arena.City.String, arena.State.String)
+		if !found {
+			continue
+		}
+
+		if !arena.Timezone.Valid {
+			arenas[i].Timezone.String = location.Timezone
+			arenas[i].Timezone.Valid = true
+		}
+		if !arena.Latitude.Valid || !arena.Longitude.Valid {
+			arenas[i].Latitude.Float64 = location.Latitude
+			arenas[i].Latitude.Valid = true
+			arenas[i].Longitude.Float64 = location.Longitude
+			arenas[i].Longitude.Valid = true
+		}
+	}
+
 	return s.ArenaStore.UpdateArenas(ctx, arenas)
 }
@@ -13,6 +13,9 @@ type ArenaUpdate struct {
 	City       sql.NullString
 	State      sql.NullString
 	Country    string
+	Timezone   sql.NullString
+	Latitude   sql.NullFloat64
+	Longitude  sql.NullFloat64
 }
 
 type Store interface {
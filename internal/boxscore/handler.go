@@ -38,13 +38,13 @@ func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
 	r.ParseForm()
 	gameID := chi.URLParam(r, "gameID")
 	if gameID == "" {
-		util.WriteJSON(http.StatusBadRequest, "invalid request: missing game_id", w)
+		util.WriteProblem(w, r, http.StatusBadRequest, "invalid request: missing game_id")
 		return
 	}
 
 	gameDate := r.FormValue("game_date")
 	if gameDate == "" {
-		util.WriteJSON(http.StatusBadRequest, "invalid request: missing game_date", w)
+		util.WriteProblem(w, r, http.StatusBadRequest, "invalid request: missing game_date")
 		return
 	}
 
@@ -53,7 +53,7 @@ func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
 	boxscore, err := h.boxscoreService.Get(ctx, gameID, gameDate)
 	if err != nil {
 		logger.ErrorContext(ctx, "failed to get boxscore", slog.Any("error", err))
-		util.WriteJSON(http.StatusInternalServerError, err, w)
+		util.WriteError(w, r, err)
 		return
 	}
 
@@ -0,0 +1,52 @@
+package broadcast
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"go.opentelemetry.io/otel"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler interface {
+	Routes() chi.Router
+	GetGameBroadcasts(w http.ResponseWriter, r *http.Request)
+}
+
+func NewHandler(logger *slog.Logger, broadcastService Service) Handler {
+	return &handler{logger: logger, broadcastService: broadcastService}
+}
+
+type handler struct {
+	logger           *slog.Logger
+	broadcastService Service
+}
+
+// Routes are mounted under a game
+func (h *handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.GetGameBroadcasts)
+
+	return r
+}
+
+func (h *handler) GetGameBroadcasts(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("broadcast").Start(r.Context(), "broadcast.handler.GetGameBroadcasts")
+	defer span.End()
+
+	gameID := chi.URLParam(r, "gameID")
+
+	logger := h.logger.With(slog.String("game_id", gameID))
+
+	gameBroadcasts, err := h.broadcastService.GetGameBroadcasts(ctx, gameID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get game broadcasts", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteJSON(http.StatusOK, gameBroadcasts, w)
+}
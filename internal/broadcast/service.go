@@ -0,0 +1,158 @@
+package broadcast
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/apis/nba"
+	"go.opentelemetry.io/otel"
+)
+
+type Scope string
+
+const (
+	ScopeNational      Scope = "national"
+	ScopeHome          Scope = "home"
+	ScopeAway          Scope = "away"
+	ScopeInternational Scope = "international"
+)
+
+type Media string
+
+const (
+	MediaTV    Media = "tv"
+	MediaRadio Media = "radio"
+)
+
+type Service interface {
+	UpdateBroadcasters(ctx context.Context, broadcasterUpdates []BroadcasterUpdate) ([]api.Broadcaster, error)
+	UpdateGameBroadcasts(ctx context.Context, gameBroadcastUpdates []GameBroadcastUpdate) error
+	GetGameBroadcasts(ctx context.Context, gameID string) ([]api.GameBroadcast, error)
+}
+
+func NewService(broadcastStore Store) Service {
+	return &service{broadcastStore: broadcastStore}
+}
+
+type service struct {
+	broadcastStore Store
+}
+
+func (s *service) UpdateBroadcasters(ctx context.Context, broadcasterUpdates []BroadcasterUpdate) ([]api.Broadcaster, error) {
+	ctx, span := otel.Tracer("broadcast").Start(ctx, "broadcast.service.UpdateBroadcasters")
+	defer span.End()
+
+	// the same broadcasters are listed on most games of a schedule so only upsert each one once
+	seen := make(map[int]bool, len(broadcasterUpdates))
+	var uniqueBroadcasterUpdates []BroadcasterUpdate
+	for _, broadcasterUpdate := range broadcasterUpdates {
+		if seen[broadcasterUpdate.NBABroadcasterID] {
+			continue
+		}
+		seen[broadcasterUpdate.NBABroadcasterID] = true
+		uniqueBroadcasterUpdates = append(uniqueBroadcasterUpdates, broadcasterUpdate)
+	}
+
+	return s.broadcastStore.UpdateBroadcasters(ctx, uniqueBroadcasterUpdates)
+}
+
+func (s *service) UpdateGameBroadcasts(ctx context.Context, gameBroadcastUpdates []GameBroadcastUpdate) error {
+	ctx, span := otel.Tracer("broadcast").Start(ctx, "broadcast.service.UpdateGameBroadcasts")
+	defer span.End()
+
+	return s.broadcastStore.UpdateGameBroadcasts(ctx, gameBroadcastUpdates)
+}
+
+func (s *service) GetGameBroadcasts(ctx context.Context, gameID string) ([]api.GameBroadcast, error) {
+	ctx, span := otel.Tracer("broadcast").Start(ctx, "broadcast.service.GetGameBroadcasts")
+	defer span.End()
+
+	gameBroadcasts, err := s.broadcastStore.GetGameBroadcasts(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game broadcasts: %w", err)
+	}
+
+	return gameBroadcasts, nil
+}
+
+// ScheduledGameUpdates maps every broadcaster listed for a scheduled game to the broadcasters to upsert and the game's
+// broadcasts
+func ScheduledGameUpdates(game nba.Game) ([]BroadcasterUpdate, []GameBroadcastUpdate) {
+	broadcasterLists := []struct {
+		scope        Scope
+		media        Media
+		broadcasters []nba.GameBroadcaster
+	}{
+		{scope: ScopeNational, media: MediaTV, broadcasters: game.Broadcasters.NationalTvBroadcasters},
+		{scope: ScopeNational, media: MediaRadio, broadcasters: game.Broadcasters.NationalRadioBroadcasters},
+		{scope: ScopeHome, media: MediaTV, broadcasters: game.Broadcasters.HomeTvBroadcasters},
+		{scope: ScopeHome, media: MediaRadio, broadcasters: game.Broadcasters.HomeRadioBroadcasters},
+		{scope: ScopeAway, media: MediaTV, broadcasters: game.Broadcasters.AwayTvBroadcasters},
+		{scope: ScopeAway, media: MediaRadio, broadcasters: game.Broadcasters.AwayRadioBroadcasters},
+		{scope: ScopeInternational, media: MediaTV, broadcasters: game.Broadcasters.IntlTvBroadcasters},
+		{scope: ScopeInternational, media: MediaRadio, broadcasters: game.Broadcasters.IntlRadioBroadcasters},
+	}
+
+	var broadcasterUpdates []BroadcasterUpdate
+	var gameBroadcastUpdates []GameBroadcastUpdate
+
+	for _, broadcasterList := range broadcasterLists {
+		for _, broadcaster := range broadcasterList.broadcasters {
+			if broadcaster.BroadcasterID == 0 {
+				continue
+			}
+
+			regionID := sql.NullInt64{}
+			if broadcaster.RegionID != 0 {
+				regionID.Int64 = int64(broadcaster.RegionID)
+				regionID.Valid = true
+			}
+
+			broadcasterUpdates = append(broadcasterUpdates, BroadcasterUpdate{
+				NBABroadcasterID: broadcaster.BroadcasterID,
+				Display:          broadcaster.BroadcasterDisplay,
+				Abbreviation:     broadcaster.BroadcasterAbbreviation,
+				RegionID:         regionID,
+			})
+
+			tapeDelayComments := sql.NullString{}
+			if broadcaster.TapeDelayComments != "" {
+				tapeDelayComments.String = broadcaster.TapeDelayComments
+				tapeDelayComments.Valid = true
+			}
+
+			gameBroadcastUpdates = append(gameBroadcastUpdates, GameBroadcastUpdate{
+				NBAGameID:               game.GameID,
+				NBABroadcasterID:        sql.NullInt64{Int64: int64(broadcaster.BroadcasterID), Valid: true},
+				BroadcasterAbbreviation: broadcaster.BroadcasterAbbreviation,
+				Scope:                   broadcasterList.scope,
+				Media:                   broadcasterList.media,
+				TapeDelayComments:       tapeDelayComments,
+			})
+		}
+	}
+
+	return broadcasterUpdates, gameBroadcastUpdates
+}
+
+// SummaryGameUpdates maps the national tv broadcasters from a boxscore summary which is all older games have
+func SummaryGameUpdates(nbaGameID string, tvBroadcasts []nba.BoxscoreSummaryTVBroadcast) []GameBroadcastUpdate {
+	var gameBroadcastUpdates []GameBroadcastUpdate
+
+	for _, tvBroadcast := range tvBroadcasts {
+		if tvBroadcast.NationalTVBroadcasterAbbreviation == nil || *tvBroadcast.NationalTVBroadcasterAbbreviation == "" {
+			continue
+		}
+
+		gameBroadcastUpdates = append(gameBroadcastUpdates, GameBroadcastUpdate{
+			NBAGameID:               nbaGameID,
+			BroadcasterAbbreviation: *tvBroadcast.NationalTVBroadcasterAbbreviation,
+			Scope:                   ScopeNational,
+			Media:                   MediaTV,
+		})
+	}
+
+	return gameBroadcastUpdates
+}
@@ -0,0 +1,32 @@
+package broadcast
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+)
+
+type Store interface {
+	UpdateBroadcasters(ctx context.Context, broadcasterUpdates []BroadcasterUpdate) ([]api.Broadcaster, error)
+	UpdateGameBroadcasts(ctx context.Context, gameBroadcastUpdates []GameBroadcastUpdate) error
+	GetGameBroadcasts(ctx context.Context, gameID string) ([]api.GameBroadcast, error)
+}
+
+type BroadcasterUpdate struct {
+	NBABroadcasterID int
+	Display          string
+	Abbreviation     string
+	RegionID         sql.NullInt64
+}
+
+// GameBroadcastUpdate links a game to a broadcaster by the nba broadcaster id when known, otherwise by the
+// broadcaster's abbreviation since the boxscore summary only has the abbreviation of national tv broadcasters
+type GameBroadcastUpdate struct {
+	NBAGameID               string
+	NBABroadcasterID        sql.NullInt64
+	BroadcasterAbbreviation string
+	Scope                   Scope
+	Media                   Media
+	TapeDelayComments       sql.NullString
+}
@@ -0,0 +1,197 @@
+package chart
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+type point struct {
+	X float64
+	Y float64
+}
+
+type textAnchor string
+
+const (
+	textAnchorStart  textAnchor = "start"
+	textAnchorMiddle textAnchor = "middle"
+	textAnchorEnd    textAnchor = "end"
+)
+
+// canvas is the set of drawing primitives the charts are built from so the same chart can be output as svg or png
+type canvas interface {
+	Rect(x, y, w, h float64, fill color.RGBA)
+	Polyline(points []point, width float64, stroke color.RGBA)
+	Circle(cx, cy, r float64, fill color.RGBA)
+	Text(x, y float64, s string, fill color.RGBA, anchor textAnchor)
+	Encode() ([]byte, error)
+}
+
+func newCanvas(format Format, width, height int) (canvas, error) {
+	switch format {
+	case FormatSVG:
+		return newSVGCanvas(width, height), nil
+	case FormatPNG:
+		return newRasterCanvas(width, height), nil
+	default:
+		return nil, fmt.Errorf("unsupported chart format: %s", format)
+	}
+}
+
+type svgCanvas struct {
+	width  int
+	height int
+	body   strings.Builder
+}
+
+func newSVGCanvas(width, height int) *svgCanvas {
+	return &svgCanvas{width: width, height: height}
+}
+
+func svgColor(c color.RGBA) string {
+	return fmt.Sprintf("rgb(%d,%d,%d)", c.R, c.G, c.B)
+}
+
+func svgOpacity(c color.RGBA) string {
+	return fmt.Sprintf("%.3f", float64(c.A)/255)
+}
+
+func (s *svgCanvas) Rect(x, y, w, h float64, fill color.RGBA) {
+	fmt.Fprintf(&s.body, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s" fill-opacity="%s"/>`, x, y, w, h, svgColor(fill), svgOpacity(fill))
+}
+
+func (s *svgCanvas) Polyline(points []point, width float64, stroke color.RGBA) {
+	coords := make([]string, len(points))
+	for i, p := range points {
+		coords[i] = fmt.Sprintf("%.1f,%.1f", p.X, p.Y)
+	}
+	fmt.Fprintf(&s.body, `<polyline points="%s" fill="none" stroke="%s" stroke-opacity="%s" stroke-width="%.1f" stroke-linejoin="round"/>`, strings.Join(coords, " "), svgColor(stroke), svgOpacity(stroke), width)
+}
+
+func (s *svgCanvas) Circle(cx, cy, r float64, fill color.RGBA) {
+	fmt.Fprintf(&s.body, `<circle cx="%.1f" cy="%.1f" r="%.1f" fill="%s" fill-opacity="%s"/>`, cx, cy, r, svgColor(fill), svgOpacity(fill))
+}
+
+func (s *svgCanvas) Text(x, y float64, text string, fill color.RGBA, anchor textAnchor) {
+	fmt.Fprintf(&s.body, `<text x="%.1f" y="%.1f" fill="%s" font-family="sans-serif" font-size="12" text-anchor="%s">%s</text>`, x, y, svgColor(fill), anchor, html.EscapeString(text))
+}
+
+func (s *svgCanvas) Encode() ([]byte, error) {
+	b := bytes.Buffer{}
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`, s.width, s.height, s.width, s.height)
+	b.WriteString(s.body.String())
+	b.WriteString(`</svg>`)
+
+	return b.Bytes(), nil
+}
+
+// rasterCanvas draws directly to an in memory image using a pure go rasterizer
+type rasterCanvas struct {
+	img *image.RGBA
+}
+
+func newRasterCanvas(width, height int) *rasterCanvas {
+	return &rasterCanvas{img: image.NewRGBA(image.Rect(0, 0, width, height))}
+}
+
+func (r *rasterCanvas) fillPolygon(points []point, fill color.RGBA) {
+	if len(points) < 3 {
+		return
+	}
+
+	bounds := r.img.Bounds()
+	z := vector.NewRasterizer(bounds.Dx(), bounds.Dy())
+	z.MoveTo(float32(points[0].X), float32(points[0].Y))
+	for _, p := range points[1:] {
+		z.LineTo(float32(p.X), float32(p.Y))
+	}
+	z.ClosePath()
+	z.Draw(r.img, bounds, image.NewUniform(fill), image.Point{})
+}
+
+func (r *rasterCanvas) Rect(x, y, w, h float64, fill color.RGBA) {
+	r.fillPolygon([]point{{x, y}, {x + w, y}, {x + w, y + h}, {x, y + h}}, fill)
+}
+
+func (r *rasterCanvas) Polyline(points []point, width float64, stroke color.RGBA) {
+	// draw each segment as a quad plus a round join so the stroke has no gaps at the corners
+	for i := 1; i < len(points); i++ {
+		p1, p2 := points[i-1], points[i]
+		dx, dy := p2.X-p1.X, p2.Y-p1.Y
+		length := math.Hypot(dx, dy)
+		if length == 0 {
+			continue
+		}
+		nx, ny := -dy/length*width/2, dx/length*width/2
+		r.fillPolygon([]point{
+			{p1.X + nx, p1.Y + ny},
+			{p2.X + nx, p2.Y + ny},
+			{p2.X - nx, p2.Y - ny},
+			{p1.X - nx, p1.Y - ny},
+		}, stroke)
+		if i < len(points)-1 {
+			r.Circle(p2.X, p2.Y, width/2, stroke)
+		}
+	}
+}
+
+func (r *rasterCanvas) Circle(cx, cy, radius float64, fill color.RGBA) {
+	r.fillPolygon(arcPoints(cx, cy, radius, 0, 2*math.Pi, 32), fill)
+}
+
+func (r *rasterCanvas) Text(x, y float64, s string, fill color.RGBA, anchor textAnchor) {
+	d := font.Drawer{
+		Dst:  r.img,
+		Src:  image.NewUniform(fill),
+		Face: basicfont.Face7x13,
+	}
+
+	width := d.MeasureString(s)
+	start := fixed.I(int(x))
+	switch anchor {
+	case textAnchorMiddle:
+		start -= width / 2
+	case textAnchorEnd:
+		start -= width
+	}
+
+	d.Dot = fixed.Point26_6{X: start, Y: fixed.I(int(y))}
+	d.DrawString(s)
+}
+
+func (r *rasterCanvas) Encode() ([]byte, error) {
+	// flatten onto an opaque background; most image hosts don't handle transparent pngs well
+	dst := image.NewRGBA(r.img.Bounds())
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), r.img, image.Point{}, draw.Over)
+
+	b := bytes.Buffer{}
+	if err := png.Encode(&b, dst); err != nil {
+		return nil, fmt.Errorf("failed to encode chart to png: %w", err)
+	}
+
+	return b.Bytes(), nil
+}
+
+// arcPoints approximates the arc of a circle from start to end radians with the given number of segments
+func arcPoints(cx, cy, radius, start, end float64, segments int) []point {
+	points := make([]point, 0, segments+1)
+	for i := 0; i <= segments; i++ {
+		theta := start + (end-start)*float64(i)/float64(segments)
+		points = append(points, point{X: cx + radius*math.Cos(theta), Y: cy + radius*math.Sin(theta)})
+	}
+
+	return points
+}
@@ -0,0 +1,331 @@
+package chart
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/apis/nba"
+)
+
+type Kind string
+
+const (
+	KindShot          Kind = "shot"
+	KindFlow          Kind = "flow"
+	KindPeriodScoring Kind = "periods"
+)
+
+var Kinds = []Kind{KindShot, KindFlow, KindPeriodScoring}
+
+func (k Kind) Valid() bool {
+	switch k {
+	case KindShot, KindFlow, KindPeriodScoring:
+		return true
+	default:
+		return false
+	}
+}
+
+type Format string
+
+const (
+	FormatSVG Format = "svg"
+	FormatPNG Format = "png"
+)
+
+var (
+	colorHome       = color.RGBA{R: 0x23, G: 0x50, B: 0x92, A: 0xff}
+	colorAway       = color.RGBA{R: 0xc8, G: 0x10, B: 0x2e, A: 0xff}
+	colorLine       = color.RGBA{R: 0x88, G: 0x88, B: 0x88, A: 0xff}
+	colorGrid       = color.RGBA{R: 0xdd, G: 0xdd, B: 0xdd, A: 0xff}
+	colorText       = color.RGBA{R: 0x22, G: 0x22, B: 0x22, A: 0xff}
+	colorBackground = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	colorCourt      = color.RGBA{R: 0xf5, G: 0xe6, B: 0xc8, A: 0xff}
+)
+
+func render(kind Kind, format Format, pbp nba.PlayByPlay, regulationPeriods int) ([]byte, error) {
+	switch kind {
+	case KindShot:
+		return renderShotChart(format, pbp)
+	case KindFlow:
+		return renderGameFlowChart(format, pbp, regulationPeriods)
+	case KindPeriodScoring:
+		return renderPeriodScoringChart(format, pbp, regulationPeriods)
+	default:
+		return nil, fmt.Errorf("unsupported chart kind: %s", kind)
+	}
+}
+
+// shot chart coordinates use the legacy stats coordinates which are tenths of feet from the center of the basket
+const (
+	courtWidth       = 500.0
+	courtLength      = 470.0
+	courtBaselineY   = -52.5
+	shotChartHeaderH = 30.0
+)
+
+func renderShotChart(format Format, pbp nba.PlayByPlay) ([]byte, error) {
+	c, err := newCanvas(format, int(courtWidth), int(courtLength+shotChartHeaderH))
+	if err != nil {
+		return nil, err
+	}
+
+	teams := pbp.Teams()
+
+	// translate legacy coordinates to canvas coordinates with the baseline along the top
+	ox := courtWidth / 2
+	oy := shotChartHeaderH - courtBaselineY
+	at := func(x, y float64) point {
+		return point{X: ox + x, Y: oy + y}
+	}
+	line := func(points ...point) {
+		c.Polyline(points, 2, colorLine)
+	}
+	arc := func(cx, cy, r, start, end float64) {
+		center := at(cx, cy)
+		c.Polyline(arcPoints(center.X, center.Y, r, start, end, 48), 2, colorLine)
+	}
+
+	c.Rect(0, 0, courtWidth, courtLength+shotChartHeaderH, colorBackground)
+	c.Rect(0, shotChartHeaderH, courtWidth, courtLength, colorCourt)
+	c.Text(courtWidth/2, 20, fmt.Sprintf("%s @ %s shot chart", teams.AwayTricode, teams.HomeTricode), colorText, textAnchorMiddle)
+
+	halfCourtY := courtBaselineY + courtLength
+	freeThrowLineY := courtBaselineY + 190
+	line(at(-250, courtBaselineY), at(250, courtBaselineY), at(250, halfCourtY), at(-250, halfCourtY), at(-250, courtBaselineY))
+	line(at(-80, courtBaselineY), at(-80, freeThrowLineY), at(80, freeThrowLineY), at(80, courtBaselineY))
+	arc(0, freeThrowLineY, 60, 0, 2*math.Pi)
+	arc(0, 0, 40, 0, math.Pi)
+	arc(0, 0, 7.5, 0, 2*math.Pi)
+	line(at(-30, -7.5), at(30, -7.5))
+	arc(0, halfCourtY, 60, math.Pi, 2*math.Pi)
+
+	// three point line is straight in the corners until it meets the arc
+	threePointRadius := 237.5
+	cornerX := 220.0
+	cornerY := math.Sqrt(threePointRadius*threePointRadius - cornerX*cornerX)
+	line(at(-cornerX, courtBaselineY), at(-cornerX, cornerY))
+	line(at(cornerX, courtBaselineY), at(cornerX, cornerY))
+	theta := math.Atan2(cornerY, cornerX)
+	arc(0, 0, threePointRadius, theta, math.Pi-theta)
+
+	for _, action := range pbp.Game.Actions {
+		if action.IsFieldGoal != 1 || action.XLegacy == nil || action.YLegacy == nil {
+			continue
+		}
+
+		teamColor := colorAway
+		if action.TeamID == teams.HomeTeamID {
+			teamColor = colorHome
+		}
+
+		p := at(float64(*action.XLegacy), float64(*action.YLegacy))
+		if action.ShotResult == "Made" {
+			c.Circle(p.X, p.Y, 5, teamColor)
+			continue
+		}
+
+		c.Polyline([]point{{p.X - 4, p.Y - 4}, {p.X + 4, p.Y + 4}}, 2, teamColor)
+		c.Polyline([]point{{p.X - 4, p.Y + 4}, {p.X + 4, p.Y - 4}}, 2, teamColor)
+	}
+
+	return c.Encode()
+}
+
+const (
+	flowChartWidth  = 800.0
+	flowChartHeight = 400.0
+	marginLeft      = 60.0
+	marginRight     = 20.0
+	marginTop       = 40.0
+	marginBottom    = 40.0
+)
+
+func renderGameFlowChart(format Format, pbp nba.PlayByPlay, regulationPeriods int) ([]byte, error) {
+	c, err := newCanvas(format, int(flowChartWidth), int(flowChartHeight))
+	if err != nil {
+		return nil, err
+	}
+
+	teams := pbp.Teams()
+
+	type marginPoint struct {
+		elapsed time.Duration
+		margin  int
+	}
+
+	lastPeriod := regulationPeriods
+	maxMargin := 10
+	margins := []marginPoint{{elapsed: 0, margin: 0}}
+	for _, action := range pbp.Game.Actions {
+		home, away, err := action.Score()
+		if err != nil {
+			continue
+		}
+		lastPeriod = max(lastPeriod, action.Period)
+
+		margin := home - away
+		if margin == margins[len(margins)-1].margin {
+			continue
+		}
+		maxMargin = max(maxMargin, int(math.Abs(float64(margin))))
+		margins = append(margins, marginPoint{elapsed: action.GameElapsed(regulationPeriods), margin: margin})
+	}
+	// round the axis up to the next multiple of 5 so the gridlines land on round numbers
+	maxMargin = int(math.Ceil(float64(maxMargin)/5) * 5)
+
+	totalElapsed := nba.GameElapsed(lastPeriod, regulationPeriods, 0)
+
+	plotWidth := flowChartWidth - marginLeft - marginRight
+	plotHeight := flowChartHeight - marginTop - marginBottom
+	zeroY := marginTop + plotHeight/2
+	xFor := func(elapsed time.Duration) float64 {
+		return marginLeft + plotWidth*float64(elapsed)/float64(totalElapsed)
+	}
+	yFor := func(margin int) float64 {
+		return zeroY - (plotHeight/2)*float64(margin)/float64(maxMargin)
+	}
+
+	c.Rect(0, 0, flowChartWidth, flowChartHeight, colorBackground)
+	c.Text(flowChartWidth/2, 24, fmt.Sprintf("%s @ %s game flow", teams.AwayTricode, teams.HomeTricode), colorText, textAnchorMiddle)
+
+	for m := -maxMargin; m <= maxMargin; m += 5 {
+		y := yFor(m)
+		c.Polyline([]point{{marginLeft, y}, {flowChartWidth - marginRight, y}}, 1, colorGrid)
+		label := fmt.Sprintf("%d", int(math.Abs(float64(m))))
+		if m > 0 {
+			label = fmt.Sprintf("%s +%d", teams.HomeTricode, m)
+		} else if m < 0 {
+			label = fmt.Sprintf("%s +%d", teams.AwayTricode, -m)
+		}
+		c.Text(marginLeft-6, y+4, label, colorText, textAnchorEnd)
+	}
+	c.Polyline([]point{{marginLeft, zeroY}, {flowChartWidth - marginRight, zeroY}}, 1, colorLine)
+
+	for period := 1; period <= lastPeriod; period++ {
+		start := xFor(nba.GameElapsed(period, regulationPeriods, nba.PeriodLength(period, regulationPeriods)))
+		end := xFor(nba.GameElapsed(period, regulationPeriods, 0))
+		c.Polyline([]point{{end, marginTop}, {end, flowChartHeight - marginBottom}}, 1, colorGrid)
+		c.Text((start+end)/2, flowChartHeight-marginBottom+18, periodLabel(period, regulationPeriods), colorText, textAnchorMiddle)
+	}
+
+	// draw the margin as steps since the score only changes on scoring plays
+	var points []point
+	for i, m := range margins {
+		x := xFor(m.elapsed)
+		if i > 0 {
+			points = append(points, point{X: x, Y: yFor(margins[i-1].margin)})
+		}
+		points = append(points, point{X: x, Y: yFor(m.margin)})
+	}
+	points = append(points, point{X: xFor(totalElapsed), Y: yFor(margins[len(margins)-1].margin)})
+	c.Polyline(points, 2, colorHome)
+
+	return c.Encode()
+}
+
+const (
+	periodChartWidth  = 600.0
+	periodChartHeight = 360.0
+)
+
+func renderPeriodScoringChart(format Format, pbp nba.PlayByPlay, regulationPeriods int) ([]byte, error) {
+	c, err := newCanvas(format, int(periodChartWidth), int(periodChartHeight))
+	if err != nil {
+		return nil, err
+	}
+
+	teams := pbp.Teams()
+	homePoints, awayPoints := periodPoints(pbp.Game.Actions)
+
+	maxPoints := 10
+	for i := range homePoints {
+		maxPoints = max(maxPoints, homePoints[i], awayPoints[i])
+	}
+	maxPoints = int(math.Ceil(float64(maxPoints)/10) * 10)
+
+	plotWidth := periodChartWidth - marginLeft - marginRight
+	plotHeight := periodChartHeight - marginTop - marginBottom
+	baseY := marginTop + plotHeight
+	yFor := func(points int) float64 {
+		return baseY - plotHeight*float64(points)/float64(maxPoints)
+	}
+
+	c.Rect(0, 0, periodChartWidth, periodChartHeight, colorBackground)
+	c.Text(periodChartWidth/2, 24, fmt.Sprintf("%s @ %s scoring by period", teams.AwayTricode, teams.HomeTricode), colorText, textAnchorMiddle)
+
+	for p := 0; p <= maxPoints; p += 10 {
+		y := yFor(p)
+		c.Polyline([]point{{marginLeft, y}, {periodChartWidth - marginRight, y}}, 1, colorGrid)
+		c.Text(marginLeft-6, y+4, fmt.Sprintf("%d", p), colorText, textAnchorEnd)
+	}
+
+	if len(homePoints) == 0 {
+		return c.Encode()
+	}
+
+	groupWidth := plotWidth / float64(len(homePoints))
+	barWidth := groupWidth * 0.35
+	for i := range homePoints {
+		groupX := marginLeft + groupWidth*float64(i)
+		awayX := groupX + groupWidth/2 - barWidth
+		homeX := groupX + groupWidth/2
+
+		c.Rect(awayX, yFor(awayPoints[i]), barWidth, baseY-yFor(awayPoints[i]), colorAway)
+		c.Rect(homeX, yFor(homePoints[i]), barWidth, baseY-yFor(homePoints[i]), colorHome)
+		c.Text(awayX+barWidth/2, yFor(awayPoints[i])-4, fmt.Sprintf("%d", awayPoints[i]), colorText, textAnchorMiddle)
+		c.Text(homeX+barWidth/2, yFor(homePoints[i])-4, fmt.Sprintf("%d", homePoints[i]), colorText, textAnchorMiddle)
+		c.Text(groupX+groupWidth/2, baseY+18, periodLabel(i+1, regulationPeriods), colorText, textAnchorMiddle)
+	}
+	c.Polyline([]point{{marginLeft, baseY}, {periodChartWidth - marginRight, baseY}}, 1, colorLine)
+
+	c.Rect(marginLeft, periodChartHeight-14, 10, 10, colorAway)
+	c.Text(marginLeft+14, periodChartHeight-5, teams.AwayTricode, colorText, textAnchorStart)
+	c.Rect(marginLeft+60, periodChartHeight-14, 10, 10, colorHome)
+	c.Text(marginLeft+74, periodChartHeight-5, teams.HomeTricode, colorText, textAnchorStart)
+
+	return c.Encode()
+}
+
+// periodPoints returns the points scored by the home and away teams in each period indexed by period - 1
+func periodPoints(actions []nba.PlayByPlayAction) ([]int, []int) {
+	var homeTotals, awayTotals []int
+	for _, action := range actions {
+		home, away, err := action.Score()
+		if err != nil || action.Period < 1 {
+			continue
+		}
+		for len(homeTotals) < action.Period {
+			homeTotals = append(homeTotals, 0)
+			awayTotals = append(awayTotals, 0)
+		}
+		homeTotals[action.Period-1] = max(homeTotals[action.Period-1], home)
+		awayTotals[action.Period-1] = max(awayTotals[action.Period-1], away)
+	}
+
+	homePoints := make([]int, len(homeTotals))
+	awayPoints := make([]int, len(awayTotals))
+	prevHome, prevAway := 0, 0
+	for i := range homeTotals {
+		// a period with no scoring carries the previous total forward
+		homeTotals[i] = max(homeTotals[i], prevHome)
+		awayTotals[i] = max(awayTotals[i], prevAway)
+		homePoints[i] = homeTotals[i] - prevHome
+		awayPoints[i] = awayTotals[i] - prevAway
+		prevHome, prevAway = homeTotals[i], awayTotals[i]
+	}
+
+	return homePoints, awayPoints
+}
+
+func periodLabel(period int, regulationPeriods int) string {
+	if period > regulationPeriods {
+		if period == regulationPeriods+1 {
+			return "OT"
+		}
+		return fmt.Sprintf("%dOT", period-regulationPeriods)
+	}
+	return fmt.Sprintf("Q%d", period)
+}
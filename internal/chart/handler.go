@@ -0,0 +1,72 @@
+package chart
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"go.opentelemetry.io/otel"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler interface {
+	Routes() chi.Router
+	GetSVG(w http.ResponseWriter, r *http.Request)
+	GetPNG(w http.ResponseWriter, r *http.Request)
+}
+
+func NewHandler(logger *slog.Logger, chartService Service) Handler {
+	return &handler{logger: logger, chartService: chartService}
+}
+
+type handler struct {
+	logger       *slog.Logger
+	chartService Service
+}
+
+func (h *handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/{kind}.svg", h.GetSVG)
+	r.Get("/{kind}.png", h.GetPNG)
+
+	return r
+}
+
+func (h *handler) GetSVG(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("chart").Start(r.Context(), "chart.handler.GetSVG")
+	defer span.End()
+
+	h.writeChart(ctx, w, r, FormatSVG, util.ContentTypeSVG)
+}
+
+func (h *handler) GetPNG(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("chart").Start(r.Context(), "chart.handler.GetPNG")
+	defer span.End()
+
+	h.writeChart(ctx, w, r, FormatPNG, util.ContentTypePNG)
+}
+
+func (h *handler) writeChart(ctx context.Context, w http.ResponseWriter, r *http.Request, format Format, contentType string) {
+	gameID := chi.URLParam(r, "gameID")
+	kind := Kind(chi.URLParam(r, "kind"))
+	if !kind.Valid() {
+		util.WriteProblem(w, r, http.StatusBadRequest, "invalid chart kind")
+		return
+	}
+
+	logger := h.logger.With(slog.String("game_id", gameID), slog.String("kind", string(kind)))
+
+	chart, err := h.chartService.GetGameChart(ctx, logger, gameID, kind, format)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get game chart", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(chart)
+}
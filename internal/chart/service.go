@@ -0,0 +1,135 @@
+package chart
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/drewthor/wolves_reddit_bot/apis/cloudflare"
+	"github.com/drewthor/wolves_reddit_bot/apis/nba"
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+type Service interface {
+	GetGameChart(ctx context.Context, logger *slog.Logger, gameID string, kind Kind, format Format) ([]byte, error)
+	ArchiveGameCharts(ctx context.Context, logger *slog.Logger, nbaGameID string) error
+}
+
+func NewService(chartStore Store, r2Client cloudflare.Client) Service {
+	return &service{chartStore: chartStore, r2Client: r2Client}
+}
+
+type service struct {
+	chartStore Store
+
+	r2Client cloudflare.Client
+}
+
+func (s *service) GetGameChart(ctx context.Context, logger *slog.Logger, gameID string, kind Kind, format Format) ([]byte, error) {
+	ctx, span := otel.Tracer("chart").Start(ctx, "chart.service.GetGameChart")
+	defer span.End()
+
+	g, err := s.chartStore.GetGameWithID(ctx, gameID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, util.NewError(util.ErrNotFound, "game not found")
+		}
+		return nil, fmt.Errorf("failed to get game for chart: %w", err)
+	}
+
+	pbp, err := s.archivedPlayByPlay(ctx, g.NBAGameID)
+	if err != nil {
+		return nil, err
+	}
+
+	regulationPeriods, err := s.regulationPeriods(ctx, g.NBAGameID)
+	if err != nil {
+		return nil, err
+	}
+
+	chart, err := render(kind, format, pbp, regulationPeriods)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s chart: %w", kind, err)
+	}
+
+	return chart, nil
+}
+
+// ArchiveGameCharts renders every chart kind for a game and stores them in r2 next to the raw play by play, the play
+// by play must already have been archived by the game update
+func (s *service) ArchiveGameCharts(ctx context.Context, logger *slog.Logger, nbaGameID string) error {
+	ctx, span := otel.Tracer("chart").Start(ctx, "chart.service.ArchiveGameCharts")
+	defer span.End()
+
+	pbp, err := s.archivedPlayByPlay(ctx, nbaGameID)
+	if err != nil {
+		return err
+	}
+
+	regulationPeriods, err := s.regulationPeriods(ctx, nbaGameID)
+	if err != nil {
+		return err
+	}
+
+	formatContentTypes := map[Format]string{
+		FormatSVG: util.ContentTypeSVG,
+		FormatPNG: util.ContentTypePNG,
+	}
+
+	for _, kind := range Kinds {
+		for format, contentType := range formatContentTypes {
+			chart, err := render(kind, format, pbp, regulationPeriods)
+			if err != nil {
+				return fmt.Errorf("failed to render %s chart to archive: %w", kind, err)
+			}
+
+			objectKey := fmt.Sprintf("playbyplay/%s_%s.%s", nbaGameID, kind, format)
+			if err := s.r2Client.PutObject(ctx, util.NBAR2Bucket, objectKey, contentType, bytes.NewReader(chart)); err != nil {
+				return fmt.Errorf("failed to archive %s chart to r2: %w", kind, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// archivedPlayByPlay reads the play by play the game update archived in r2 so rendering a chart never goes to the nba
+// cdn or overwrites the archive
+func (s *service) archivedPlayByPlay(ctx context.Context, nbaGameID string) (nba.PlayByPlay, error) {
+	b, err := s.r2Client.GetObject(ctx, util.NBAR2Bucket, fmt.Sprintf("playbyplay/%s.json", nbaGameID))
+	if err != nil {
+		var respErr *awshttp.ResponseError
+		if errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotFound {
+			return nba.PlayByPlay{}, util.NewError(util.ErrNotFound, "play by play not found")
+		}
+		return nba.PlayByPlay{}, fmt.Errorf("failed to get archived play by play for chart: %w", err)
+	}
+
+	pbp := nba.PlayByPlay{}
+	if err := json.Unmarshal(b, &pbp); err != nil {
+		return nba.PlayByPlay{}, fmt.Errorf("failed to unmarshal archived play by play for chart: %w", err)
+	}
+
+	return pbp, nil
+}
+
+// regulationPeriods returns the number of regulation periods stored for the game, falling back to the default for
+// games that haven't been ingested yet
+func (s *service) regulationPeriods(ctx context.Context, nbaGameID string) (int, error) {
+	regulationPeriods, err := s.chartStore.GetGameRegulationPeriods(ctx, nbaGameID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("failed to get regulation periods for chart: %w", err)
+	}
+	if regulationPeriods == nil {
+		return nba.DefaultRegulationPeriods, nil
+	}
+
+	return *regulationPeriods, nil
+}
@@ -0,0 +1,12 @@
+package chart
+
+import (
+	"context"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+)
+
+type Store interface {
+	GetGameWithID(ctx context.Context, id string) (api.Game, error)
+	GetGameRegulationPeriods(ctx context.Context, nbaGameID string) (*int, error)
+}
@@ -4,6 +4,7 @@ import (
 	"log/slog"
 	"net/http"
 
+	"github.com/drewthor/wolves_reddit_bot/internal/api_key"
 	"github.com/drewthor/wolves_reddit_bot/util"
 	"go.opentelemetry.io/otel"
 
@@ -14,6 +15,7 @@ type Handler interface {
 	Routes() chi.Router
 	List(w http.ResponseWriter, r *http.Request)
 	Get(w http.ResponseWriter, r *http.Request)
+	GetTimeline(w http.ResponseWriter, r *http.Request)
 	UpdateFranchises(w http.ResponseWriter, r *http.Request)
 }
 
@@ -31,21 +33,63 @@ func (h *handler) Routes() chi.Router {
 
 	r.Get("/", h.List)
 
+	// teamID is the id or nba team id of any team that has been part of the franchise
 	r.Get("/{teamID}", h.Get)
+	r.Get("/{teamID}/timeline", h.GetTimeline)
 
-	r.Post("/update", h.UpdateFranchises)
+	r.With(api_key.RequireScope(api_key.ScopeIngest)).Post("/update", h.UpdateFranchises)
 
 	return r
 }
 
 func (h *handler) List(w http.ResponseWriter, r *http.Request) {
-	_, span := otel.Tracer("team").Start(r.Context(), "franchise.handler.List")
+	ctx, span := otel.Tracer("team").Start(r.Context(), "franchise.handler.List")
 	defer span.End()
+
+	franchises, err := h.franchiseService.ListFranchises(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list franchises", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteCacheableListJSON(w, r, franchises, util.CacheControlRevalidate)
 }
 
 func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
-	_, span := otel.Tracer("team").Start(r.Context(), "franchise.handler.Get")
+	ctx, span := otel.Tracer("team").Start(r.Context(), "franchise.handler.Get")
+	defer span.End()
+
+	teamID := chi.URLParam(r, "teamID")
+
+	logger := h.logger.With(slog.String("team_id", teamID))
+
+	franchise, err := h.franchiseService.GetFranchise(ctx, teamID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get franchise", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteCacheableJSON(w, r, franchise, util.CacheControlRevalidate)
+}
+
+func (h *handler) GetTimeline(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("team").Start(r.Context(), "franchise.handler.GetTimeline")
 	defer span.End()
+
+	teamID := chi.URLParam(r, "teamID")
+
+	logger := h.logger.With(slog.String("team_id", teamID))
+
+	timeline, err := h.franchiseService.GetFranchiseTimeline(ctx, teamID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get franchise timeline", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteCacheableListJSON(w, r, timeline, util.CacheControlRevalidate)
 }
 
 func (h *handler) UpdateFranchises(w http.ResponseWriter, r *http.Request) {
@@ -55,7 +99,7 @@ func (h *handler) UpdateFranchises(w http.ResponseWriter, r *http.Request) {
 	franchises, err := h.franchiseService.UpdateFranchises(ctx, h.logger)
 	if err != nil {
 		h.logger.ErrorContext(ctx, "failed to update franchises", slog.Any("error", err))
-		util.WriteJSON(http.StatusInternalServerError, err, w)
+		util.WriteError(w, r, err)
 		return
 	}
 
@@ -2,24 +2,33 @@ package franchise
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
 
 	"github.com/drewthor/wolves_reddit_bot/api"
 	"github.com/drewthor/wolves_reddit_bot/apis/nba"
+	"github.com/drewthor/wolves_reddit_bot/internal/season"
 	"github.com/drewthor/wolves_reddit_bot/internal/team"
 	"github.com/drewthor/wolves_reddit_bot/internal/team_season"
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"github.com/jackc/pgx/v5"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
 )
 
 type Service interface {
+	ListFranchises(ctx context.Context) ([]api.Franchise, error)
+	GetFranchise(ctx context.Context, teamID string) (api.Franchise, error)
+	GetFranchiseTimeline(ctx context.Context, teamID string) (api.FranchiseTimeline, error)
 	UpdateFranchises(ctx context.Context, logger *slog.Logger) ([]api.Franchise, error)
 }
 
-func NewService(franchiseStore Store, teamService team.Service, teamSeasonService team_season.Service, nbaClient nba.Client) Service {
+func NewService(franchiseStore Store, seasonService season.Service, teamService team.Service, teamSeasonService team_season.Service, nbaClient nba.Client) Service {
 	return &service{
 		franchiseStore:    franchiseStore,
+		seasonService:     seasonService,
 		teamService:       teamService,
 		teamSeasonService: teamSeasonService,
 		nbaClient:         nbaClient,
@@ -28,16 +37,75 @@ func NewService(franchiseStore Store, teamService team.Service, teamSeasonServic
 
 type service struct {
 	franchiseStore    Store
+	seasonService     season.Service
 	teamService       team.Service
 	teamSeasonService team_season.Service
 
 	nbaClient nba.Client
 }
 
+func (s service) ListFranchises(ctx context.Context) ([]api.Franchise, error) {
+	ctx, span := otel.Tracer("team").Start(ctx, "franchise.service.ListFranchises")
+	defer span.End()
+
+	return s.franchiseStore.ListFranchises(ctx)
+}
+
+// GetFranchise resolves the franchise of any team that has been part of it, teamID is either the team's id or its nba team id
+func (s service) GetFranchise(ctx context.Context, teamID string) (api.Franchise, error) {
+	ctx, span := otel.Tracer("team").Start(ctx, "franchise.service.GetFranchise")
+	defer span.End()
+
+	var franchise api.Franchise
+	var err error
+	if nbaTeamID, convErr := strconv.Atoi(teamID); convErr == nil {
+		franchise, err = s.franchiseStore.GetFranchiseWithNBATeamID(ctx, nbaTeamID)
+	} else {
+		franchise, err = s.franchiseStore.GetFranchiseWithTeamID(ctx, teamID)
+	}
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.Franchise{}, util.NewError(util.ErrNotFound, "franchise not found")
+		}
+		return api.Franchise{}, fmt.Errorf("failed to get franchise for team: %w", err)
+	}
+
+	return franchise, nil
+}
+
+func (s service) GetFranchiseTimeline(ctx context.Context, teamID string) (api.FranchiseTimeline, error) {
+	ctx, span := otel.Tracer("team").Start(ctx, "franchise.service.GetFranchiseTimeline")
+	defer span.End()
+
+	franchise, err := s.GetFranchise(ctx, teamID)
+	if err != nil {
+		return api.FranchiseTimeline{}, err
+	}
+
+	teamSeasons, err := s.franchiseStore.GetFranchiseTeamSeasons(ctx, franchise.ID)
+	if err != nil {
+		return api.FranchiseTimeline{}, fmt.Errorf("failed to get franchise team seasons: %w", err)
+	}
+
+	games, err := s.franchiseStore.GetFranchiseGames(ctx, franchise.ID)
+	if err != nil {
+		return api.FranchiseTimeline{}, fmt.Errorf("failed to get franchise games: %w", err)
+	}
+
+	currentSeasonStartYear, err := s.seasonService.GetCurrentSeasonStartYear(ctx)
+	if err != nil {
+		return api.FranchiseTimeline{}, fmt.Errorf("failed to get current season start year: %w", err)
+	}
+
+	return franchiseTimeline(franchise, teamSeasons, games, currentSeasonStartYear), nil
+}
+
 func (s service) UpdateFranchises(ctx context.Context, logger *slog.Logger) ([]api.Franchise, error) {
 	ctx, span := otel.Tracer("team").Start(ctx, "franchise.service.UpdateFranchises")
 	defer span.End()
 
+	ctx = util.WithIngestRun(ctx, util.NewIngestRun("franchisehistory/00"))
+
 	franchises, err := s.nbaClient.FranchiseHistory(ctx, "00")
 	if err != nil {
 		span.RecordError(err)
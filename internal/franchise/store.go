@@ -2,14 +2,38 @@ package franchise
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/drewthor/wolves_reddit_bot/api"
 )
 
 type Store interface {
+	ListFranchises(ctx context.Context) ([]api.Franchise, error)
+	GetFranchiseWithTeamID(ctx context.Context, teamID string) (api.Franchise, error)
+	GetFranchiseWithNBATeamID(ctx context.Context, nbaTeamID int) (api.Franchise, error)
+	GetFranchiseTeamSeasons(ctx context.Context, franchiseID string) ([]FranchiseTeamSeason, error)
+	GetFranchiseGames(ctx context.Context, franchiseID string) ([]FranchiseGame, error)
 	UpdateFranchises(ctx context.Context, franchises []FranchiseUpdate) ([]api.Franchise, error)
 }
 
+// FranchiseTeamSeason is the city and name a franchise played under in a season
+type FranchiseTeamSeason struct {
+	TeamID          string
+	SeasonStartYear int
+	City            sql.NullString
+	Name            sql.NullString
+}
+
+// FranchiseGame is a completed game played by any of a franchise's teams
+type FranchiseGame struct {
+	NBAGameID       string
+	SeasonStartYear int
+	TeamID          string
+	City            string
+	Name            string
+	Won             bool
+}
+
 type FranchiseUpdate struct {
 	NBALeagueID        string
 	NBATeamID          int
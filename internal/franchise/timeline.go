@@ -0,0 +1,158 @@
+package franchise
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/apis/nba"
+	"github.com/drewthor/wolves_reddit_bot/internal/playoff"
+)
+
+// season stages as encoded in nba game ids
+const (
+	gameIDSeasonStageRegular = 2
+	gameIDSeasonStagePost    = 4
+)
+
+const (
+	finalsRound       = 4
+	playoffWinsNeeded = 4
+)
+
+type playoffRoundRecord struct {
+	wins, losses int
+}
+
+// franchiseTimeline builds the season by season history of a franchise, games decide the records and playoff results
+// while the team seasons decide the city and name the franchise played under
+func franchiseTimeline(franchise api.Franchise, teamSeasons []FranchiseTeamSeason, games []FranchiseGame, currentSeasonStartYear int) api.FranchiseTimeline {
+	seasons := map[int]*api.FranchiseSeason{}
+	seasonPlayoffRounds := map[int]map[int]*playoffRoundRecord{}
+
+	getSeason := func(seasonStartYear int) *api.FranchiseSeason {
+		if _, ok := seasons[seasonStartYear]; !ok {
+			seasons[seasonStartYear] = &api.FranchiseSeason{SeasonStartYear: seasonStartYear}
+		}
+		return seasons[seasonStartYear]
+	}
+
+	for _, teamSeason := range teamSeasons {
+		season := getSeason(teamSeason.SeasonStartYear)
+		season.TeamID = teamSeason.TeamID
+		season.City = teamSeason.City.String
+		season.Name = teamSeason.Name.String
+	}
+
+	for _, game := range games {
+		stage, ok := nba.GameIDSeasonStage(game.NBAGameID)
+		if !ok || (stage != gameIDSeasonStageRegular && stage != gameIDSeasonStagePost) {
+			continue
+		}
+
+		season := getSeason(game.SeasonStartYear)
+		if season.TeamID == "" {
+			season.TeamID = game.TeamID
+		}
+		if season.City == "" && season.Name == "" {
+			season.City = game.City
+			season.Name = game.Name
+		}
+
+		if stage == gameIDSeasonStageRegular {
+			if game.Won {
+				season.Wins++
+			} else {
+				season.Losses++
+			}
+			continue
+		}
+
+		if game.Won {
+			season.PlayoffWins++
+		} else {
+			season.PlayoffLosses++
+		}
+
+		playoffGameID, _ := nba.ParsePlayoffGameID(game.NBAGameID)
+		if _, ok := seasonPlayoffRounds[game.SeasonStartYear]; !ok {
+			seasonPlayoffRounds[game.SeasonStartYear] = map[int]*playoffRoundRecord{}
+		}
+		roundRecord, ok := seasonPlayoffRounds[game.SeasonStartYear][playoffGameID.Round]
+		if !ok {
+			roundRecord = &playoffRoundRecord{}
+			seasonPlayoffRounds[game.SeasonStartYear][playoffGameID.Round] = roundRecord
+		}
+		if game.Won {
+			roundRecord.wins++
+		} else {
+			roundRecord.losses++
+		}
+	}
+
+	timeline := api.FranchiseTimeline{
+		Franchise: franchise,
+		Eras:      []api.FranchiseEra{},
+		Seasons:   []api.FranchiseSeason{},
+		Titles:    []int{},
+	}
+
+	seasonStartYears := make([]int, 0, len(seasons))
+	for seasonStartYear := range seasons {
+		seasonStartYears = append(seasonStartYears, seasonStartYear)
+	}
+	sort.Ints(seasonStartYears)
+
+	for _, seasonStartYear := range seasonStartYears {
+		season := seasons[seasonStartYear]
+
+		if playoffRounds, ok := seasonPlayoffRounds[seasonStartYear]; ok {
+			deepestRound := 0
+			for round := range playoffRounds {
+				deepestRound = max(deepestRound, round)
+			}
+			roundRecord := playoffRounds[deepestRound]
+			// series lengths changed over the years so only this season's last series can still be undecided
+			decided := seasonStartYear != currentSeasonStartYear || roundRecord.wins >= playoffWinsNeeded || roundRecord.losses >= playoffWinsNeeded
+			won := roundRecord.wins > roundRecord.losses
+
+			roundName := strings.ToLower(playoff.RoundName(deepestRound))
+			var result string
+			switch {
+			case !decided:
+				result = fmt.Sprintf("in %s", roundName)
+			case deepestRound == finalsRound && won:
+				result = "won nba finals"
+				season.Champion = true
+				timeline.Titles = append(timeline.Titles, seasonStartYear)
+			case won:
+				// the franchise won its last series but the next round has not started yet
+				result = fmt.Sprintf("won %s", roundName)
+			default:
+				result = fmt.Sprintf("lost %s", roundName)
+			}
+
+			season.PlayoffRound = &deepestRound
+			season.PlayoffResult = &result
+		}
+
+		timeline.Seasons = append(timeline.Seasons, *season)
+
+		lastEra := len(timeline.Eras) - 1
+		if lastEra >= 0 && timeline.Eras[lastEra].City == season.City && timeline.Eras[lastEra].Name == season.Name && timeline.Eras[lastEra].EndYear == seasonStartYear-1 {
+			timeline.Eras[lastEra].EndYear = seasonStartYear
+			timeline.Eras[lastEra].Seasons++
+			continue
+		}
+		timeline.Eras = append(timeline.Eras, api.FranchiseEra{
+			City:      season.City,
+			Name:      season.Name,
+			StartYear: seasonStartYear,
+			EndYear:   seasonStartYear,
+			Seasons:   1,
+		})
+	}
+
+	return timeline
+}
@@ -1,21 +1,29 @@
 package game
 
 import (
+	"database/sql"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
 	"strconv"
+	"time"
 
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/internal/api_key"
 	"github.com/drewthor/wolves_reddit_bot/internal/boxscore"
 	"github.com/drewthor/wolves_reddit_bot/util"
 	"go.opentelemetry.io/otel"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 )
 
 type Handler interface {
 	Routes() chi.Router
 	List(w http.ResponseWriter, r *http.Request)
 	UpdateGames(w http.ResponseWriter, r *http.Request)
+	BackfillHistoricalSeason(w http.ResponseWriter, r *http.Request)
 }
 
 func NewHandler(logger *slog.Logger, gameService Service) Handler {
@@ -36,8 +44,9 @@ func (h *handler) Routes() chi.Router {
 		r.Mount("/boxscore", boxscore.NewHandler(h.logger, boxscore.NewService()).Routes())
 	})
 
-	r.Post("/update", h.UpdateGames)
-	r.Post("/updateGame", h.UpdateGame)
+	r.With(api_key.RequireScope(api_key.ScopeIngest)).Post("/update", h.UpdateGames)
+	r.With(api_key.RequireScope(api_key.ScopeIngest)).Post("/updateGame", h.UpdateGame)
+	r.With(api_key.RequireScope(api_key.ScopeIngest)).Post("/backfill", h.BackfillHistoricalSeason)
 
 	return r
 }
@@ -46,22 +55,31 @@ func (h *handler) List(w http.ResponseWriter, r *http.Request) {
 	ctx, span := otel.Tracer("game").Start(r.Context(), "game.handler.List")
 	defer span.End()
 
-	//gameDate := r.URL.Query().Get("game-date")
-	//if gameDate == "" {
-	//	util.WriteJSON(http.StatusBadRequest, "invalid request: missing game_date", w)
-	//	return
-	//}
-
-	//logger := h.logger.With(slog.String("game_date", gameDate))
+	filter, err := parseListFilter(r)
+	if err != nil {
+		util.WriteError(w, r, err)
+		return
+	}
 
-	games, err := h.gameService.List(ctx)
+	games, nextCursor, err := h.gameService.List(ctx, filter)
 	if err != nil {
 		h.logger.ErrorContext(ctx, "failed to get games", slog.Any("error", err))
-		util.WriteJSON(http.StatusInternalServerError, err, w)
+		util.WriteError(w, r, err)
 		return
 	}
 
-	util.WriteJSON(http.StatusOK, games, w)
+	if nextCursor != nil {
+		w.Header().Set(util.NextCursorHeader, nextCursor.Encode())
+	}
+
+	// a page of games that are all final won't change
+	cacheControl := util.CacheControlRevalidate
+	now := time.Now()
+	if len(games) > 0 && !slices.ContainsFunc(games, func(g api.Game) bool { return !IsFinal(g, now) }) {
+		cacheControl = util.CacheControlFinal
+	}
+
+	util.WriteCacheableListJSON(w, r, games, cacheControl)
 }
 
 func (h *handler) UpdateGames(w http.ResponseWriter, r *http.Request) {
@@ -70,7 +88,7 @@ func (h *handler) UpdateGames(w http.ResponseWriter, r *http.Request) {
 
 	seasonStartYear, err := strconv.Atoi(r.URL.Query().Get("season-start-year"))
 	if err != nil {
-		util.WriteJSON(http.StatusBadRequest, "invalid required season-start-year", w)
+		util.WriteProblem(w, r, http.StatusBadRequest, "invalid required season-start-year")
 		return
 	}
 
@@ -79,7 +97,29 @@ func (h *handler) UpdateGames(w http.ResponseWriter, r *http.Request) {
 	games, err := h.gameService.UpdateSeasonGames(ctx, logger, seasonStartYear)
 	if err != nil {
 		logger.ErrorContext(ctx, "could not update games", slog.Any("error", err))
-		util.WriteJSON(http.StatusInternalServerError, err, w)
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteJSON(http.StatusOK, games, w)
+}
+
+func (h *handler) BackfillHistoricalSeason(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("game").Start(r.Context(), "game.handler.BackfillHistoricalSeason")
+	defer span.End()
+
+	seasonStartYear, err := strconv.Atoi(r.URL.Query().Get("season-start-year"))
+	if err != nil {
+		util.WriteProblem(w, r, http.StatusBadRequest, "invalid required season-start-year")
+		return
+	}
+
+	logger := h.logger.With(slog.Int("season_start_year", seasonStartYear))
+
+	games, err := h.gameService.BackfillHistoricalSeason(ctx, logger, seasonStartYear)
+	if err != nil {
+		logger.ErrorContext(ctx, "could not backfill historical season", slog.Any("error", err))
+		util.WriteError(w, r, err)
 		return
 	}
 
@@ -92,14 +132,14 @@ func (h *handler) UpdateGame(w http.ResponseWriter, r *http.Request) {
 
 	gameID := r.URL.Query().Get("game-id")
 	if gameID == "" {
-		util.WriteJSON(http.StatusBadRequest, "invalid required game-id", w)
+		util.WriteProblem(w, r, http.StatusBadRequest, "invalid required game-id")
 		return
 	}
 
 	seasonStartYearStr := r.URL.Query().Get("season-start-year")
 	seasonStartYear, err := strconv.Atoi(seasonStartYearStr)
 	if err != nil {
-		util.WriteJSON(http.StatusBadRequest, "invalid required season-start-year", w)
+		util.WriteProblem(w, r, http.StatusBadRequest, "invalid required season-start-year")
 		return
 	}
 
@@ -108,9 +148,96 @@ func (h *handler) UpdateGame(w http.ResponseWriter, r *http.Request) {
 	games, err := h.gameService.UpdateGame(ctx, logger, gameID, seasonStartYear)
 	if err != nil {
 		logger.ErrorContext(ctx, "could not update game", slog.Any("error", err))
-		util.WriteJSON(http.StatusInternalServerError, err, w)
+		util.WriteError(w, r, err)
 		return
 	}
 
 	util.WriteJSON(http.StatusOK, games, w)
 }
+
+// parseListFilter reads the game list query params, a date filters to the games on that day and start-date and end-date
+// to the games within the range
+func parseListFilter(r *http.Request) (ListFilter, error) {
+	listParams, err := util.ParseListParams(r, util.ListParamsOptions{
+		SortFields:   []string{SortStartTime},
+		DefaultSort:  util.Sort{Field: SortStartTime},
+		DefaultLimit: DefaultListLimit,
+		MaxLimit:     MaxListLimit,
+	})
+	if err != nil {
+		return ListFilter{}, err
+	}
+
+	filter := ListFilter{ListParams: listParams}
+	query := r.URL.Query()
+
+	if broadcaster := query.Get("broadcaster"); broadcaster != "" {
+		filter.Broadcaster = sql.NullString{String: broadcaster, Valid: true}
+	}
+
+	if date, ok, err := util.ParseDateParam(r, "date"); err != nil {
+		return ListFilter{}, err
+	} else if ok {
+		filter.StartDate = sql.NullTime{Time: date, Valid: true}
+		filter.EndDate = sql.NullTime{Time: date, Valid: true}
+	}
+	if startDate, ok, err := util.ParseDateParam(r, "start-date"); err != nil {
+		return ListFilter{}, err
+	} else if ok {
+		filter.StartDate = sql.NullTime{Time: startDate, Valid: true}
+	}
+	if endDate, ok, err := util.ParseDateParam(r, "end-date"); err != nil {
+		return ListFilter{}, err
+	} else if ok {
+		filter.EndDate = sql.NullTime{Time: endDate, Valid: true}
+	}
+
+	if seasonStartYearStr := query.Get("season-start-year"); seasonStartYearStr != "" {
+		seasonStartYear, err := strconv.Atoi(seasonStartYearStr)
+		if err != nil {
+			return ListFilter{}, fmt.Errorf("%w: season-start-year must be a year", util.ErrInvalidListParam)
+		}
+		filter.SeasonStartYear = sql.NullInt64{Int64: int64(seasonStartYear), Valid: true}
+	}
+
+	if seasonStage := query.Get("season-stage"); seasonStage != "" {
+		seasonStages := []util.SeasonStage{util.SeasonStagePre, util.SeasonStageRegular, util.SeasonStageAllStar, util.SeasonStagePost, util.SeasonStagePlayIn}
+		if !slices.Contains(seasonStages, util.SeasonStage(seasonStage)) {
+			return ListFilter{}, fmt.Errorf("%w: unknown season-stage %s", util.ErrInvalidListParam, seasonStage)
+		}
+		filter.SeasonStageName = sql.NullString{String: seasonStage, Valid: true}
+	}
+
+	if teamID := query.Get("team-id"); teamID != "" {
+		if _, err := uuid.Parse(teamID); err != nil {
+			return ListFilter{}, fmt.Errorf("%w: team-id must be a team's id", util.ErrInvalidListParam)
+		}
+		filter.TeamID = sql.NullString{String: teamID, Valid: true}
+		filter.TeamSide = TeamSideEither
+	}
+	if teamSide := query.Get("team-side"); teamSide != "" {
+		if !filter.TeamID.Valid {
+			return ListFilter{}, fmt.Errorf("%w: team-side requires team-id", util.ErrInvalidListParam)
+		}
+		if !slices.Contains([]TeamSide{TeamSideHome, TeamSideAway, TeamSideEither}, TeamSide(teamSide)) {
+			return ListFilter{}, fmt.Errorf("%w: team-side must be home, away or either", util.ErrInvalidListParam)
+		}
+		filter.TeamSide = TeamSide(teamSide)
+	}
+
+	if status := query.Get("status"); status != "" {
+		if !slices.Contains([]GameStatus{GameStatusScheduled, GameStatusStarted, GameStatusCompleted}, GameStatus(status)) {
+			return ListFilter{}, fmt.Errorf("%w: status must be scheduled, started or completed", util.ErrInvalidListParam)
+		}
+		filter.StatusName = sql.NullString{String: status, Valid: true}
+	}
+
+	if arenaID := query.Get("arena-id"); arenaID != "" {
+		if _, err := uuid.Parse(arenaID); err != nil {
+			return ListFilter{}, fmt.Errorf("%w: arena-id must be an arena's id", util.ErrInvalidListParam)
+		}
+		filter.ArenaID = sql.NullString{String: arenaID, Valid: true}
+	}
+
+	return filter, nil
+}
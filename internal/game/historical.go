@@ -0,0 +1,257 @@
+package game
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/apis/nba"
+	"github.com/drewthor/wolves_reddit_bot/internal/player_game_stats"
+	"github.com/drewthor/wolves_reddit_bot/internal/team_game_stats"
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// firstHistoricalSeasonStartYear is the first BAA season, which the NBA counts as its first
+const firstHistoricalSeasonStartYear = 1946
+
+// ErrInvalidSeason is returned when backfilling a season the league didn't play
+var ErrInvalidSeason = util.NewError(util.ErrValidation, "invalid season")
+
+// historicalGameTimeZone is the time zone GAME_DATE is in, the game logs don't have a start time so games start at
+// midnight of the day they were played
+const historicalGameTimeZone = "America/New_York"
+
+// BackfillHistoricalSeason fills in the games, team totals and player lines of a season from the stats.nba.com game logs
+// for seasons the cdn doesn't have boxscores for. Games, totals and lines that already came from the cdn are left as is
+func (s *service) BackfillHistoricalSeason(ctx context.Context, logger *slog.Logger, seasonStartYear int) ([]api.Game, error) {
+	ctx, span := otel.Tracer("game").Start(ctx, "game.service.BackfillHistoricalSeason")
+	defer span.End()
+
+	run := util.NewIngestRun(fmt.Sprintf("leaguegamelog/%d", seasonStartYear))
+	ctx = util.WithIngestRun(ctx, run)
+	logger = logger.With(slog.String("ingest_run_id", run.ID))
+
+	if seasonStartYear < firstHistoricalSeasonStartYear {
+		return nil, fmt.Errorf("%w: no seasons before %d", ErrInvalidSeason, firstHistoricalSeasonStartYear)
+	}
+
+	location, err := time.LoadLocation(historicalGameTimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load historical game time zone: %w", err)
+	}
+
+	if err := s.seasonService.EnsureSeasonExistsForLeague(ctx, "00", seasonStartYear); err != nil {
+		return nil, fmt.Errorf("failed to ensure season exists when backfilling historical season: %w", err)
+	}
+
+	var games []api.Game
+	for _, seasonType := range []nba.SeasonType{nba.SeasonTypeRegular, nba.SeasonTypePlayoffs} {
+		seasonTypeGames, err := s.backfillHistoricalSeasonType(ctx, logger, seasonStartYear, seasonType, location)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("failed to backfill %s games: %w", seasonType, err)
+		}
+		games = append(games, seasonTypeGames...)
+	}
+
+	return games, nil
+}
+
+func (s *service) backfillHistoricalSeasonType(ctx context.Context, logger *slog.Logger, seasonStartYear int, seasonType nba.SeasonType, location *time.Location) ([]api.Game, error) {
+	ctx, span := otel.Tracer("game").Start(ctx, "game.service.backfillHistoricalSeasonType")
+	defer span.End()
+
+	gameLogs, err := s.nbaClient.LeagueGameLog(ctx, seasonStartYear, seasonType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league game log: %w", err)
+	}
+
+	if len(gameLogs) == 0 {
+		return []api.Game{}, nil
+	}
+
+	playerGameLogs, err := s.nbaClient.LeaguePlayerGameLog(ctx, seasonStartYear, seasonType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league player game log: %w", err)
+	}
+
+	var gameUpdates []GameHistoricalUpdate
+	var teamGameStatsTotalUpdates []team_game_stats.TeamGameStatsTotalHistoricalUpdate
+	teamIDs := map[int]struct{}{}
+
+	for _, gameLog := range gameLogs {
+		// neutral site games from the early seasons don't always have a home team
+		if gameLog.HomeTeam.TeamID == 0 || gameLog.AwayTeam.TeamID == 0 {
+			logger.WarnContext(ctx, "skipping historical game missing a team", slog.String("game_id", gameLog.GameID))
+			continue
+		}
+
+		teamIDs[gameLog.HomeTeam.TeamID] = struct{}{}
+		teamIDs[gameLog.AwayTeam.TeamID] = struct{}{}
+
+		// MIN in the team game log is the total minutes played by the team's players
+		durationSeconds := sql.NullInt64{}
+		if gameLog.TotalDurationMinutes > 0 {
+			durationSeconds = sql.NullInt64{Int64: int64(gameLog.TotalDurationMinutes * 60 / 5), Valid: true}
+		}
+
+		gameUpdates = append(gameUpdates, GameHistoricalUpdate{
+			NBAGameID:         gameLog.GameID,
+			NBAHomeTeamID:     gameLog.HomeTeam.TeamID,
+			NBAAwayTeamID:     gameLog.AwayTeam.TeamID,
+			HomeTeamPoints:    gameLog.HomeTeam.Points,
+			AwayTeamPoints:    gameLog.AwayTeam.Points,
+			GameStatusName:    string(GameStatusCompleted),
+			SeasonStartYear:   seasonStartYear,
+			SeasonStageName:   string(seasonStageName(gameLog.GameID)),
+			DurationSeconds:   durationSeconds,
+			RegulationPeriods: 4,
+			StartTime:         time.Date(gameLog.GameDate.Year(), gameLog.GameDate.Month(), gameLog.GameDate.Day(), 0, 0, 0, 0, location),
+			Source:            string(util.DataSourceStatsLeagueGameLog),
+		})
+
+		teamGameStatsTotalUpdates = append(teamGameStatsTotalUpdates,
+			historicalTeamGameStatsTotalUpdate(gameLog.GameID, gameLog.HomeTeam, gameLog.AwayTeam.Points, durationSeconds),
+			historicalTeamGameStatsTotalUpdate(gameLog.GameID, gameLog.AwayTeam, gameLog.HomeTeam.Points, durationSeconds),
+		)
+	}
+
+	nbaTeamIDs := make([]int, 0, len(teamIDs))
+	for teamID := range teamIDs {
+		nbaTeamIDs = append(nbaTeamIDs, teamID)
+	}
+
+	if err := s.teamService.EnsureTeamsExistForLeague(ctx, logger, "00", nbaTeamIDs); err != nil {
+		return nil, fmt.Errorf("failed to ensure teams exist: %w", err)
+	}
+
+	games, err := s.gameStore.UpdateHistoricalGames(ctx, gameUpdates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update historical games: %w", err)
+	}
+
+	if _, err := s.teamGameStatsService.UpdateHistoricalTeamGameStatsTotals(ctx, teamGameStatsTotalUpdates); err != nil {
+		return nil, fmt.Errorf("failed to update historical team game stats totals: %w", err)
+	}
+
+	playerGameStatsTotalUpdates := player_game_stats.GameLogUpdates(playerGameLogs)
+
+	// the player game log is missing players for some of the earliest games so those teams fall back to the boxscore
+	gameTeamsWithPlayers := map[string]map[int]struct{}{}
+	for _, playerGameLog := range playerGameLogs {
+		if _, ok := gameTeamsWithPlayers[playerGameLog.GameID]; !ok {
+			gameTeamsWithPlayers[playerGameLog.GameID] = map[int]struct{}{}
+		}
+		gameTeamsWithPlayers[playerGameLog.GameID][playerGameLog.TeamID] = struct{}{}
+	}
+
+	players := map[int]api.Player{}
+	for _, playerGameLog := range playerGameLogs {
+		players[playerGameLog.PlayerID] = historicalPlayer(playerGameLog.PlayerID, playerGameLog.PlayerName)
+	}
+
+	for _, gameUpdate := range gameUpdates {
+		teamsWithPlayers := gameTeamsWithPlayers[gameUpdate.NBAGameID]
+		_, homeTeamHasPlayers := teamsWithPlayers[gameUpdate.NBAHomeTeamID]
+		_, awayTeamHasPlayers := teamsWithPlayers[gameUpdate.NBAAwayTeamID]
+		if homeTeamHasPlayers && awayTeamHasPlayers {
+			continue
+		}
+
+		objectKey := fmt.Sprintf("boxscoretraditional/%d/%s.json", seasonStartYear, gameUpdate.NBAGameID)
+		boxscore, err := s.nbaClient.GetBoxscoreTraditional(ctx, gameUpdate.NBAGameID, objectKey)
+		if err != nil {
+			if errors.Is(err, nba.ErrNotFound) {
+				logger.WarnContext(ctx, "no boxscore for historical game missing player lines", slog.String("game_id", gameUpdate.NBAGameID))
+				continue
+			}
+			return nil, fmt.Errorf("failed to get boxscore traditional for game %s: %w", gameUpdate.NBAGameID, err)
+		}
+
+		for _, boxscoreUpdate := range player_game_stats.BoxscoreTraditionalUpdates(boxscore) {
+			if _, ok := teamsWithPlayers[boxscoreUpdate.NBATeamID]; ok {
+				continue
+			}
+			playerGameStatsTotalUpdates = append(playerGameStatsTotalUpdates, boxscoreUpdate)
+		}
+
+		for _, boxscorePlayer := range boxscore.Players {
+			if _, ok := players[boxscorePlayer.PlayerID]; !ok {
+				players[boxscorePlayer.PlayerID] = historicalPlayer(boxscorePlayer.PlayerID, boxscorePlayer.PlayerName)
+			}
+		}
+	}
+
+	playerUpdates := make([]api.Player, 0, len(players))
+	for _, player := range players {
+		playerUpdates = append(playerUpdates, player)
+	}
+
+	if err := s.playerService.EnsurePlayersExist(ctx, playerUpdates); err != nil {
+		return nil, fmt.Errorf("failed to ensure players exist: %w", err)
+	}
+
+	if _, err := s.playerGameStatsService.UpdateHistoricalPlayerGameStatsTotals(ctx, playerGameStatsTotalUpdates); err != nil {
+		return nil, fmt.Errorf("failed to update historical player game stats totals: %w", err)
+	}
+
+	return games, nil
+}
+
+func historicalTeamGameStatsTotalUpdate(nbaGameID string, teamGameLog nba.TeamGameLog, pointsAgainst int, durationSeconds sql.NullInt64) team_game_stats.TeamGameStatsTotalHistoricalUpdate {
+	totalPlayerTimePlayedSeconds := sql.NullInt64{}
+	if durationSeconds.Valid {
+		totalPlayerTimePlayedSeconds = sql.NullInt64{Int64: durationSeconds.Int64 * 5, Valid: true}
+	}
+
+	statLine := teamGameLog.GameLogStatLine
+
+	return team_game_stats.TeamGameStatsTotalHistoricalUpdate{
+		NBAGameID:                    nbaGameID,
+		NBATeamID:                    teamGameLog.TeamID,
+		GameTimePlayedSeconds:        durationSeconds,
+		TotalPlayerTimePlayedSeconds: totalPlayerTimePlayedSeconds,
+		Points:                       statLine.Points,
+		PointsAgainst:                pointsAgainst,
+		Assists:                      nullInt64(statLine.Assists),
+		TotalTurnovers:               nullInt64(statLine.Turnovers),
+		Steals:                       nullInt64(statLine.Steals),
+		ThreePointersAttempted:       nullInt64(statLine.ThreePointersAttempted),
+		ThreePointersMade:            nullInt64(statLine.ThreePointersMade),
+		FieldGoalsAttempted:          nullInt64(statLine.FieldGoalsAttempted),
+		FieldGoalsMade:               statLine.FieldGoalsMade,
+		FreeThrowsAttempted:          statLine.FreeThrowsAttempted,
+		FreeThrowsMade:               statLine.FreeThrowsMade,
+		Blocks:                       nullInt64(statLine.Blocks),
+		TotalOffensiveRebounds:       nullInt64(statLine.OffensiveRebounds),
+		TotalDefensiveRebounds:       nullInt64(statLine.DefensiveRebounds),
+		TotalRebounds:                nullInt64(statLine.TotalRebounds),
+		PersonalFouls:                nullInt64(statLine.PersonalFouls),
+		Source:                       string(util.DataSourceStatsLeagueGameLog),
+	}
+}
+
+// historicalPlayer is a player from a game log which only has the player's full name
+func historicalPlayer(nbaPlayerID int, name string) api.Player {
+	firstName, lastName, _ := strings.Cut(strings.TrimSpace(name), " ")
+	return api.Player{
+		FirstName:   firstName,
+		LastName:    lastName,
+		NBAPlayerID: nbaPlayerID,
+	}
+}
+
+func nullInt64(i *int) sql.NullInt64 {
+	if i == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(*i), Valid: true}
+}
@@ -12,14 +12,24 @@ import (
 
 	"github.com/drewthor/wolves_reddit_bot/apis/cloudflare"
 	"github.com/drewthor/wolves_reddit_bot/internal/arena"
+	"github.com/drewthor/wolves_reddit_bot/internal/broadcast"
+	"github.com/drewthor/wolves_reddit_bot/internal/game_analysis"
 	"github.com/drewthor/wolves_reddit_bot/internal/game_referee"
 	"github.com/drewthor/wolves_reddit_bot/internal/league"
+	"github.com/drewthor/wolves_reddit_bot/internal/live"
+	"github.com/drewthor/wolves_reddit_bot/internal/milestone"
 	"github.com/drewthor/wolves_reddit_bot/internal/playbyplay"
+	"github.com/drewthor/wolves_reddit_bot/internal/player"
+	"github.com/drewthor/wolves_reddit_bot/internal/player_game_stats"
+	"github.com/drewthor/wolves_reddit_bot/internal/playoff"
 	"github.com/drewthor/wolves_reddit_bot/internal/referee"
+	"github.com/drewthor/wolves_reddit_bot/internal/schedule_context"
 	"github.com/drewthor/wolves_reddit_bot/internal/season"
 	"github.com/drewthor/wolves_reddit_bot/internal/team"
 	"github.com/drewthor/wolves_reddit_bot/internal/team_game_stats"
+	"github.com/drewthor/wolves_reddit_bot/internal/webhook"
 	"github.com/drewthor/wolves_reddit_bot/util"
+	"github.com/jackc/pgx/v5"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
 
@@ -27,6 +37,11 @@ import (
 	"github.com/drewthor/wolves_reddit_bot/apis/nba"
 )
 
+const (
+	DefaultListLimit = 100
+	MaxListLimit     = 1000
+)
+
 type GameStatus string
 
 const (
@@ -35,39 +50,80 @@ const (
 	GameStatusStarted   GameStatus = "started"
 )
 
+// FinalAfter is how long after a completed game ends that it's done changing, the league makes stat corrections within
+// it
+const FinalAfter = 72 * time.Hour
+
+// IsFinal reports whether a game is done changing
+func IsFinal(game api.Game, now time.Time) bool {
+	if game.Status != string(GameStatusCompleted) {
+		return false
+	}
+
+	// games backfilled from game logs have no end time
+	endTime := game.StartTime
+	if game.EndTime != nil {
+		endTime = *game.EndTime
+	}
+
+	return now.Sub(endTime) > FinalAfter
+}
+
 type Service interface {
 	GetGameWithID(ctx context.Context, id string) (api.Game, error)
-	List(ctx context.Context) ([]api.Game, error)
+	List(ctx context.Context, filter ListFilter) ([]api.Game, *util.Cursor, error)
 	GetGameWithNBAID(ctx context.Context, nbaID string) (api.Game, error)
 	UpdateGame(ctx context.Context, logger *slog.Logger, gameID string, seasonStartYear int) (api.Game, error)
+	// UpdateGameReferees stores the officials the boxscore lists for a game that's already stored without updating the
+	// rest of it
+	UpdateGameReferees(ctx context.Context, logger *slog.Logger, gameID string, seasonStartYear int) error
 	UpdateSeasonGames(ctx context.Context, logger *slog.Logger, seasonStartYear int) ([]api.Game, error)
+	BackfillHistoricalSeason(ctx context.Context, logger *slog.Logger, seasonStartYear int) ([]api.Game, error)
 }
 
 func NewService(
 	gameStore Store,
 	arenaService arena.Service,
+	broadcastService broadcast.Service,
+	gameAnalysisService game_analysis.Service,
 	gameRefereeService game_referee.Service,
 	leagueService league.Service,
+	liveService live.Service,
+	milestoneService milestone.Service,
 	playByPlayService playbyplay.Service,
+	playerService player.Service,
+	playerGameStatsService player_game_stats.Service,
+	playoffService playoff.Service,
 	refereeService referee.Service,
+	scheduleContextService schedule_context.Service,
 	seasonService season.Service,
 	teamService team.Service,
 	teamGameStatsService team_game_stats.Service,
+	webhookService webhook.Service,
 	nbaClient nba.Client,
 	r2Client cloudflare.Client,
 ) Service {
 	return &service{
-		gameStore:            gameStore,
-		arenaService:         arenaService,
-		gameRefereeService:   gameRefereeService,
-		leagueService:        leagueService,
-		playByPlayService:    playByPlayService,
-		refereeService:       refereeService,
-		seasonService:        seasonService,
-		teamService:          teamService,
-		teamGameStatsService: teamGameStatsService,
-		nbaClient:            nbaClient,
-		r2Client:             r2Client,
+		gameStore:              gameStore,
+		arenaService:           arenaService,
+		broadcastService:       broadcastService,
+		gameAnalysisService:    gameAnalysisService,
+		gameRefereeService:     gameRefereeService,
+		leagueService:          leagueService,
+		liveService:            liveService,
+		milestoneService:       milestoneService,
+		playByPlayService:      playByPlayService,
+		playerService:          playerService,
+		playerGameStatsService: playerGameStatsService,
+		playoffService:         playoffService,
+		refereeService:         refereeService,
+		scheduleContextService: scheduleContextService,
+		seasonService:          seasonService,
+		teamService:            teamService,
+		teamGameStatsService:   teamGameStatsService,
+		webhookService:         webhookService,
+		nbaClient:              nbaClient,
+		r2Client:               r2Client,
 	}
 }
 
@@ -81,14 +137,23 @@ type gameUpdateRequest struct {
 type service struct {
 	gameStore Store
 
-	arenaService         arena.Service
-	gameRefereeService   game_referee.Service
-	leagueService        league.Service
-	playByPlayService    playbyplay.Service
-	refereeService       referee.Service
-	seasonService        season.Service
-	teamService          team.Service
-	teamGameStatsService team_game_stats.Service
+	arenaService           arena.Service
+	broadcastService       broadcast.Service
+	gameAnalysisService    game_analysis.Service
+	gameRefereeService     game_referee.Service
+	leagueService          league.Service
+	liveService            live.Service
+	milestoneService       milestone.Service
+	playByPlayService      playbyplay.Service
+	playerService          player.Service
+	playerGameStatsService player_game_stats.Service
+	playoffService         playoff.Service
+	refereeService         referee.Service
+	scheduleContextService schedule_context.Service
+	seasonService          season.Service
+	teamService            team.Service
+	teamGameStatsService   team_game_stats.Service
+	webhookService         webhook.Service
 
 	nbaClient nba.Client
 	r2Client  cloudflare.Client
@@ -98,18 +163,56 @@ func (s *service) GetGameWithID(ctx context.Context, id string) (api.Game, error
 	ctx, span := otel.Tracer("game").Start(ctx, "game.service.GetGameWithID")
 	defer span.End()
 
-	return s.gameStore.GetGameWithID(ctx, id)
+	g, err := s.gameStore.GetGameWithID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.Game{}, util.NewError(util.ErrNotFound, "game not found")
+		}
+		return api.Game{}, fmt.Errorf("failed to get game: %w", err)
+	}
+
+	games := []api.Game{g}
+	if err := s.scheduleContextService.AddScheduleContextsToGames(ctx, games); err != nil {
+		return api.Game{}, fmt.Errorf("failed to add schedule contexts to game: %w", err)
+	}
+
+	return games[0], nil
 }
 
-func (s *service) List(ctx context.Context) ([]api.Game, error) {
+// List returns a page of the games matching the filter along with the cursor of the next page if there is one
+func (s *service) List(ctx context.Context, filter ListFilter) ([]api.Game, *util.Cursor, error) {
 	ctx, span := otel.Tracer("game").Start(ctx, "game.service.List")
 	defer span.End()
 
-	games, err := s.gameStore.List(ctx)
+	if filter.Sort.Field == "" {
+		filter.Sort = util.Sort{Field: SortStartTime}
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultListLimit
+	}
+	filter.Limit = min(filter.Limit, MaxListLimit)
+
+	// one more game than the page holds shows whether there's a next page
+	limit := filter.Limit
+	filter.Limit++
+
+	games, err := s.gameStore.List(ctx, filter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get games: %w", err)
+		return nil, nil, fmt.Errorf("failed to get games: %w", err)
 	}
-	return games, nil
+
+	var nextCursor *util.Cursor
+	if len(games) > limit {
+		games = games[:limit]
+		last := games[limit-1]
+		nextCursor = &util.Cursor{Sort: filter.Sort.String(), Value: last.StartTime.Format(time.RFC3339Nano), ID: last.ID}
+	}
+
+	if err := s.scheduleContextService.AddScheduleContextsToGames(ctx, games); err != nil {
+		return nil, nil, fmt.Errorf("failed to add schedule contexts to games: %w", err)
+	}
+
+	return games, nextCursor, nil
 }
 
 func (s *service) GetGameWithNBAID(ctx context.Context, id string) (api.Game, error) {
@@ -123,6 +226,10 @@ func (s *service) UpdateGame(ctx context.Context, logger *slog.Logger, gameID st
 	ctx, span := otel.Tracer("game").Start(ctx, "game.service.UpdateGame")
 	defer span.End()
 
+	run := util.NewIngestRun(fmt.Sprintf("boxscore/%d/%s_cdn.json", seasonStartYear, gameID))
+	ctx = util.WithIngestRun(ctx, run)
+	logger = logger.With(slog.String("ingest_run_id", run.ID))
+
 	g := gameUpdateRequest{
 		nbaGameID:       gameID,
 		seasonStartYear: seasonStartYear,
@@ -209,15 +316,17 @@ func (s *service) updateGames(ctx context.Context, logger *slog.Logger, gameUpda
 	var arenaUpdates []arena.ArenaUpdate
 	var refereeUpdates []referee.RefereeUpdate
 	var gameScheduledUpdates []GameScheduledUpdate
+	var broadcasterUpdates []broadcast.BroadcasterUpdate
+	var gameBroadcastUpdates []broadcast.GameBroadcastUpdate
 	var gameSummaryUpdates []GameSummaryUpdate
 	var gameUpdates []GameUpdate
 	var teamGameStatsTotalUpdates []team_game_stats.TeamGameStatsTotalUpdate
+	var playerGameStatsTotalUpdates []player_game_stats.PlayerGameStatsTotalUpdate
 	var gameRefereeUpdates []game_referee.GameRefereeUpdate
 	var leagueUpdates []league.LeagueUpdate
 	teamIDsMap := make(map[int]bool)
 
 	gameStatusNameMappings := util.NBAGameStatusNameMappings()
-	seasonStageNameMappings := util.NBASeasonStageNameMappings()
 
 	leagueNameStartYearUpdatesMap := map[string]int{}
 
@@ -247,6 +356,16 @@ func (s *service) updateGames(ctx context.Context, logger *slog.Logger, gameUpda
 				awayTeamPoints.Int64 = int64(boxscoreResult.Scheduled.AwayTeam.Score)
 				awayTeamPoints.Valid = true
 			}
+			var homeTeamSeed sql.NullInt64
+			if boxscoreResult.Scheduled.HomeTeam.Seed > 0 {
+				homeTeamSeed.Int64 = int64(boxscoreResult.Scheduled.HomeTeam.Seed)
+				homeTeamSeed.Valid = true
+			}
+			var awayTeamSeed sql.NullInt64
+			if boxscoreResult.Scheduled.AwayTeam.Seed > 0 {
+				awayTeamSeed.Int64 = int64(boxscoreResult.Scheduled.AwayTeam.Seed)
+				awayTeamSeed.Valid = true
+			}
 
 			gameScheduledUpdate := GameScheduledUpdate{
 				NBAGameID:       boxscoreResult.Scheduled.GameID,
@@ -256,12 +375,21 @@ func (s *service) updateGames(ctx context.Context, logger *slog.Logger, gameUpda
 				AwayTeamPoints:  awayTeamPoints,
 				GameStatusName:  gameStatusNameMappings[boxscoreResult.Scheduled.GameStatus],
 				NBAArenaName:    boxscoreResult.Scheduled.ArenaName,
+				NBAArenaCity:    boxscoreResult.Scheduled.ArenaCity,
+				NBAArenaState:   boxscoreResult.Scheduled.ArenaState,
 				SeasonStartYear: boxscoreResult.NBASeasonStartYear,
-				SeasonStageName: string(seasonStageNameMappings[2]),
+				SeasonStageName: string(seasonStageName(boxscoreResult.Scheduled.GameID)),
 				StartTime:       boxscoreResult.Scheduled.GameDateUTC,
+				HomeTeamSeed:    homeTeamSeed,
+				AwayTeamSeed:    awayTeamSeed,
+				IfNecessary:     boxscoreResult.Scheduled.IfNecessary,
 			}
 
 			gameScheduledUpdates = append(gameScheduledUpdates, gameScheduledUpdate)
+
+			scheduledBroadcasterUpdates, scheduledGameBroadcastUpdates := broadcast.ScheduledGameUpdates(*boxscoreResult.Scheduled)
+			broadcasterUpdates = append(broadcasterUpdates, scheduledBroadcasterUpdates...)
+			gameBroadcastUpdates = append(gameBroadcastUpdates, scheduledGameBroadcastUpdates...)
 		}
 		boxscoreSummary := boxscoreResult.Summary
 		if boxscoreSummary != nil {
@@ -304,6 +432,8 @@ func (s *service) updateGames(ctx context.Context, logger *slog.Logger, gameUpda
 			//	awayTeamPointsValid = false
 			// }
 
+			gameBroadcastUpdates = append(gameBroadcastUpdates, broadcast.SummaryGameUpdates(boxscoreSummary.GameID, boxscoreSummary.TVBroadcasts)...)
+
 			startTime := boxscoreSummary.GameDate
 
 			endTime := sql.NullTime{
@@ -388,27 +518,9 @@ func (s *service) updateGames(ctx context.Context, logger *slog.Logger, gameUpda
 			arenaUpdates = append(arenaUpdates, arenaUpdate)
 
 			// official
-			for _, boxscoreOfficial := range boxscore.GameNode.Officials {
-				jerseyNumber, err := strconv.Atoi(boxscoreOfficial.JerseyNumber)
-				if err != nil {
-				}
-
-				refereeUpdate := referee.RefereeUpdate{
-					NBARefereeID: boxscoreOfficial.PersonID,
-					FirstName:    boxscoreOfficial.FirstName,
-					LastName:     boxscoreOfficial.LastName,
-					JerseyNumber: jerseyNumber,
-				}
-
-				gameRefereeUpdate := game_referee.GameRefereeUpdate{
-					NBAGameID:    boxscore.GameNode.GameID,
-					NBARefereeID: boxscoreOfficial.PersonID,
-					Assignment:   boxscoreOfficial.Assignment,
-				}
-
-				refereeUpdates = append(refereeUpdates, refereeUpdate)
-				gameRefereeUpdates = append(gameRefereeUpdates, gameRefereeUpdate)
-			}
+			boxscoreRefereeUpdates, boxscoreGameRefereeUpdates := officialUpdates(*boxscore)
+			refereeUpdates = append(refereeUpdates, boxscoreRefereeUpdates...)
+			gameRefereeUpdates = append(gameRefereeUpdates, boxscoreGameRefereeUpdates...)
 
 			sellout, err := strconv.ParseBool(boxscore.GameNode.Sellout)
 			if err != nil {
@@ -437,7 +549,7 @@ func (s *service) updateGames(ctx context.Context, logger *slog.Logger, gameUpda
 				GameStatusName:                  gameStatusNameMappings[boxscore.GameNode.GameStatus],
 				NBAArenaID:                      boxscore.GameNode.Arena.ID,
 				SeasonStartYear:                 boxscoreResult.NBASeasonStartYear,
-				SeasonStageName:                 string(seasonStageNameMappings[2]),
+				SeasonStageName:                 string(seasonStageName(boxscore.GameNode.GameID)),
 				Attendance:                      boxscore.GameNode.Attendance,
 				Sellout:                         sellout,
 				Period:                          boxscore.GameNode.Period,
@@ -454,6 +566,8 @@ func (s *service) updateGames(ctx context.Context, logger *slog.Logger, gameUpda
 			for _, teamData := range []nba.BoxscoreTeam{boxscore.GameNode.HomeTeam, boxscore.GameNode.AwayTeam} {
 				teamIDsMap[teamData.ID] = true
 
+				playerGameStatsTotalUpdates = append(playerGameStatsTotalUpdates, player_game_stats.BoxscoreUpdates(boxscore.GameNode.GameID, teamData)...)
+
 				teamGameStatsTotalUpdate := team_game_stats.TeamGameStatsTotalUpdate{
 					NBAGameID:                    boxscore.GameNode.GameID,
 					NBATeamID:                    teamData.ID,
@@ -584,6 +698,16 @@ func (s *service) updateGames(ctx context.Context, logger *slog.Logger, gameUpda
 		updateGamesMap[updatedGame.ID] = updatedGame
 	}
 
+	_, err = s.broadcastService.UpdateBroadcasters(ctx, broadcasterUpdates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update broadcasters: %w", err)
+	}
+
+	err = s.broadcastService.UpdateGameBroadcasts(ctx, gameBroadcastUpdates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update game broadcasts: %w", err)
+	}
+
 	updatedGames := []api.Game{}
 	var startedGameIDs []string
 	for _, updatedGame := range updateGamesMap {
@@ -598,31 +722,143 @@ func (s *service) updateGames(ctx context.Context, logger *slog.Logger, gameUpda
 		return nil, fmt.Errorf("failed to update team game stats totals: %w", err)
 	}
 
+	playerGameStatsTotalsUpdated, err := s.playerGameStatsService.UpdatePlayerGameStatsTotals(ctx, playerGameStatsTotalUpdates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update player game stats totals: %w", err)
+	}
+	logger.InfoContext(ctx, fmt.Sprintf("updated %d of %d player game stats totals", playerGameStatsTotalsUpdated, len(playerGameStatsTotalUpdates)))
+
 	err = s.gameRefereeService.UpdateGameReferees(ctx, gameRefereeUpdates)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update game referees: %w", err)
 	}
 
+	// rebuild the playoff series for any season with updated playoff or play-in games
+	playoffSeasonStartYears := map[int]bool{}
+	for _, gur := range gameUpdateRequests {
+		if _, ok := nba.ParsePlayoffGameID(gur.nbaGameID); ok {
+			playoffSeasonStartYears[gur.seasonStartYear] = true
+		}
+	}
+
+	for seasonStartYear := range playoffSeasonStartYears {
+		if err := s.playoffService.UpdatePlayoffSeries(ctx, logger, seasonStartYear); err != nil {
+			return nil, fmt.Errorf("failed to update playoff series: %w", err)
+		}
+	}
+
 	// TODO: play by play add error handling
-	_, err = s.playByPlayService.UpdatePlayByPlayForGames(ctx, logger, startedGameIDs)
+	playByPlays, err := s.playByPlayService.UpdatePlayByPlayForGames(ctx, logger, startedGameIDs)
 	if err != nil {
 		logger.ErrorContext(ctx, "failed update play by play for games", slog.Any("error", err))
+	} else if err := s.gameAnalysisService.AnalyzeGames(ctx, logger, startedGameIDs); err != nil {
+		logger.ErrorContext(ctx, "failed to analyze play by play for games", slog.Any("error", err))
 	}
 	// if err != nil {
 	//	return nil, fmt.Errorf("failed to update play by play for games: %w", err)
 	// }
 
+	// milestones run after play by play so they can point at the play they happened on
+	var updatedGameIDs []string
+	for _, updatedGame := range updatedGames {
+		updatedGameIDs = append(updatedGameIDs, updatedGame.ID)
+	}
+
+	// live streams only need the new state so they are published to before milestone detection
+	s.liveService.PublishGameUpdates(ctx, updatedGames, playByPlays)
+
+	milestones, err := s.milestoneService.DetectMilestones(ctx, logger, updatedGameIDs)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to detect milestones for games", slog.Any("error", err))
+	}
+	for _, m := range milestones {
+		logger.InfoContext(ctx, "milestone reached", slog.String("game_id", m.GameID), slog.String("type", m.Type), slog.String("description", m.Description))
+	}
+
+	queued, err := s.webhookService.EnqueueGameEvents(ctx, updatedGames, milestones)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to enqueue webhook events for games", slog.Any("error", err))
+	} else if queued > 0 {
+		logger.InfoContext(ctx, fmt.Sprintf("queued %d webhook deliveries", queued))
+	}
+
 	return updatedGames, nil
 }
 
+func (s *service) UpdateGameReferees(ctx context.Context, logger *slog.Logger, gameID string, seasonStartYear int) error {
+	ctx, span := otel.Tracer("game").Start(ctx, "game.service.UpdateGameReferees")
+	defer span.End()
+
+	detailedObjectKey := fmt.Sprintf("boxscore/%d/%s_cdn.json", seasonStartYear, gameID)
+	boxscore, err := s.nbaClient.GetBoxscoreDetailed(ctx, gameID, detailedObjectKey)
+	if err != nil {
+		if errors.Is(err, nba.ErrNotFound) {
+			// the boxscore isn't published until shortly before tipoff
+			return nil
+		}
+		return fmt.Errorf("failed to get boxscore for game referees: %w", err)
+	}
+
+	refereeUpdates, gameRefereeUpdates := officialUpdates(boxscore)
+	if len(gameRefereeUpdates) == 0 {
+		return nil
+	}
+
+	if _, err := s.refereeService.UpdateReferees(ctx, refereeUpdates); err != nil {
+		return fmt.Errorf("failed to update referees: %w", err)
+	}
+
+	if err := s.gameRefereeService.UpdateGameReferees(ctx, gameRefereeUpdates); err != nil {
+		return fmt.Errorf("failed to update game referees: %w", err)
+	}
+
+	logger.InfoContext(ctx, "updated game referees", slog.String("game_id", gameID), slog.Int("referees", len(gameRefereeUpdates)))
+
+	return nil
+}
+
+// officialUpdates returns the referee and game referee updates of the officials listed in the boxscore
+func officialUpdates(boxscore nba.Boxscore) ([]referee.RefereeUpdate, []game_referee.GameRefereeUpdate) {
+	var refereeUpdates []referee.RefereeUpdate
+	var gameRefereeUpdates []game_referee.GameRefereeUpdate
+
+	for _, boxscoreOfficial := range boxscore.GameNode.Officials {
+		jerseyNumber, err := strconv.Atoi(boxscoreOfficial.JerseyNumber)
+		if err != nil {
+		}
+
+		refereeUpdate := referee.RefereeUpdate{
+			NBARefereeID: boxscoreOfficial.PersonID,
+			FirstName:    boxscoreOfficial.FirstName,
+			LastName:     boxscoreOfficial.LastName,
+			JerseyNumber: jerseyNumber,
+		}
+
+		gameRefereeUpdate := game_referee.GameRefereeUpdate{
+			NBAGameID:    boxscore.GameNode.GameID,
+			NBARefereeID: boxscoreOfficial.PersonID,
+			Assignment:   boxscoreOfficial.Assignment,
+		}
+
+		refereeUpdates = append(refereeUpdates, refereeUpdate)
+		gameRefereeUpdates = append(gameRefereeUpdates, gameRefereeUpdate)
+	}
+
+	return refereeUpdates, gameRefereeUpdates
+}
+
 func (s *service) UpdateSeasonGames(ctx context.Context, logger *slog.Logger, seasonStartYear int) ([]api.Game, error) {
 	ctx, span := otel.Tracer("game").Start(ctx, "game.service.UpdateSeasonGames")
 	defer span.End()
 
+	run := util.NewIngestRun(fmt.Sprintf("boxscore/%d/", seasonStartYear))
+	ctx = util.WithIngestRun(ctx, run)
+	logger = logger.With(slog.String("ingest_run_id", run.ID))
+
 	var gameUpdateRequests []gameUpdateRequest
 
 	currentSeason, err := s.seasonService.GetCurrentSeasonStartYear(ctx)
-	if err != nil && currentSeason == seasonStartYear {
+	if err == nil && currentSeason == seasonStartYear {
 		nbaGames, err := s.getCurrentSeasonLeagueScheduleFromNBAAPI(ctx, seasonStartYear)
 		if err != nil {
 			span.RecordError(err)
@@ -691,6 +927,25 @@ func (s *service) getCurrentSeasonLeagueScheduleFromNBAAPI(ctx context.Context,
 		return nil, err
 	}
 
+	var broadcasterUpdates []broadcast.BroadcasterUpdate
+	for _, broadcaster := range schedule.LeagueSchedule.BroadcasterList {
+		regionID := sql.NullInt64{}
+		if broadcaster.RegionID != 0 {
+			regionID.Int64 = int64(broadcaster.RegionID)
+			regionID.Valid = true
+		}
+		broadcasterUpdates = append(broadcasterUpdates, broadcast.BroadcasterUpdate{
+			NBABroadcasterID: broadcaster.BroadcasterID,
+			Display:          broadcaster.BroadcasterDisplay,
+			Abbreviation:     broadcaster.BroadcasterAbbreviation,
+			RegionID:         regionID,
+		})
+	}
+
+	if _, err := s.broadcastService.UpdateBroadcasters(ctx, broadcasterUpdates); err != nil {
+		return nil, fmt.Errorf("failed to update broadcasters from league schedule: %w", err)
+	}
+
 	var nbaGames []nba.Game
 	for _, gameDate := range schedule.LeagueSchedule.GameDates {
 		nbaGames = append(nbaGames, gameDate.Games...)
@@ -698,3 +953,14 @@ func (s *service) getCurrentSeasonLeagueScheduleFromNBAAPI(ctx context.Context,
 
 	return nbaGames, nil
 }
+
+// seasonStageName returns the season stage encoded in the nba game id, defaulting to the regular season
+func seasonStageName(nbaGameID string) util.SeasonStage {
+	if stageID, ok := nba.GameIDSeasonStage(nbaGameID); ok {
+		if stage, ok := util.NBASeasonStageNameMappings()[stageID]; ok {
+			return stage
+		}
+	}
+
+	return util.SeasonStageRegular
+}
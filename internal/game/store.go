@@ -6,16 +6,45 @@ import (
 	"time"
 
 	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/util"
 )
 
 type Store interface {
-	List(ctx context.Context) ([]api.Game, error)
+	List(ctx context.Context, filter ListFilter) ([]api.Game, error)
 	GetGameWithID(ctx context.Context, id string) (api.Game, error)
 	GetGamesWithIDs(ctx context.Context, ids []string) ([]api.Game, error)
 	GetGameWithNBAID(ctx context.Context, id string) (api.Game, error)
 	UpdateGamesSummary(ctx context.Context, gameUpdates []GameSummaryUpdate) ([]api.Game, error)
 	UpdateGames(ctx context.Context, gameUpdates []GameUpdate) ([]api.Game, error)
 	UpdateScheduledGames(ctx context.Context, gameUpdates []GameScheduledUpdate) ([]api.Game, error)
+	UpdateHistoricalGames(ctx context.Context, gameUpdates []GameHistoricalUpdate) ([]api.Game, error)
+}
+
+// SortStartTime is the only sort games can be listed by
+const SortStartTime = "start-time"
+
+type TeamSide string
+
+const (
+	TeamSideHome   TeamSide = "home"
+	TeamSideAway   TeamSide = "away"
+	TeamSideEither TeamSide = "either"
+)
+
+type ListFilter struct {
+	// Broadcaster matches a broadcaster's abbreviation or display name, ex. ESPN
+	Broadcaster sql.NullString
+	// StartDate and EndDate are inclusive dates in eastern time, the time zone the league schedules games in
+	StartDate       sql.NullTime
+	EndDate         sql.NullTime
+	SeasonStartYear sql.NullInt64
+	SeasonStageName sql.NullString
+	TeamID          sql.NullString
+	// TeamSide is which side of the game TeamID has to be on
+	TeamSide   TeamSide
+	StatusName sql.NullString
+	ArenaID    sql.NullString
+	util.ListParams
 }
 
 type GameSummaryUpdate struct {
@@ -63,7 +92,29 @@ type GameScheduledUpdate struct {
 	AwayTeamPoints  sql.NullInt64
 	GameStatusName  string
 	NBAArenaName    string
+	NBAArenaCity    string
+	NBAArenaState   string
 	SeasonStartYear int
 	SeasonStageName string
 	StartTime       time.Time
+	HomeTeamSeed    sql.NullInt64
+	AwayTeamSeed    sql.NullInt64
+	IfNecessary     bool
+}
+
+// GameHistoricalUpdate is a completed game from the stats.nba.com game logs, which don't have the arena, attendance or
+// officials of the game and only have the date the game was played on
+type GameHistoricalUpdate struct {
+	NBAGameID         string
+	NBAHomeTeamID     int
+	NBAAwayTeamID     int
+	HomeTeamPoints    int
+	AwayTeamPoints    int
+	GameStatusName    string
+	SeasonStartYear   int
+	SeasonStageName   string
+	DurationSeconds   sql.NullInt64
+	RegulationPeriods int
+	StartTime         time.Time
+	Source            string
 }
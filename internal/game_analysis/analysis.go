@@ -0,0 +1,276 @@
+package game_analysis
+
+import (
+	"github.com/drewthor/wolves_reddit_bot/api"
+)
+
+const (
+	// DefaultMinRunPoints is the fewest unanswered points that count as a scoring run unless configured otherwise
+	DefaultMinRunPoints = 8
+
+	// clutch time is the last five minutes of the last regulation period or overtime with the margin at five or less
+	clutchClockTenthSeconds = 5 * 60 * 10
+	clutchMargin            = 5
+)
+
+type ScoreEventType string
+
+const (
+	ScoreEventTypeLeadChange ScoreEventType = "lead_change"
+	ScoreEventTypeTie        ScoreEventType = "tie"
+)
+
+type scoringPlay struct {
+	playByPlay api.PlayByPlay
+	homeScore  int
+	awayScore  int
+	homePoints int
+	awayPoints int
+}
+
+// scoringPlays returns the plays where the score changed along with how many points each team scored on the play
+func scoringPlays(playByPlays []api.PlayByPlay) []scoringPlay {
+	var plays []scoringPlay
+	homeScore, awayScore := 0, 0
+	for _, playByPlay := range playByPlays {
+		if playByPlay.HomeScore == nil || playByPlay.AwayScore == nil {
+			continue
+		}
+		if *playByPlay.HomeScore == homeScore && *playByPlay.AwayScore == awayScore {
+			continue
+		}
+
+		plays = append(plays, scoringPlay{
+			playByPlay: playByPlay,
+			homeScore:  *playByPlay.HomeScore,
+			awayScore:  *playByPlay.AwayScore,
+			homePoints: *playByPlay.HomeScore - homeScore,
+			awayPoints: *playByPlay.AwayScore - awayScore,
+		})
+		homeScore, awayScore = *playByPlay.HomeScore, *playByPlay.AwayScore
+	}
+
+	return plays
+}
+
+func detectScoringRuns(g api.Game, plays []scoringPlay, minRunPoints int) []ScoringRunUpdate {
+	var runs []ScoringRunUpdate
+
+	var current *ScoringRunUpdate
+	closeRun := func() {
+		if current != nil && current.Points >= minRunPoints {
+			runs = append(runs, *current)
+		}
+		current = nil
+	}
+
+	for _, play := range plays {
+		// score corrections can take points away; they end any run rather than count against it
+		if play.homePoints < 0 || play.awayPoints < 0 || (play.homePoints > 0 && play.awayPoints > 0) {
+			closeRun()
+			continue
+		}
+
+		teamID, points := g.HomeTeamID, play.homePoints
+		if play.awayPoints > 0 {
+			teamID, points = g.AwayTeamID, play.awayPoints
+		}
+
+		if current == nil || !sameTeam(current.TeamID, teamID) {
+			closeRun()
+			current = &ScoringRunUpdate{
+				GameID:                 g.ID,
+				TeamID:                 teamID,
+				StartActionNumber:      play.playByPlay.ActionNumber,
+				StartPeriod:            play.playByPlay.Period,
+				StartClockTenthSeconds: play.playByPlay.ClockTenthSeconds,
+			}
+		}
+
+		current.Points += points
+		current.EndActionNumber = play.playByPlay.ActionNumber
+		current.EndPeriod = play.playByPlay.Period
+		current.EndClockTenthSeconds = play.playByPlay.ClockTenthSeconds
+	}
+	closeRun()
+
+	return runs
+}
+
+func detectScoreEvents(g api.Game, plays []scoringPlay) []ScoreEventUpdate {
+	var events []ScoreEventUpdate
+
+	// leader is 1 when the home team leads and -1 when the away team leads; lastLeader ignores ties so a lead that goes
+	// through a tie to the other team still counts as a lead change
+	leader, lastLeader := 0, 0
+	for _, play := range plays {
+		newLeader := sign(play.homeScore - play.awayScore)
+
+		event := ScoreEventUpdate{
+			GameID:            g.ID,
+			ActionNumber:      play.playByPlay.ActionNumber,
+			Period:            play.playByPlay.Period,
+			ClockTenthSeconds: play.playByPlay.ClockTenthSeconds,
+			HomeScore:         play.homeScore,
+			AwayScore:         play.awayScore,
+		}
+
+		switch {
+		case newLeader == 0 && leader != 0:
+			event.Type = ScoreEventTypeTie
+			events = append(events, event)
+		case newLeader != 0 && lastLeader != 0 && newLeader != lastLeader:
+			event.Type = ScoreEventTypeLeadChange
+			event.LeadingTeamID = g.HomeTeamID
+			if newLeader < 0 {
+				event.LeadingTeamID = g.AwayTeamID
+			}
+			events = append(events, event)
+		}
+
+		leader = newLeader
+		if newLeader != 0 {
+			lastLeader = newLeader
+		}
+	}
+
+	return events
+}
+
+// isClutch reports if the play happened in clutch time given the margin before the play
+func isClutch(playByPlay api.PlayByPlay, margin int, regulationPeriods int) bool {
+	if playByPlay.Period < regulationPeriods || playByPlay.ClockTenthSeconds == nil {
+		return false
+	}
+
+	return *playByPlay.ClockTenthSeconds <= clutchClockTenthSeconds && abs(margin) <= clutchMargin
+}
+
+func detectClutch(g api.Game, playByPlays []api.PlayByPlay, regulationPeriods int) ([]ClutchSegmentUpdate, []PlayerClutchStatsUpdate) {
+	var segments []ClutchSegmentUpdate
+	playerStats := map[string]*PlayerClutchStatsUpdate{}
+	var playerOrder []string
+
+	var current *ClutchSegmentUpdate
+	homeScore, awayScore := 0, 0
+	for _, playByPlay := range playByPlays {
+		clutch := isClutch(playByPlay, homeScore-awayScore, regulationPeriods)
+
+		points := 0
+		if playByPlay.HomeScore != nil && playByPlay.AwayScore != nil {
+			points = max(*playByPlay.HomeScore-homeScore, *playByPlay.AwayScore-awayScore, 0)
+			homeScore, awayScore = *playByPlay.HomeScore, *playByPlay.AwayScore
+		}
+
+		if !clutch || (current != nil && current.Period != playByPlay.Period) {
+			if current != nil {
+				segments = append(segments, *current)
+				current = nil
+			}
+		}
+		if !clutch {
+			continue
+		}
+
+		if current == nil {
+			current = &ClutchSegmentUpdate{
+				GameID:                 g.ID,
+				Period:                 playByPlay.Period,
+				StartActionNumber:      playByPlay.ActionNumber,
+				StartClockTenthSeconds: playByPlay.ClockTenthSeconds,
+			}
+		}
+		current.EndActionNumber = playByPlay.ActionNumber
+		current.EndClockTenthSeconds = playByPlay.ClockTenthSeconds
+
+		if playByPlay.PlayerID == nil {
+			continue
+		}
+
+		stats, ok := playerStats[*playByPlay.PlayerID]
+		if !ok {
+			stats = &PlayerClutchStatsUpdate{GameID: g.ID, PlayerID: *playByPlay.PlayerID, TeamID: playByPlay.TeamID}
+			playerStats[*playByPlay.PlayerID] = stats
+			playerOrder = append(playerOrder, *playByPlay.PlayerID)
+		}
+
+		made := playByPlay.ShotResult != nil && *playByPlay.ShotResult == "Made"
+		actionType := ""
+		if playByPlay.ActionType != nil {
+			actionType = *playByPlay.ActionType
+		}
+
+		switch actionType {
+		case "2pt", "3pt":
+			stats.FieldGoalsAttempted++
+			if made {
+				stats.FieldGoalsMade++
+			}
+			if actionType == "3pt" {
+				stats.ThreePointersAttempted++
+				if made {
+					stats.ThreePointersMade++
+				}
+			}
+			stats.Points += points
+		case "freethrow":
+			stats.FreeThrowsAttempted++
+			if made {
+				stats.FreeThrowsMade++
+			}
+			stats.Points += points
+		case "rebound":
+			stats.Rebounds++
+		case "turnover":
+			stats.Turnovers++
+		}
+	}
+	if current != nil {
+		segments = append(segments, *current)
+	}
+
+	clutchStats := make([]PlayerClutchStatsUpdate, 0, len(playerOrder))
+	for _, playerID := range playerOrder {
+		clutchStats = append(clutchStats, *playerStats[playerID])
+	}
+
+	return segments, clutchStats
+}
+
+func analyze(g api.Game, playByPlays []api.PlayByPlay, minRunPoints int, regulationPeriods int) GameAnalysisUpdate {
+	plays := scoringPlays(playByPlays)
+	clutchSegments, playerClutchStats := detectClutch(g, playByPlays, regulationPeriods)
+
+	return GameAnalysisUpdate{
+		GameID:            g.ID,
+		ScoringRuns:       detectScoringRuns(g, plays, minRunPoints),
+		ScoreEvents:       detectScoreEvents(g, plays),
+		ClutchSegments:    clutchSegments,
+		PlayerClutchStats: playerClutchStats,
+	}
+}
+
+func sameTeam(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,87 @@
+package game_analysis
+
+import (
+	"testing"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+)
+
+// scores builds the play by play for a game from the running home and away score after each scoring play
+func scores(running ...[2]int) []api.PlayByPlay {
+	playByPlays := make([]api.PlayByPlay, len(running))
+	for i, score := range running {
+		homeScore, awayScore := score[0], score[1]
+		playByPlays[i] = api.PlayByPlay{
+			Period:       1,
+			ActionNumber: i + 1,
+			HomeScore:    &homeScore,
+			AwayScore:    &awayScore,
+		}
+	}
+	return playByPlays
+}
+
+func TestDetectScoringRunsMinRunPoints(t *testing.T) {
+	homeTeamID, awayTeamID := "home", "away"
+	g := api.Game{ID: "game", HomeTeamID: &homeTeamID, AwayTeamID: &awayTeamID}
+
+	// the home team scores 6 unanswered, the away team answers with 4, then the home team scores 9 unanswered
+	plays := scoringPlays(scores(
+		[2]int{2, 0}, [2]int{4, 0}, [2]int{6, 0},
+		[2]int{6, 2}, [2]int{6, 4},
+		[2]int{9, 4}, [2]int{11, 4}, [2]int{13, 4}, [2]int{15, 4},
+	))
+
+	tests := []struct {
+		name         string
+		minRunPoints int
+		want         []int
+	}{
+		{name: "default", minRunPoints: DefaultMinRunPoints, want: []int{9}},
+		{name: "lower", minRunPoints: 6, want: []int{6, 9}},
+		{name: "higher", minRunPoints: 10, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs := detectScoringRuns(g, plays, tt.minRunPoints)
+			if len(runs) != len(tt.want) {
+				t.Fatalf("got %d runs, want %d", len(runs), len(tt.want))
+			}
+			for i, run := range runs {
+				if run.Points != tt.want[i] {
+					t.Errorf("run %d got %d points, want %d", i, run.Points, tt.want[i])
+				}
+				if *run.TeamID != homeTeamID {
+					t.Errorf("run %d got team %s, want %s", i, *run.TeamID, homeTeamID)
+				}
+			}
+		})
+	}
+}
+
+func TestIsClutchRegulationPeriods(t *testing.T) {
+	clock := 2 * 60 * 10
+
+	tests := []struct {
+		name              string
+		period            int
+		regulationPeriods int
+		want              bool
+	}{
+		{name: "fourth of four", period: 4, regulationPeriods: 4, want: true},
+		{name: "overtime", period: 5, regulationPeriods: 4, want: true},
+		{name: "third of four", period: 3, regulationPeriods: 4, want: false},
+		{name: "fourth of five", period: 4, regulationPeriods: 5, want: false},
+		{name: "second of two halves", period: 2, regulationPeriods: 2, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			playByPlay := api.PlayByPlay{Period: tt.period, ClockTenthSeconds: &clock}
+			if got := isClutch(playByPlay, 3, tt.regulationPeriods); got != tt.want {
+				t.Errorf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package game_analysis
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"go.opentelemetry.io/otel"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler interface {
+	GameRoutes() chi.Router
+	PlayerRoutes() chi.Router
+	GetGameRuns(w http.ResponseWriter, r *http.Request)
+	GetPlayerClutchStats(w http.ResponseWriter, r *http.Request)
+}
+
+func NewHandler(logger *slog.Logger, gameAnalysisService Service) Handler {
+	return &handler{logger: logger, gameAnalysisService: gameAnalysisService}
+}
+
+type handler struct {
+	logger              *slog.Logger
+	gameAnalysisService Service
+}
+
+// GameRoutes are mounted under a game
+func (h *handler) GameRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.GetGameRuns)
+
+	return r
+}
+
+// PlayerRoutes are mounted under a player
+func (h *handler) PlayerRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.GetPlayerClutchStats)
+
+	return r
+}
+
+func (h *handler) GetGameRuns(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("game_analysis").Start(r.Context(), "game_analysis.handler.GetGameRuns")
+	defer span.End()
+
+	gameID := chi.URLParam(r, "gameID")
+
+	logger := h.logger.With(slog.String("game_id", gameID))
+
+	gameRuns, err := h.gameAnalysisService.GetGameRuns(ctx, gameID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get game runs", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteJSON(http.StatusOK, gameRuns, w)
+}
+
+func (h *handler) GetPlayerClutchStats(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("game_analysis").Start(r.Context(), "game_analysis.handler.GetPlayerClutchStats")
+	defer span.End()
+
+	playerID := chi.URLParam(r, "playerID")
+
+	logger := h.logger.With(slog.String("player_id", playerID))
+
+	clutchStats, err := h.gameAnalysisService.GetPlayerClutchStats(ctx, playerID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get player clutch stats", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteJSON(http.StatusOK, clutchStats, w)
+}
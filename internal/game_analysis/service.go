@@ -0,0 +1,149 @@
+package game_analysis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/apis/nba"
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+type Service interface {
+	AnalyzeGames(ctx context.Context, logger *slog.Logger, nbaGameIDs []string) error
+	GetGameRuns(ctx context.Context, gameID string) (api.GameRuns, error)
+	GetPlayerClutchStats(ctx context.Context, playerID string) (api.PlayerClutchStats, error)
+}
+
+// NewService detects scoring runs of at least minRunPoints unanswered points
+func NewService(gameAnalysisStore Store, minRunPoints int) Service {
+	return &service{gameAnalysisStore: gameAnalysisStore, minRunPoints: minRunPoints}
+}
+
+type service struct {
+	gameAnalysisStore Store
+
+	minRunPoints int
+}
+
+// AnalyzeGames detects the scoring runs, lead changes, ties, and clutch time in the stored play by play of the games
+func (s *service) AnalyzeGames(ctx context.Context, logger *slog.Logger, nbaGameIDs []string) error {
+	ctx, span := otel.Tracer("game_analysis").Start(ctx, "game_analysis.service.AnalyzeGames")
+	defer span.End()
+
+	for _, nbaGameID := range nbaGameIDs {
+		g, err := s.gameAnalysisStore.GetGameWithNBAID(ctx, nbaGameID)
+		if err != nil {
+			return fmt.Errorf("failed to get game to analyze: %w", err)
+		}
+
+		playByPlays, err := s.gameAnalysisStore.GetPlayByPlaysForGame(ctx, g.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get play by plays to analyze game: %w", err)
+		}
+		if len(playByPlays) == 0 {
+			continue
+		}
+
+		periods, err := s.gameAnalysisStore.GetGameRegulationPeriods(ctx, nbaGameID)
+		if err != nil {
+			return fmt.Errorf("failed to get regulation periods to analyze game: %w", err)
+		}
+		// games whose boxscore hasn't been ingested yet are assumed to have the usual number of periods
+		regulationPeriods := nba.DefaultRegulationPeriods
+		if periods != nil {
+			regulationPeriods = *periods
+		}
+
+		update := analyze(g, playByPlays, s.minRunPoints, regulationPeriods)
+		if err := s.gameAnalysisStore.UpdateGameAnalysis(ctx, update); err != nil {
+			return fmt.Errorf("failed to update game analysis: %w", err)
+		}
+
+		logger.InfoContext(ctx, "analyzed game",
+			slog.String("game_id", nbaGameID),
+			slog.Int("scoring_runs", len(update.ScoringRuns)),
+			slog.Int("score_events", len(update.ScoreEvents)),
+			slog.Int("clutch_segments", len(update.ClutchSegments)))
+	}
+
+	return nil
+}
+
+func (s *service) GetGameRuns(ctx context.Context, gameID string) (api.GameRuns, error) {
+	ctx, span := otel.Tracer("game_analysis").Start(ctx, "game_analysis.service.GetGameRuns")
+	defer span.End()
+
+	if _, err := s.gameAnalysisStore.GetGameWithID(ctx, gameID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.GameRuns{}, util.NewError(util.ErrNotFound, "game not found")
+		}
+		return api.GameRuns{}, fmt.Errorf("failed to get game for runs: %w", err)
+	}
+
+	scoringRuns, err := s.gameAnalysisStore.GetScoringRunsForGame(ctx, gameID)
+	if err != nil {
+		return api.GameRuns{}, fmt.Errorf("failed to get scoring runs for game: %w", err)
+	}
+
+	scoreEvents, err := s.gameAnalysisStore.GetScoreEventsForGame(ctx, gameID)
+	if err != nil {
+		return api.GameRuns{}, fmt.Errorf("failed to get score events for game: %w", err)
+	}
+
+	clutchSegments, err := s.gameAnalysisStore.GetClutchSegmentsForGame(ctx, gameID)
+	if err != nil {
+		return api.GameRuns{}, fmt.Errorf("failed to get clutch segments for game: %w", err)
+	}
+
+	gameRuns := api.GameRuns{
+		GameID:         gameID,
+		ScoringRuns:    scoringRuns,
+		LeadChanges:    []api.GameScoreEvent{},
+		Ties:           []api.GameScoreEvent{},
+		ClutchSegments: clutchSegments,
+	}
+	for _, scoreEvent := range scoreEvents {
+		switch ScoreEventType(scoreEvent.Type) {
+		case ScoreEventTypeLeadChange:
+			gameRuns.LeadChanges = append(gameRuns.LeadChanges, scoreEvent)
+		case ScoreEventTypeTie:
+			gameRuns.Ties = append(gameRuns.Ties, scoreEvent)
+		}
+	}
+
+	return gameRuns, nil
+}
+
+func (s *service) GetPlayerClutchStats(ctx context.Context, playerID string) (api.PlayerClutchStats, error) {
+	ctx, span := otel.Tracer("game_analysis").Start(ctx, "game_analysis.service.GetPlayerClutchStats")
+	defer span.End()
+
+	gameLogs, err := s.gameAnalysisStore.GetPlayerGameClutchStats(ctx, playerID)
+	if err != nil {
+		return api.PlayerClutchStats{}, fmt.Errorf("failed to get player game clutch stats: %w", err)
+	}
+
+	clutchStats := api.PlayerClutchStats{
+		PlayerID: playerID,
+		Games:    len(gameLogs),
+		GameLogs: gameLogs,
+	}
+	for _, gameLog := range gameLogs {
+		clutchStats.Totals.Points += gameLog.Points
+		clutchStats.Totals.FieldGoalsMade += gameLog.FieldGoalsMade
+		clutchStats.Totals.FieldGoalsAttempted += gameLog.FieldGoalsAttempted
+		clutchStats.Totals.ThreePointersMade += gameLog.ThreePointersMade
+		clutchStats.Totals.ThreePointersAttempted += gameLog.ThreePointersAttempted
+		clutchStats.Totals.FreeThrowsMade += gameLog.FreeThrowsMade
+		clutchStats.Totals.FreeThrowsAttempted += gameLog.FreeThrowsAttempted
+		clutchStats.Totals.Rebounds += gameLog.Rebounds
+		clutchStats.Totals.Turnovers += gameLog.Turnovers
+	}
+
+	return clutchStats, nil
+}
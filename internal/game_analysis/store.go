@@ -0,0 +1,76 @@
+package game_analysis
+
+import (
+	"context"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+)
+
+type Store interface {
+	GetGameWithID(ctx context.Context, id string) (api.Game, error)
+	GetGameWithNBAID(ctx context.Context, nbaID string) (api.Game, error)
+	GetGameRegulationPeriods(ctx context.Context, nbaGameID string) (*int, error)
+	GetPlayByPlaysForGame(ctx context.Context, gameID string) ([]api.PlayByPlay, error)
+	UpdateGameAnalysis(ctx context.Context, gameAnalysisUpdate GameAnalysisUpdate) error
+	GetScoringRunsForGame(ctx context.Context, gameID string) ([]api.ScoringRun, error)
+	GetScoreEventsForGame(ctx context.Context, gameID string) ([]api.GameScoreEvent, error)
+	GetClutchSegmentsForGame(ctx context.Context, gameID string) ([]api.ClutchSegment, error)
+	GetPlayerGameClutchStats(ctx context.Context, playerID string) ([]api.PlayerGameClutchStats, error)
+}
+
+// GameAnalysisUpdate replaces everything previously detected for the game since runs and segments can grow or be
+// corrected while the game is live
+type GameAnalysisUpdate struct {
+	GameID            string
+	ScoringRuns       []ScoringRunUpdate
+	ScoreEvents       []ScoreEventUpdate
+	ClutchSegments    []ClutchSegmentUpdate
+	PlayerClutchStats []PlayerClutchStatsUpdate
+}
+
+type ScoringRunUpdate struct {
+	GameID                 string
+	TeamID                 *string
+	Points                 int
+	StartActionNumber      int
+	StartPeriod            int
+	StartClockTenthSeconds *int
+	EndActionNumber        int
+	EndPeriod              int
+	EndClockTenthSeconds   *int
+}
+
+type ScoreEventUpdate struct {
+	GameID            string
+	Type              ScoreEventType
+	ActionNumber      int
+	Period            int
+	ClockTenthSeconds *int
+	HomeScore         int
+	AwayScore         int
+	LeadingTeamID     *string
+}
+
+type ClutchSegmentUpdate struct {
+	GameID                 string
+	Period                 int
+	StartActionNumber      int
+	StartClockTenthSeconds *int
+	EndActionNumber        int
+	EndClockTenthSeconds   *int
+}
+
+type PlayerClutchStatsUpdate struct {
+	GameID                 string
+	PlayerID               string
+	TeamID                 *string
+	Points                 int
+	FieldGoalsMade         int
+	FieldGoalsAttempted    int
+	ThreePointersMade      int
+	ThreePointersAttempted int
+	FreeThrowsMade         int
+	FreeThrowsAttempted    int
+	Rebounds               int
+	Turnovers              int
+}
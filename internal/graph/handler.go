@@ -0,0 +1,94 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/drewthor/wolves_reddit_bot/pkg/graphql"
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"go.opentelemetry.io/otel"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// maxQueryBodySize leaves room for large queries with fragments while bounding what gets parsed
+const maxQueryBodySize = 256 << 10
+
+type Handler interface {
+	Routes() chi.Router
+	Query(w http.ResponseWriter, r *http.Request)
+	Schema(w http.ResponseWriter, r *http.Request)
+}
+
+func NewHandler(logger *slog.Logger, graphService Service) Handler {
+	return &handler{logger: logger, graphService: graphService}
+}
+
+type handler struct {
+	logger       *slog.Logger
+	graphService Service
+}
+
+func (h *handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/", h.Query)
+	r.Get("/", h.Query)
+	r.Get("/schema.graphql", h.Schema)
+
+	return r
+}
+
+func (h *handler) Query(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("graph").Start(r.Context(), "graph.handler.Query")
+	defer span.End()
+
+	req := graphql.Request{}
+
+	if r.Method == http.MethodGet {
+		req.Query = r.URL.Query().Get("query")
+		req.OperationName = r.URL.Query().Get("operationName")
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			decoder := json.NewDecoder(bytes.NewBufferString(variables))
+			decoder.UseNumber()
+			if err := decoder.Decode(&req.Variables); err != nil {
+				util.WriteProblem(w, r, http.StatusBadRequest, "invalid graphql variables: "+err.Error())
+				return
+			}
+		}
+	} else {
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxQueryBodySize))
+		decoder.UseNumber()
+		if err := decoder.Decode(&req); err != nil {
+			util.WriteProblem(w, r, http.StatusBadRequest, "invalid graphql request body: "+err.Error())
+			return
+		}
+	}
+
+	if req.Query == "" {
+		util.WriteProblem(w, r, http.StatusBadRequest, "graphql request is missing a query")
+		return
+	}
+
+	resp := h.graphService.Execute(ctx, req)
+
+	for _, err := range resp.Errors {
+		// errors with a path came from a resolver, the rest are mistakes in the query
+		if len(err.Path) > 0 {
+			h.logger.WarnContext(ctx, "graphql field failed to resolve", slog.String("error", err.Message), slog.Any("path", err.Path))
+		}
+	}
+
+	util.WriteJSON(http.StatusOK, resp, w)
+}
+
+func (h *handler) Schema(w http.ResponseWriter, r *http.Request) {
+	_, span := otel.Tracer("graph").Start(r.Context(), "graph.handler.Schema")
+	defer span.End()
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(h.graphService.SDL()))
+}
@@ -0,0 +1,139 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/pkg/dataloader"
+	"github.com/google/uuid"
+)
+
+// loaders batch the lookups resolvers make for a single request, ex. the home team of every game in a list is loaded
+// with one query
+type loaders struct {
+	games      *dataloader.Loader[string, api.Game]
+	teams      *dataloader.Loader[string, api.Team]
+	players    *dataloader.Loader[string, api.Player]
+	arenas     *dataloader.Loader[string, api.Arena]
+	franchises *dataloader.Loader[string, api.Franchise]
+	referees   *dataloader.Loader[string, api.Referee]
+
+	// the rest are keyed by game id
+	officials       *dataloader.Loader[string, []api.GameOfficial]
+	playerGameStats *dataloader.Loader[string, []api.PlayerGameStats]
+	teamGameStats   *dataloader.Loader[string, []api.TeamGameStats]
+}
+
+func newLoaders(graphStore Store) *loaders {
+	return &loaders{
+		games: newLoader(func(ctx context.Context, ids []string) (map[string]api.Game, error) {
+			games, err := graphStore.GetGamesWithIDs(ctx, ids)
+			return byID(games, func(g api.Game) string { return g.ID }), err
+		}),
+		teams: newLoader(func(ctx context.Context, ids []string) (map[string]api.Team, error) {
+			teamIDs := make([]uuid.UUID, 0, len(ids))
+			for _, id := range ids {
+				teamIDs = append(teamIDs, uuid.MustParse(id))
+			}
+			teams, err := graphStore.GetTeamsWithIDs(ctx, teamIDs)
+			return byID(teams, func(t api.Team) string { return t.ID }), err
+		}),
+		players: newLoader(func(ctx context.Context, ids []string) (map[string]api.Player, error) {
+			players, err := graphStore.GetPlayersWithIDs(ctx, ids)
+			return byID(players, func(p api.Player) string { return p.ID }), err
+		}),
+		arenas: newLoader(func(ctx context.Context, ids []string) (map[string]api.Arena, error) {
+			arenas, err := graphStore.GetArenasWithIDs(ctx, ids)
+			return byID(arenas, func(a api.Arena) string { return a.ID }), err
+		}),
+		franchises: newLoader(func(ctx context.Context, ids []string) (map[string]api.Franchise, error) {
+			franchises, err := graphStore.GetFranchisesWithIDs(ctx, ids)
+			return byID(franchises, func(f api.Franchise) string { return f.ID }), err
+		}),
+		referees: newLoader(func(ctx context.Context, ids []string) (map[string]api.Referee, error) {
+			referees, err := graphStore.GetRefereesWithIDs(ctx, ids)
+			return byID(referees, func(r api.Referee) string { return r.ID }), err
+		}),
+		officials: newLoader(func(ctx context.Context, gameIDs []string) (map[string][]api.GameOfficial, error) {
+			officials, err := graphStore.GetGameOfficials(ctx, gameIDs)
+			return groupByID(officials, func(o api.GameOfficial) string { return o.GameID }), err
+		}),
+		playerGameStats: newLoader(func(ctx context.Context, gameIDs []string) (map[string][]api.PlayerGameStats, error) {
+			playerGameStats, err := graphStore.GetPlayerGameStatsForGames(ctx, gameIDs)
+			return groupByID(playerGameStats, func(s api.PlayerGameStats) string { return s.GameID }), err
+		}),
+		teamGameStats: newLoader(func(ctx context.Context, gameIDs []string) (map[string][]api.TeamGameStats, error) {
+			teamGameStats, err := graphStore.GetTeamGameStatsForGames(ctx, gameIDs)
+			return groupByID(teamGameStats, func(s api.TeamGameStats) string { return s.GameID }), err
+		}),
+	}
+}
+
+// newLoader wraps a batch func so ids that aren't uuids are never sent to the store, postgres would fail the whole
+// batch on one of them
+func newLoader[V any](batchFunc dataloader.BatchFunc[string, V]) *dataloader.Loader[string, V] {
+	return dataloader.New(func(ctx context.Context, ids []string) (map[string]V, error) {
+		validIDs := make([]string, 0, len(ids))
+		for _, id := range ids {
+			if _, err := uuid.Parse(id); err == nil {
+				validIDs = append(validIDs, id)
+			}
+		}
+		if len(validIDs) == 0 {
+			return map[string]V{}, nil
+		}
+		return batchFunc(ctx, validIDs)
+	}, dataloader.DefaultWait, dataloader.DefaultMaxBatch)
+}
+
+func byID[V any](values []V, id func(V) string) map[string]V {
+	m := make(map[string]V, len(values))
+	for _, v := range values {
+		m[id(v)] = v
+	}
+	return m
+}
+
+func groupByID[V any](values []V, id func(V) string) map[string][]V {
+	m := map[string][]V{}
+	for _, v := range values {
+		m[id(v)] = append(m[id(v)], v)
+	}
+	return m
+}
+
+// load gets a value by id, a missing id or a nil id is a null value rather than an error
+func load[V any](ctx context.Context, loader *dataloader.Loader[string, V], id *string) (*V, error) {
+	if id == nil {
+		return nil, nil
+	}
+
+	v, err := loader.Load(ctx, *id)
+	if errors.Is(err, dataloader.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// loadList gets the values for a game id, a game without any is an empty list
+func loadList[V any](ctx context.Context, loader *dataloader.Loader[string, []V], gameID string) ([]V, error) {
+	values, err := loader.Load(ctx, gameID)
+	if errors.Is(err, dataloader.ErrNotFound) {
+		return []V{}, nil
+	}
+	return values, err
+}
+
+type loadersContextKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersContextKey{}, l)
+}
+
+func loadersFromContext(ctx context.Context) *loaders {
+	return ctx.Value(loadersContextKey{}).(*loaders)
+}
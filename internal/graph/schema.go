@@ -0,0 +1,613 @@
+package graph
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/apis/nba"
+	"github.com/drewthor/wolves_reddit_bot/internal/game"
+	"github.com/drewthor/wolves_reddit_bot/pkg/graphql"
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"github.com/google/uuid"
+)
+
+var dateTime = &graphql.Scalar{
+	Name:        "DateTime",
+	Description: "An RFC 3339 timestamp ex. 2024-01-02T01:00:00Z",
+	Serialize: func(v any) (any, error) {
+		t, ok := v.(time.Time)
+		if !ok {
+			return nil, fmt.Errorf("DateTime cannot represent value %v", v)
+		}
+		return t.UTC().Format(time.RFC3339), nil
+	},
+	ParseValue: func(v any) (any, error) {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("DateTime cannot represent a non string value %v", v)
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return nil, fmt.Errorf("DateTime must be an RFC 3339 timestamp: %w", err)
+		}
+		return t, nil
+	},
+}
+
+func nonNull(t graphql.Type) graphql.Type {
+	return &graphql.NonNull{OfType: t}
+}
+
+// listOf is a non null list of non null items
+func listOf(t graphql.Type) graphql.Type {
+	return nonNull(&graphql.List{OfType: nonNull(t)})
+}
+
+// gamePage is a page of games and the cursor of the next page, nil on the last page
+type gamePage struct {
+	Games      []api.Game `json:"games"`
+	NextCursor *string    `json:"next_cursor"`
+}
+
+// newSchema builds the graphql schema. Relations are resolved through the request's loaders so a list of games loads
+// its teams, arenas, officials and stats with one query each
+func newSchema(graphStore Store, gameService game.Service) (*graphql.Schema, error) {
+	gameType := &graphql.Object{Name: "Game"}
+	gamePageType := &graphql.Object{Name: "GamePage"}
+	teamType := &graphql.Object{Name: "Team"}
+	franchiseType := &graphql.Object{Name: "Franchise"}
+	playerType := &graphql.Object{Name: "Player"}
+	arenaType := &graphql.Object{Name: "Arena"}
+	refereeType := &graphql.Object{Name: "Referee"}
+	gameOfficialType := &graphql.Object{Name: "GameOfficial", Description: "A referee working a game"}
+	seasonType := &graphql.Object{Name: "Season"}
+	playerGameStatsType := &graphql.Object{Name: "PlayerGameStats", Description: "A player's line in a game"}
+	teamGameStatsType := &graphql.Object{Name: "TeamGameStats", Description: "A team's totals in a game"}
+
+	gamesArgs := []*graphql.Argument{
+		{Name: "teamId", Type: graphql.ID},
+		{Name: "teamSide", Type: graphql.String, Description: "Which side of the game teamId has to be on, home, away or either, only allowed with teamId"},
+		{Name: "seasonStartYear", Type: graphql.Int},
+		{Name: "seasonStage", Type: graphql.String, Description: "pre, regular, all-star, post or play-in"},
+		{Name: "status", Type: graphql.String, Description: "scheduled, started or completed"},
+		{Name: "arenaId", Type: graphql.ID},
+		{Name: "startDate", Type: graphql.String, Description: "Inclusive YYYY-MM-DD date in eastern time"},
+		{Name: "endDate", Type: graphql.String, Description: "Inclusive YYYY-MM-DD date in eastern time"},
+		{Name: "limit", Type: graphql.Int, DefaultValue: game.DefaultListLimit},
+		{Name: "after", Type: graphql.String, Description: "The nextCursor of the previous page"},
+	}
+
+	gamePageType.Fields = []*graphql.Field{
+		{Name: "games", Type: listOf(gameType)},
+		{Name: "nextCursor", Type: graphql.String, Description: "Passed as after to get the next page, null on the last page"},
+	}
+
+	gameType.Fields = []*graphql.Field{
+		{Name: "id", Type: nonNull(graphql.ID)},
+		{Name: "nbaGameId", Type: nonNull(graphql.String)},
+		{Name: "status", Type: nonNull(graphql.String)},
+		{
+			Name: "season",
+			Type: nonNull(seasonType),
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				return parseSeason(p.Source.(api.Game).Season)
+			},
+		},
+		{Name: "seasonStage", Type: nonNull(graphql.String)},
+		{Name: "startTime", Type: nonNull(dateTime)},
+		{Name: "endTime", Type: dateTime},
+		{Name: "period", Type: graphql.Int},
+		{Name: "periodTimeRemaining", Type: graphql.Int, Description: "Tenths of a second left in the period"},
+		{Name: "duration", Type: graphql.Int, Description: "Length of the game in seconds"},
+		{Name: "attendance", Type: graphql.Int},
+		{Name: "source", Type: nonNull(graphql.String)},
+		{Name: "homeTeamId", Type: graphql.ID},
+		{Name: "awayTeamId", Type: graphql.ID},
+		{Name: "homeTeamPoints", Type: graphql.Int},
+		{Name: "awayTeamPoints", Type: graphql.Int},
+		{
+			Name: "homeTeam",
+			Type: teamType,
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				return load(ctx, loadersFromContext(ctx).teams, p.Source.(api.Game).HomeTeamID)
+			},
+		},
+		{
+			Name: "awayTeam",
+			Type: teamType,
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				return load(ctx, loadersFromContext(ctx).teams, p.Source.(api.Game).AwayTeamID)
+			},
+		},
+		{Name: "arenaId", Type: graphql.ID},
+		{
+			Name: "arena",
+			Type: arenaType,
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				return load(ctx, loadersFromContext(ctx).arenas, p.Source.(api.Game).ArenaID)
+			},
+		},
+		{
+			Name: "officials",
+			Type: listOf(gameOfficialType),
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				return loadList(ctx, loadersFromContext(ctx).officials, p.Source.(api.Game).ID)
+			},
+		},
+		{
+			Name: "homeTeamStats",
+			Type: teamGameStatsType,
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				return teamGameStats(ctx, p.Source.(api.Game), p.Source.(api.Game).HomeTeamID)
+			},
+		},
+		{
+			Name: "awayTeamStats",
+			Type: teamGameStatsType,
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				return teamGameStats(ctx, p.Source.(api.Game), p.Source.(api.Game).AwayTeamID)
+			},
+		},
+		{
+			Name:        "playerStats",
+			Type:        listOf(playerGameStatsType),
+			Description: "Player lines with the most minutes first, only the team's players when teamId is set",
+			Args:        []*graphql.Argument{{Name: "teamId", Type: graphql.ID}},
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				playerGameStats, err := loadList(ctx, loadersFromContext(ctx).playerGameStats, p.Source.(api.Game).ID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get player stats: %w", err)
+				}
+				if teamID, ok := p.Args["teamId"].(string); ok {
+					playerGameStats = slices.DeleteFunc(slices.Clone(playerGameStats), func(s api.PlayerGameStats) bool { return s.TeamID != teamID })
+				}
+				return playerGameStats, nil
+			},
+		},
+		{Name: "createdAt", Type: nonNull(dateTime)},
+		{Name: "updatedAt", Type: dateTime},
+	}
+
+	teamType.Fields = []*graphql.Field{
+		{Name: "id", Type: nonNull(graphql.ID)},
+		{Name: "name", Type: nonNull(graphql.String)},
+		{Name: "nickname", Type: nonNull(graphql.String)},
+		{Name: "city", Type: nonNull(graphql.String)},
+		{Name: "alternateCity", Type: graphql.String},
+		{Name: "state", Type: graphql.String},
+		{Name: "country", Type: graphql.String},
+		{Name: "nbaUrlName", Type: graphql.String},
+		{Name: "nbaShortName", Type: graphql.String, Description: "The team's tricode ex. MIN"},
+		{Name: "nbaTeamId", Type: nonNull(graphql.Int)},
+		{
+			Name: "logoUrl",
+			Type: nonNull(graphql.String),
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				return fmt.Sprintf(nba.TeamLogoURL, p.Source.(api.Team).NBATeamID), nil
+			},
+		},
+		{Name: "franchiseId", Type: graphql.ID},
+		{
+			Name: "franchise",
+			Type: franchiseType,
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				return load(ctx, loadersFromContext(ctx).franchises, p.Source.(api.Team).FranchiseID)
+			},
+		},
+		{Name: "createdAt", Type: nonNull(dateTime)},
+		{Name: "updatedAt", Type: dateTime},
+	}
+
+	franchiseType.Fields = []*graphql.Field{
+		{Name: "id", Type: nonNull(graphql.ID)},
+		{Name: "leagueId", Type: nonNull(graphql.ID)},
+		{Name: "nbaTeamId", Type: nonNull(graphql.Int)},
+		{Name: "city", Type: nonNull(graphql.String)},
+		{Name: "state", Type: nonNull(graphql.String)},
+		{Name: "country", Type: nonNull(graphql.String)},
+		{Name: "name", Type: nonNull(graphql.String)},
+		{Name: "nickname", Type: nonNull(graphql.String)},
+		{Name: "startYear", Type: nonNull(graphql.Int)},
+		{Name: "endYear", Type: nonNull(graphql.Int)},
+		{Name: "years", Type: nonNull(graphql.Int)},
+		{Name: "games", Type: nonNull(graphql.Int)},
+		{Name: "wins", Type: nonNull(graphql.Int)},
+		{Name: "losses", Type: nonNull(graphql.Int)},
+		{Name: "playoffAppearances", Type: nonNull(graphql.Int)},
+		{Name: "divisionTitles", Type: nonNull(graphql.Int)},
+		{Name: "conferenceTitles", Type: nonNull(graphql.Int)},
+		{Name: "leagueTitles", Type: nonNull(graphql.Int)},
+		{Name: "active", Type: nonNull(graphql.Boolean)},
+		{Name: "createdAt", Type: nonNull(dateTime)},
+		{Name: "updatedAt", Type: dateTime},
+	}
+
+	playerType.Fields = []*graphql.Field{
+		{Name: "id", Type: nonNull(graphql.ID)},
+		{Name: "firstName", Type: nonNull(graphql.String)},
+		{Name: "lastName", Type: nonNull(graphql.String)},
+		{Name: "birthdate", Type: dateTime},
+		{Name: "heightFeet", Type: graphql.Int},
+		{Name: "heightInches", Type: graphql.Int},
+		{Name: "heightMeters", Type: graphql.Float},
+		{Name: "weightPounds", Type: graphql.Int},
+		{Name: "weightKilograms", Type: graphql.Float},
+		{Name: "jerseyNumber", Type: graphql.Int},
+		{Name: "positions", Type: listOf(graphql.String)},
+		{Name: "active", Type: nonNull(graphql.Boolean)},
+		{Name: "yearsPro", Type: graphql.Int},
+		{Name: "nbaDebutYear", Type: graphql.Int},
+		{Name: "nbaPlayerId", Type: nonNull(graphql.Int)},
+		{Name: "country", Type: graphql.String},
+		{Name: "createdAt", Type: nonNull(dateTime)},
+		{Name: "updatedAt", Type: dateTime},
+	}
+
+	arenaType.Fields = []*graphql.Field{
+		{Name: "id", Type: nonNull(graphql.ID)},
+		{Name: "name", Type: nonNull(graphql.String)},
+		{Name: "city", Type: graphql.String},
+		{Name: "state", Type: graphql.String},
+		{Name: "country", Type: nonNull(graphql.String)},
+		{Name: "timezone", Type: graphql.String, Description: "IANA time zone ex. America/Chicago"},
+		{Name: "latitude", Type: graphql.Float},
+		{Name: "longitude", Type: graphql.Float},
+		{Name: "nbaArenaId", Type: nonNull(graphql.Int)},
+		{Name: "createdAt", Type: nonNull(dateTime)},
+		{Name: "updatedAt", Type: dateTime},
+	}
+
+	refereeType.Fields = []*graphql.Field{
+		{Name: "id", Type: nonNull(graphql.ID)},
+		{Name: "firstName", Type: nonNull(graphql.String)},
+		{Name: "lastName", Type: nonNull(graphql.String)},
+		{Name: "jerseyNumber", Type: nonNull(graphql.Int)},
+		{Name: "nbaRefereeId", Type: nonNull(graphql.Int)},
+		{Name: "createdAt", Type: nonNull(dateTime)},
+		{Name: "updatedAt", Type: dateTime},
+	}
+
+	gameOfficialType.Fields = []*graphql.Field{
+		{
+			Name: "referee",
+			Type: nonNull(refereeType),
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				refereeID := p.Source.(api.GameOfficial).RefereeID
+				return load(ctx, loadersFromContext(ctx).referees, &refereeID)
+			},
+		},
+		{Name: "assignment", Type: graphql.String, Description: "ex. crew chief, referee or umpire"},
+	}
+
+	seasonType.Fields = []*graphql.Field{
+		{Name: "name", Type: nonNull(graphql.String), Description: "The start and end year ex. 2024-2025"},
+		{Name: "startYear", Type: nonNull(graphql.Int)},
+		{Name: "endYear", Type: nonNull(graphql.Int)},
+		{
+			Name: "games",
+			Type: nonNull(gamePageType),
+			Args: slices.DeleteFunc(slices.Clone(gamesArgs), func(arg *graphql.Argument) bool { return arg.Name == "seasonStartYear" }),
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				args := make(map[string]any, len(p.Args)+1)
+				for name, v := range p.Args {
+					args[name] = v
+				}
+				args["seasonStartYear"] = p.Source.(api.Season).StartYear
+				return listGames(ctx, gameService, args)
+			},
+		},
+	}
+
+	gameStatFields := []*graphql.Field{
+		{Name: "points", Type: graphql.Int},
+		{Name: "assists", Type: graphql.Int},
+		{Name: "turnovers", Type: graphql.Int},
+		{Name: "steals", Type: graphql.Int},
+		{Name: "blocks", Type: graphql.Int},
+		{Name: "threePointersAttempted", Type: graphql.Int},
+		{Name: "threePointersMade", Type: graphql.Int},
+		{Name: "fieldGoalsAttempted", Type: graphql.Int},
+		{Name: "fieldGoalsMade", Type: graphql.Int},
+		{Name: "freeThrowsAttempted", Type: graphql.Int},
+		{Name: "freeThrowsMade", Type: graphql.Int},
+		{Name: "reboundsOffensive", Type: graphql.Int},
+		{Name: "reboundsDefensive", Type: graphql.Int},
+		{Name: "reboundsTotal", Type: graphql.Int},
+		{Name: "foulsPersonal", Type: graphql.Int},
+	}
+
+	playerGameStatsType.Fields = slices.Concat([]*graphql.Field{
+		{Name: "gameId", Type: nonNull(graphql.ID)},
+		{
+			Name: "game",
+			Type: nonNull(gameType),
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				gameID := p.Source.(api.PlayerGameStats).GameID
+				return load(ctx, loadersFromContext(ctx).games, &gameID)
+			},
+		},
+		{Name: "teamId", Type: nonNull(graphql.ID)},
+		{
+			Name: "team",
+			Type: nonNull(teamType),
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				teamID := p.Source.(api.PlayerGameStats).TeamID
+				return load(ctx, loadersFromContext(ctx).teams, &teamID)
+			},
+		},
+		{Name: "playerId", Type: nonNull(graphql.ID)},
+		{
+			Name: "player",
+			Type: nonNull(playerType),
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				playerID := p.Source.(api.PlayerGameStats).PlayerID
+				return load(ctx, loadersFromContext(ctx).players, &playerID)
+			},
+		},
+		{Name: "timePlayedSeconds", Type: graphql.Int},
+	}, gameStatFields, []*graphql.Field{
+		{Name: "plusMinus", Type: graphql.Int},
+	})
+
+	teamGameStatsType.Fields = slices.Concat([]*graphql.Field{
+		{Name: "gameId", Type: nonNull(graphql.ID)},
+		{Name: "teamId", Type: nonNull(graphql.ID)},
+		{
+			Name: "team",
+			Type: nonNull(teamType),
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+				teamID := p.Source.(api.TeamGameStats).TeamID
+				return load(ctx, loadersFromContext(ctx).teams, &teamID)
+			},
+		},
+		{Name: "pointsAgainst", Type: graphql.Int},
+	}, gameStatFields, []*graphql.Field{
+		{Name: "fastBreakPoints", Type: graphql.Int},
+		{Name: "pointsInPaint", Type: graphql.Int},
+		{Name: "secondChancePoints", Type: graphql.Int},
+		{Name: "pointsOffTurnovers", Type: graphql.Int},
+		{Name: "biggestLead", Type: graphql.Int},
+	})
+
+	idArgs := []*graphql.Argument{{Name: "id", Type: nonNull(graphql.ID)}}
+
+	queryType := &graphql.Object{
+		Name: "Query",
+		Fields: []*graphql.Field{
+			{
+				Name: "game",
+				Type: gameType,
+				Args: idArgs,
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					id := p.Args["id"].(string)
+					return load(ctx, loadersFromContext(ctx).games, &id)
+				},
+			},
+			{
+				Name:        "games",
+				Type:        nonNull(gamePageType),
+				Description: "Games by start time, filtered the same as GET /games",
+				Args:        gamesArgs,
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					return listGames(ctx, gameService, p.Args)
+				},
+			},
+			{
+				Name: "team",
+				Type: teamType,
+				Args: idArgs,
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					id := p.Args["id"].(string)
+					return load(ctx, loadersFromContext(ctx).teams, &id)
+				},
+			},
+			{
+				Name: "teams",
+				Type: listOf(teamType),
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					return graphStore.ListTeams(ctx)
+				},
+			},
+			{
+				Name: "franchise",
+				Type: franchiseType,
+				Args: idArgs,
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					id := p.Args["id"].(string)
+					return load(ctx, loadersFromContext(ctx).franchises, &id)
+				},
+			},
+			{
+				Name: "franchises",
+				Type: listOf(franchiseType),
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					return graphStore.ListFranchises(ctx)
+				},
+			},
+			{
+				Name: "player",
+				Type: playerType,
+				Args: idArgs,
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					id := p.Args["id"].(string)
+					return load(ctx, loadersFromContext(ctx).players, &id)
+				},
+			},
+			{
+				Name: "players",
+				Type: listOf(playerType),
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					return graphStore.ListPlayers(ctx)
+				},
+			},
+			{
+				Name: "arena",
+				Type: arenaType,
+				Args: idArgs,
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					id := p.Args["id"].(string)
+					return load(ctx, loadersFromContext(ctx).arenas, &id)
+				},
+			},
+			{
+				Name: "referee",
+				Type: refereeType,
+				Args: idArgs,
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					id := p.Args["id"].(string)
+					return load(ctx, loadersFromContext(ctx).referees, &id)
+				},
+			},
+			{
+				Name: "referees",
+				Type: listOf(refereeType),
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					return graphStore.ListReferees(ctx)
+				},
+			},
+			{
+				Name: "seasons",
+				Type: listOf(seasonType),
+				Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+					return graphStore.ListSeasons(ctx)
+				},
+			},
+		},
+	}
+
+	return graphql.NewSchema(queryType)
+}
+
+func teamGameStats(ctx context.Context, g api.Game, teamID *string) (*api.TeamGameStats, error) {
+	if teamID == nil {
+		return nil, nil
+	}
+
+	teamGameStats, err := loadList(ctx, loadersFromContext(ctx).teamGameStats, g.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team stats: %w", err)
+	}
+
+	i := slices.IndexFunc(teamGameStats, func(s api.TeamGameStats) bool { return s.TeamID == *teamID })
+	if i == -1 {
+		return nil, nil
+	}
+	return &teamGameStats[i], nil
+}
+
+// parseSeason parses a game's season name ex. 2024-2025
+func parseSeason(name string) (api.Season, error) {
+	startYearStr, endYearStr, _ := strings.Cut(name, "-")
+
+	startYear, err := strconv.Atoi(startYearStr)
+	if err != nil {
+		return api.Season{}, fmt.Errorf("invalid season %s", name)
+	}
+
+	endYear, err := strconv.Atoi(endYearStr)
+	if err != nil {
+		return api.Season{}, fmt.Errorf("invalid season %s", name)
+	}
+
+	return api.Season{Name: name, StartYear: startYear, EndYear: endYear}, nil
+}
+
+// listGames lists games with the same filters and cursor pagination as GET /games
+func listGames(ctx context.Context, gameService game.Service, args map[string]any) (gamePage, error) {
+	limit, ok := args["limit"].(int)
+	if !ok {
+		limit = game.DefaultListLimit
+	}
+
+	filter := game.ListFilter{
+		ListParams: util.ListParams{
+			Sort:  util.Sort{Field: game.SortStartTime},
+			Limit: min(limit, game.MaxListLimit),
+		},
+	}
+	if filter.Limit <= 0 {
+		return gamePage{}, fmt.Errorf("limit must be a positive number")
+	}
+
+	if after, ok := args["after"].(string); ok {
+		cursor, err := util.DecodeCursor(after)
+		if err != nil {
+			return gamePage{}, err
+		}
+		if cursor.Sort != filter.Sort.String() {
+			return gamePage{}, fmt.Errorf("%w: cursor is for sort %s", util.ErrInvalidListParam, cursor.Sort)
+		}
+		filter.Cursor = &cursor
+	}
+
+	if teamID, ok := args["teamId"].(string); ok {
+		if _, err := uuid.Parse(teamID); err != nil {
+			return gamePage{}, fmt.Errorf("%w: teamId must be a team's id", util.ErrInvalidListParam)
+		}
+		filter.TeamID = sql.NullString{String: teamID, Valid: true}
+		filter.TeamSide = game.TeamSideEither
+	}
+	if teamSide, ok := args["teamSide"].(string); ok {
+		if !filter.TeamID.Valid {
+			return gamePage{}, fmt.Errorf("%w: teamSide requires teamId", util.ErrInvalidListParam)
+		}
+		if !slices.Contains([]game.TeamSide{game.TeamSideHome, game.TeamSideAway, game.TeamSideEither}, game.TeamSide(teamSide)) {
+			return gamePage{}, fmt.Errorf("%w: teamSide must be home, away or either", util.ErrInvalidListParam)
+		}
+		filter.TeamSide = game.TeamSide(teamSide)
+	}
+
+	if seasonStartYear, ok := args["seasonStartYear"].(int); ok {
+		filter.SeasonStartYear = sql.NullInt64{Int64: int64(seasonStartYear), Valid: true}
+	}
+
+	if seasonStage, ok := args["seasonStage"].(string); ok {
+		seasonStages := []util.SeasonStage{util.SeasonStagePre, util.SeasonStageRegular, util.SeasonStageAllStar, util.SeasonStagePost, util.SeasonStagePlayIn}
+		if !slices.Contains(seasonStages, util.SeasonStage(seasonStage)) {
+			return gamePage{}, fmt.Errorf("%w: unknown seasonStage %s", util.ErrInvalidListParam, seasonStage)
+		}
+		filter.SeasonStageName = sql.NullString{String: seasonStage, Valid: true}
+	}
+
+	if status, ok := args["status"].(string); ok {
+		if !slices.Contains([]game.GameStatus{game.GameStatusScheduled, game.GameStatusStarted, game.GameStatusCompleted}, game.GameStatus(status)) {
+			return gamePage{}, fmt.Errorf("%w: status must be scheduled, started or completed", util.ErrInvalidListParam)
+		}
+		filter.StatusName = sql.NullString{String: status, Valid: true}
+	}
+
+	if arenaID, ok := args["arenaId"].(string); ok {
+		if _, err := uuid.Parse(arenaID); err != nil {
+			return gamePage{}, fmt.Errorf("%w: arenaId must be an arena's id", util.ErrInvalidListParam)
+		}
+		filter.ArenaID = sql.NullString{String: arenaID, Valid: true}
+	}
+
+	for name, date := range map[string]*sql.NullTime{"startDate": &filter.StartDate, "endDate": &filter.EndDate} {
+		if dateStr, ok := args[name].(string); ok {
+			t, err := time.Parse(util.DateLayout, dateStr)
+			if err != nil {
+				return gamePage{}, fmt.Errorf("%w: %s must be a YYYY-MM-DD date", util.ErrInvalidListParam, name)
+			}
+			*date = sql.NullTime{Time: t, Valid: true}
+		}
+	}
+
+	games, nextCursor, err := gameService.List(ctx, filter)
+	if err != nil {
+		return gamePage{}, fmt.Errorf("failed to list games: %w", err)
+	}
+
+	page := gamePage{Games: games}
+	if nextCursor != nil {
+		encoded := nextCursor.Encode()
+		page.NextCursor = &encoded
+	}
+	return page, nil
+}
@@ -0,0 +1,41 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/drewthor/wolves_reddit_bot/internal/game"
+	"github.com/drewthor/wolves_reddit_bot/pkg/graphql"
+	"go.opentelemetry.io/otel"
+)
+
+type Service interface {
+	Execute(ctx context.Context, req graphql.Request) graphql.Response
+	SDL() string
+}
+
+func NewService(graphStore Store, gameService game.Service) (Service, error) {
+	schema, err := newSchema(graphStore, gameService)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service{graphStore: graphStore, schema: schema}, nil
+}
+
+type service struct {
+	graphStore Store
+
+	schema *graphql.Schema
+}
+
+func (s *service) Execute(ctx context.Context, req graphql.Request) graphql.Response {
+	ctx, span := otel.Tracer("graph").Start(ctx, "graph.service.Execute")
+	defer span.End()
+
+	// every request gets its own loaders so batches and cached values are never shared between requests
+	return s.schema.Execute(withLoaders(ctx, newLoaders(s.graphStore)), req)
+}
+
+func (s *service) SDL() string {
+	return s.schema.SDL()
+}
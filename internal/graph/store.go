@@ -0,0 +1,27 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/google/uuid"
+)
+
+// Store is what the graphql resolvers load through dataloaders, every method loads many ids at once
+type Store interface {
+	GetGamesWithIDs(ctx context.Context, ids []string) ([]api.Game, error)
+	GetTeamsWithIDs(ctx context.Context, ids []uuid.UUID) ([]api.Team, error)
+	GetPlayersWithIDs(ctx context.Context, ids []string) ([]api.Player, error)
+	GetArenasWithIDs(ctx context.Context, ids []string) ([]api.Arena, error)
+	GetFranchisesWithIDs(ctx context.Context, ids []string) ([]api.Franchise, error)
+	GetRefereesWithIDs(ctx context.Context, ids []string) ([]api.Referee, error)
+	GetGameOfficials(ctx context.Context, gameIDs []string) ([]api.GameOfficial, error)
+	GetPlayerGameStatsForGames(ctx context.Context, gameIDs []string) ([]api.PlayerGameStats, error)
+	GetTeamGameStatsForGames(ctx context.Context, gameIDs []string) ([]api.TeamGameStats, error)
+
+	ListTeams(ctx context.Context) ([]api.Team, error)
+	ListFranchises(ctx context.Context) ([]api.Franchise, error)
+	ListPlayers(ctx context.Context) ([]api.Player, error)
+	ListReferees(ctx context.Context) ([]api.Referee, error)
+	ListSeasons(ctx context.Context) ([]api.Season, error)
+}
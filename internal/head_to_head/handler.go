@@ -0,0 +1,53 @@
+package head_to_head
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"go.opentelemetry.io/otel"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler interface {
+	Routes() chi.Router
+	GetHeadToHead(w http.ResponseWriter, r *http.Request)
+}
+
+func NewHandler(logger *slog.Logger, headToHeadService Service) Handler {
+	return &handler{logger: logger, headToHeadService: headToHeadService}
+}
+
+type handler struct {
+	logger            *slog.Logger
+	headToHeadService Service
+}
+
+// Routes are mounted under a team
+func (h *handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/{opponentID}", h.GetHeadToHead)
+
+	return r
+}
+
+func (h *handler) GetHeadToHead(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("head_to_head").Start(r.Context(), "head_to_head.handler.GetHeadToHead")
+	defer span.End()
+
+	teamID := chi.URLParam(r, "teamID")
+	opponentID := chi.URLParam(r, "opponentID")
+
+	logger := h.logger.With(slog.String("team_id", teamID), slog.String("opponent_id", opponentID))
+
+	headToHead, err := h.headToHeadService.GetHeadToHead(ctx, teamID, opponentID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get head to head", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteJSON(http.StatusOK, headToHead, w)
+}
@@ -0,0 +1,263 @@
+package head_to_head
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/apis/nba"
+	"github.com/drewthor/wolves_reddit_bot/internal/season"
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+var ErrSameFranchise = util.NewError(util.ErrValidation, "team and opponent are the same franchise")
+
+// season stages as encoded in nba game ids
+const (
+	gameIDSeasonStagePre     = 1
+	gameIDSeasonStageRegular = 2
+	gameIDSeasonStageAllStar = 3
+	gameIDSeasonStagePost    = 4
+)
+
+const (
+	gameStatusCompleted = "completed"
+	playoffWinsNeeded   = 4
+)
+
+type Service interface {
+	GetHeadToHead(ctx context.Context, teamID string, opponentID string) (api.HeadToHead, error)
+}
+
+func NewService(headToHeadStore Store, seasonService season.Service) Service {
+	return &service{headToHeadStore: headToHeadStore, seasonService: seasonService}
+}
+
+type service struct {
+	headToHeadStore Store
+
+	seasonService season.Service
+}
+
+func (s *service) GetHeadToHead(ctx context.Context, teamID string, opponentID string) (api.HeadToHead, error) {
+	ctx, span := otel.Tracer("head_to_head").Start(ctx, "head_to_head.service.GetHeadToHead")
+	defer span.End()
+
+	franchiseID, err := s.headToHeadStore.GetTeamFranchiseID(ctx, teamID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.HeadToHead{}, util.NewError(util.ErrNotFound, "team not found")
+		}
+		return api.HeadToHead{}, fmt.Errorf("failed to get franchise of team: %w", err)
+	}
+
+	opponentFranchiseID, err := s.headToHeadStore.GetTeamFranchiseID(ctx, opponentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.HeadToHead{}, util.NewError(util.ErrNotFound, "team not found")
+		}
+		return api.HeadToHead{}, fmt.Errorf("failed to get franchise of opponent: %w", err)
+	}
+
+	if franchiseID == opponentFranchiseID {
+		return api.HeadToHead{}, ErrSameFranchise
+	}
+
+	matchupGames, err := s.headToHeadStore.GetFranchiseMatchupGames(ctx, franchiseID, opponentFranchiseID)
+	if err != nil {
+		return api.HeadToHead{}, fmt.Errorf("failed to get games between franchises: %w", err)
+	}
+
+	currentSeasonStartYear, err := s.seasonService.GetCurrentSeasonStartYear(ctx)
+	if err != nil {
+		return api.HeadToHead{}, fmt.Errorf("failed to get current season start year: %w", err)
+	}
+
+	return headToHead(teamID, opponentID, matchupGames, currentSeasonStartYear), nil
+}
+
+type recordTally struct {
+	record                         api.HeadToHeadRecord
+	margin, homeMargin, awayMargin int
+	homeGames, awayGames           int
+}
+
+func (t *recordTally) add(g MatchupGame) {
+	margin := int(g.TeamPoints.Int64 - g.OpponentPoints.Int64)
+	won := margin > 0
+
+	t.record.Games++
+	t.margin += margin
+	if won {
+		t.record.Wins++
+	} else {
+		t.record.Losses++
+	}
+
+	if g.Home {
+		t.homeGames++
+		t.homeMargin += margin
+		if won {
+			t.record.HomeWins++
+		} else {
+			t.record.HomeLosses++
+		}
+	} else {
+		t.awayGames++
+		t.awayMargin += margin
+		if won {
+			t.record.AwayWins++
+		} else {
+			t.record.AwayLosses++
+		}
+	}
+}
+
+func (t *recordTally) result() api.HeadToHeadRecord {
+	record := t.record
+	record.AverageMargin = averageMargin(t.margin, record.Games)
+	record.HomeAverageMargin = averageMargin(t.homeMargin, t.homeGames)
+	record.AwayAverageMargin = averageMargin(t.awayMargin, t.awayGames)
+	return record
+}
+
+func averageMargin(margin int, games int) *float64 {
+	if games == 0 {
+		return nil
+	}
+	average := float64(margin) / float64(games)
+	return &average
+}
+
+type playoffMeetingKey struct {
+	seasonStartYear int
+	round           int
+}
+
+func headToHead(teamID string, opponentID string, matchupGames []MatchupGame, currentSeasonStartYear int) api.HeadToHead {
+	var allTime, regularSeason, playoffs recordTally
+	seasonRegularSeasons := map[int]*recordTally{}
+	seasonPlayoffs := map[int]*recordTally{}
+	var seasonStartYears []int
+
+	var playoffMeetingKeys []playoffMeetingKey
+	playoffMeetings := map[playoffMeetingKey]*api.HeadToHeadPlayoffMeeting{}
+
+	var currentSeasonSeries *api.HeadToHeadSeasonSeries
+	var currentSeasonTally recordTally
+
+	for _, g := range matchupGames {
+		stage, ok := nba.GameIDSeasonStage(g.NBAGameID)
+		if !ok || stage == gameIDSeasonStagePre || stage == gameIDSeasonStageAllStar {
+			continue
+		}
+
+		completed := g.GameStatusName == gameStatusCompleted && g.TeamPoints.Valid && g.OpponentPoints.Valid
+
+		if stage == gameIDSeasonStageRegular && g.SeasonStartYear == currentSeasonStartYear {
+			if currentSeasonSeries == nil {
+				currentSeasonSeries = &api.HeadToHeadSeasonSeries{SeasonStartYear: currentSeasonStartYear, Played: []api.HeadToHeadGame{}, Remaining: []api.HeadToHeadGame{}}
+			}
+			if completed {
+				currentSeasonSeries.Played = append(currentSeasonSeries.Played, headToHeadGame(g))
+				currentSeasonTally.add(g)
+			} else {
+				currentSeasonSeries.Remaining = append(currentSeasonSeries.Remaining, headToHeadGame(g))
+			}
+		}
+
+		if !completed {
+			continue
+		}
+
+		allTime.add(g)
+
+		if _, ok := seasonRegularSeasons[g.SeasonStartYear]; !ok {
+			seasonStartYears = append(seasonStartYears, g.SeasonStartYear)
+			seasonRegularSeasons[g.SeasonStartYear] = &recordTally{}
+			seasonPlayoffs[g.SeasonStartYear] = &recordTally{}
+		}
+
+		switch stage {
+		case gameIDSeasonStageRegular:
+			regularSeason.add(g)
+			seasonRegularSeasons[g.SeasonStartYear].add(g)
+		case gameIDSeasonStagePost:
+			playoffs.add(g)
+			seasonPlayoffs[g.SeasonStartYear].add(g)
+
+			playoffGameID, _ := nba.ParsePlayoffGameID(g.NBAGameID)
+			key := playoffMeetingKey{seasonStartYear: g.SeasonStartYear, round: playoffGameID.Round}
+			meeting, ok := playoffMeetings[key]
+			if !ok {
+				playoffMeetingKeys = append(playoffMeetingKeys, key)
+				meeting = &api.HeadToHeadPlayoffMeeting{SeasonStartYear: key.seasonStartYear, Round: key.round}
+				playoffMeetings[key] = meeting
+			}
+			if g.TeamPoints.Int64 > g.OpponentPoints.Int64 {
+				meeting.Wins++
+			} else {
+				meeting.Losses++
+			}
+		}
+	}
+
+	h := api.HeadToHead{
+		TeamID:          teamID,
+		OpponentID:      opponentID,
+		AllTime:         allTime.result(),
+		RegularSeason:   regularSeason.result(),
+		Playoffs:        playoffs.result(),
+		Seasons:         []api.HeadToHeadSeason{},
+		PlayoffMeetings: []api.HeadToHeadPlayoffMeeting{},
+		CurrentSeason:   currentSeasonSeries,
+	}
+
+	sort.Ints(seasonStartYears)
+	for _, seasonStartYear := range seasonStartYears {
+		h.Seasons = append(h.Seasons, api.HeadToHeadSeason{
+			SeasonStartYear: seasonStartYear,
+			RegularSeason:   seasonRegularSeasons[seasonStartYear].result(),
+			Playoffs:        seasonPlayoffs[seasonStartYear].result(),
+		})
+	}
+
+	for _, key := range playoffMeetingKeys {
+		meeting := *playoffMeetings[key]
+		// series lengths changed over the years so only this season's series can still be undecided
+		if key.seasonStartYear != currentSeasonStartYear || meeting.Wins >= playoffWinsNeeded || meeting.Losses >= playoffWinsNeeded {
+			wonSeries := meeting.Wins > meeting.Losses
+			meeting.WonSeries = &wonSeries
+		}
+		h.PlayoffMeetings = append(h.PlayoffMeetings, meeting)
+	}
+
+	if currentSeasonSeries != nil {
+		currentSeasonSeries.Record = currentSeasonTally.result()
+	}
+
+	return h
+}
+
+func headToHeadGame(g MatchupGame) api.HeadToHeadGame {
+	headToHeadGame := api.HeadToHeadGame{
+		GameID:    g.GameID,
+		NBAGameID: g.NBAGameID,
+		StartTime: g.StartTime,
+		Home:      g.Home,
+		Status:    g.GameStatusName,
+	}
+	if g.TeamPoints.Valid {
+		teamPoints := int(g.TeamPoints.Int64)
+		headToHeadGame.TeamPoints = &teamPoints
+	}
+	if g.OpponentPoints.Valid {
+		opponentPoints := int(g.OpponentPoints.Int64)
+		headToHeadGame.OpponentPoints = &opponentPoints
+	}
+	return headToHeadGame
+}
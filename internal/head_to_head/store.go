@@ -0,0 +1,24 @@
+package head_to_head
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type Store interface {
+	GetTeamFranchiseID(ctx context.Context, teamID string) (string, error)
+	GetFranchiseMatchupGames(ctx context.Context, franchiseID string, opponentFranchiseID string) ([]MatchupGame, error)
+}
+
+// MatchupGame is a game between two franchises from the perspective of the first franchise
+type MatchupGame struct {
+	GameID          string
+	NBAGameID       string
+	SeasonStartYear int
+	StartTime       time.Time
+	GameStatusName  string
+	Home            bool
+	TeamPoints      sql.NullInt64
+	OpponentPoints  sql.NullInt64
+}
@@ -0,0 +1,145 @@
+package history
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"go.opentelemetry.io/otel"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler interface {
+	GameRoutes() chi.Router
+	PlayerRoutes() chi.Router
+	TeamRoutes() chi.Router
+	GetGameHistory(w http.ResponseWriter, r *http.Request)
+	GetPlayerHistory(w http.ResponseWriter, r *http.Request)
+	GetTeamHistory(w http.ResponseWriter, r *http.Request)
+}
+
+func NewHandler(logger *slog.Logger, historyService Service) Handler {
+	return &handler{logger: logger, historyService: historyService}
+}
+
+type handler struct {
+	logger         *slog.Logger
+	historyService Service
+}
+
+// GameRoutes are mounted under a game
+func (h *handler) GameRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.GetGameHistory)
+
+	return r
+}
+
+// PlayerRoutes are mounted under a player
+func (h *handler) PlayerRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.GetPlayerHistory)
+
+	return r
+}
+
+// TeamRoutes are mounted under a team
+func (h *handler) TeamRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.GetTeamHistory)
+
+	return r
+}
+
+func (h *handler) GetGameHistory(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("history").Start(r.Context(), "history.handler.GetGameHistory")
+	defer span.End()
+
+	gameID := chi.URLParam(r, "gameID")
+
+	filter, ok := listFilter(w, r)
+	if !ok {
+		return
+	}
+	filter.GameID = sql.NullString{String: gameID, Valid: true}
+
+	h.writeHistory(ctx, w, r, filter, h.logger.With(slog.String("game_id", gameID)))
+}
+
+func (h *handler) GetPlayerHistory(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("history").Start(r.Context(), "history.handler.GetPlayerHistory")
+	defer span.End()
+
+	playerID := chi.URLParam(r, "playerID")
+
+	filter, ok := listFilter(w, r)
+	if !ok {
+		return
+	}
+	filter.PlayerID = sql.NullString{String: playerID, Valid: true}
+
+	h.writeHistory(ctx, w, r, filter, h.logger.With(slog.String("player_id", playerID)))
+}
+
+func (h *handler) GetTeamHistory(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("history").Start(r.Context(), "history.handler.GetTeamHistory")
+	defer span.End()
+
+	teamID := chi.URLParam(r, "teamID")
+
+	filter, ok := listFilter(w, r)
+	if !ok {
+		return
+	}
+	filter.TeamID = sql.NullString{String: teamID, Valid: true}
+
+	h.writeHistory(ctx, w, r, filter, h.logger.With(slog.String("team_id", teamID)))
+}
+
+func (h *handler) writeHistory(ctx context.Context, w http.ResponseWriter, r *http.Request, filter ListFilter, logger *slog.Logger) {
+	history, err := h.historyService.ListHistory(ctx, filter)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to list history", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteJSON(http.StatusOK, history, w)
+}
+
+// listFilter reads the query params shared by every history route, writing a bad request when one is invalid
+func listFilter(w http.ResponseWriter, r *http.Request) (ListFilter, bool) {
+	filter := ListFilter{}
+	if tableName := r.URL.Query().Get("table"); tableName != "" {
+		filter.TableName = sql.NullString{String: tableName, Valid: true}
+	}
+	if ingestRunID := r.URL.Query().Get("ingest-run-id"); ingestRunID != "" {
+		filter.IngestRunID = sql.NullString{String: ingestRunID, Valid: true}
+	}
+	if beforeStr := r.URL.Query().Get("before"); beforeStr != "" {
+		before, err := time.Parse(time.RFC3339Nano, beforeStr)
+		if err != nil {
+			util.WriteProblem(w, r, http.StatusBadRequest, "invalid before, expected an RFC 3339 time")
+			return ListFilter{}, false
+		}
+		filter.Before = sql.NullTime{Time: before, Valid: true}
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			util.WriteProblem(w, r, http.StatusBadRequest, "invalid limit, expected a positive number")
+			return ListFilter{}, false
+		}
+		filter.Limit = limit
+	}
+
+	return filter, true
+}
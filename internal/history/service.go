@@ -0,0 +1,39 @@
+package history
+
+import (
+	"context"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	defaultListLimit = 100
+	maxListLimit     = 1000
+)
+
+type Service interface {
+	ListHistory(ctx context.Context, filter ListFilter) ([]api.History, error)
+}
+
+func NewService(historyStore Store) Service {
+	return &service{historyStore: historyStore}
+}
+
+type service struct {
+	historyStore Store
+}
+
+func (s *service) ListHistory(ctx context.Context, filter ListFilter) ([]api.History, error) {
+	ctx, span := otel.Tracer("history").Start(ctx, "history.service.ListHistory")
+	defer span.End()
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+
+	return s.historyStore.ListHistory(ctx, filter)
+}
@@ -0,0 +1,23 @@
+package history
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+)
+
+type Store interface {
+	ListHistory(ctx context.Context, filter ListFilter) ([]api.History, error)
+}
+
+type ListFilter struct {
+	GameID      sql.NullString
+	TeamID      sql.NullString
+	PlayerID    sql.NullString
+	TableName   sql.NullString
+	IngestRunID sql.NullString
+	// Before only includes changes made before the time so older pages can be requested with the last change's time
+	Before sql.NullTime
+	Limit  int
+}
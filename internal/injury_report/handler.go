@@ -0,0 +1,52 @@
+package injury_report
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"go.opentelemetry.io/otel"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler interface {
+	Routes() chi.Router
+	GetGameAvailability(w http.ResponseWriter, r *http.Request)
+}
+
+func NewHandler(logger *slog.Logger, injuryReportService Service) Handler {
+	return &handler{logger: logger, injuryReportService: injuryReportService}
+}
+
+type handler struct {
+	logger              *slog.Logger
+	injuryReportService Service
+}
+
+// Routes are mounted under a game
+func (h *handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.GetGameAvailability)
+
+	return r
+}
+
+func (h *handler) GetGameAvailability(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("injury_report").Start(r.Context(), "injury_report.handler.GetGameAvailability")
+	defer span.End()
+
+	gameID := chi.URLParam(r, "gameID")
+
+	logger := h.logger.With(slog.String("game_id", gameID))
+
+	availability, err := h.injuryReportService.GetGameAvailability(ctx, gameID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get game availability", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteJSON(http.StatusOK, availability, w)
+}
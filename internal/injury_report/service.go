@@ -0,0 +1,171 @@
+package injury_report
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/apis/nba"
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+// StatusNotListed is used in a player's timeline when a later report for the game no longer lists them
+const StatusNotListed = "not_listed"
+
+type Service interface {
+	UpdateInjuryReport(ctx context.Context, logger *slog.Logger, reportTime time.Time) error
+	GetGameAvailability(ctx context.Context, gameID string) (api.GameAvailability, error)
+}
+
+func NewService(injuryReportStore Store, nbaClient nba.Client) Service {
+	return &service{injuryReportStore: injuryReportStore, nbaClient: nbaClient}
+}
+
+type service struct {
+	injuryReportStore Store
+
+	nbaClient nba.Client
+}
+
+func (s *service) UpdateInjuryReport(ctx context.Context, logger *slog.Logger, reportTime time.Time) error {
+	ctx, span := otel.Tracer("injury_report").Start(ctx, "injury_report.service.UpdateInjuryReport")
+	defer span.End()
+
+	reportTime, err := nba.InjuryReportTime(reportTime)
+	if err != nil {
+		return err
+	}
+
+	objectKey := fmt.Sprintf("injuryreport/%s.pdf", reportTime.Format("2006-01-02_15"))
+	injuryReport, err := s.nbaClient.GetInjuryReport(ctx, reportTime, objectKey)
+	if err != nil {
+		return fmt.Errorf("failed to get injury report: %w", err)
+	}
+
+	injuryReportUpdate := InjuryReportUpdate{ReportTime: injuryReport.ReportTime}
+	for _, entry := range injuryReport.Entries {
+		reason := sql.NullString{}
+		if entry.Reason != "" {
+			reason.String = entry.Reason
+			reason.Valid = true
+		}
+
+		injuryReportUpdate.Entries = append(injuryReportUpdate.Entries, InjuryReportEntryUpdate{
+			GameDate:        entry.GameDate,
+			Matchup:         entry.Matchup,
+			TeamName:        entry.Team,
+			PlayerName:      entry.PlayerName,
+			PlayerFirstName: entry.PlayerFirstName,
+			PlayerLastName:  entry.PlayerLastName,
+			Status:          string(entry.Status),
+			Reason:          reason,
+		})
+	}
+
+	updated, err := s.injuryReportStore.UpdateInjuryReport(ctx, injuryReportUpdate)
+	if err != nil {
+		return fmt.Errorf("failed to update injury report: %w", err)
+	}
+
+	logger.InfoContext(ctx, fmt.Sprintf("stored %d injury report entries", updated), slog.Time("report_time", injuryReport.ReportTime))
+
+	return nil
+}
+
+func (s *service) GetGameAvailability(ctx context.Context, gameID string) (api.GameAvailability, error) {
+	ctx, span := otel.Tracer("injury_report").Start(ctx, "injury_report.service.GetGameAvailability")
+	defer span.End()
+
+	if _, err := s.injuryReportStore.GetGameWithID(ctx, gameID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.GameAvailability{}, util.NewError(util.ErrNotFound, "game not found")
+		}
+		return api.GameAvailability{}, fmt.Errorf("failed to get game for availability: %w", err)
+	}
+
+	entries, err := s.injuryReportStore.GetInjuryReportEntriesForGame(ctx, gameID)
+	if err != nil {
+		return api.GameAvailability{}, fmt.Errorf("failed to get injury report entries for game: %w", err)
+	}
+
+	return gameAvailability(gameID, entries), nil
+}
+
+// gameAvailability builds each player's timeline from the entries, which must be ordered by report time, only keeping
+// the reports where their status or reason changed
+func gameAvailability(gameID string, entries []api.InjuryReportEntry) api.GameAvailability {
+	availability := api.GameAvailability{GameID: gameID, Players: []api.PlayerAvailability{}}
+
+	var reportTimes []time.Time
+	entriesByReportTime := map[time.Time]map[string]api.InjuryReportEntry{}
+	playerIndexes := map[string]int{}
+
+	for _, entry := range entries {
+		reportTime := entry.ReportTime.UTC()
+		if _, ok := entriesByReportTime[reportTime]; !ok {
+			reportTimes = append(reportTimes, reportTime)
+			entriesByReportTime[reportTime] = map[string]api.InjuryReportEntry{}
+		}
+
+		key := entry.TeamName + "|" + entry.PlayerName
+		entriesByReportTime[reportTime][key] = entry
+
+		if _, ok := playerIndexes[key]; !ok {
+			playerIndexes[key] = len(availability.Players)
+			availability.Players = append(availability.Players, api.PlayerAvailability{
+				PlayerID:   entry.PlayerID,
+				PlayerName: entry.PlayerName,
+				TeamID:     entry.TeamID,
+				TeamName:   entry.TeamName,
+				Timeline:   []api.AvailabilityChange{},
+			})
+		}
+	}
+
+	if len(reportTimes) == 0 {
+		return availability
+	}
+	availability.LatestReportTime = &reportTimes[len(reportTimes)-1]
+
+	for key, i := range playerIndexes {
+		player := &availability.Players[i]
+
+		for _, reportTime := range reportTimes {
+			change := api.AvailabilityChange{ReportTime: reportTime, Status: StatusNotListed}
+			if entry, ok := entriesByReportTime[reportTime][key]; ok {
+				change.Status = entry.Status
+				change.Reason = entry.Reason
+			} else if len(player.Timeline) == 0 {
+				// not listed yet
+				continue
+			}
+
+			if len(player.Timeline) > 0 {
+				last := player.Timeline[len(player.Timeline)-1]
+				if last.Status == change.Status && sameReason(last.Reason, change.Reason) {
+					continue
+				}
+			}
+			player.Timeline = append(player.Timeline, change)
+		}
+
+		latest := player.Timeline[len(player.Timeline)-1]
+		player.Status = latest.Status
+		player.Reason = latest.Reason
+	}
+
+	return availability
+}
+
+func sameReason(a *string, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
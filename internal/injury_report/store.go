@@ -0,0 +1,34 @@
+package injury_report
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+)
+
+type Store interface {
+	GetGameWithID(ctx context.Context, id string) (api.Game, error)
+	UpdateInjuryReport(ctx context.Context, injuryReportUpdate InjuryReportUpdate) (int, error)
+	GetInjuryReportEntriesForGame(ctx context.Context, gameID string) ([]api.InjuryReportEntry, error)
+}
+
+// InjuryReportUpdate replaces the entries of the report published at ReportTime
+type InjuryReportUpdate struct {
+	ReportTime time.Time
+	Entries    []InjuryReportEntryUpdate
+}
+
+// InjuryReportEntryUpdate is linked to its game by the team playing on the game date and to its player by name since
+// the injury report doesn't include any nba ids
+type InjuryReportEntryUpdate struct {
+	GameDate        time.Time
+	Matchup         string
+	TeamName        string
+	PlayerName      string
+	PlayerFirstName string
+	PlayerLastName  string
+	Status          string
+	Reason          sql.NullString
+}
@@ -0,0 +1,199 @@
+package live
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/pkg/pubsub"
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"go.opentelemetry.io/otel"
+	"golang.org/x/net/websocket"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	// heartbeatInterval keeps idle event streams from being closed by proxies between plays
+	heartbeatInterval = 15 * time.Second
+	writeTimeout      = 10 * time.Second
+	// sseRetry is how long browsers wait before reconnecting a dropped event stream
+	sseRetry = 3 * time.Second
+)
+
+type Handler interface {
+	Routes() chi.Router
+	StreamGame(w http.ResponseWriter, r *http.Request)
+}
+
+func NewHandler(logger *slog.Logger, liveService Service) Handler {
+	return &handler{logger: logger, liveService: liveService}
+}
+
+type handler struct {
+	logger      *slog.Logger
+	liveService Service
+}
+
+// Routes are mounted under a game
+func (h *handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.StreamGame)
+
+	return r
+}
+
+// StreamGame streams the game's events as server-sent events or over a websocket when the request asks to upgrade. the
+// last event id comes from the Last-Event-ID header browsers send when reconnecting or the last-event-id query param
+func (h *handler) StreamGame(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("live").Start(r.Context(), "live.handler.StreamGame")
+	defer span.End()
+
+	gameID := chi.URLParam(r, "gameID")
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if queryLastEventID := r.URL.Query().Get("last-event-id"); queryLastEventID != "" {
+		lastEventID = queryLastEventID
+	}
+
+	logger := h.logger.With(slog.String("game_id", gameID))
+
+	subscription, err := h.liveService.Subscribe(ctx, gameID, lastEventID)
+	if err != nil {
+		if errors.Is(err, ErrGameFinished) {
+			// no content tells browsers to stop reconnecting
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		logger.ErrorContext(ctx, "failed to subscribe to live game", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+	defer subscription.Close()
+
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		server := websocket.Server{Handler: func(conn *websocket.Conn) {
+			h.streamWebSocket(conn, logger, subscription)
+		}}
+		server.ServeHTTP(w, r.WithContext(ctx))
+		return
+	}
+
+	h.streamServerSentEvents(w, r.WithContext(ctx), logger, subscription)
+}
+
+func (h *handler) streamServerSentEvents(w http.ResponseWriter, r *http.Request, logger *slog.Logger, subscription *Subscription) {
+	ctx := r.Context()
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// stops nginx from buffering the stream
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	write := func(s string) error {
+		_ = rc.SetWriteDeadline(time.Now().Add(writeTimeout))
+		if _, err := io.WriteString(w, s); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+
+	if err := write(fmt.Sprintf("retry: %d\n\n", sseRetry.Milliseconds())); err != nil {
+		return
+	}
+
+	if subscription.Snapshot != nil {
+		if err := writeServerSentEvent(write, *subscription.Snapshot); err != nil {
+			logger.WarnContext(ctx, "failed to write live game snapshot", slog.Any("error", err))
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if err := write(": heartbeat\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-subscription.Events:
+			if !ok {
+				if errors.Is(subscription.Err(), pubsub.ErrSlowSubscriber) {
+					logger.WarnContext(ctx, "dropped slow live game subscriber")
+				}
+				return
+			}
+			if err := writeServerSentEvent(write, event); err != nil {
+				logger.WarnContext(ctx, "failed to write live game event", slog.Any("error", err))
+				return
+			}
+		}
+	}
+}
+
+func writeServerSentEvent(write func(string) error, event api.LiveGameEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal live game event: %w", err)
+	}
+
+	return write(fmt.Sprintf("id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data))
+}
+
+func (h *handler) streamWebSocket(conn *websocket.Conn, logger *slog.Logger, subscription *Subscription) {
+	ctx := conn.Request().Context()
+	defer conn.Close()
+
+	// clients don't send anything, reading only notices when they go away
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		_, _ = io.Copy(io.Discard, conn)
+	}()
+
+	send := func(event api.LiveGameEvent) error {
+		_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		return websocket.JSON.Send(conn, event)
+	}
+
+	if subscription.Snapshot != nil {
+		if err := send(*subscription.Snapshot); err != nil {
+			logger.WarnContext(ctx, "failed to send live game snapshot", slog.Any("error", err))
+			return
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-closed:
+			return
+		case event, ok := <-subscription.Events:
+			if !ok {
+				if errors.Is(subscription.Err(), pubsub.ErrSlowSubscriber) {
+					logger.WarnContext(ctx, "dropped slow live game subscriber")
+				}
+				return
+			}
+			if err := send(event); err != nil {
+				logger.WarnContext(ctx, "failed to send live game event", slog.Any("error", err))
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package live
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/pkg/pubsub"
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	EventTypeState  = "state"
+	EventTypeStatus = "status"
+	EventTypePeriod = "period"
+	EventTypePlay   = "play"
+	EventTypeScore  = "score"
+	EventTypeFinal  = "final"
+)
+
+const (
+	gameStatusScheduled = "scheduled"
+	gameStatusCompleted = "completed"
+)
+
+const (
+	// replaySize is how many events are kept for each game so reconnecting clients can resume, it covers a full game's
+	// play by play
+	replaySize       = 1000
+	subscriberBuffer = 256
+	// completedGameRetention is how long a completed game's events are kept for clients resuming after the final event
+	completedGameRetention = time.Hour
+)
+
+// ErrGameFinished is returned when resuming a stream that has already received the game's final event
+var ErrGameFinished = util.NewError(util.ErrConflict, "game is finished and has no more events")
+
+type Service interface {
+	PublishGameUpdates(ctx context.Context, games []api.Game, playByPlays []api.PlayByPlay)
+	Subscribe(ctx context.Context, gameID string, lastEventID string) (*Subscription, error)
+}
+
+func NewService(liveStore Store) Service {
+	return &service{
+		liveStore: liveStore,
+		games:     map[string]*gameState{},
+		broker:    pubsub.NewBroker[api.LiveGameEvent](replaySize, subscriberBuffer),
+		epoch:     strconv.FormatInt(time.Now().UnixMilli(), 10),
+	}
+}
+
+type service struct {
+	liveStore Store
+
+	mu     sync.Mutex
+	games  map[string]*gameState
+	broker *pubsub.Broker[api.LiveGameEvent]
+	// epoch is part of every event id so ids from before a restart, whose events are gone, aren't resumed from
+	epoch string
+}
+
+// gameState is the last ingested state of a game that events are diffed against
+type gameState struct {
+	status              string
+	period              *int
+	periodTimeRemaining *int
+	homeTeamPoints      *int
+	awayTeamPoints      *int
+	// lastActionNumber is nil until the game's play by play has been seen, the plays from then are the baseline and
+	// only newer ones are sent as events
+	lastActionNumber *int
+	final            bool
+}
+
+func newGameState(g api.Game) *gameState {
+	state := &gameState{
+		status:              g.Status,
+		period:              g.Period,
+		periodTimeRemaining: g.PeriodTimeRemaining,
+		homeTeamPoints:      g.HomeTeamPoints,
+		awayTeamPoints:      g.AwayTeamPoints,
+		final:               g.Status == gameStatusCompleted,
+	}
+	if g.Status == gameStatusScheduled {
+		// nothing has happened yet so every play is new
+		noActions := 0
+		state.lastActionNumber = &noActions
+	}
+	return state
+}
+
+// Subscription is a stream of a game's events
+type Subscription struct {
+	// Snapshot is the game's current state and comes before Events when the stream isn't resuming from a last event id
+	Snapshot *api.LiveGameEvent
+	// Events is closed after the final event or when the subscriber falls too far behind, check Err to tell them apart
+	Events <-chan api.LiveGameEvent
+
+	subscription *pubsub.Subscription[api.LiveGameEvent]
+}
+
+func (s *Subscription) Close() {
+	s.subscription.Unsubscribe()
+}
+
+// Err returns pubsub.ErrSlowSubscriber if Events was closed because the subscriber fell behind, it can reconnect with
+// the id of the last event it received to pick up where it left off
+func (s *Subscription) Err() error {
+	return s.subscription.Err()
+}
+
+// PublishGameUpdates diffs freshly ingested games against their last known state and publishes the changes. it never
+// blocks on subscribers so it is safe to call from the ingest path
+func (s *service) PublishGameUpdates(ctx context.Context, games []api.Game, playByPlays []api.PlayByPlay) {
+	ctx, span := otel.Tracer("live").Start(ctx, "live.service.PublishGameUpdates")
+	defer span.End()
+
+	playByPlaysByGameID := map[string][]api.PlayByPlay{}
+	for _, playByPlay := range playByPlays {
+		playByPlaysByGameID[playByPlay.GameID] = append(playByPlaysByGameID[playByPlay.GameID], playByPlay)
+	}
+
+	now := time.Now().UTC()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, g := range games {
+		plays := playByPlaysByGameID[g.ID]
+		slices.SortFunc(plays, func(a, b api.PlayByPlay) int { return a.ActionNumber - b.ActionNumber })
+
+		state, ok := s.games[g.ID]
+		if !ok {
+			// completed games are only tracked once something is watching them, ex. stat corrections shouldn't keep
+			// every recent game in memory
+			if g.Status == gameStatusCompleted {
+				continue
+			}
+			state = newGameState(g)
+			s.games[g.ID] = state
+		}
+
+		s.publishGameUpdate(g, state, plays, now)
+	}
+}
+
+func (s *service) publishGameUpdate(g api.Game, state *gameState, plays []api.PlayByPlay, now time.Time) {
+	completed := g.Status == gameStatusCompleted
+
+	if g.Status != state.status && !completed {
+		s.publish(g.ID, EventTypeStatus, api.LiveGameStatus{Status: g.Status, PreviousStatus: state.status}, now)
+	}
+
+	if g.Period != nil && !equalInts(g.Period, state.period) {
+		s.publish(g.ID, EventTypePeriod, api.LiveGamePeriod{Period: *g.Period, PreviousPeriod: state.period}, now)
+	}
+
+	if len(plays) > 0 {
+		if state.lastActionNumber == nil {
+			state.lastActionNumber = &plays[len(plays)-1].ActionNumber
+		}
+		lastActionNumber := *state.lastActionNumber
+		for _, play := range plays {
+			if play.ActionNumber <= lastActionNumber {
+				continue
+			}
+			s.publish(g.ID, EventTypePlay, play, now)
+			lastActionNumber = play.ActionNumber
+		}
+		state.lastActionNumber = &lastActionNumber
+	}
+
+	if g.HomeTeamPoints != nil && g.AwayTeamPoints != nil && (!equalInts(g.HomeTeamPoints, state.homeTeamPoints) || !equalInts(g.AwayTeamPoints, state.awayTeamPoints)) {
+		s.publish(g.ID, EventTypeScore, api.LiveGameScore{HomeTeamPoints: *g.HomeTeamPoints, AwayTeamPoints: *g.AwayTeamPoints}, now)
+	}
+
+	state.status = g.Status
+	state.period = g.Period
+	state.periodTimeRemaining = g.PeriodTimeRemaining
+	state.homeTeamPoints = g.HomeTeamPoints
+	state.awayTeamPoints = g.AwayTeamPoints
+
+	if completed && !state.final {
+		s.publish(g.ID, EventTypeFinal, api.LiveGameFinal{HomeTeamPoints: g.HomeTeamPoints, AwayTeamPoints: g.AwayTeamPoints, EndTime: g.EndTime}, now)
+		state.final = true
+		s.finish(g.ID)
+	}
+}
+
+func (s *service) publish(gameID string, eventType string, data any, now time.Time) {
+	s.broker.Publish(gameID, func(seq uint64) api.LiveGameEvent {
+		return api.LiveGameEvent{ID: s.eventID(seq), Type: eventType, GameID: gameID, Time: now, Data: data}
+	})
+}
+
+// finish ends the game's streams and forgets it after completedGameRetention
+func (s *service) finish(gameID string) {
+	s.broker.Close(gameID)
+
+	time.AfterFunc(completedGameRetention, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if state, ok := s.games[gameID]; ok && state.final {
+			delete(s.games, gameID)
+			s.broker.Remove(gameID)
+		}
+	})
+}
+
+// Subscribe streams the game's events. a stream resumes after lastEventID when its events are still buffered, otherwise
+// it starts with a snapshot of the game
+func (s *service) Subscribe(ctx context.Context, gameID string, lastEventID string) (*Subscription, error) {
+	ctx, span := otel.Tracer("live").Start(ctx, "live.service.Subscribe")
+	defer span.End()
+
+	s.mu.Lock()
+	_, tracked := s.games[gameID]
+	s.mu.Unlock()
+
+	var g api.Game
+	if !tracked {
+		var err error
+		g, err = s.liveStore.GetGameWithID(ctx, gameID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, util.NewError(util.ErrNotFound, "game not found")
+			}
+			return nil, fmt.Errorf("failed to get game to subscribe to: %w", err)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the game may have been ingested while it was being looked up
+	state, ok := s.games[gameID]
+	if !ok {
+		state = newGameState(g)
+		s.games[gameID] = state
+		if state.final {
+			s.finish(gameID)
+		}
+	}
+
+	after, ok := s.parseEventID(lastEventID)
+	var afterSeq *uint64
+	if ok {
+		afterSeq = &after
+	}
+
+	subscription, resumed := s.broker.Subscribe(gameID, afterSeq)
+	if resumed && state.final && len(subscription.C) == 0 {
+		subscription.Unsubscribe()
+		return nil, ErrGameFinished
+	}
+
+	sub := &Subscription{Events: subscription.C, subscription: subscription}
+	if !resumed {
+		sub.Snapshot = &api.LiveGameEvent{
+			ID:     s.eventID(s.broker.Seq(gameID)),
+			Type:   EventTypeState,
+			GameID: gameID,
+			Time:   time.Now().UTC(),
+			Data: api.LiveGameState{
+				Status:              state.status,
+				Period:              state.period,
+				PeriodTimeRemaining: state.periodTimeRemaining,
+				HomeTeamPoints:      state.homeTeamPoints,
+				AwayTeamPoints:      state.awayTeamPoints,
+			},
+		}
+	}
+
+	return sub, nil
+}
+
+func (s *service) eventID(seq uint64) string {
+	return fmt.Sprintf("%s-%d", s.epoch, seq)
+}
+
+// parseEventID returns the sequence number of an event id from this process
+func (s *service) parseEventID(eventID string) (uint64, bool) {
+	epoch, seqStr, ok := strings.Cut(eventID, "-")
+	if !ok || epoch != s.epoch {
+		return 0, false
+	}
+
+	seq, err := strconv.ParseUint(seqStr, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return seq, true
+}
+
+func equalInts(a *int, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,64 @@
+package live
+
+import (
+	"context"
+	"testing"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+)
+
+type fakeStore struct {
+	games map[string]api.Game
+}
+
+func (f fakeStore) GetGameWithID(ctx context.Context, id string) (api.Game, error) {
+	return f.games[id], nil
+}
+
+func TestSubscribeResume(t *testing.T) {
+	ctx := context.Background()
+	s := NewService(fakeStore{}).(*service)
+
+	points := func(n int) *int { return &n }
+	s.PublishGameUpdates(ctx, []api.Game{{ID: "1", Status: "started", HomeTeamPoints: points(0), AwayTeamPoints: points(0)}}, nil)
+	s.PublishGameUpdates(ctx, []api.Game{{ID: "1", Status: "started", HomeTeamPoints: points(2), AwayTeamPoints: points(0)}}, nil)
+
+	tests := []struct {
+		name         string
+		lastEventID  string
+		wantSnapshot bool
+		wantEvents   int
+	}{
+		{name: "no last event id", lastEventID: "", wantSnapshot: true},
+		{name: "resume", lastEventID: s.eventID(0), wantEvents: 1},
+		{name: "caught up", lastEventID: s.eventID(1)},
+		// the events of an id from before a restart are gone even though its sequence number is buffered
+		{name: "stale epoch", lastEventID: "1-0", wantSnapshot: true},
+		{name: "malformed", lastEventID: s.epoch + "-x", wantSnapshot: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, err := s.Subscribe(ctx, "1", tt.lastEventID)
+			if err != nil {
+				t.Fatalf("failed to subscribe: %v", err)
+			}
+			defer sub.Close()
+
+			if got := sub.Snapshot != nil; got != tt.wantSnapshot {
+				t.Errorf("got snapshot %t, want %t", got, tt.wantSnapshot)
+			}
+			if sub.Snapshot != nil {
+				if sub.Snapshot.ID != s.eventID(1) {
+					t.Errorf("got snapshot id %s, want %s", sub.Snapshot.ID, s.eventID(1))
+				}
+				if state := sub.Snapshot.Data.(api.LiveGameState); *state.HomeTeamPoints != 2 {
+					t.Errorf("got snapshot home points %d, want 2", *state.HomeTeamPoints)
+				}
+			}
+			if got := len(sub.Events); got != tt.wantEvents {
+				t.Errorf("got %d events, want %d", got, tt.wantEvents)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package live
+
+import (
+	"context"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+)
+
+type Store interface {
+	GetGameWithID(ctx context.Context, id string) (api.Game, error)
+}
@@ -0,0 +1,192 @@
+package milestone
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+const (
+	MilestoneTypeFranchiseTotal              = "franchise_total"
+	MilestoneTypeCareerTotal                 = "career_total"
+	MilestoneTypeSeasonHigh                  = "season_high"
+	MilestoneTypeTripleDouble                = "triple_double"
+	MilestoneTypeFranchiseRecord             = "franchise_record"
+	MilestoneTypeTeamFranchiseRecord         = "team_franchise_record"
+	StatPoints                               = "points"
+	StatRebounds                             = "rebounds"
+	StatAssists                              = "assists"
+	StatSteals                               = "steals"
+	StatBlocks                               = "blocks"
+	StatTripleDouble                         = "triple_double"
+	minSeasonGamesForSeasonHigh              = 10
+	minFranchisePlayerLinesForRecord         = 800 // roughly a season of player lines so records aren't set by the first games ingested
+	minFranchiseTeamGamesForRecord           = 82
+	doubleFigures                            = 10
+	categoriesInDoubleFiguresTripleDouble    = 3
+	categoriesInDoubleFiguresQuadrupleDouble = 4
+)
+
+type statThreshold struct {
+	stat string
+	// every multiple of step is a milestone ex. every 1,000 points
+	franchiseStep int
+	careerStep    int
+	value         func(StatLine) int
+}
+
+var trackedStats = []statThreshold{
+	{stat: StatPoints, franchiseStep: 1000, careerStep: 5000, value: func(s StatLine) int { return s.Points }},
+	{stat: StatRebounds, franchiseStep: 1000, careerStep: 2500, value: func(s StatLine) int { return s.Rebounds }},
+	{stat: StatAssists, franchiseStep: 500, careerStep: 2500, value: func(s StatLine) int { return s.Assists }},
+}
+
+// detectedMilestone is a milestone along with the number of points the player needed in the game to reach it so the
+// play it happened on can be looked up
+type detectedMilestone struct {
+	MilestoneUpdate
+	pointsNeeded int
+}
+
+// crossedThreshold returns the highest multiple of step passed going from before to before+added
+func crossedThreshold(before int, added int, step int) (int, bool) {
+	if step <= 0 || added <= 0 {
+		return 0, false
+	}
+
+	threshold := ((before + added) / step) * step
+	if threshold <= before || threshold == 0 {
+		return 0, false
+	}
+
+	return threshold, true
+}
+
+func detectPlayerMilestones(line PlayerGameLineHistory) []detectedMilestone {
+	var milestones []detectedMilestone
+
+	newMilestone := func(milestoneType string, stat string, value int, previousValue sql.NullInt64, description string) detectedMilestone {
+		return detectedMilestone{
+			MilestoneUpdate: MilestoneUpdate{
+				GameID:        line.GameID,
+				TeamID:        sql.NullString{String: line.TeamID, Valid: true},
+				PlayerID:      sql.NullString{String: line.PlayerID, Valid: true},
+				Type:          milestoneType,
+				Stat:          stat,
+				Value:         value,
+				PreviousValue: previousValue,
+				Description:   description,
+			},
+		}
+	}
+
+	for _, tracked := range trackedStats {
+		gameValue := tracked.value(line.Line)
+
+		franchiseTotal := tracked.value(line.FranchiseTotals)
+		if threshold, ok := crossedThreshold(franchiseTotal, gameValue, tracked.franchiseStep); ok {
+			m := newMilestone(MilestoneTypeFranchiseTotal, tracked.stat, threshold, sql.NullInt64{Int64: int64(franchiseTotal), Valid: true},
+				fmt.Sprintf("%s reached %s %s for the %s franchise", line.PlayerName, formatNumber(threshold), tracked.stat, line.TeamName))
+			if tracked.stat == StatPoints {
+				m.pointsNeeded = threshold - franchiseTotal
+			}
+			milestones = append(milestones, m)
+		}
+
+		careerTotal := tracked.value(line.CareerTotals)
+		if threshold, ok := crossedThreshold(careerTotal, gameValue, tracked.careerStep); ok {
+			m := newMilestone(MilestoneTypeCareerTotal, tracked.stat, threshold, sql.NullInt64{Int64: int64(careerTotal), Valid: true},
+				fmt.Sprintf("%s reached %s career %s", line.PlayerName, formatNumber(threshold), tracked.stat))
+			if tracked.stat == StatPoints {
+				m.pointsNeeded = threshold - careerTotal
+			}
+			milestones = append(milestones, m)
+		}
+
+		seasonHigh := tracked.value(line.SeasonHighs)
+		if line.SeasonGames >= minSeasonGamesForSeasonHigh && gameValue > seasonHigh {
+			m := newMilestone(MilestoneTypeSeasonHigh, tracked.stat, gameValue, sql.NullInt64{Int64: int64(seasonHigh), Valid: true},
+				fmt.Sprintf("%s set a season high with %d %s", line.PlayerName, gameValue, tracked.stat))
+			if tracked.stat == StatPoints {
+				m.pointsNeeded = seasonHigh + 1
+			}
+			milestones = append(milestones, m)
+		}
+
+		franchiseRecord := tracked.value(line.FranchiseRecords)
+		if line.FranchiseRecordGames >= minFranchisePlayerLinesForRecord && gameValue > franchiseRecord {
+			m := newMilestone(MilestoneTypeFranchiseRecord, tracked.stat, gameValue, sql.NullInt64{Int64: int64(franchiseRecord), Valid: true},
+				fmt.Sprintf("%s set the %s single game record with %d %s", line.PlayerName, line.TeamName, gameValue, tracked.stat))
+			if tracked.stat == StatPoints {
+				m.pointsNeeded = franchiseRecord + 1
+			}
+			milestones = append(milestones, m)
+		}
+	}
+
+	var doubleFigureStats []string
+	for _, stat := range []struct {
+		name  string
+		value int
+	}{
+		{StatPoints, line.Line.Points},
+		{StatRebounds, line.Line.Rebounds},
+		{StatAssists, line.Line.Assists},
+		{StatSteals, line.Line.Steals},
+		{StatBlocks, line.Line.Blocks},
+	} {
+		if stat.value >= doubleFigures {
+			doubleFigureStats = append(doubleFigureStats, fmt.Sprintf("%d %s", stat.value, stat.name))
+		}
+	}
+
+	if len(doubleFigureStats) >= categoriesInDoubleFiguresTripleDouble {
+		kind := "triple-double"
+		if len(doubleFigureStats) >= categoriesInDoubleFiguresQuadrupleDouble {
+			kind = "quadruple-double"
+		}
+		milestones = append(milestones, newMilestone(MilestoneTypeTripleDouble, StatTripleDouble, len(doubleFigureStats), sql.NullInt64{},
+			fmt.Sprintf("%s had a %s with %s", line.PlayerName, kind, strings.Join(doubleFigureStats, ", "))))
+	}
+
+	return milestones
+}
+
+func detectTeamMilestones(line TeamGameLineHistory) []detectedMilestone {
+	var milestones []detectedMilestone
+
+	if line.FranchiseRecordGames < minFranchiseTeamGamesForRecord {
+		return nil
+	}
+
+	for _, tracked := range trackedStats {
+		gameValue := tracked.value(line.Line)
+		franchiseRecord := tracked.value(line.FranchiseRecords)
+		if gameValue <= franchiseRecord {
+			continue
+		}
+
+		milestones = append(milestones, detectedMilestone{
+			MilestoneUpdate: MilestoneUpdate{
+				GameID:        line.GameID,
+				TeamID:        sql.NullString{String: line.TeamID, Valid: true},
+				Type:          MilestoneTypeTeamFranchiseRecord,
+				Stat:          tracked.stat,
+				Value:         gameValue,
+				PreviousValue: sql.NullInt64{Int64: int64(franchiseRecord), Valid: true},
+				Description:   fmt.Sprintf("the %s set a franchise single game record with %d %s", line.TeamName, gameValue, tracked.stat),
+			},
+		})
+	}
+
+	return milestones
+}
+
+// formatNumber adds thousands separators ex. 10000 -> 10,000
+func formatNumber(n int) string {
+	s := fmt.Sprintf("%d", n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
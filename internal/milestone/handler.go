@@ -0,0 +1,71 @@
+package milestone
+
+import (
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"go.opentelemetry.io/otel"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler interface {
+	Routes() chi.Router
+	List(w http.ResponseWriter, r *http.Request)
+}
+
+func NewHandler(logger *slog.Logger, milestoneService Service) Handler {
+	return &handler{logger: logger, milestoneService: milestoneService}
+}
+
+type handler struct {
+	logger           *slog.Logger
+	milestoneService Service
+}
+
+func (h *handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.List)
+
+	return r
+}
+
+func (h *handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("milestone").Start(r.Context(), "milestone.handler.List")
+	defer span.End()
+
+	filter := ListFilter{}
+	if gameID := r.URL.Query().Get("game-id"); gameID != "" {
+		filter.GameID = sql.NullString{String: gameID, Valid: true}
+	}
+	if teamID := r.URL.Query().Get("team-id"); teamID != "" {
+		filter.TeamID = sql.NullString{String: teamID, Valid: true}
+	}
+	if playerID := r.URL.Query().Get("player-id"); playerID != "" {
+		filter.PlayerID = sql.NullString{String: playerID, Valid: true}
+	}
+	if milestoneType := r.URL.Query().Get("type"); milestoneType != "" {
+		filter.Type = sql.NullString{String: milestoneType, Valid: true}
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			util.WriteProblem(w, r, http.StatusBadRequest, "invalid limit, expected a positive number")
+			return
+		}
+		filter.Limit = limit
+	}
+
+	milestones, err := h.milestoneService.ListMilestones(ctx, filter)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list milestones", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteCacheableListJSON(w, r, milestones, util.CacheControlRevalidate)
+}
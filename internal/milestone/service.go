@@ -0,0 +1,97 @@
+package milestone
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	defaultListLimit = 100
+	maxListLimit     = 500
+)
+
+type Service interface {
+	DetectMilestones(ctx context.Context, logger *slog.Logger, gameIDs []string) ([]api.Milestone, error)
+	ListMilestones(ctx context.Context, filter ListFilter) ([]api.Milestone, error)
+}
+
+func NewService(milestoneStore Store) Service {
+	return &service{milestoneStore: milestoneStore}
+}
+
+type service struct {
+	milestoneStore Store
+}
+
+// DetectMilestones checks the lines from completed games against the history before each game and returns the milestones
+// that had not already been recorded
+func (s *service) DetectMilestones(ctx context.Context, logger *slog.Logger, gameIDs []string) ([]api.Milestone, error) {
+	ctx, span := otel.Tracer("milestone").Start(ctx, "milestone.service.DetectMilestones")
+	defer span.End()
+
+	if len(gameIDs) == 0 {
+		return []api.Milestone{}, nil
+	}
+
+	playerLines, err := s.milestoneStore.GetPlayerGameLineHistories(ctx, gameIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player game line histories: %w", err)
+	}
+
+	teamLines, err := s.milestoneStore.GetTeamGameLineHistories(ctx, gameIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team game line histories: %w", err)
+	}
+
+	var detectedMilestones []detectedMilestone
+	for _, playerLine := range playerLines {
+		detectedMilestones = append(detectedMilestones, detectPlayerMilestones(playerLine)...)
+	}
+	for _, teamLine := range teamLines {
+		detectedMilestones = append(detectedMilestones, detectTeamMilestones(teamLine)...)
+	}
+
+	milestoneUpdates := make([]MilestoneUpdate, 0, len(detectedMilestones))
+	for _, detected := range detectedMilestones {
+		milestoneUpdate := detected.MilestoneUpdate
+		if detected.pointsNeeded > 0 {
+			playByPlayID, err := s.milestoneStore.GetPlayerPointsPlayByPlayID(ctx, milestoneUpdate.GameID, milestoneUpdate.PlayerID.String, detected.pointsNeeded)
+			if err != nil {
+				// the milestone still happened even if the play can't be found
+				logger.WarnContext(ctx, "failed to get play milestone happened on", slog.String("game_id", milestoneUpdate.GameID), slog.String("player_id", milestoneUpdate.PlayerID.String), slog.Any("error", err))
+			} else {
+				milestoneUpdate.PlayByPlayID = playByPlayID
+			}
+		}
+		milestoneUpdates = append(milestoneUpdates, milestoneUpdate)
+	}
+
+	if len(milestoneUpdates) == 0 {
+		return []api.Milestone{}, nil
+	}
+
+	milestones, err := s.milestoneStore.UpdateMilestones(ctx, milestoneUpdates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update milestones: %w", err)
+	}
+
+	return milestones, nil
+}
+
+func (s *service) ListMilestones(ctx context.Context, filter ListFilter) ([]api.Milestone, error) {
+	ctx, span := otel.Tracer("milestone").Start(ctx, "milestone.service.ListMilestones")
+	defer span.End()
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+
+	return s.milestoneStore.ListMilestones(ctx, filter)
+}
@@ -0,0 +1,74 @@
+package milestone
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+)
+
+type Store interface {
+	GetPlayerGameLineHistories(ctx context.Context, gameIDs []string) ([]PlayerGameLineHistory, error)
+	GetTeamGameLineHistories(ctx context.Context, gameIDs []string) ([]TeamGameLineHistory, error)
+	GetPlayerPointsPlayByPlayID(ctx context.Context, gameID string, playerID string, points int) (sql.NullString, error)
+	UpdateMilestones(ctx context.Context, milestoneUpdates []MilestoneUpdate) ([]api.Milestone, error)
+	ListMilestones(ctx context.Context, filter ListFilter) ([]api.Milestone, error)
+}
+
+type ListFilter struct {
+	GameID   sql.NullString
+	TeamID   sql.NullString
+	PlayerID sql.NullString
+	Type     sql.NullString
+	Limit    int
+}
+
+// StatLine is the counting stats milestones are tracked for
+type StatLine struct {
+	Points   int
+	Rebounds int
+	Assists  int
+	Steals   int
+	Blocks   int
+}
+
+// PlayerGameLineHistory is a player's line in a completed game along with their history from before the game
+type PlayerGameLineHistory struct {
+	GameID          string
+	TeamID          string
+	PlayerID        string
+	PlayerName      string
+	TeamName        string
+	SeasonStartYear int
+	Line            StatLine
+	// FranchiseTotals are the player's totals for the team's franchise before the game
+	FranchiseTotals StatLine
+	CareerTotals    StatLine
+	SeasonGames     int
+	SeasonHighs     StatLine
+	// FranchiseRecordGames is the number of lines any player has for the franchise before the game
+	FranchiseRecordGames int
+	FranchiseRecords     StatLine
+}
+
+// TeamGameLineHistory is a team's line in a completed game along with the franchise's single game records from before the game
+type TeamGameLineHistory struct {
+	GameID               string
+	TeamID               string
+	TeamName             string
+	Line                 StatLine
+	FranchiseRecordGames int
+	FranchiseRecords     StatLine
+}
+
+type MilestoneUpdate struct {
+	GameID        string
+	TeamID        sql.NullString
+	PlayerID      sql.NullString
+	PlayByPlayID  sql.NullString
+	Type          string
+	Stat          string
+	Value         int
+	PreviousValue sql.NullInt64
+	Description   string
+}
@@ -0,0 +1,195 @@
+package notification
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	ChannelTypeDiscord = "discord"
+	ChannelTypeSlack   = "slack"
+)
+
+// DefaultScoreUpdateInterval keeps score updates to a few per quarter
+const DefaultScoreUpdateInterval = 5 * time.Minute
+
+const quietHoursLayout = "15:04"
+
+var ErrInvalidChannel = errors.New("invalid notification channel")
+
+// formatter builds the webhook request body for a channel type
+type formatter interface {
+	Format(n Notification) ([]byte, error)
+}
+
+// ChannelConfig is how a channel is configured in the NOTIFICATION_CHANNELS_FILE json array
+type ChannelConfig struct {
+	Name string `json:"name"`
+	// Type is discord or slack
+	Type       string `json:"type"`
+	WebhookURL string `json:"webhook_url"`
+	// Teams are nba tricodes ex. MIN, only games involving one of them are sent. empty sends every game
+	Teams []string `json:"teams"`
+	// Kinds are the notifications to send ex. score_update, empty sends all of them
+	Kinds []string `json:"kinds"`
+	// ScoreUpdateInterval is the least time between score updates for a game ex. 10m
+	ScoreUpdateInterval string `json:"score_update_interval"`
+	// QuietHours pauses the channel every day, ex. overnight when a west coast game ends
+	QuietHours *QuietHoursConfig `json:"quiet_hours"`
+}
+
+type QuietHoursConfig struct {
+	// Start and End are clock times ex. 23:00, the quiet hours wrap past midnight when End is before Start
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	TimeZone string `json:"time_zone"`
+}
+
+// Channel is a discord or slack webhook notifications are posted to
+type Channel struct {
+	Name                string
+	webhookURL          string
+	formatter           formatter
+	teams               []string
+	kinds               []string
+	scoreUpdateInterval time.Duration
+	quietHours          *quietHours
+}
+
+type quietHours struct {
+	start    time.Duration
+	end      time.Duration
+	location *time.Location
+}
+
+// LoadChannels reads the channel configs from a json file
+func LoadChannels(path string) ([]Channel, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notification channels file: %w", err)
+	}
+
+	channelConfigs := []ChannelConfig{}
+	if err := json.Unmarshal(data, &channelConfigs); err != nil {
+		return nil, fmt.Errorf("failed to parse notification channels file: %w", err)
+	}
+
+	channels := make([]Channel, 0, len(channelConfigs))
+	for _, channelConfig := range channelConfigs {
+		channel, err := NewChannel(channelConfig)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, channel)
+	}
+
+	return channels, nil
+}
+
+func NewChannel(channelConfig ChannelConfig) (Channel, error) {
+	channel := Channel{
+		Name:                channelConfig.Name,
+		webhookURL:          channelConfig.WebhookURL,
+		scoreUpdateInterval: DefaultScoreUpdateInterval,
+	}
+
+	if channel.Name == "" {
+		return Channel{}, fmt.Errorf("%w: name is required", ErrInvalidChannel)
+	}
+
+	if !strings.HasPrefix(channel.webhookURL, "https://") {
+		return Channel{}, fmt.Errorf("%w: %s webhook_url must be an https url", ErrInvalidChannel, channel.Name)
+	}
+
+	switch channelConfig.Type {
+	case ChannelTypeDiscord:
+		channel.formatter = discordFormatter{}
+	case ChannelTypeSlack:
+		channel.formatter = slackFormatter{}
+	default:
+		return Channel{}, fmt.Errorf("%w: %s type must be discord or slack", ErrInvalidChannel, channel.Name)
+	}
+
+	for _, team := range channelConfig.Teams {
+		channel.teams = append(channel.teams, strings.ToUpper(team))
+	}
+
+	for _, kind := range channelConfig.Kinds {
+		if !slices.Contains(Kinds, kind) {
+			return Channel{}, fmt.Errorf("%w: %s has unknown kind %s", ErrInvalidChannel, channel.Name, kind)
+		}
+	}
+	channel.kinds = channelConfig.Kinds
+
+	if channelConfig.ScoreUpdateInterval != "" {
+		interval, err := time.ParseDuration(channelConfig.ScoreUpdateInterval)
+		if err != nil {
+			return Channel{}, fmt.Errorf("%w: %s score_update_interval must be a duration ex. 5m", ErrInvalidChannel, channel.Name)
+		}
+		channel.scoreUpdateInterval = interval
+	}
+
+	if channelConfig.QuietHours != nil {
+		quietHours, err := newQuietHours(*channelConfig.QuietHours)
+		if err != nil {
+			return Channel{}, fmt.Errorf("%w: %s quiet_hours %w", ErrInvalidChannel, channel.Name, err)
+		}
+		channel.quietHours = &quietHours
+	}
+
+	return channel, nil
+}
+
+func newQuietHours(quietHoursConfig QuietHoursConfig) (quietHours, error) {
+	start, err := time.Parse(quietHoursLayout, quietHoursConfig.Start)
+	if err != nil {
+		return quietHours{}, errors.New("start must be a time ex. 23:00")
+	}
+
+	end, err := time.Parse(quietHoursLayout, quietHoursConfig.End)
+	if err != nil {
+		return quietHours{}, errors.New("end must be a time ex. 08:00")
+	}
+
+	location := time.UTC
+	if quietHoursConfig.TimeZone != "" {
+		location, err = time.LoadLocation(quietHoursConfig.TimeZone)
+		if err != nil {
+			return quietHours{}, errors.New("time_zone must be an iana time zone ex. America/Chicago")
+		}
+	}
+
+	return quietHours{
+		start:    time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute,
+		end:      time.Duration(end.Hour())*time.Hour + time.Duration(end.Minute())*time.Minute,
+		location: location,
+	}, nil
+}
+
+func (q quietHours) contains(t time.Time) bool {
+	local := t.In(q.location)
+	clock := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute
+
+	if q.start <= q.end {
+		return clock >= q.start && clock < q.end
+	}
+	return clock >= q.start || clock < q.end
+}
+
+// wants reports whether the channel sends the kind of notification for a game between the teams at the time
+func (c Channel) wants(kind string, homeTricode string, awayTricode string, t time.Time) bool {
+	if len(c.kinds) > 0 && !slices.Contains(c.kinds, kind) {
+		return false
+	}
+
+	if len(c.teams) > 0 && !slices.Contains(c.teams, homeTricode) && !slices.Contains(c.teams, awayTricode) {
+		return false
+	}
+
+	return c.quietHours == nil || !c.quietHours.contains(t)
+}
@@ -0,0 +1,72 @@
+package notification
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// discord embed colors for each kind of notification
+var discordColors = map[string]int{
+	KindScoreUpdate: 0x236192,
+	KindPeriodEnd:   0x78BE20,
+	KindFinal:       0x0C2340,
+}
+
+type discordMessage struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Color       int                 `json:"color"`
+	Timestamp   string              `json:"timestamp"`
+	Author      discordEmbedAuthor  `json:"author"`
+	Thumbnail   discordEmbedImage   `json:"thumbnail"`
+	Fields      []discordEmbedField `json:"fields"`
+	Footer      discordEmbedFooter  `json:"footer"`
+}
+
+type discordEmbedAuthor struct {
+	Name    string `json:"name"`
+	IconURL string `json:"icon_url"`
+}
+
+type discordEmbedImage struct {
+	URL string `json:"url"`
+}
+
+type discordEmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+type discordEmbedFooter struct {
+	Text string `json:"text"`
+}
+
+// discordFormatter builds a discord webhook message with an embed showing the away team's logo next to the title and
+// the home team's as the thumbnail
+type discordFormatter struct{}
+
+func (discordFormatter) Format(n Notification) ([]byte, error) {
+	return json.Marshal(discordMessage{
+		Embeds: []discordEmbed{
+			{
+				Title:       n.Title(),
+				Description: n.Summary(),
+				Color:       discordColors[n.Kind],
+				Timestamp:   n.Time.UTC().Format(time.RFC3339),
+				Author:      discordEmbedAuthor{Name: n.Away.Name, IconURL: n.Away.LogoURL()},
+				Thumbnail:   discordEmbedImage{URL: n.Home.LogoURL()},
+				Fields: []discordEmbedField{
+					{Name: n.Away.Name, Value: strconv.Itoa(n.Away.Points), Inline: true},
+					{Name: n.Home.Name, Value: strconv.Itoa(n.Home.Points), Inline: true},
+				},
+				Footer: discordEmbedFooter{Text: n.Status()},
+			},
+		},
+	})
+}
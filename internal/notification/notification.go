@@ -0,0 +1,121 @@
+package notification
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/apis/nba"
+)
+
+const (
+	KindScoreUpdate = "score_update"
+	KindPeriodEnd   = "period_end"
+	KindFinal       = "final"
+)
+
+var Kinds = []string{KindScoreUpdate, KindPeriodEnd, KindFinal}
+
+// regulationPeriods is the number of quarters before overtime
+const regulationPeriods = 4
+
+// Notification is a game update formatted for a channel
+type Notification struct {
+	Kind   string
+	GameID string
+	Home   TeamScore
+	Away   TeamScore
+	Period int
+	// PeriodTimeRemaining is in tenths of a second
+	PeriodTimeRemaining *int
+	Time                time.Time
+}
+
+type TeamScore struct {
+	Name      string
+	Tricode   string
+	NBATeamID int
+	Points    int
+}
+
+func (t TeamScore) LogoURL() string {
+	return fmt.Sprintf(nba.TeamLogoURL, t.NBATeamID)
+}
+
+// Title is the score with the away team first ex. MIN 54 - 50 DEN
+func (n Notification) Title() string {
+	return fmt.Sprintf("%s %d - %d %s", n.Away.Tricode, n.Away.Points, n.Home.Points, n.Home.Tricode)
+}
+
+// Status describes where the game is ex. Q3 4:12, End of 2nd quarter or Final/OT
+func (n Notification) Status() string {
+	switch n.Kind {
+	case KindFinal:
+		if n.Period > regulationPeriods {
+			return "Final/" + periodName(n.Period)
+		}
+		return "Final"
+	case KindPeriodEnd:
+		if n.Period == regulationPeriods/2 {
+			return "Halftime"
+		}
+		if n.Period > regulationPeriods {
+			return "End of " + periodName(n.Period)
+		}
+		return fmt.Sprintf("End of %s quarter", ordinal(n.Period))
+	default:
+		status := periodName(n.Period)
+		if n.PeriodTimeRemaining != nil {
+			status = fmt.Sprintf("%s %s", status, formatClock(*n.PeriodTimeRemaining))
+		}
+		return status
+	}
+}
+
+// Summary is a sentence about the update ex. Timberwolves lead Nuggets by 4
+func (n Notification) Summary() string {
+	leader, trailer := n.Home, n.Away
+	if n.Away.Points > n.Home.Points {
+		leader, trailer = n.Away, n.Home
+	}
+
+	margin := leader.Points - trailer.Points
+	switch {
+	case margin == 0:
+		return fmt.Sprintf("%s and %s are tied at %d", n.Away.Name, n.Home.Name, n.Home.Points)
+	case n.Kind == KindFinal:
+		return fmt.Sprintf("%s beat %s by %d", leader.Name, trailer.Name, margin)
+	default:
+		return fmt.Sprintf("%s lead %s by %d", leader.Name, trailer.Name, margin)
+	}
+}
+
+func periodName(period int) string {
+	if period <= regulationPeriods {
+		return fmt.Sprintf("Q%d", period)
+	}
+	if period == regulationPeriods+1 {
+		return "OT"
+	}
+	return fmt.Sprintf("%dOT", period-regulationPeriods)
+}
+
+func ordinal(n int) string {
+	switch n {
+	case 1:
+		return "1st"
+	case 2:
+		return "2nd"
+	case 3:
+		return "3rd"
+	default:
+		return fmt.Sprintf("%dth", n)
+	}
+}
+
+func formatClock(tenthSeconds int) string {
+	seconds := tenthSeconds / 10
+	if seconds < 60 {
+		return fmt.Sprintf("%d.%d", seconds, tenthSeconds%10)
+	}
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
@@ -0,0 +1,252 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/internal/team"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const (
+	gameStatusStarted   = "started"
+	gameStatusCompleted = "completed"
+)
+
+const sendTimeout = 10 * time.Second
+
+type Service interface {
+	// NotifyGameUpdate compares a game to its previous update and sends score updates, period ends and finals to the
+	// channels that want them. the first update for a game has no previous game and only records its state
+	NotifyGameUpdate(ctx context.Context, logger *slog.Logger, previous *api.Game, current api.Game)
+}
+
+func NewService(channels []Channel, teamService team.Service, httpClient *http.Client) Service {
+	return &service{
+		channels:    channels,
+		teamService: teamService,
+		httpClient:  httpClient,
+		teams:       map[string]api.Team{},
+		games:       map[string]*gameState{},
+	}
+}
+
+type service struct {
+	channels    []Channel
+	teamService team.Service
+	httpClient  *http.Client
+
+	mu sync.Mutex
+	// teams are cached by id since they rarely change and every update needs both teams
+	teams map[string]api.Team
+	games map[string]*gameState
+}
+
+type gameState struct {
+	// endedPeriod is the last period an end of period notification was sent for
+	endedPeriod int
+	// lastScoreUpdate is when each channel was last sent a score update for the game
+	lastScoreUpdate map[string]time.Time
+}
+
+func (s *service) NotifyGameUpdate(ctx context.Context, logger *slog.Logger, previous *api.Game, current api.Game) {
+	ctx, span := otel.Tracer("notification").Start(ctx, "notification.service.NotifyGameUpdate")
+	defer span.End()
+
+	if len(s.channels) == 0 || previous == nil || current.HomeTeamID == nil || current.AwayTeamID == nil {
+		return
+	}
+
+	logger = logger.With(slog.String("game_id", current.ID))
+
+	kind, period, ok := s.transition(*previous, current)
+	if !ok {
+		return
+	}
+
+	home, err := s.team(ctx, *current.HomeTeamID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get home team for game notification", slog.Any("error", err))
+		return
+	}
+
+	away, err := s.team(ctx, *current.AwayTeamID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get away team for game notification", slog.Any("error", err))
+		return
+	}
+
+	now := time.Now()
+	n := Notification{
+		Kind:                kind,
+		GameID:              current.ID,
+		Home:                teamScore(home, current.HomeTeamPoints),
+		Away:                teamScore(away, current.AwayTeamPoints),
+		Period:              period,
+		PeriodTimeRemaining: current.PeriodTimeRemaining,
+		Time:                now,
+	}
+
+	for _, channel := range s.channels {
+		if !channel.wants(kind, n.Home.Tricode, n.Away.Tricode, now) {
+			continue
+		}
+
+		if kind == KindScoreUpdate && !s.claimScoreUpdate(current.ID, channel, now) {
+			continue
+		}
+
+		if err := s.send(ctx, channel, n); err != nil {
+			logger.ErrorContext(ctx, "failed to send game notification", slog.String("channel", channel.Name), slog.String("kind", kind), slog.Any("error", err))
+		}
+	}
+}
+
+// transition works out which notification an update is, if any, and the period it is for. a game ending is only sent as
+// a final and an update that ends a period isn't also sent as a score update
+func (s *service) transition(previous api.Game, current api.Game) (string, int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if current.Status == gameStatusCompleted {
+		delete(s.games, current.ID)
+		if previous.Status == gameStatusCompleted {
+			return "", 0, false
+		}
+		return KindFinal, valueOrZero(current.Period), true
+	}
+
+	if current.Status != gameStatusStarted {
+		return "", 0, false
+	}
+
+	state, ok := s.games[current.ID]
+	if !ok {
+		state = &gameState{lastScoreUpdate: map[string]time.Time{}}
+		s.games[current.ID] = state
+	}
+
+	previousPeriod, currentPeriod := valueOrZero(previous.Period), valueOrZero(current.Period)
+
+	// the clock hitting zero ends the period, if an update was missed the period advancing ends the previous one. the
+	// clock can read zero before tipoff so it only counts once the game was already started
+	endedPeriod := 0
+	if previous.Status == gameStatusStarted && currentPeriod > 0 && current.PeriodTimeRemaining != nil && *current.PeriodTimeRemaining == 0 {
+		endedPeriod = currentPeriod
+	} else if currentPeriod > previousPeriod && previousPeriod > 0 {
+		endedPeriod = previousPeriod
+	}
+	if endedPeriod > state.endedPeriod {
+		state.endedPeriod = endedPeriod
+		return KindPeriodEnd, endedPeriod, true
+	}
+
+	if valueOrZero(previous.HomeTeamPoints) != valueOrZero(current.HomeTeamPoints) || valueOrZero(previous.AwayTeamPoints) != valueOrZero(current.AwayTeamPoints) {
+		return KindScoreUpdate, currentPeriod, true
+	}
+
+	return "", 0, false
+}
+
+// claimScoreUpdate reports whether the channel's score update interval has passed for the game and if so records the
+// update as sent
+func (s *service) claimScoreUpdate(gameID string, channel Channel, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.games[gameID]
+	if !ok {
+		return false
+	}
+
+	if last, ok := state.lastScoreUpdate[channel.Name]; ok && now.Sub(last) < channel.scoreUpdateInterval {
+		return false
+	}
+
+	state.lastScoreUpdate[channel.Name] = now
+	return true
+}
+
+func (s *service) team(ctx context.Context, teamID string) (api.Team, error) {
+	s.mu.Lock()
+	t, ok := s.teams[teamID]
+	s.mu.Unlock()
+	if ok {
+		return t, nil
+	}
+
+	t, err := s.teamService.Get(ctx, teamID)
+	if err != nil {
+		return api.Team{}, err
+	}
+
+	s.mu.Lock()
+	s.teams[teamID] = t
+	s.mu.Unlock()
+
+	return t, nil
+}
+
+func (s *service) send(ctx context.Context, channel Channel, n Notification) error {
+	ctx, span := otel.Tracer("notification").Start(ctx, "notification.service.send")
+	defer span.End()
+
+	body, err := channel.formatter.Format(n)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to format notification: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, channel.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to create notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to post notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		err = fmt.Errorf("unexpected status %d: %s", resp.StatusCode, respBody)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func teamScore(t api.Team, points *int) TeamScore {
+	score := TeamScore{Name: t.Name, Tricode: t.Name, NBATeamID: t.NBATeamID, Points: valueOrZero(points)}
+	if t.NBAShortName != nil {
+		score.Tricode = *t.NBAShortName
+	}
+	return score
+}
+
+func valueOrZero(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
@@ -0,0 +1,61 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type slackMessage struct {
+	// Text is shown in notifications and by clients that can't render blocks
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type      string      `json:"type"`
+	Text      *slackText  `json:"text,omitempty"`
+	Fields    []slackText `json:"fields,omitempty"`
+	Elements  []slackText `json:"elements,omitempty"`
+	Accessory *slackImage `json:"accessory,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackImage struct {
+	Type     string `json:"type"`
+	ImageURL string `json:"image_url"`
+	AltText  string `json:"alt_text"`
+}
+
+// slackFormatter builds a slack incoming webhook message with a header for the score, a section with each team's
+// points and the home team's logo and a context line with the game status. slack requires omitempty since it rejects
+// null block fields
+type slackFormatter struct{}
+
+func (slackFormatter) Format(n Notification) ([]byte, error) {
+	return json.Marshal(slackMessage{
+		Text: fmt.Sprintf("%s (%s)", n.Title(), n.Status()),
+		Blocks: []slackBlock{
+			{
+				Type: "header",
+				Text: &slackText{Type: "plain_text", Text: n.Title()},
+			},
+			{
+				Type: "section",
+				Text: &slackText{Type: "mrkdwn", Text: n.Summary()},
+				Fields: []slackText{
+					{Type: "mrkdwn", Text: fmt.Sprintf("*%s*\n%d", n.Away.Name, n.Away.Points)},
+					{Type: "mrkdwn", Text: fmt.Sprintf("*%s*\n%d", n.Home.Name, n.Home.Points)},
+				},
+				Accessory: &slackImage{Type: "image", ImageURL: n.Home.LogoURL(), AltText: n.Home.Name},
+			},
+			{
+				Type:     "context",
+				Elements: []slackText{{Type: "mrkdwn", Text: n.Status()}},
+			},
+		},
+	})
+}
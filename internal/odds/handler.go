@@ -0,0 +1,52 @@
+package odds
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"go.opentelemetry.io/otel"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler interface {
+	Routes() chi.Router
+	GetGameOdds(w http.ResponseWriter, r *http.Request)
+}
+
+func NewHandler(logger *slog.Logger, oddsService Service) Handler {
+	return &handler{logger: logger, oddsService: oddsService}
+}
+
+type handler struct {
+	logger      *slog.Logger
+	oddsService Service
+}
+
+// Routes are mounted under a game
+func (h *handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.GetGameOdds)
+
+	return r
+}
+
+func (h *handler) GetGameOdds(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("odds").Start(r.Context(), "odds.handler.GetGameOdds")
+	defer span.End()
+
+	gameID := chi.URLParam(r, "gameID")
+
+	logger := h.logger.With(slog.String("game_id", gameID))
+
+	gameOdds, err := h.oddsService.GetGameOdds(ctx, gameID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get game odds", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteJSON(http.StatusOK, gameOdds, w)
+}
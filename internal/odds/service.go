@@ -0,0 +1,204 @@
+package odds
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/apis/nba"
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	AgainstTheSpreadHomeCovered = "home_covered"
+	AgainstTheSpreadAwayCovered = "away_covered"
+	AgainstTheSpreadPush        = "push"
+)
+
+type Service interface {
+	UpdateTodaysOdds(ctx context.Context, logger *slog.Logger) error
+	GetGameOdds(ctx context.Context, gameID string) (api.GameOdds, error)
+}
+
+func NewService(oddsStore Store, nbaClient nba.Client) Service {
+	return &service{oddsStore: oddsStore, nbaClient: nbaClient}
+}
+
+type service struct {
+	oddsStore Store
+
+	nbaClient nba.Client
+}
+
+func (s *service) UpdateTodaysOdds(ctx context.Context, logger *slog.Logger) error {
+	ctx, span := otel.Tracer("odds").Start(ctx, "odds.service.UpdateTodaysOdds")
+	defer span.End()
+
+	capturedAt := time.Now().UTC()
+	objectKey := fmt.Sprintf("odds/%s_cdn.json", capturedAt.Truncate(time.Minute).Format(time.RFC3339))
+
+	todaysGamesOdds, err := s.nbaClient.GetTodaysGamesOdds(ctx, objectKey)
+	if err != nil {
+		return fmt.Errorf("failed to get todays games odds: %w", err)
+	}
+
+	gameOddsUpdates := gameOddsUpdates(todaysGamesOdds, capturedAt)
+
+	changed, err := s.oddsStore.UpdateGameOdds(ctx, gameOddsUpdates)
+	if err != nil {
+		return fmt.Errorf("failed to update game odds: %w", err)
+	}
+
+	logger.InfoContext(ctx, "stored changed game odds", slog.Int("changed", changed), slog.Int("updates", len(gameOddsUpdates)), slog.Int("games", len(todaysGamesOdds.Games)))
+
+	return nil
+}
+
+func gameOddsUpdates(todaysGamesOdds nba.TodaysGamesOdds, capturedAt time.Time) []GameOddsUpdate {
+	var gameOddsUpdates []GameOddsUpdate
+
+	for _, gameOdds := range todaysGamesOdds.Games {
+		for _, market := range gameOdds.Markets {
+			marketType := market.Type()
+			if marketType == nba.OddsMarketTypeUnknown {
+				continue
+			}
+
+			for _, book := range market.Books {
+				for _, outcome := range book.Outcomes {
+					gameOddsUpdate := GameOddsUpdate{
+						NBAGameID:      gameOdds.GameID,
+						NBABookmakerID: book.ID,
+						Bookmaker:      book.Name,
+						Market:         string(marketType),
+						Outcome:        outcome.Type,
+						Odds:           sql.NullFloat64{Float64: outcome.Odds.Float64, Valid: outcome.Odds.Valid},
+						OpeningOdds:    sql.NullFloat64{Float64: outcome.OpeningOdds.Float64, Valid: outcome.OpeningOdds.Valid},
+						CapturedAt:     capturedAt,
+					}
+					if line, ok := outcome.Line(); ok {
+						gameOddsUpdate.Line = sql.NullFloat64{Float64: line, Valid: true}
+					}
+					if openingLine, ok := outcome.OpeningLine(); ok {
+						gameOddsUpdate.OpeningLine = sql.NullFloat64{Float64: openingLine, Valid: true}
+					}
+
+					gameOddsUpdates = append(gameOddsUpdates, gameOddsUpdate)
+				}
+			}
+		}
+	}
+
+	return gameOddsUpdates
+}
+
+func (s *service) GetGameOdds(ctx context.Context, gameID string) (api.GameOdds, error) {
+	ctx, span := otel.Tracer("odds").Start(ctx, "odds.service.GetGameOdds")
+	defer span.End()
+
+	g, err := s.oddsStore.GetGameWithID(ctx, gameID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.GameOdds{}, util.NewError(util.ErrNotFound, "game not found")
+		}
+		return api.GameOdds{}, fmt.Errorf("failed to get game for odds: %w", err)
+	}
+
+	snapshots, err := s.oddsStore.GetGameOddsSnapshots(ctx, gameID)
+	if err != nil {
+		return api.GameOdds{}, fmt.Errorf("failed to get game odds snapshots: %w", err)
+	}
+
+	lines := linesFromSnapshots(snapshots, g.StartTime)
+
+	return api.GameOdds{
+		GameID:           gameID,
+		Lines:            lines,
+		AgainstTheSpread: againstTheSpread(g, lines),
+	}, nil
+}
+
+// linesFromSnapshots groups the snapshots, which must be ordered by bookmaker, market, outcome and captured at, into
+// lines. The opening line is the one the bookmaker reports as opening when it does, otherwise the first one captured.
+// The closing line is the last one captured before the game started.
+func linesFromSnapshots(snapshots []api.GameOddsSnapshot, startTime time.Time) []api.GameOddsLine {
+	lines := []api.GameOddsLine{}
+
+	for _, snapshot := range snapshots {
+		if len(lines) == 0 || !sameLine(lines[len(lines)-1], snapshot) {
+			lines = append(lines, api.GameOddsLine{
+				NBABookmakerID: snapshot.NBABookmakerID,
+				Bookmaker:      snapshot.Bookmaker,
+				Market:         snapshot.Market,
+				Outcome:        snapshot.Outcome,
+				OpeningOdds:    snapshot.Odds,
+				OpeningLine:    snapshot.Line,
+			})
+		}
+
+		line := &lines[len(lines)-1]
+		if snapshot.OpeningOdds != nil {
+			line.OpeningOdds = snapshot.OpeningOdds
+		}
+		if snapshot.OpeningLine != nil {
+			line.OpeningLine = snapshot.OpeningLine
+		}
+		if !snapshot.CapturedAt.After(startTime) {
+			line.ClosingOdds = snapshot.Odds
+			line.ClosingLine = snapshot.Line
+		}
+		line.History = append(line.History, snapshot)
+	}
+
+	return lines
+}
+
+func sameLine(line api.GameOddsLine, snapshot api.GameOddsSnapshot) bool {
+	return line.NBABookmakerID == snapshot.NBABookmakerID && line.Market == snapshot.Market && line.Outcome == snapshot.Outcome
+}
+
+// againstTheSpread grades each bookmaker's closing home spread once the game is final
+func againstTheSpread(g api.Game, lines []api.GameOddsLine) []api.AgainstTheSpreadResult {
+	results := []api.AgainstTheSpreadResult{}
+
+	if g.EndTime == nil || g.HomeTeamPoints == nil || g.AwayTeamPoints == nil {
+		return results
+	}
+
+	homeMargin := *g.HomeTeamPoints - *g.AwayTeamPoints
+
+	for _, line := range lines {
+		if line.Market != string(nba.OddsMarketTypeSpread) || line.Outcome != "home" || line.ClosingLine == nil {
+			continue
+		}
+
+		result := api.AgainstTheSpreadResult{
+			NBABookmakerID: line.NBABookmakerID,
+			Bookmaker:      line.Bookmaker,
+			HomeSpread:     *line.ClosingLine,
+			HomeMargin:     homeMargin,
+		}
+
+		coverMargin := float64(homeMargin) + *line.ClosingLine
+		switch {
+		case coverMargin > 0:
+			result.Result = AgainstTheSpreadHomeCovered
+			result.CoverTeamID = g.HomeTeamID
+		case coverMargin < 0:
+			result.Result = AgainstTheSpreadAwayCovered
+			result.CoverTeamID = g.AwayTeamID
+		default:
+			result.Result = AgainstTheSpreadPush
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
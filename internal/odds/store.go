@@ -0,0 +1,28 @@
+package odds
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+)
+
+type Store interface {
+	GetGameWithID(ctx context.Context, id string) (api.Game, error)
+	UpdateGameOdds(ctx context.Context, gameOddsUpdates []GameOddsUpdate) (int, error)
+	GetGameOddsSnapshots(ctx context.Context, gameID string) ([]api.GameOddsSnapshot, error)
+}
+
+type GameOddsUpdate struct {
+	NBAGameID      string
+	NBABookmakerID string
+	Bookmaker      string
+	Market         string
+	Outcome        string
+	Odds           sql.NullFloat64
+	Line           sql.NullFloat64
+	OpeningOdds    sql.NullFloat64
+	OpeningLine    sql.NullFloat64
+	CapturedAt     time.Time
+}
@@ -0,0 +1,460 @@
+package openapi
+
+import (
+	"fmt"
+	"go/format"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// GenerateClient generates the Go client's types and methods for every operation in the spec. The methods call the
+// client's do, decodeJSON and close helpers which are written by hand in the client package
+func GenerateClient(doc *Document, packageName string) ([]byte, error) {
+	g := &clientGenerator{doc: doc, imports: map[string]bool{}}
+
+	var body strings.Builder
+	g.out = &body
+
+	componentNames := make([]string, 0, len(doc.Components.Schemas))
+	for name := range doc.Components.Schemas {
+		componentNames = append(componentNames, name)
+	}
+	sort.Strings(componentNames)
+	for _, name := range componentNames {
+		if err := g.component(name, doc.Components.Schemas[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	type methodOperation struct {
+		method    string
+		path      string
+		operation *Operation
+	}
+	operations := []methodOperation{}
+	for p, pathItem := range doc.Paths {
+		for method, operation := range pathItem.Operations() {
+			operations = append(operations, methodOperation{method: method, path: p, operation: operation})
+		}
+	}
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].operation.OperationID < operations[j].operation.OperationID
+	})
+	for _, o := range operations {
+		if err := g.operation(o.method, o.path, o.operation); err != nil {
+			return nil, fmt.Errorf("operation %s: %w", o.operation.OperationID, err)
+		}
+	}
+
+	var out strings.Builder
+	out.WriteString("// Code generated by cmd/openapi_client from the api's openapi spec. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\nimport (\n", packageName)
+	// the standard library's imports are grouped before the rest
+	for i, standardLibrary := range []bool{true, false} {
+		if i > 0 {
+			out.WriteString("\n")
+		}
+		for _, imp := range sortedKeys(g.imports) {
+			if !strings.Contains(strings.Split(imp, "/")[0], ".") == standardLibrary {
+				fmt.Fprintf(&out, "\t%q\n", imp)
+			}
+		}
+	}
+	out.WriteString(")\n")
+	out.WriteString(body.String())
+
+	return format.Source([]byte(out.String()))
+}
+
+type clientGenerator struct {
+	doc     *Document
+	imports map[string]bool
+	out     *strings.Builder
+}
+
+func (g *clientGenerator) printf(format string, args ...any) {
+	fmt.Fprintf(g.out, format, args...)
+}
+
+// component generates a struct for schemas that don't name a Go type clients can import
+func (g *clientGenerator) component(name string, schema *Schema) error {
+	if schema.GoType != "" {
+		return nil
+	}
+	if schema.Type != "object" {
+		return fmt.Errorf("component %s: only object components can be generated", name)
+	}
+
+	g.printf("\ntype %s struct {\n", name)
+	propertyNames := make([]string, 0, len(schema.Properties))
+	for propertyName := range schema.Properties {
+		propertyNames = append(propertyNames, propertyName)
+	}
+	sort.Strings(propertyNames)
+	for _, propertyName := range propertyNames {
+		goType, err := g.goType(schema.Properties[propertyName])
+		if err != nil {
+			return fmt.Errorf("component %s property %s: %w", name, propertyName, err)
+		}
+		tag := propertyName
+		if !slices.Contains(schema.Required, propertyName) {
+			tag += ",omitempty"
+		}
+		g.printf("\t%s %s `json:%q`\n", GoName(propertyName), goType, tag)
+	}
+	g.printf("}\n")
+
+	return nil
+}
+
+func (g *clientGenerator) operation(method, operationPath string, operation *Operation) error {
+	name := GoName(operation.OperationID)
+
+	pathParameters := []*Parameter{}
+	otherParameters := []*Parameter{}
+	for _, parameter := range operation.Parameters {
+		if parameter.In == ParameterInPath {
+			pathParameters = append(pathParameters, parameter)
+		} else {
+			otherParameters = append(otherParameters, parameter)
+		}
+	}
+
+	// the query and header params are passed in a params struct
+	paramsType := name + "Params"
+	if len(otherParameters) > 0 {
+		g.printf("\n// %s are the params of %s\ntype %s struct {\n", paramsType, name, paramsType)
+		for _, parameter := range otherParameters {
+			goType := parameterGoType(parameter.Schema)
+			if !parameter.Required {
+				goType = "*" + goType
+			}
+			if parameter.Description != "" {
+				kind := "param"
+				if parameter.In == ParameterInHeader {
+					kind = "header"
+				}
+				g.printf("\t// %s is the %s %s, %s\n", parameterFieldName(parameter), parameter.Name, kind, lowerFirst(parameter.Description))
+			}
+			g.printf("\t%s %s\n", parameterFieldName(parameter), goType)
+		}
+		g.printf("}\n")
+	}
+
+	success, status, err := successResponse(operation)
+	if err != nil {
+		return err
+	}
+
+	// resultType is what the method returns with its error, empty when it only returns an error
+	resultType, zeroResult := "", ""
+	decode := ""
+	switch {
+	case success == nil || len(success.Content) == 0:
+	case success.Content[contentTypeJSON] != nil:
+		bodyType, err := g.goType(success.Content[contentTypeJSON].Schema)
+		if err != nil {
+			return fmt.Errorf("response %s: %w", status, err)
+		}
+		resultType, decode = bodyType, bodyType
+		if len(success.Headers) > 0 {
+			resultType = name + "Response"
+			g.printf("\n// %s is the body and headers of %s's response\ntype %s struct {\n\tBody %s\n", resultType, name, resultType, bodyType)
+			for _, header := range sortedKeys(success.Headers) {
+				g.printf("\t// %s is the %s header, %s\n\t%s string\n", headerFieldName(header), header, lowerFirst(success.Headers[header].Description), headerFieldName(header))
+			}
+			g.printf("}\n")
+		}
+		zeroResult = zeroValue(resultType)
+	default:
+		// anything that isn't json is streamed to the caller to read
+		g.imports["io"] = true
+		resultType, zeroResult = "io.ReadCloser", "nil"
+	}
+
+	arguments := []string{"ctx context.Context"}
+	g.imports["context"] = true
+	for _, parameter := range pathParameters {
+		arguments = append(arguments, fmt.Sprintf("%s %s", lowerFirst(GoName(parameter.Name)), parameterGoType(parameter.Schema)))
+	}
+	if len(otherParameters) > 0 {
+		arguments = append(arguments, "params "+paramsType)
+	}
+	bodyArgument := "nil"
+	if operation.RequestBody != nil {
+		media, ok := operation.RequestBody.Content[contentTypeJSON]
+		if !ok {
+			return fmt.Errorf("only json request bodies are supported")
+		}
+		bodyType, err := g.goType(media.Schema)
+		if err != nil {
+			return fmt.Errorf("request body: %w", err)
+		}
+		arguments = append(arguments, "body "+bodyType)
+		bodyArgument = "body"
+	}
+
+	returns := "error"
+	errorReturn := "return err"
+	if resultType != "" {
+		returns = fmt.Sprintf("(%s, error)", resultType)
+		errorReturn = fmt.Sprintf("return %s, err", zeroResult)
+	}
+
+	g.printf("\n// %s calls %s %s to %s\n", name, method, operationPath, lowerFirst(operation.Summary))
+	if operation.Description != "" {
+		if operation.Deprecated {
+			g.printf("//\n// Deprecated: %s\n", operation.Description)
+		} else {
+			g.printf("//\n// %s\n", operation.Description)
+		}
+	}
+	g.printf("func (c *Client) %s(%s) %s {\n", name, strings.Join(arguments, ", "), returns)
+
+	query, header := "nil", "nil"
+	for _, parameter := range otherParameters {
+		target := "query"
+		if parameter.In == ParameterInHeader {
+			target = "header"
+		}
+		if target == "query" && query == "nil" {
+			g.imports["net/url"] = true
+			g.printf("\tquery := url.Values{}\n")
+			query = "query"
+		}
+		if target == "header" && header == "nil" {
+			g.imports["net/http"] = true
+			g.printf("\theader := http.Header{}\n")
+			header = "header"
+		}
+
+		field := "params." + parameterFieldName(parameter)
+		if parameter.Required {
+			g.printf("\t%s.Set(%q, %s)\n", target, parameter.Name, g.formatValue(field, parameter.Schema))
+			continue
+		}
+		g.printf("\tif %s != nil {\n\t\t%s.Set(%q, %s)\n\t}\n", field, target, parameter.Name, g.formatValue("*"+field, parameter.Schema))
+	}
+
+	pathExpression, err := g.pathExpression(operationPath, pathParameters)
+	if err != nil {
+		return err
+	}
+
+	g.imports["net/http"] = true
+	g.printf("\tresp, err := c.do(ctx, http.Method%s, %s, %s, %s, %s)\n", methodName(method), pathExpression, query, header, bodyArgument)
+	g.printf("\tif err != nil {\n\t\t%s\n\t}\n", errorReturn)
+
+	switch {
+	case resultType == "":
+		g.printf("\tclose(resp)\n\treturn nil\n")
+	case resultType == "io.ReadCloser":
+		g.printf("\treturn resp.Body, nil\n")
+	case resultType != decode:
+		g.printf("\tbody, err := decodeJSON[%s](resp)\n\tif err != nil {\n\t\t%s\n\t}\n", decode, errorReturn)
+		g.printf("\treturn %s{\n\t\tBody: body,\n", resultType)
+		for _, header := range sortedKeys(success.Headers) {
+			g.printf("\t\t%s: resp.Header.Get(%q),\n", headerFieldName(header), header)
+		}
+		g.printf("\t}, nil\n")
+	default:
+		g.printf("\treturn decodeJSON[%s](resp)\n", decode)
+	}
+	g.printf("}\n")
+
+	return nil
+}
+
+// successResponse returns the operation's lowest 2xx response
+func successResponse(operation *Operation) (*Response, string, error) {
+	for _, status := range sortedKeys(operation.Responses) {
+		if strings.HasPrefix(status, "2") {
+			return operation.Responses[status], status, nil
+		}
+	}
+	return nil, "", fmt.Errorf("no successful response")
+}
+
+func (g *clientGenerator) pathExpression(operationPath string, pathParameters []*Parameter) (string, error) {
+	parts := []string{}
+	rest := operationPath
+	for _, match := range pathParameterPattern.FindAllStringSubmatchIndex(operationPath, -1) {
+		offset := len(operationPath) - len(rest)
+		if literal := rest[:match[0]-offset]; literal != "" {
+			parts = append(parts, fmt.Sprintf("%q", literal))
+		}
+
+		name := operationPath[match[2]:match[3]]
+		i := slices.IndexFunc(pathParameters, func(p *Parameter) bool { return p.Name == name })
+		if i == -1 {
+			return "", fmt.Errorf("path param %s isn't declared", name)
+		}
+		g.imports["net/url"] = true
+		parts = append(parts, fmt.Sprintf("url.PathEscape(%s)", g.formatValue(lowerFirst(GoName(name)), pathParameters[i].Schema)))
+
+		rest = operationPath[match[1]:]
+	}
+	if rest != "" {
+		parts = append(parts, fmt.Sprintf("%q", rest))
+	}
+	return strings.Join(parts, " + "), nil
+}
+
+// formatValue formats a param value as a string
+func (g *clientGenerator) formatValue(value string, schema *Schema) string {
+	if parameterGoType(schema) == "string" {
+		return value
+	}
+	g.imports["fmt"] = true
+	return fmt.Sprintf("fmt.Sprint(%s)", value)
+}
+
+func (g *clientGenerator) goType(schema *Schema) (string, error) {
+	if schema == nil {
+		return "any", nil
+	}
+
+	if schema.Ref != "" {
+		name := path.Base(schema.Ref)
+		component, ok := g.doc.Components.Schemas[name]
+		if !ok {
+			return "", fmt.Errorf("unknown component %s", name)
+		}
+		if component.GoType != "" {
+			g.imports[component.GoTypeImport] = true
+			return component.GoType, nil
+		}
+		return name, nil
+	}
+
+	if len(schema.AllOf) == 1 {
+		goType, err := g.goType(schema.AllOf[0])
+		if err != nil {
+			return "", err
+		}
+		if schema.Nullable {
+			return "*" + goType, nil
+		}
+		return goType, nil
+	}
+
+	goType := ""
+	switch schema.Type {
+	case "":
+		return "any", nil
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			g.imports["time"] = true
+			goType = "time.Time"
+		case "binary", "byte":
+			return "[]byte", nil
+		default:
+			goType = "string"
+		}
+	case "integer":
+		goType = "int"
+		if schema.Format == "int32" || schema.Format == "int64" {
+			goType = schema.Format
+		}
+	case "number":
+		goType = "float64"
+		if schema.Format == "float" {
+			goType = "float32"
+		}
+	case "boolean":
+		goType = "bool"
+	case "array":
+		itemType, err := g.goType(schema.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + itemType, nil
+	case "object":
+		if len(schema.Properties) > 0 {
+			return "", fmt.Errorf("inline objects aren't supported, use a component")
+		}
+		valueType, err := g.goType(schema.AdditionalProperties)
+		if err != nil {
+			return "", err
+		}
+		return "map[string]" + valueType, nil
+	default:
+		return "", fmt.Errorf("unsupported schema type %s", schema.Type)
+	}
+
+	if schema.Nullable {
+		return "*" + goType, nil
+	}
+	return goType, nil
+}
+
+// parameterGoType is the type of a param in Go, params are sent as strings so formatted strings stay strings
+func parameterGoType(schema *Schema) string {
+	switch schema.Type {
+	case "integer":
+		return "int"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	default:
+		return "string"
+	}
+}
+
+// parameterFieldName is the params struct field of a param, header params are suffixed since they can share a name
+// with a query param
+func parameterFieldName(parameter *Parameter) string {
+	if parameter.In == ParameterInHeader {
+		return GoName(parameter.Name) + "Header"
+	}
+	return GoName(parameter.Name)
+}
+
+func headerFieldName(header string) string {
+	return GoName(strings.TrimPrefix(header, "X-"))
+}
+
+func zeroValue(goType string) string {
+	switch {
+	case strings.HasPrefix(goType, "[]"), strings.HasPrefix(goType, "map["), strings.HasPrefix(goType, "*"), goType == "any":
+		return "nil"
+	case goType == "string":
+		return `""`
+	case goType == "bool":
+		return "false"
+	case strings.HasPrefix(goType, "int"), strings.HasPrefix(goType, "float"):
+		return "0"
+	default:
+		return goType + "{}"
+	}
+}
+
+func methodName(method string) string {
+	return method[:1] + strings.ToLower(method[1:])
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	// a name that's only an initialism is lowered as a whole ex. ID is id
+	for _, initialism := range initialisms {
+		if s == initialism {
+			return strings.ToLower(s)
+		}
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
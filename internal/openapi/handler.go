@@ -0,0 +1,41 @@
+package openapi
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"go.opentelemetry.io/otel"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler interface {
+	Routes() chi.Router
+	Get(w http.ResponseWriter, r *http.Request)
+}
+
+func NewHandler(logger *slog.Logger) Handler {
+	return &handler{logger: logger, spec: Spec()}
+}
+
+type handler struct {
+	logger *slog.Logger
+	// spec is built once since it only changes with the code
+	spec *Document
+}
+
+func (h *handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.Get)
+
+	return r
+}
+
+func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
+	_, span := otel.Tracer("openapi").Start(r.Context(), "openapi.handler.Get")
+	defer span.End()
+
+	util.WriteJSON(http.StatusOK, h.spec, w)
+}
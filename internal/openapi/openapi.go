@@ -0,0 +1,684 @@
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/internal/api_key"
+	"github.com/drewthor/wolves_reddit_bot/internal/chart"
+	"github.com/drewthor/wolves_reddit_bot/internal/game"
+	"github.com/drewthor/wolves_reddit_bot/internal/stat_correction"
+	"github.com/drewthor/wolves_reddit_bot/internal/webhook"
+	"github.com/drewthor/wolves_reddit_bot/pkg/graphql"
+	"github.com/drewthor/wolves_reddit_bot/util"
+)
+
+const contentTypeJSON = "application/json"
+
+// problemRef references the component of the problem errors are written as, Spec registers it
+const problemRef = "#/components/schemas/Problem"
+
+const (
+	securitySchemeBearer = "bearer"
+	securitySchemeAPIKey = "apiKey"
+)
+
+var pathParameterPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+var pathParameterDescriptions = map[string]string{
+	"gameID":         "The game's id",
+	"teamID":         "The team's id",
+	"opponentID":     "The opposing team's id",
+	"playerID":       "The player's id",
+	"id":             "The id of the resource",
+	"season":         "The season's start year ex. 2024 for 2024-25",
+	"kind":           "The kind of chart",
+	"subscriptionID": "The webhook subscription's id",
+	"deliveryID":     "The webhook delivery's id",
+}
+
+var errorDescriptions = map[string]string{
+	"400": "The request's params are invalid, the body describes which",
+	"401": "The request has no api key or the key is unknown or revoked",
+	"403": "The api key doesn't have the scope the operation requires",
+	"404": "The resource doesn't exist",
+	"409": "The request conflicts with the resource's state",
+	"429": "The api key's rate limit was exceeded, retry after the Retry-After header's seconds",
+	"500": "The request failed, the problem's trace id finds why",
+	"503": "The nba's api is unavailable, retry later",
+}
+
+// Spec builds the spec of every route the api serves, the response schemas are generated from the types the handlers
+// encode
+func Spec() *Document {
+	b := &builder{
+		doc: &Document{
+			OpenAPI: Version,
+			Info: Info{
+				Title:       "NBA API",
+				Description: "NBA games, teams, players and stats ingested from the nba's apis",
+				Version:     "1.0.0",
+			},
+			Tags: []Tag{
+				{Name: "games"},
+				{Name: "teams"},
+				{Name: "players"},
+				{Name: "franchises"},
+				{Name: "referees"},
+				{Name: "playoffs"},
+				{Name: "milestones"},
+				{Name: "history", Description: "Changes to ingested rows"},
+				{Name: "stat-corrections", Description: "Stats the nba changed after a game ended"},
+				{Name: "webhooks"},
+				{Name: "graphql"},
+				{Name: "spec"},
+			},
+			Paths: map[string]*PathItem{},
+			Components: Components{
+				SecuritySchemes: map[string]*SecurityScheme{
+					securitySchemeBearer: {Type: SecuritySchemeTypeHTTP, Scheme: "bearer", Description: "An api key sent as a bearer token"},
+					securitySchemeAPIKey: {Type: SecuritySchemeTypeAPIKey, Name: api_key.HeaderAPIKey, In: ParameterInHeader, Description: "An api key sent in a header"},
+				},
+			},
+			Security: []SecurityRequirement{{securitySchemeBearer: {}}, {securitySchemeAPIKey: {}}},
+		},
+		schemas: newSchemas(),
+	}
+
+	b.addGames()
+	b.addTeams()
+	b.addPlayers()
+	b.addFranchises()
+	b.addReferees()
+	b.addPlayoffs()
+	b.addMilestones()
+	b.addHistory()
+	b.addStatCorrections()
+	b.addWebhooks()
+	b.addGraphQL()
+
+	b.add("GET", "/openapi.json", &Operation{
+		OperationID: "getOpenAPISpec",
+		Summary:     "Get this OpenAPI spec",
+		Tags:        []string{"spec"},
+		Responses:   ok("The spec", &Schema{Type: "object"}),
+		Security:    &[]SecurityRequirement{},
+	})
+
+	// registers the component problemRef references
+	b.schemas.ref(api.Problem{})
+	b.doc.Components.Schemas = b.schemas.components
+
+	return b.doc
+}
+
+func (b *builder) addGames() {
+	gameStatuses := []any{string(game.GameStatusScheduled), string(game.GameStatusStarted), string(game.GameStatusCompleted)}
+	seasonStages := []any{string(util.SeasonStagePre), string(util.SeasonStageRegular), string(util.SeasonStageAllStar), string(util.SeasonStagePost), string(util.SeasonStagePlayIn)}
+	teamSides := []any{string(game.TeamSideHome), string(game.TeamSideAway), string(game.TeamSideEither)}
+	tags := []string{"games"}
+
+	listGames := ok("The page of games", b.schemas.arrayOf(api.Game{}))
+	listGames["200"].Headers = map[string]*Header{
+		util.NextCursorHeader: {Description: "The cursor of the next page, left out on the last page", Schema: str()},
+	}
+	b.add("GET", "/games", &Operation{
+		OperationID: "listGames",
+		Summary:     "List games",
+		Description: "Games are listed a page at a time, the next page is listed by passing the X-Next-Cursor header as the cursor with the same sort",
+		Tags:        tags,
+		Parameters: []*Parameter{
+			query("sort", "The field to sort by, prefixed with - for descending", enum(game.SortStartTime, "-"+game.SortStartTime)),
+			query("cursor", "The X-Next-Cursor of the previous page", str()),
+			limit("games", game.DefaultListLimit, game.MaxListLimit),
+			query("broadcaster", "Only games broadcast by the broadcaster", str()),
+			query("date", "Only games on the day in eastern time", date()),
+			query("start-date", "Only games on or after the day in eastern time", date()),
+			query("end-date", "Only games on or before the day in eastern time", date()),
+			query("season-start-year", "Only games in the season starting in the year", integer()),
+			query("season-stage", "Only games in the stage of the season", enum(seasonStages...)),
+			query("team-id", "Only games the team played in", uuid()),
+			query("team-side", "Which side of the game team-id has to be on, only allowed with team-id", enum(teamSides...)),
+			query("status", "Only games with the status", enum(gameStatuses...)),
+			query("arena-id", "Only games played in the arena", uuid()),
+		},
+		Responses: withErrors(listGames, "400", "500"),
+	})
+	b.add("POST", "/games/update", &Operation{
+		OperationID:   "updateSeasonGames",
+		RequiredScope: string(api_key.ScopeIngest),
+		Summary:       "Ingest every game of a season from the nba",
+		Tags:          tags,
+		Parameters:    []*Parameter{requiredQuery("season-start-year", "The season's start year", integer())},
+		Responses:     withErrors(ok("The season's games", b.schemas.arrayOf(api.Game{})), "400", "500", "503"),
+	})
+	b.add("POST", "/games/updateGame", &Operation{
+		OperationID:   "updateGame",
+		RequiredScope: string(api_key.ScopeIngest),
+		Summary:       "Ingest a game from the nba",
+		Tags:          tags,
+		Parameters: []*Parameter{
+			requiredQuery("game-id", "The nba's id of the game", str()),
+			requiredQuery("season-start-year", "The game's season start year", integer()),
+		},
+		Responses: withErrors(ok("The game", b.schemas.ref(api.Game{})), "400", "404", "500", "503"),
+	})
+	b.add("POST", "/games/backfill", &Operation{
+		OperationID:   "backfillSeasonGames",
+		RequiredScope: string(api_key.ScopeIngest),
+		Summary:       "Backfill a historical season's games from stats.nba.com game logs",
+		Tags:          tags,
+		Parameters:    []*Parameter{requiredQuery("season-start-year", "The season's start year", integer())},
+		Responses:     withErrors(ok("The season's games", b.schemas.arrayOf(api.Game{})), "400", "500", "503"),
+	})
+
+	boxscoreParameters := []*Parameter{requiredQuery("game_date", "The game's date ex. 20240102", str())}
+	b.add("GET", "/games/{gameID}/boxscore", &Operation{
+		OperationID: "getBoxscore",
+		Summary:     "Get a game's boxscore",
+		Tags:        tags,
+		Parameters:  boxscoreParameters,
+		Responses:   withErrors(ok("The boxscore", b.schemas.ref(api.Boxscore{})), "400", "500", "503"),
+	})
+	b.add("GET", "/boxscores", &Operation{
+		OperationID: "getBoxscoreWithoutGame",
+		Summary:     "Get a boxscore without a game",
+		Description: "The route has no game id so it always responds with a bad request, use /games/{gameID}/boxscore",
+		Tags:        tags,
+		Parameters:  boxscoreParameters,
+		Responses:   withErrors(ok("The boxscore", b.schemas.ref(api.Boxscore{})), "400", "500"),
+		Deprecated:  true,
+	})
+	b.add("GET", "/games/{gameID}/broadcasts", &Operation{
+		OperationID: "listGameBroadcasts",
+		Summary:     "List a game's broadcasts",
+		Tags:        tags,
+		Responses:   withErrors(ok("The broadcasts", b.schemas.arrayOf(api.GameBroadcast{})), "500"),
+	})
+	b.add("GET", "/games/{gameID}/availability", &Operation{
+		OperationID: "getGameAvailability",
+		Summary:     "Get the latest injury report availability of a game's players",
+		Tags:        tags,
+		Responses:   withErrors(ok("The availability", b.schemas.ref(api.GameAvailability{})), "404", "500"),
+	})
+	b.add("GET", "/games/{gameID}/odds", &Operation{
+		OperationID: "getGameOdds",
+		Summary:     "Get a game's betting lines",
+		Tags:        tags,
+		Responses:   withErrors(ok("The odds", b.schemas.ref(api.GameOdds{})), "404", "500"),
+	})
+
+	chartKinds := make([]any, 0, len(chart.Kinds))
+	for _, kind := range chart.Kinds {
+		chartKinds = append(chartKinds, string(kind))
+	}
+	for _, format := range []struct {
+		format      chart.Format
+		contentType string
+	}{
+		{format: chart.FormatSVG, contentType: "image/svg+xml"},
+		{format: chart.FormatPNG, contentType: "image/png"},
+	} {
+		b.add("GET", fmt.Sprintf("/games/{gameID}/charts/{kind}.%s", format.format), &Operation{
+			OperationID: "getGameChart" + GoName(string(format.format)),
+			Summary:     fmt.Sprintf("Render a game chart as %s", GoName(string(format.format))),
+			Tags:        tags,
+			Parameters:  []*Parameter{{Name: "kind", In: ParameterInPath, Description: "The kind of chart", Required: true, Schema: enum(chartKinds...)}},
+			Responses: withErrors(map[string]*Response{
+				"200": {Description: "The chart", Content: map[string]*MediaType{format.contentType: {Schema: &Schema{Type: "string", Format: "binary"}}}},
+			}, "400", "404", "500"),
+		})
+	}
+
+	b.add("GET", "/games/{gameID}/live", &Operation{
+		OperationID: "streamGame",
+		Summary:     "Stream a game's events as they happen",
+		Description: "Events are sent as server-sent events, or over a websocket when the request asks to upgrade. Each event's data is a LiveGameEvent",
+		Tags:        tags,
+		Parameters: []*Parameter{
+			query("last-event-id", "The id of the last event received to resume a stream from, takes precedence over the Last-Event-ID header", str()),
+			{Name: "Last-Event-ID", In: ParameterInHeader, Description: "The id of the last event received, sent by browsers when reconnecting", Schema: str()},
+		},
+		Responses: withErrors(map[string]*Response{
+			"200": {Description: "The event stream", Content: map[string]*MediaType{"text/event-stream": {Schema: b.schemas.ref(api.LiveGameEvent{})}}},
+			"204": {Description: "The game is finished so there's nothing to stream"},
+		}, "404", "500"),
+	})
+	b.add("GET", "/games/{gameID}/win-probability", &Operation{
+		OperationID: "getGameWinProbability",
+		Summary:     "Get the home team's win probability after every play of a game",
+		Tags:        tags,
+		Responses:   withErrors(ok("The win probability", b.schemas.ref(api.WinProbability{})), "404", "500"),
+	})
+	b.add("GET", "/games/{gameID}/runs", &Operation{
+		OperationID: "getGameRuns",
+		Summary:     "Get a game's scoring runs",
+		Tags:        tags,
+		Responses:   withErrors(ok("The runs", b.schemas.ref(api.GameRuns{})), "404", "500"),
+	})
+}
+
+func (b *builder) addTeams() {
+	tags := []string{"teams"}
+
+	b.add("GET", "/teams", &Operation{
+		OperationID: "listTeams",
+		Summary:     "List teams",
+		Tags:        tags,
+		Responses:   withErrors(ok("The teams", b.schemas.arrayOf(api.Team{})), "500"),
+	})
+	b.add("GET", "/teams/{teamID}", &Operation{
+		OperationID: "getTeam",
+		Summary:     "Get a team",
+		Tags:        tags,
+		Responses:   withErrors(ok("The team", b.schemas.ref(api.Team{})), "404", "500"),
+	})
+	b.add("POST", "/teams/update", &Operation{
+		OperationID:   "updateTeams",
+		RequiredScope: string(api_key.ScopeIngest),
+		Summary:       "Ingest a season's teams from the nba",
+		Tags:          tags,
+		Parameters:    []*Parameter{requiredQuery("season-start-year", "The season's start year", integer())},
+		Responses:     withErrors(ok("The season's teams", b.schemas.arrayOf(api.Team{})), "400", "500", "503"),
+	})
+	b.add("GET", "/teams/{teamID}/schedule-context", &Operation{
+		OperationID: "listTeamScheduleContexts",
+		Summary:     "List the rest and travel context of a team's games",
+		Tags:        tags,
+		Parameters:  []*Parameter{query("season-start-year", "Only games in the season starting in the year", integer())},
+		Responses:   withErrors(ok("The schedule contexts", b.schemas.arrayOf(api.ScheduleContext{})), "400", "500"),
+	})
+	b.add("GET", "/teams/{teamID}/vs/{opponentID}", &Operation{
+		OperationID: "getHeadToHead",
+		Summary:     "Get a team's record and games against an opponent",
+		Tags:        tags,
+		Responses:   withErrors(ok("The head to head", b.schemas.ref(api.HeadToHead{})), "400", "404", "500"),
+	})
+
+	seasonParameter := query("season", "The season's start year or the nba's format ex. 2024-25, defaults to the current season", str())
+	b.add("GET", "/teams/{teamID}/games", &Operation{
+		OperationID: "getTeamSchedule",
+		Summary:     "Get a team's schedule with its running record",
+		Tags:        tags,
+		Parameters:  []*Parameter{seasonParameter},
+		Responses:   withErrors(ok("The schedule", b.schemas.ref(api.TeamSchedule{})), "400", "404", "500"),
+	})
+	b.add("GET", "/teams/{teamID}/schedule.ics", &Operation{
+		OperationID: "getTeamScheduleCalendar",
+		Summary:     "Get a team's schedule as an iCalendar feed",
+		Tags:        tags,
+		Parameters:  []*Parameter{seasonParameter},
+		Responses: withErrors(map[string]*Response{
+			"200": {Description: "The calendar", Content: map[string]*MediaType{"text/calendar": {Schema: str()}}},
+		}, "400", "404", "500"),
+	})
+}
+
+func (b *builder) addPlayers() {
+	tags := []string{"players"}
+
+	b.add("GET", "/players", &Operation{
+		OperationID: "listPlayers",
+		Summary:     "List players",
+		Tags:        tags,
+		Responses:   withErrors(ok("The players", b.schemas.arrayOf(api.Player{})), "500"),
+	})
+	b.add("GET", "/players/{id}", &Operation{
+		OperationID: "getPlayer",
+		Summary:     "Get a player",
+		Tags:        tags,
+		Responses:   withErrors(ok("The player", b.schemas.ref(api.Player{})), "404", "500"),
+	})
+	b.add("POST", "/players/update", &Operation{
+		OperationID:   "updatePlayers",
+		RequiredScope: string(api_key.ScopeIngest),
+		Summary:       "Ingest a season's players from the nba",
+		Tags:          tags,
+		Parameters:    []*Parameter{requiredQuery("season-start-year", "The season's start year", integer())},
+		Responses:     withErrors(ok("The season's players", b.schemas.arrayOf(api.Player{})), "400", "500", "503"),
+	})
+	b.add("GET", "/players/{playerID}/clutch", &Operation{
+		OperationID: "getPlayerClutchStats",
+		Summary:     "Get a player's stats in clutch time",
+		Tags:        tags,
+		Responses:   withErrors(ok("The clutch stats", b.schemas.ref(api.PlayerClutchStats{})), "500"),
+	})
+}
+
+func (b *builder) addFranchises() {
+	tags := []string{"franchises"}
+
+	b.add("GET", "/franchises", &Operation{
+		OperationID: "listFranchises",
+		Summary:     "List franchises",
+		Tags:        tags,
+		Responses:   withErrors(ok("The franchises", b.schemas.arrayOf(api.Franchise{})), "500"),
+	})
+	b.add("GET", "/franchises/{teamID}", &Operation{
+		OperationID: "getFranchise",
+		Summary:     "Get a team's franchise",
+		Tags:        tags,
+		Responses:   withErrors(ok("The franchise", b.schemas.ref(api.Franchise{})), "404", "500"),
+	})
+	b.add("GET", "/franchises/{teamID}/timeline", &Operation{
+		OperationID: "getFranchiseTimeline",
+		Summary:     "Get a franchise's eras and seasons",
+		Tags:        tags,
+		Responses:   withErrors(ok("The timeline", b.schemas.ref(api.FranchiseTimeline{})), "404", "500"),
+	})
+	b.add("POST", "/franchises/update", &Operation{
+		OperationID:   "updateFranchises",
+		RequiredScope: string(api_key.ScopeIngest),
+		Summary:       "Ingest franchises from the nba",
+		Tags:          tags,
+		Responses:     withErrors(ok("The franchises", b.schemas.arrayOf(api.Franchise{})), "500", "503"),
+	})
+}
+
+func (b *builder) addReferees() {
+	tags := []string{"referees"}
+
+	b.add("GET", "/referees", &Operation{
+		OperationID: "listReferees",
+		Summary:     "List referees",
+		Tags:        tags,
+		Responses:   withErrors(ok("The referees", b.schemas.arrayOf(api.Referee{})), "500"),
+	})
+	b.add("GET", "/referees/{id}", &Operation{
+		OperationID: "getReferee",
+		Summary:     "Get a referee's seasons, tendencies and crew chief record",
+		Tags:        tags,
+		Parameters:  []*Parameter{query("nba-team-id", "The nba's id of a team to get the crew chief record of", integer())},
+		Responses:   withErrors(ok("The referee", b.schemas.ref(api.RefereeDetails{})), "400", "404", "500"),
+	})
+}
+
+func (b *builder) addPlayoffs() {
+	b.add("GET", "/playoffs/{season}/bracket", &Operation{
+		OperationID: "getPlayoffBracket",
+		Summary:     "Get a season's playoff bracket",
+		Tags:        []string{"playoffs"},
+		Parameters:  []*Parameter{{Name: "season", In: ParameterInPath, Description: "The season's start year ex. 2024 for 2024-25", Required: true, Schema: integer()}},
+		Responses:   withErrors(ok("The bracket", b.schemas.ref(api.PlayoffBracket{})), "400", "404", "500"),
+	})
+}
+
+func (b *builder) addMilestones() {
+	b.add("GET", "/milestones", &Operation{
+		OperationID: "listMilestones",
+		Summary:     "List milestones reached in games, newest first",
+		Tags:        []string{"milestones"},
+		Parameters: []*Parameter{
+			query("game-id", "Only milestones reached in the game", uuid()),
+			query("team-id", "Only milestones reached by the team", uuid()),
+			query("player-id", "Only milestones reached by the player", uuid()),
+			query("type", "Only milestones of the type", str()),
+			limit("milestones", 100, 500),
+		},
+		Responses: withErrors(ok("The milestones", b.schemas.arrayOf(api.Milestone{})), "400", "500"),
+	})
+}
+
+func (b *builder) addHistory() {
+	parameters := []*Parameter{
+		query("table", "Only changes to rows of the table", str()),
+		query("ingest-run-id", "Only changes made by the ingest run", str()),
+		query("before", "Only changes made before the time", dateTime()),
+		limit("changes", 100, 1000),
+	}
+
+	for _, resource := range []struct {
+		name string
+		path string
+	}{
+		{name: "Game", path: "/games/{gameID}/history"},
+		{name: "Player", path: "/players/{playerID}/history"},
+		{name: "Team", path: "/teams/{teamID}/history"},
+	} {
+		b.add("GET", resource.path, &Operation{
+			OperationID: "list" + resource.name + "History",
+			Summary:     fmt.Sprintf("List changes to a %s's rows, newest first", resource.name),
+			Tags:        []string{"history"},
+			Parameters:  parameters,
+			Responses:   withErrors(ok("The changes", b.schemas.arrayOf(api.History{})), "400", "500"),
+		})
+	}
+}
+
+func (b *builder) addStatCorrections() {
+	tags := []string{"stat-corrections"}
+
+	b.add("GET", "/stat-corrections", &Operation{
+		OperationID: "listStatCorrections",
+		Summary:     "List stat corrections, newest first",
+		Tags:        tags,
+		Parameters: []*Parameter{
+			query("game-id", "Only corrections to the game", uuid()),
+			query("entity", "Only corrections to the entity", enum(stat_correction.EntityTeamGameStatsTotal, stat_correction.EntityPlayerGameStatsTotal, stat_correction.EntityPlayByPlay)),
+			query("since", "Only corrections detected at or after the time", dateTime()),
+			limit("corrections", 100, 500),
+		},
+		Responses: withErrors(ok("The corrections", b.schemas.arrayOf(api.StatCorrection{})), "400", "500"),
+	})
+	b.add("POST", "/stat-corrections/sweep", &Operation{
+		OperationID:   "sweepStatCorrections",
+		RequiredScope: string(api_key.ScopeIngest),
+		Summary:       "Re-ingest recently finished games to detect stat corrections",
+		Tags:          tags,
+		Parameters:    []*Parameter{query("window-hours", "How many hours back finished games are re-ingested", integer())},
+		Responses:     withErrors(ok("The corrections detected", b.schemas.arrayOf(api.StatCorrection{})), "400", "500", "503"),
+	})
+}
+
+func (b *builder) addWebhooks() {
+	tags := []string{"webhooks"}
+	deliveryLimit := limit("deliveries", webhook.DefaultListLimit, webhook.MaxListLimit)
+
+	b.add("POST", "/webhooks", &Operation{
+		OperationID:   "createWebhookSubscription",
+		RequiredScope: string(api_key.ScopeAdmin),
+		Summary:       "Subscribe a url to game events",
+		Description:   "The subscription's secret is only returned when it's created, deliveries are signed with it",
+		Tags:          tags,
+		RequestBody:   &RequestBody{Required: true, Content: map[string]*MediaType{contentTypeJSON: {Schema: b.schemas.ref(webhook.SubscriptionCreate{})}}},
+		Responses: withErrors(map[string]*Response{
+			"201": {Description: "The subscription", Content: jsonContent(b.schemas.ref(api.WebhookSubscription{}))},
+		}, "400", "500"),
+	})
+	b.add("GET", "/webhooks", &Operation{
+		OperationID:   "listWebhookSubscriptions",
+		RequiredScope: string(api_key.ScopeAdmin),
+		Summary:       "List webhook subscriptions",
+		Tags:          tags,
+		Responses:     withErrors(ok("The subscriptions", b.schemas.arrayOf(api.WebhookSubscription{})), "500"),
+	})
+	b.add("GET", "/webhooks/dead-letters", &Operation{
+		OperationID:   "listWebhookDeadLetters",
+		RequiredScope: string(api_key.ScopeAdmin),
+		Summary:       "List deliveries that ran out of attempts",
+		Tags:          tags,
+		Parameters:    []*Parameter{query("subscription-id", "Only deliveries to the subscription", uuid()), deliveryLimit},
+		Responses:     withErrors(ok("The dead deliveries", b.schemas.arrayOf(api.WebhookDelivery{})), "400", "500"),
+	})
+	b.add("POST", "/webhooks/deliveries/{deliveryID}/retry", &Operation{
+		OperationID:   "retryWebhookDelivery",
+		RequiredScope: string(api_key.ScopeAdmin),
+		Summary:       "Queue a dead delivery to be attempted again",
+		Tags:          tags,
+		Responses:     withErrors(ok("The delivery", b.schemas.ref(api.WebhookDelivery{})), "404", "500"),
+	})
+	b.add("GET", "/webhooks/{subscriptionID}", &Operation{
+		OperationID:   "getWebhookSubscription",
+		RequiredScope: string(api_key.ScopeAdmin),
+		Summary:       "Get a webhook subscription",
+		Tags:          tags,
+		Responses:     withErrors(ok("The subscription", b.schemas.ref(api.WebhookSubscription{})), "404", "500"),
+	})
+	b.add("DELETE", "/webhooks/{subscriptionID}", &Operation{
+		OperationID:   "deleteWebhookSubscription",
+		RequiredScope: string(api_key.ScopeAdmin),
+		Summary:       "Delete a webhook subscription",
+		Tags:          tags,
+		Responses:     withErrors(map[string]*Response{"204": {Description: "The subscription was deleted"}}, "404", "500"),
+	})
+	b.add("GET", "/webhooks/{subscriptionID}/deliveries", &Operation{
+		OperationID:   "listWebhookDeliveries",
+		RequiredScope: string(api_key.ScopeAdmin),
+		Summary:       "List a subscription's deliveries, newest first",
+		Tags:          tags,
+		Parameters: []*Parameter{
+			query("status", "Only deliveries with the status", enum(webhook.DeliveryStatusPending, webhook.DeliveryStatusDelivered, webhook.DeliveryStatusDead)),
+			deliveryLimit,
+		},
+		Responses: withErrors(ok("The deliveries", b.schemas.arrayOf(api.WebhookDelivery{})), "400", "404", "500"),
+	})
+}
+
+func (b *builder) addGraphQL() {
+	tags := []string{"graphql"}
+	description := "The schema is served at /graphql/schema.graphql. Errors executing the query are in the response's errors"
+
+	b.add("POST", "/graphql", &Operation{
+		OperationID: "executeGraphQL",
+		Summary:     "Execute a graphql query",
+		Description: description,
+		Tags:        tags,
+		RequestBody: &RequestBody{Required: true, Content: map[string]*MediaType{contentTypeJSON: {Schema: b.schemas.ref(graphql.Request{})}}},
+		Responses:   withErrors(ok("The query's result", b.schemas.ref(graphql.Response{})), "400"),
+	})
+	b.add("GET", "/graphql", &Operation{
+		OperationID: "executeGraphQLQuery",
+		Summary:     "Execute a graphql query from query params",
+		Description: description,
+		Tags:        tags,
+		Parameters: []*Parameter{
+			requiredQuery("query", "The graphql query", str()),
+			query("operationName", "The operation to execute when the query has many", str()),
+			query("variables", "The query's variables as a json object", str()),
+		},
+		Responses: withErrors(ok("The query's result", b.schemas.ref(graphql.Response{})), "400"),
+	})
+	b.add("GET", "/graphql/schema.graphql", &Operation{
+		OperationID: "getGraphQLSchema",
+		Summary:     "Get the graphql schema",
+		Tags:        tags,
+		Responses: map[string]*Response{
+			"200": {Description: "The schema in the graphql schema definition language", Content: map[string]*MediaType{"text/plain": {Schema: str()}}},
+		},
+	})
+}
+
+type builder struct {
+	doc     *Document
+	schemas *schemas
+}
+
+// add adds an operation, its path params are declared from the path when the operation doesn't declare them
+func (b *builder) add(method, path string, operation *Operation) {
+	pathParameters := []*Parameter{}
+	for _, match := range pathParameterPattern.FindAllStringSubmatch(path, -1) {
+		name := match[1]
+		if slices.ContainsFunc(operation.Parameters, func(p *Parameter) bool { return p.In == ParameterInPath && p.Name == name }) {
+			continue
+		}
+		pathParameters = append(pathParameters, &Parameter{Name: name, In: ParameterInPath, Description: pathParameterDescriptions[name], Required: true, Schema: str()})
+	}
+	operation.Parameters = append(pathParameters, operation.Parameters...)
+
+	// operations without their own security use the document's api keys
+	if operation.Security == nil {
+		if operation.RequiredScope == "" {
+			operation.RequiredScope = string(api_key.ScopeRead)
+		}
+		withErrors(operation.Responses, "401", "403", "429")
+		operation.Responses["429"].Headers = map[string]*Header{
+			"Retry-After": {Description: "The seconds until the key is allowed another request", Schema: integer()},
+		}
+	}
+
+	pathItem, ok := b.doc.Paths[path]
+	if !ok {
+		pathItem = &PathItem{}
+		b.doc.Paths[path] = pathItem
+	}
+
+	switch method {
+	case "GET":
+		pathItem.Get = operation
+	case "POST":
+		pathItem.Post = operation
+	case "PUT":
+		pathItem.Put = operation
+	case "PATCH":
+		pathItem.Patch = operation
+	case "DELETE":
+		pathItem.Delete = operation
+	default:
+		panic("openapi: unsupported method " + method)
+	}
+}
+
+func ok(description string, schema *Schema) map[string]*Response {
+	return map[string]*Response{"200": {Description: description, Content: jsonContent(schema)}}
+}
+
+// withErrors adds the error responses, the handlers write errors as problem+json
+func withErrors(responses map[string]*Response, statuses ...string) map[string]*Response {
+	for _, status := range statuses {
+		responses[status] = &Response{
+			Description: errorDescriptions[status],
+			Content:     map[string]*MediaType{util.ContentTypeProblemJSON: {Schema: &Schema{Ref: problemRef}}},
+		}
+	}
+	return responses
+}
+
+func jsonContent(schema *Schema) map[string]*MediaType {
+	return map[string]*MediaType{contentTypeJSON: {Schema: schema}}
+}
+
+func query(name, description string, schema *Schema) *Parameter {
+	return &Parameter{Name: name, In: ParameterInQuery, Description: description, Schema: schema}
+}
+
+func requiredQuery(name, description string, schema *Schema) *Parameter {
+	parameter := query(name, description, schema)
+	parameter.Required = true
+	return parameter
+}
+
+func str() *Schema {
+	return &Schema{Type: "string"}
+}
+
+func uuid() *Schema {
+	return &Schema{Type: "string", Format: "uuid"}
+}
+
+func date() *Schema {
+	return &Schema{Type: "string", Format: "date"}
+}
+
+func dateTime() *Schema {
+	return &Schema{Type: "string", Format: "date-time"}
+}
+
+func integer() *Schema {
+	return &Schema{Type: "integer"}
+}
+
+func enum[T any](values ...T) *Schema {
+	schema := str()
+	for _, v := range values {
+		schema.Enum = append(schema.Enum, v)
+	}
+	return schema
+}
+
+// limit is a list's limit param, limits over the max are lowered to it rather than rejected
+func limit(items string, defaultLimit, maxLimit int) *Parameter {
+	minimum := 1.0
+	return query("limit", fmt.Sprintf("The most %s to return, at most %d", items, maxLimit), &Schema{Type: "integer", Default: defaultLimit, Minimum: &minimum})
+}
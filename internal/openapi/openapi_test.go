@@ -0,0 +1,147 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSpecIsValid(t *testing.T) {
+	spec := Spec()
+
+	b, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("failed to encode spec: %v", err)
+	}
+	decoded := map[string]any{}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("failed to decode spec: %v", err)
+	}
+	for _, field := range []string{"openapi", "info", "paths", "components"} {
+		if _, ok := decoded[field]; !ok {
+			t.Errorf("spec is missing the required %s field", field)
+		}
+	}
+
+	var checkSchema func(location string, schema *Schema)
+	checkSchema = func(location string, schema *Schema) {
+		if schema == nil {
+			return
+		}
+		if schema.Ref != "" {
+			if _, ok := spec.Components.Schemas[path.Base(schema.Ref)]; !ok || !strings.HasPrefix(schema.Ref, "#/components/schemas/") {
+				t.Errorf("%s references %s which isn't a component", location, schema.Ref)
+			}
+		}
+		if schema.Type == "array" && schema.Items == nil {
+			t.Errorf("%s is an array without items", location)
+		}
+		for _, s := range schema.AllOf {
+			checkSchema(location, s)
+		}
+		checkSchema(location, schema.Items)
+		checkSchema(location, schema.AdditionalProperties)
+		for name, property := range schema.Properties {
+			checkSchema(location+"."+name, property)
+		}
+		for _, required := range schema.Required {
+			if _, ok := schema.Properties[required]; !ok {
+				t.Errorf("%s requires %s which isn't a property", location, required)
+			}
+		}
+	}
+
+	for name, schema := range spec.Components.Schemas {
+		checkSchema("component "+name, schema)
+	}
+
+	operationIDs := map[string]string{}
+	for operationPath, pathItem := range spec.Paths {
+		templateParameters := []string{}
+		for _, match := range pathParameterPattern.FindAllStringSubmatch(operationPath, -1) {
+			templateParameters = append(templateParameters, match[1])
+		}
+
+		for method, operation := range pathItem.Operations() {
+			location := method + " " + operationPath
+
+			if operation.OperationID == "" {
+				t.Errorf("%s has no operation id", location)
+			} else if other, ok := operationIDs[operation.OperationID]; ok {
+				t.Errorf("%s and %s have the same operation id %s", location, other, operation.OperationID)
+			}
+			operationIDs[operation.OperationID] = location
+
+			pathParameters := []string{}
+			seen := map[string]bool{}
+			for _, parameter := range operation.Parameters {
+				key := parameter.In + " " + parameter.Name
+				if seen[key] {
+					t.Errorf("%s declares the %s param %s twice", location, parameter.In, parameter.Name)
+				}
+				seen[key] = true
+
+				if !slices.Contains([]string{ParameterInPath, ParameterInQuery, ParameterInHeader}, parameter.In) {
+					t.Errorf("%s param %s is in unknown location %s", location, parameter.Name, parameter.In)
+				}
+				if parameter.In == ParameterInPath {
+					pathParameters = append(pathParameters, parameter.Name)
+					if !parameter.Required {
+						t.Errorf("%s path param %s has to be required", location, parameter.Name)
+					}
+				}
+				if parameter.Schema == nil {
+					t.Errorf("%s param %s has no schema", location, parameter.Name)
+				}
+				checkSchema(location+" param "+parameter.Name, parameter.Schema)
+			}
+			slices.Sort(pathParameters)
+			slices.Sort(templateParameters)
+			if !slices.Equal(pathParameters, templateParameters) {
+				t.Errorf("%s declares path params %v but its path has %v", location, pathParameters, templateParameters)
+			}
+
+			if operation.RequestBody != nil {
+				for contentType, media := range operation.RequestBody.Content {
+					checkSchema(location+" request "+contentType, media.Schema)
+				}
+			}
+
+			successful := false
+			for status, response := range operation.Responses {
+				if strings.HasPrefix(status, "2") {
+					successful = true
+				}
+				if response.Description == "" {
+					t.Errorf("%s %s response has no description", location, status)
+				}
+				for contentType, media := range response.Content {
+					checkSchema(location+" "+status+" "+contentType, media.Schema)
+				}
+			}
+			if !successful {
+				t.Errorf("%s has no successful response", location)
+			}
+		}
+	}
+}
+
+func TestClientIsGenerated(t *testing.T) {
+	source, err := GenerateClient(Spec(), "client")
+	if err != nil {
+		t.Fatalf("failed to generate client: %v", err)
+	}
+
+	generated, err := os.ReadFile("../../pkg/client/client.gen.go")
+	if err != nil {
+		t.Fatalf("failed to read generated client: %v", err)
+	}
+
+	if !bytes.Equal(source, generated) {
+		t.Error("pkg/client/client.gen.go is out of date with the openapi spec, run go generate ./pkg/client")
+	}
+}
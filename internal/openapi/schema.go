@@ -0,0 +1,193 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType       = reflect.TypeFor[time.Time]()
+	rawMessageType = reflect.TypeFor[json.RawMessage]()
+)
+
+// schemas generates component schemas from the Go types the handlers encode, following encoding/json's rules so the
+// spec can't drift from the responses
+type schemas struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+func newSchemas() *schemas {
+	return &schemas{components: map[string]*Schema{}, names: map[reflect.Type]string{}}
+}
+
+// ref returns a reference to the component schema of v's type, registering it the first time
+func (s *schemas) ref(v any) *Schema {
+	return s.schema(reflect.TypeOf(v))
+}
+
+// arrayOf returns an array schema of v's type
+func (s *schemas) arrayOf(v any) *Schema {
+	return &Schema{Type: "array", Items: s.ref(v)}
+}
+
+func (s *schemas) schema(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := s.schema(t.Elem())
+		if schema.Ref != "" {
+			// siblings of a $ref are ignored so a nullable reference has to wrap it
+			return &Schema{AllOf: []*Schema{schema}, Nullable: true}
+		}
+		schema.Nullable = true
+		return schema
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer"}
+	case reflect.Int32, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		// nil slices are encoded as null
+		return &Schema{Type: "array", Items: s.schema(t.Elem()), Nullable: t.Kind() == reflect.Slice}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.schema(t.Elem()), Nullable: true}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + s.component(t)}
+	default:
+		panic(fmt.Sprintf("openapi: %s can't be encoded as json", t))
+	}
+}
+
+// component registers the schema of a named struct type returning its component name
+func (s *schemas) component(t reflect.Type) string {
+	if name, ok := s.names[t]; ok {
+		return name
+	}
+
+	name := componentName(t)
+	if _, ok := s.components[name]; ok {
+		panic(fmt.Sprintf("openapi: %s and another type both have the component name %s", t, name))
+	}
+
+	// registered before the properties are generated so recursive types reference themselves
+	schema := &Schema{}
+	s.names[t] = name
+	s.components[name] = schema
+
+	*schema = *s.object(t)
+	if !strings.Contains(t.PkgPath(), "/internal/") {
+		schema.GoType = path.Base(t.PkgPath()) + "." + t.Name()
+		schema.GoTypeImport = t.PkgPath()
+	}
+
+	return name
+}
+
+func (s *schemas) object(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for _, field := range reflect.VisibleFields(t) {
+		tag := field.Tag.Get("json")
+		// the fields of embedded structs are promoted and visited on their own
+		if !field.IsExported() || (field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct) || tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = s.schema(field.Type)
+		if !strings.Contains(options, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema
+}
+
+// componentName is the type's name, types outside of the api package are prefixed with their package's name
+func componentName(t reflect.Type) string {
+	pkg := path.Base(t.PkgPath())
+	if pkg == "api" {
+		return t.Name()
+	}
+	return GoName(pkg) + t.Name()
+}
+
+// initialisms are written in all caps in Go names
+var initialisms = map[string]string{
+	"api":     "API",
+	"graphql": "GraphQL",
+	"id":      "ID",
+	"ids":     "IDs",
+	"json":    "JSON",
+	"nba":     "NBA",
+	"png":     "PNG",
+	"svg":     "SVG",
+	"url":     "URL",
+}
+
+// GoName converts a kebab, snake or camel case name to an exported Go name ex. season-start-year is SeasonStartYear
+func GoName(name string) string {
+	var sb strings.Builder
+	for _, word := range splitWords(name) {
+		if initialism, ok := initialisms[strings.ToLower(word)]; ok {
+			sb.WriteString(initialism)
+			continue
+		}
+		sb.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return sb.String()
+}
+
+func splitWords(name string) []string {
+	words := []string{}
+	start := 0
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '-' || c == '_' || c == '.' || c == '/' || c == ' ':
+			if i > start {
+				words = append(words, name[start:i])
+			}
+			start = i + 1
+		case c >= 'A' && c <= 'Z' && i > start && name[i-1] >= 'a' && name[i-1] <= 'z':
+			words = append(words, name[start:i])
+			start = i
+		}
+	}
+	if start < len(name) {
+		words = append(words, name[start:])
+	}
+	return words
+}
@@ -2,6 +2,7 @@ package playbyplay
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -9,6 +10,7 @@ import (
 	"github.com/drewthor/wolves_reddit_bot/api"
 	"github.com/drewthor/wolves_reddit_bot/apis/cloudflare"
 	"github.com/drewthor/wolves_reddit_bot/apis/nba"
+	"github.com/drewthor/wolves_reddit_bot/internal/win_probability"
 	"github.com/drewthor/wolves_reddit_bot/util"
 	"go.opentelemetry.io/otel"
 )
@@ -18,13 +20,15 @@ type Service interface {
 	UpdatePlayByPlayForGames(ctx context.Context, logger *slog.Logger, nbaGameIDs []string) ([]api.PlayByPlay, error)
 }
 
-func NewService(nbaClient nba.Client, r2Client cloudflare.Client, playByPlayStore PlayByPlayWriter) Service {
-	return &service{nbaClient: nbaClient, r2Client: r2Client, playByPlayStore: playByPlayStore}
+func NewService(nbaClient nba.Client, r2Client cloudflare.Client, playByPlayStore PlayByPlayWriter, winProbabilityService win_probability.Service) Service {
+	return &service{nbaClient: nbaClient, r2Client: r2Client, playByPlayStore: playByPlayStore, winProbabilityService: winProbabilityService}
 }
 
 type service struct {
 	playByPlayStore PlayByPlayWriter
 
+	winProbabilityService win_probability.Service
+
 	nbaClient nba.Client
 	r2Client  cloudflare.Client
 }
@@ -37,7 +41,7 @@ func (s service) FetchPlayByPlayForGame(ctx context.Context, logger *slog.Logger
 	playByPlay, err := s.nbaClient.PlayByPlayForGame(ctx, gameID, util.WithR2OutputWriter(logger, s.r2Client, util.NBAR2Bucket, objectKey))
 	if err != nil {
 		if errors.Is(err, nba.ErrNotFound) {
-			return nba.PlayByPlay{}, util.ErrNotFound
+			return nba.PlayByPlay{}, util.NewError(util.ErrNotFound, "play by play not found")
 		}
 		return nba.PlayByPlay{}, fmt.Errorf("failed to get play by play for game: %w", err)
 	}
@@ -74,13 +78,40 @@ func (s service) UpdatePlayByPlayForGames(ctx context.Context, logger *slog.Logg
 			return nil, fmt.Errorf("failed to fetch playbyplayv3 for game: %w", err)
 		}
 
-		for _, action := range pbp.Game.Actions {
+		var homeWinProbabilities []float64
+		if len(pbp.Game.Actions) > 0 {
+			homeWinProbabilities, err = s.winProbabilityService.ModelGame(ctx, nbaGameID, pbp.Game.Actions)
+			if err != nil {
+				// the play by play is still stored without win probabilities rather than losing the whole ingest
+				logger.ErrorContext(ctx, "failed to model win probability for game", slog.String("game_id", nbaGameID), slog.Any("error", err))
+				homeWinProbabilities = nil
+			}
+		}
+
+		for i, action := range pbp.Game.Actions {
 			update := PlayByPlayUpdate{
-				NBAGameID:    nbaGameID,
-				NBATeamID:    pbp.Game.GameID,
-				NBAPlayerID:  action.PersonID,
-				Period:       action.Period,
-				ActionNumber: action.ActionNumber,
+				NBAGameID:           nbaGameID,
+				NBATeamID:           nullableNBAID(action.TeamID),
+				NBAPlayerID:         nullableNBAID(action.PersonID),
+				Period:              action.Period,
+				ActionNumber:        action.ActionNumber,
+				ClockTenthSeconds:   action.Clock.DurationTenthSeconds,
+				ActionType:          action.ActionType,
+				Description:         action.Description,
+				NBAPossessionTeamID: nullableNBAID(action.Possession),
+			}
+			if homeWinProbabilities != nil {
+				update.HomeWinProbability = sql.NullFloat64{Float64: homeWinProbabilities[i], Valid: true}
+			}
+			if action.SubType != "" {
+				update.SubType = sql.NullString{String: action.SubType, Valid: true}
+			}
+			if action.ShotResult != "" {
+				update.ShotResult = sql.NullString{String: action.ShotResult, Valid: true}
+			}
+			if homeScore, awayScore, err := action.Score(); err == nil {
+				update.HomeScore = sql.NullInt64{Int64: int64(homeScore), Valid: true}
+				update.AwayScore = sql.NullInt64{Int64: int64(awayScore), Valid: true}
 			}
 
 			playByPlayUpdates = append(playByPlayUpdates, update)
@@ -89,3 +120,12 @@ func (s service) UpdatePlayByPlayForGames(ctx context.Context, logger *slog.Logg
 
 	return s.playByPlayStore.UpdatePlayByPlays(ctx, playByPlayUpdates)
 }
+
+// nullableNBAID treats the zero ids the nba uses for actions without a team or player as null
+func nullableNBAID(id int) sql.NullInt64 {
+	if id == 0 {
+		return sql.NullInt64{}
+	}
+
+	return sql.NullInt64{Int64: int64(id), Valid: true}
+}
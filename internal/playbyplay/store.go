@@ -2,6 +2,7 @@ package playbyplay
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/drewthor/wolves_reddit_bot/api"
 )
@@ -12,9 +13,18 @@ type PlayByPlayWriter interface {
 
 type PlayByPlayUpdate struct {
 	NBAGameID            string
-	NBATeamID            string
-	NBAPlayerID          int
+	NBATeamID            sql.NullInt64
+	NBAPlayerID          sql.NullInt64
 	SecondaryNBAPlayerID *int
 	Period               int
 	ActionNumber         int
+	ClockTenthSeconds    int
+	ActionType           string
+	SubType              sql.NullString
+	Description          string
+	ShotResult           sql.NullString
+	HomeScore            sql.NullInt64
+	AwayScore            sql.NullInt64
+	NBAPossessionTeamID  sql.NullInt64
+	HomeWinProbability   sql.NullFloat64
 }
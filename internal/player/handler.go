@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/drewthor/wolves_reddit_bot/internal/api_key"
 	"github.com/drewthor/wolves_reddit_bot/util"
 	"go.opentelemetry.io/otel"
 
@@ -34,7 +35,7 @@ func (h handler) Routes() chi.Router {
 
 	r.Get("/{id}", h.Get)
 
-	r.Post("/update", h.UpdatePlayers)
+	r.With(api_key.RequireScope(api_key.ScopeIngest)).Post("/update", h.UpdatePlayers)
 
 	return r
 }
@@ -47,11 +48,11 @@ func (h *handler) List(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		h.logger.ErrorContext(ctx, "failed to list players", slog.Any("error", err))
-		util.WriteJSON(http.StatusInternalServerError, err, w)
+		util.WriteError(w, r, err)
 		return
 	}
 
-	util.WriteJSON(http.StatusOK, players, w)
+	util.WriteCacheableListJSON(w, r, players, util.CacheControlRevalidate)
 }
 
 func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
@@ -65,11 +66,11 @@ func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		logger.ErrorContext(ctx, "failed to get player", slog.Any("error", err))
-		util.WriteJSON(http.StatusInternalServerError, err, w)
+		util.WriteError(w, r, err)
 		return
 	}
 
-	util.WriteJSON(http.StatusOK, player, w)
+	util.WriteCacheableJSON(w, r, player, util.CacheControlRevalidate)
 }
 
 func (h *handler) UpdatePlayers(w http.ResponseWriter, r *http.Request) {
@@ -78,7 +79,7 @@ func (h *handler) UpdatePlayers(w http.ResponseWriter, r *http.Request) {
 
 	seasonStartYear, err := strconv.Atoi(r.URL.Query().Get("season-start-year"))
 	if err != nil {
-		util.WriteJSON(http.StatusBadRequest, "invalid required season-start-year", w)
+		util.WriteProblem(w, r, http.StatusBadRequest, "invalid required season-start-year")
 		return
 	}
 
@@ -86,7 +87,7 @@ func (h *handler) UpdatePlayers(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		h.logger.ErrorContext(ctx, "failed to update players", slog.Any("error", err))
-		util.WriteJSON(http.StatusInternalServerError, err, w)
+		util.WriteError(w, r, err)
 		return
 	}
 
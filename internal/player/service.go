@@ -2,6 +2,8 @@ package player
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strconv"
 	"time"
 
@@ -9,12 +11,16 @@ import (
 
 	"github.com/drewthor/wolves_reddit_bot/api"
 	"github.com/drewthor/wolves_reddit_bot/apis/nba"
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 )
 
 type Service interface {
 	Get(ctx context.Context, playerID string) (api.Player, error)
 	ListPlayers(ctx context.Context) ([]api.Player, error)
 	UpdatePlayers(ctx context.Context, seasonStartYear int) ([]api.Player, error)
+	EnsurePlayersExist(ctx context.Context, players []api.Player) error
 }
 
 func NewService(playerStore Store) Service {
@@ -29,8 +35,15 @@ func (s *service) Get(ctx context.Context, playerID string) (api.Player, error)
 	ctx, span := otel.Tracer("player").Start(ctx, "player.service.Get")
 	defer span.End()
 
+	if _, err := uuid.Parse(playerID); err != nil {
+		return api.Player{}, util.NewError(util.ErrNotFound, "player not found")
+	}
+
 	player, err := s.PlayerStore.GetPlayerWithID(ctx, playerID)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.Player{}, util.NewError(util.ErrNotFound, "player not found")
+		}
 		return player, err
 	}
 	return player, nil
@@ -51,6 +64,8 @@ func (s *service) UpdatePlayers(ctx context.Context, seasonStartYear int) ([]api
 	ctx, span := otel.Tracer("player").Start(ctx, "player.service.UpdatePlayers")
 	defer span.End()
 
+	ctx = util.WithIngestRun(ctx, util.NewIngestRun(fmt.Sprintf("players/%d", seasonStartYear)))
+
 	players, err := s.getSeasonPlayers(ctx, seasonStartYear)
 	if err != nil {
 		return nil, err
@@ -157,3 +172,20 @@ func (s *service) getSeasonPlayers(ctx context.Context, seasonStartYear int) ([]
 	}
 	return players, nil
 }
+
+// EnsurePlayersExist adds the players that aren't stored yet, players that already exist are left as is since the
+// players passed in may only have a name ex. players from the historical game logs
+func (s *service) EnsurePlayersExist(ctx context.Context, players []api.Player) error {
+	ctx, span := otel.Tracer("player").Start(ctx, "player.service.EnsurePlayersExist")
+	defer span.End()
+
+	if len(players) == 0 {
+		return nil
+	}
+
+	if _, err := s.PlayerStore.InsertMissingPlayers(ctx, players); err != nil {
+		return fmt.Errorf("failed to ensure players exist: %w", err)
+	}
+
+	return nil
+}
@@ -11,4 +11,5 @@ type Store interface {
 	ListPlayers(ctx context.Context) ([]api.Player, error)
 	GetPlayersWithIDs(ctx context.Context, ids []string) ([]api.Player, error)
 	UpdatePlayers(ctx context.Context, players []api.Player) ([]api.Player, error)
+	InsertMissingPlayers(ctx context.Context, players []api.Player) (int, error)
 }
@@ -0,0 +1,150 @@
+package player_game_stats
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/drewthor/wolves_reddit_bot/apis/nba"
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"go.opentelemetry.io/otel"
+)
+
+type Service interface {
+	UpdatePlayerGameStatsTotals(ctx context.Context, playerGameStatsTotalUpdates []PlayerGameStatsTotalUpdate) (int, error)
+	UpdateHistoricalPlayerGameStatsTotals(ctx context.Context, playerGameStatsTotalUpdates []PlayerGameStatsTotalHistoricalUpdate) (int, error)
+}
+
+func NewService(playerGameStatsStore Store) Service {
+	return &service{playerGameStatsStore: playerGameStatsStore}
+}
+
+type service struct {
+	playerGameStatsStore Store
+}
+
+func (s *service) UpdatePlayerGameStatsTotals(ctx context.Context, playerGameStatsTotalUpdates []PlayerGameStatsTotalUpdate) (int, error) {
+	ctx, span := otel.Tracer("player_game_stats").Start(ctx, "player_game_stats.service.UpdatePlayerGameStatsTotals")
+	defer span.End()
+
+	if len(playerGameStatsTotalUpdates) == 0 {
+		return 0, nil
+	}
+
+	return s.playerGameStatsStore.UpdatePlayerGameStatsTotals(ctx, playerGameStatsTotalUpdates)
+}
+
+func (s *service) UpdateHistoricalPlayerGameStatsTotals(ctx context.Context, playerGameStatsTotalUpdates []PlayerGameStatsTotalHistoricalUpdate) (int, error) {
+	ctx, span := otel.Tracer("player_game_stats").Start(ctx, "player_game_stats.service.UpdateHistoricalPlayerGameStatsTotals")
+	defer span.End()
+
+	if len(playerGameStatsTotalUpdates) == 0 {
+		return 0, nil
+	}
+
+	return s.playerGameStatsStore.UpdateHistoricalPlayerGameStatsTotals(ctx, playerGameStatsTotalUpdates)
+}
+
+// BoxscoreUpdates are the stat lines of the players on a team that played in the game
+func BoxscoreUpdates(nbaGameID string, boxscoreTeam nba.BoxscoreTeam) []PlayerGameStatsTotalUpdate {
+	var updates []PlayerGameStatsTotalUpdate
+
+	for _, boxscorePlayer := range boxscoreTeam.Players {
+		if boxscorePlayer.Played != "1" {
+			continue
+		}
+
+		statistics := boxscorePlayer.Statistics
+
+		updates = append(updates, PlayerGameStatsTotalUpdate{
+			NBAGameID:              nbaGameID,
+			NBATeamID:              boxscoreTeam.ID,
+			NBAPlayerID:            boxscorePlayer.ID,
+			TimePlayedSeconds:      statistics.Minutes.DurationTenthSeconds / 10,
+			Points:                 statistics.Points,
+			Assists:                statistics.Assists,
+			Turnovers:              statistics.Turnovers,
+			Steals:                 statistics.Steals,
+			ThreePointersAttempted: statistics.ThreePointersAttempted,
+			ThreePointersMade:      statistics.ThreePointersMade,
+			ThreePointPercentage:   statistics.ThreePointersPercentage,
+			FieldGoalsAttempted:    statistics.FieldGoalsAttempted,
+			FieldGoalsMade:         statistics.FieldGoalsMade,
+			FieldGoalPercentage:    statistics.FieldGoalsPercentage,
+			FreeThrowsAttempted:    statistics.FreeThrowsAttempted,
+			FreeThrowsMade:         statistics.FreeThrowsMade,
+			FreeThrowPercentage:    statistics.FreeThrowsPercentage,
+			Blocks:                 statistics.Blocks,
+			ReboundsOffensive:      statistics.ReboundsOffensive,
+			ReboundsDefensive:      statistics.ReboundsDefensive,
+			ReboundsTotal:          statistics.ReboundsTotal,
+			FoulsPersonal:          statistics.FoulsPersonal,
+			PlusMinus:              int(statistics.PlusMinus),
+		})
+	}
+
+	return updates
+}
+
+// GameLogUpdates are the player lines from the league player game log
+func GameLogUpdates(playerGameLogs []nba.PlayerGameLog) []PlayerGameStatsTotalHistoricalUpdate {
+	var updates []PlayerGameStatsTotalHistoricalUpdate
+
+	for _, playerGameLog := range playerGameLogs {
+		timePlayedSeconds := sql.NullInt64{}
+		if playerGameLog.Minutes != nil {
+			timePlayedSeconds = sql.NullInt64{Int64: int64(*playerGameLog.Minutes * 60), Valid: true}
+		}
+
+		updates = append(updates, historicalUpdate(playerGameLog.GameID, playerGameLog.TeamID, playerGameLog.PlayerID, timePlayedSeconds, playerGameLog.GameLogStatLine, util.DataSourceStatsLeagueGameLog))
+	}
+
+	return updates
+}
+
+// BoxscoreTraditionalUpdates are the lines of the players that played in the game
+func BoxscoreTraditionalUpdates(boxscore nba.BoxscoreTraditional) []PlayerGameStatsTotalHistoricalUpdate {
+	var updates []PlayerGameStatsTotalHistoricalUpdate
+
+	for _, player := range boxscore.Players {
+		if !player.Played() {
+			continue
+		}
+
+		updates = append(updates, historicalUpdate(boxscore.GameID, player.TeamID, player.PlayerID, nullInt64(player.TimePlayedSeconds), player.GameLogStatLine, util.DataSourceStatsBoxscoreTraditional))
+	}
+
+	return updates
+}
+
+func historicalUpdate(nbaGameID string, nbaTeamID int, nbaPlayerID int, timePlayedSeconds sql.NullInt64, statLine nba.GameLogStatLine, source util.DataSource) PlayerGameStatsTotalHistoricalUpdate {
+	return PlayerGameStatsTotalHistoricalUpdate{
+		NBAGameID:              nbaGameID,
+		NBATeamID:              nbaTeamID,
+		NBAPlayerID:            nbaPlayerID,
+		TimePlayedSeconds:      timePlayedSeconds,
+		Points:                 statLine.Points,
+		Assists:                nullInt64(statLine.Assists),
+		Turnovers:              nullInt64(statLine.Turnovers),
+		Steals:                 nullInt64(statLine.Steals),
+		ThreePointersAttempted: nullInt64(statLine.ThreePointersAttempted),
+		ThreePointersMade:      nullInt64(statLine.ThreePointersMade),
+		FieldGoalsAttempted:    nullInt64(statLine.FieldGoalsAttempted),
+		FieldGoalsMade:         statLine.FieldGoalsMade,
+		FreeThrowsAttempted:    statLine.FreeThrowsAttempted,
+		FreeThrowsMade:         statLine.FreeThrowsMade,
+		Blocks:                 nullInt64(statLine.Blocks),
+		ReboundsOffensive:      nullInt64(statLine.OffensiveRebounds),
+		ReboundsDefensive:      nullInt64(statLine.DefensiveRebounds),
+		ReboundsTotal:          nullInt64(statLine.TotalRebounds),
+		FoulsPersonal:          nullInt64(statLine.PersonalFouls),
+		PlusMinus:              nullInt64(statLine.PlusMinus),
+		Source:                 string(source),
+	}
+}
+
+func nullInt64(i *int) sql.NullInt64 {
+	if i == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(*i), Valid: true}
+}
@@ -0,0 +1,63 @@
+package player_game_stats
+
+import (
+	"context"
+	"database/sql"
+)
+
+type Store interface {
+	UpdatePlayerGameStatsTotals(ctx context.Context, playerGameStatsTotalUpdates []PlayerGameStatsTotalUpdate) (int, error)
+	UpdateHistoricalPlayerGameStatsTotals(ctx context.Context, playerGameStatsTotalUpdates []PlayerGameStatsTotalHistoricalUpdate) (int, error)
+}
+
+type PlayerGameStatsTotalUpdate struct {
+	NBAGameID              string
+	NBATeamID              int
+	NBAPlayerID            int
+	TimePlayedSeconds      int
+	Points                 int
+	Assists                int
+	Turnovers              int
+	Steals                 int
+	ThreePointersAttempted int
+	ThreePointersMade      int
+	ThreePointPercentage   float64
+	FieldGoalsAttempted    int
+	FieldGoalsMade         int
+	FieldGoalPercentage    float64
+	FreeThrowsAttempted    int
+	FreeThrowsMade         int
+	FreeThrowPercentage    float64
+	Blocks                 int
+	ReboundsOffensive      int
+	ReboundsDefensive      int
+	ReboundsTotal          int
+	FoulsPersonal          int
+	PlusMinus              int
+}
+
+// PlayerGameStatsTotalHistoricalUpdate is a player's line from the stats.nba.com game log or traditional boxscore. Stats
+// the league didn't track for the season are null
+type PlayerGameStatsTotalHistoricalUpdate struct {
+	NBAGameID              string
+	NBATeamID              int
+	NBAPlayerID            int
+	TimePlayedSeconds      sql.NullInt64
+	Points                 int
+	Assists                sql.NullInt64
+	Turnovers              sql.NullInt64
+	Steals                 sql.NullInt64
+	ThreePointersAttempted sql.NullInt64
+	ThreePointersMade      sql.NullInt64
+	FieldGoalsAttempted    sql.NullInt64
+	FieldGoalsMade         int
+	FreeThrowsAttempted    int
+	FreeThrowsMade         int
+	Blocks                 sql.NullInt64
+	ReboundsOffensive      sql.NullInt64
+	ReboundsDefensive      sql.NullInt64
+	ReboundsTotal          sql.NullInt64
+	FoulsPersonal          sql.NullInt64
+	PlusMinus              sql.NullInt64
+	Source                 string
+}
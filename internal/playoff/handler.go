@@ -0,0 +1,56 @@
+package playoff
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"go.opentelemetry.io/otel"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler interface {
+	Routes() chi.Router
+	GetBracket(w http.ResponseWriter, r *http.Request)
+}
+
+func NewHandler(logger *slog.Logger, playoffService Service) Handler {
+	return &handler{logger: logger, playoffService: playoffService}
+}
+
+type handler struct {
+	logger         *slog.Logger
+	playoffService Service
+}
+
+func (h *handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/{season}/bracket", h.GetBracket)
+
+	return r
+}
+
+func (h *handler) GetBracket(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("playoff").Start(r.Context(), "playoff.handler.GetBracket")
+	defer span.End()
+
+	seasonStartYear, err := strconv.Atoi(chi.URLParam(r, "season"))
+	if err != nil {
+		util.WriteProblem(w, r, http.StatusBadRequest, "invalid season, expected the season start year ex. 2023")
+		return
+	}
+
+	logger := h.logger.With(slog.Int("season_start_year", seasonStartYear))
+
+	bracket, err := h.playoffService.GetBracket(ctx, seasonStartYear)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get playoff bracket", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteCacheableListJSON(w, r, bracket, util.CacheControlRevalidate)
+}
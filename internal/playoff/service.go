@@ -0,0 +1,258 @@
+package playoff
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/apis/nba"
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	SeriesStatusScheduled  = "scheduled"
+	SeriesStatusInProgress = "in_progress"
+	SeriesStatusCompleted  = "completed"
+)
+
+// game status names as stored by the game service
+const (
+	gameStatusScheduled = "scheduled"
+	gameStatusCompleted = "completed"
+)
+
+const (
+	playoffSeriesWinsNeeded = 4
+	playInWinsNeeded        = 1
+	finalsRound             = 4
+)
+
+var playoffRoundNames = map[int]string{
+	1: "First Round",
+	2: "Conference Semifinals",
+	3: "Conference Finals",
+	4: "NBA Finals",
+}
+
+// RoundName is the display name of a playoff round, ex. Conference Finals
+func RoundName(round int) string {
+	return playoffRoundNames[round]
+}
+
+type Service interface {
+	UpdatePlayoffSeries(ctx context.Context, logger *slog.Logger, seasonStartYear int) error
+	GetBracket(ctx context.Context, seasonStartYear int) (api.PlayoffBracket, error)
+}
+
+func NewService(playoffStore Store) Service {
+	return &service{playoffStore: playoffStore}
+}
+
+type service struct {
+	playoffStore Store
+}
+
+func (s *service) UpdatePlayoffSeries(ctx context.Context, logger *slog.Logger, seasonStartYear int) error {
+	ctx, span := otel.Tracer("playoff").Start(ctx, "playoff.service.UpdatePlayoffSeries")
+	defer span.End()
+
+	playoffGames, err := s.playoffStore.GetPlayoffGames(ctx, seasonStartYear)
+	if err != nil {
+		return fmt.Errorf("failed to get playoff games for season %d: %w", seasonStartYear, err)
+	}
+
+	playoffSeriesUpdates := playoffSeriesUpdates(seasonStartYear, playoffGames)
+
+	updatedPlayoffSeries, err := s.playoffStore.UpdatePlayoffSeries(ctx, playoffSeriesUpdates)
+	if err != nil {
+		return fmt.Errorf("failed to update playoff series for season %d: %w", seasonStartYear, err)
+	}
+
+	logger.InfoContext(ctx, fmt.Sprintf("updated %d playoff series from %d playoff games", len(updatedPlayoffSeries), len(playoffGames)), slog.Int("season_start_year", seasonStartYear))
+
+	return nil
+}
+
+func (s *service) GetBracket(ctx context.Context, seasonStartYear int) (api.PlayoffBracket, error) {
+	ctx, span := otel.Tracer("playoff").Start(ctx, "playoff.service.GetBracket")
+	defer span.End()
+
+	playoffSeries, err := s.playoffStore.GetPlayoffSeries(ctx, seasonStartYear)
+	if err != nil {
+		return api.PlayoffBracket{}, err
+	}
+
+	if len(playoffSeries) == 0 {
+		return api.PlayoffBracket{}, util.NewError(util.ErrNotFound, "no playoff series found for season")
+	}
+
+	return bracket(seasonStartYear, playoffSeries), nil
+}
+
+func bracket(seasonStartYear int, playoffSeries []api.PlayoffSeries) api.PlayoffBracket {
+	b := api.PlayoffBracket{SeasonStartYear: seasonStartYear, PlayIn: []api.PlayoffSeries{}, Rounds: []api.PlayoffRound{}}
+
+	roundIndexes := map[int]int{}
+	for _, series := range playoffSeries {
+		if series.PlayIn {
+			b.PlayIn = append(b.PlayIn, series)
+			continue
+		}
+
+		i, ok := roundIndexes[series.Round]
+		if !ok {
+			i = len(b.Rounds)
+			roundIndexes[series.Round] = i
+			b.Rounds = append(b.Rounds, api.PlayoffRound{Round: series.Round, Name: RoundName(series.Round)})
+		}
+		b.Rounds[i].Series = append(b.Rounds[i].Series, series)
+	}
+
+	sort.Slice(b.Rounds, func(i, j int) bool { return b.Rounds[i].Round < b.Rounds[j].Round })
+
+	return b
+}
+
+type playoffSeriesKey struct {
+	playIn       bool
+	round        int
+	seriesNumber int
+}
+
+type playoffSeriesGame struct {
+	PlayoffGame
+	gameNumber int
+}
+
+// playoffSeriesUpdates groups the playoff games of a season into series using the round, series and game number
+// encoded in their nba game ids, each play-in game is its own single game series
+func playoffSeriesUpdates(seasonStartYear int, playoffGames []PlayoffGame) []PlayoffSeriesUpdate {
+	var keys []playoffSeriesKey
+	seriesGames := map[playoffSeriesKey][]playoffSeriesGame{}
+
+	for _, playoffGame := range playoffGames {
+		gameID, ok := nba.ParsePlayoffGameID(playoffGame.NBAGameID)
+		if !ok {
+			continue
+		}
+
+		key := playoffSeriesKey{playIn: gameID.PlayIn, round: gameID.Round, seriesNumber: gameID.SeriesNumber}
+		if _, ok := seriesGames[key]; !ok {
+			keys = append(keys, key)
+		}
+		seriesGames[key] = append(seriesGames[key], playoffSeriesGame{PlayoffGame: playoffGame, gameNumber: gameID.GameNumber})
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].playIn != keys[j].playIn {
+			return keys[i].playIn
+		}
+		if keys[i].round != keys[j].round {
+			return keys[i].round < keys[j].round
+		}
+		return keys[i].seriesNumber < keys[j].seriesNumber
+	})
+
+	var updates []PlayoffSeriesUpdate
+	for _, key := range keys {
+		updates = append(updates, playoffSeriesUpdate(seasonStartYear, key, seriesGames[key]))
+	}
+
+	return updates
+}
+
+func playoffSeriesUpdate(seasonStartYear int, key playoffSeriesKey, games []playoffSeriesGame) PlayoffSeriesUpdate {
+	sort.Slice(games, func(i, j int) bool { return games[i].gameNumber < games[j].gameNumber })
+
+	update := PlayoffSeriesUpdate{
+		SeasonStartYear: seasonStartYear,
+		PlayIn:          key.playIn,
+		Round:           key.round,
+		SeriesNumber:    key.seriesNumber,
+		WinsNeeded:      playoffSeriesWinsNeeded,
+		Status:          SeriesStatusScheduled,
+	}
+	if key.playIn {
+		update.WinsNeeded = playInWinsNeeded
+	}
+
+	// the team with home court hosts games 1, 2, 5 and 7 so use the first game the teams are known for
+	for _, g := range games {
+		if !g.HomeTeamID.Valid || !g.AwayTeamID.Valid {
+			continue
+		}
+
+		update.HighSeedTeamID, update.HighSeed = g.HomeTeamID, g.HomeTeamSeed
+		update.LowSeedTeamID, update.LowSeed = g.AwayTeamID, g.AwayTeamSeed
+		if g.gameNumber == 3 || g.gameNumber == 4 || g.gameNumber == 6 {
+			update.HighSeedTeamID, update.HighSeed = g.AwayTeamID, g.AwayTeamSeed
+			update.LowSeedTeamID, update.LowSeed = g.HomeTeamID, g.HomeTeamSeed
+		}
+		break
+	}
+
+	// the finals are between conferences
+	if key.playIn || key.round != finalsRound {
+		for _, g := range games {
+			if g.HomeTeamConference.Valid {
+				update.Conference = g.HomeTeamConference
+				break
+			}
+		}
+	}
+
+	started := false
+	for _, g := range games {
+		if g.GameStatusName != gameStatusScheduled {
+			started = true
+		}
+
+		if g.GameStatusName != gameStatusCompleted || !g.HomeTeamPoints.Valid || !g.AwayTeamPoints.Valid {
+			continue
+		}
+
+		winnerTeamID := g.HomeTeamID
+		if g.AwayTeamPoints.Int64 > g.HomeTeamPoints.Int64 {
+			winnerTeamID = g.AwayTeamID
+		}
+		if !winnerTeamID.Valid {
+			continue
+		}
+
+		switch winnerTeamID {
+		case update.HighSeedTeamID:
+			update.HighSeedWins++
+		case update.LowSeedTeamID:
+			update.LowSeedWins++
+		}
+	}
+
+	decided := false
+	if update.HighSeedWins >= update.WinsNeeded {
+		decided = true
+		update.WinnerTeamID = update.HighSeedTeamID
+	} else if update.LowSeedWins >= update.WinsNeeded {
+		decided = true
+		update.WinnerTeamID = update.LowSeedTeamID
+	}
+
+	for _, g := range games {
+		// if necessary games left on the schedule are not going to be played once the series is over
+		if decided && g.IfNecessary && g.GameStatusName == gameStatusScheduled {
+			continue
+		}
+		update.GamesScheduled++
+	}
+
+	switch {
+	case decided:
+		update.Status = SeriesStatusCompleted
+	case started:
+		update.Status = SeriesStatusInProgress
+	}
+
+	return update
+}
@@ -0,0 +1,48 @@
+package playoff
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+)
+
+type Store interface {
+	GetPlayoffGames(ctx context.Context, seasonStartYear int) ([]PlayoffGame, error)
+	UpdatePlayoffSeries(ctx context.Context, playoffSeriesUpdates []PlayoffSeriesUpdate) ([]api.PlayoffSeries, error)
+	GetPlayoffSeries(ctx context.Context, seasonStartYear int) ([]api.PlayoffSeries, error)
+}
+
+// PlayoffGame is a stored playoff or play-in game, including the if necessary games still on the schedule
+type PlayoffGame struct {
+	NBAGameID          string
+	HomeTeamID         sql.NullString
+	AwayTeamID         sql.NullString
+	HomeTeamSeed       sql.NullInt64
+	AwayTeamSeed       sql.NullInt64
+	HomeTeamConference sql.NullString
+	HomeTeamPoints     sql.NullInt64
+	AwayTeamPoints     sql.NullInt64
+	GameStatusName     string
+	IfNecessary        bool
+	StartTime          time.Time
+}
+
+type PlayoffSeriesUpdate struct {
+	SeasonStartYear int
+	PlayIn          bool
+	Round           int
+	SeriesNumber    int
+	Conference      sql.NullString
+	HighSeedTeamID  sql.NullString
+	LowSeedTeamID   sql.NullString
+	HighSeed        sql.NullInt64
+	LowSeed         sql.NullInt64
+	HighSeedWins    int
+	LowSeedWins     int
+	WinsNeeded      int
+	GamesScheduled  int
+	Status          string
+	WinnerTeamID    sql.NullString
+}
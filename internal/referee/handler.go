@@ -0,0 +1,79 @@
+package referee
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"go.opentelemetry.io/otel"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler interface {
+	Routes() chi.Router
+	List(w http.ResponseWriter, r *http.Request)
+	Get(w http.ResponseWriter, r *http.Request)
+}
+
+func NewHandler(logger *slog.Logger, refereeService Service) Handler {
+	return &handler{logger: logger, refereeService: refereeService}
+}
+
+type handler struct {
+	logger         *slog.Logger
+	refereeService Service
+}
+
+func (h *handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.List)
+
+	r.Get("/{id}", h.Get)
+
+	return r
+}
+
+func (h *handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("referee").Start(r.Context(), "referee.handler.List")
+	defer span.End()
+
+	referees, err := h.refereeService.ListReferees(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list referees", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteCacheableListJSON(w, r, referees, util.CacheControlRevalidate)
+}
+
+func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("referee").Start(r.Context(), "referee.handler.Get")
+	defer span.End()
+
+	refereeID := chi.URLParam(r, "id")
+
+	nbaTeamID := util.TimberwolvesNBATeamID
+	if nbaTeamIDStr := r.URL.Query().Get("nba-team-id"); nbaTeamIDStr != "" {
+		var err error
+		nbaTeamID, err = strconv.Atoi(nbaTeamIDStr)
+		if err != nil {
+			util.WriteProblem(w, r, http.StatusBadRequest, "invalid nba-team-id")
+			return
+		}
+	}
+
+	logger := h.logger.With(slog.String("referee_id", refereeID), slog.Int("nba_team_id", nbaTeamID))
+
+	referee, err := h.refereeService.GetRefereeDetails(ctx, refereeID, nbaTeamID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get referee", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteCacheableListJSON(w, r, referee, util.CacheControlRevalidate)
+}
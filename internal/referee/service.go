@@ -2,13 +2,22 @@ package referee
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"github.com/jackc/pgx/v5"
 	"go.opentelemetry.io/otel"
 )
 
+// AssignmentCrewChief is the boxscore assignment of the referee leading the crew
+const AssignmentCrewChief = "OFFICIAL1"
+
 type Service interface {
 	UpdateReferees(ctx context.Context, refereeUpdates []RefereeUpdate) ([]api.Referee, error)
+	ListReferees(ctx context.Context) ([]api.Referee, error)
+	GetRefereeDetails(ctx context.Context, id string, nbaTeamID int) (api.RefereeDetails, error)
 }
 
 func NewService(refereeStore Store) Service {
@@ -25,3 +34,75 @@ func (s service) UpdateReferees(ctx context.Context, refereeUpdates []RefereeUpd
 
 	return s.RefereeStore.UpdateReferees(ctx, refereeUpdates)
 }
+
+func (s service) ListReferees(ctx context.Context) ([]api.Referee, error) {
+	ctx, span := otel.Tracer("referee").Start(ctx, "referee.service.ListReferees")
+	defer span.End()
+
+	return s.RefereeStore.ListReferees(ctx)
+}
+
+// GetRefereeDetails returns the referee's assignments by season, their foul tendencies, and the record of the team
+// with nbaTeamID in games the referee was crew chief
+func (s service) GetRefereeDetails(ctx context.Context, id string, nbaTeamID int) (api.RefereeDetails, error) {
+	ctx, span := otel.Tracer("referee").Start(ctx, "referee.service.GetRefereeDetails")
+	defer span.End()
+
+	referee, err := s.RefereeStore.GetRefereeWithID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.RefereeDetails{}, util.NewError(util.ErrNotFound, "referee not found")
+		}
+		return api.RefereeDetails{}, fmt.Errorf("failed to get referee: %w", err)
+	}
+
+	assignments, err := s.RefereeStore.GetRefereeAssignments(ctx, id)
+	if err != nil {
+		return api.RefereeDetails{}, fmt.Errorf("failed to get referee assignments: %w", err)
+	}
+
+	foulTotals, err := s.RefereeStore.GetRefereeFoulTotals(ctx, id)
+	if err != nil {
+		return api.RefereeDetails{}, fmt.Errorf("failed to get referee foul totals: %w", err)
+	}
+
+	crewChiefRecord, err := s.RefereeStore.GetRefereeCrewChiefRecord(ctx, id, nbaTeamID)
+	if err != nil {
+		return api.RefereeDetails{}, fmt.Errorf("failed to get team record with referee as crew chief: %w", err)
+	}
+
+	details := api.RefereeDetails{
+		Referee:         referee,
+		Seasons:         []api.RefereeSeason{},
+		CrewChiefRecord: crewChiefRecord,
+	}
+
+	// assignments come back newest first so seasons do as well
+	for _, assignment := range assignments {
+		if len(details.Seasons) == 0 || details.Seasons[len(details.Seasons)-1].Season != assignment.Season {
+			details.Seasons = append(details.Seasons, api.RefereeSeason{Season: assignment.Season})
+		}
+
+		season := &details.Seasons[len(details.Seasons)-1]
+		season.Games++
+		if assignment.Assignment != nil && *assignment.Assignment == AssignmentCrewChief {
+			season.CrewChiefGames++
+		}
+		season.Assignments = append(season.Assignments, assignment)
+	}
+
+	details.Tendencies.Games = foulTotals.Games
+	if foulTotals.Games > 0 {
+		games := float64(foulTotals.Games)
+		details.Tendencies.FoulsPerGame = float64(foulTotals.Fouls) / games
+		details.Tendencies.HomeFoulsPerGame = float64(foulTotals.HomeFouls) / games
+		details.Tendencies.AwayFoulsPerGame = float64(foulTotals.AwayFouls) / games
+		details.Tendencies.HomeAwayFoulDifferential = details.Tendencies.HomeFoulsPerGame - details.Tendencies.AwayFoulsPerGame
+		details.Tendencies.TechnicalsPerGame = float64(foulTotals.Technicals) / games
+	}
+	if foulTotals.FieldGoalsAttempted > 0 {
+		details.Tendencies.FreeThrowRate = float64(foulTotals.FreeThrowsAttempted) / float64(foulTotals.FieldGoalsAttempted)
+	}
+
+	return details, nil
+}
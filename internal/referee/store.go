@@ -8,6 +8,11 @@ import (
 
 type Store interface {
 	UpdateReferees(ctx context.Context, refereeUpdates []RefereeUpdate) ([]api.Referee, error)
+	ListReferees(ctx context.Context) ([]api.Referee, error)
+	GetRefereeWithID(ctx context.Context, id string) (api.Referee, error)
+	GetRefereeAssignments(ctx context.Context, refereeID string) ([]api.RefereeAssignment, error)
+	GetRefereeFoulTotals(ctx context.Context, refereeID string) (RefereeFoulTotals, error)
+	GetRefereeCrewChiefRecord(ctx context.Context, refereeID string, nbaTeamID int) (api.TeamRecord, error)
 }
 
 type RefereeUpdate struct {
@@ -16,3 +21,14 @@ type RefereeUpdate struct {
 	LastName     string
 	JerseyNumber int
 }
+
+// RefereeFoulTotals are the play by play totals across the completed games a referee has worked
+type RefereeFoulTotals struct {
+	Games               int
+	Fouls               int
+	HomeFouls           int
+	AwayFouls           int
+	Technicals          int
+	FreeThrowsAttempted int
+	FieldGoalsAttempted int
+}
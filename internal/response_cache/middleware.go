@@ -0,0 +1,147 @@
+package response_cache
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/drewthor/wolves_reddit_bot/util"
+)
+
+// Cache is a middleware that caches the GET responses written with util.WriteCacheableJSON or
+// util.WriteCacheableListJSON by their url and answers conditional requests for them without calling the handler. Tables
+// are every table the routes' responses are read from, a cached response is only used until one of them changes
+func Cache(responseCacheService Service, tables ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := r.URL.RequestURI()
+
+			response, generation, ok := responseCacheService.Get(key, tables)
+			if ok {
+				for name, values := range response.Header {
+					w.Header()[name] = values
+				}
+				if util.NotModified(r, response.ETag, response.LastModified) {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write(response.Body)
+				return
+			}
+
+			if generation == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// the handler writes the whole response to fill the cache even when the request is conditional, the writer
+			// still answers the conditional request with not modified when the response matches it
+			req := r.Clone(r.Context())
+			req.Header.Del("If-None-Match")
+			req.Header.Del("If-Modified-Since")
+
+			ww := &conditionalWriter{ResponseWriter: w, r: r, body: &limitedBuffer{limit: maxBodySize}}
+
+			next.ServeHTTP(ww, req)
+
+			etag := ww.Header().Get("ETag")
+			if ww.status != http.StatusOK || etag == "" || ww.body.overflowed {
+				return
+			}
+
+			lastModified, _ := http.ParseTime(ww.Header().Get("Last-Modified"))
+			responseCacheService.Add(key, generation, tables, Response{
+				Header:       ww.Header().Clone(),
+				Body:         ww.body.Bytes(),
+				ETag:         etag,
+				LastModified: lastModified,
+			})
+		})
+	}
+}
+
+// conditionalWriter copies the response the handler writes into body and answers with not modified instead when the
+// response's validators match the conditional request r
+type conditionalWriter struct {
+	http.ResponseWriter
+	r           *http.Request
+	body        *limitedBuffer
+	status      int
+	notModified bool
+}
+
+func (w *conditionalWriter) WriteHeader(status int) {
+	if w.status != 0 {
+		return
+	}
+	w.status = status
+
+	if etag := w.Header().Get("ETag"); status == http.StatusOK && etag != "" {
+		lastModified, _ := http.ParseTime(w.Header().Get("Last-Modified"))
+		if util.NotModified(w.r, etag, lastModified) {
+			w.notModified = true
+			status = http.StatusNotModified
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *conditionalWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	w.body.Write(p)
+	if w.notModified {
+		return len(p), nil
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush and Hijack pass through so streamed and upgraded responses behind the middleware keep working
+
+func (w *conditionalWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *conditionalWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer doesn't support hijacking")
+	}
+	return hijacker.Hijack()
+}
+
+func (w *conditionalWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// limitedBuffer buffers writes up to its limit, once a write goes past it the buffer is dropped
+type limitedBuffer struct {
+	bytes.Buffer
+	limit      int
+	overflowed bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.overflowed {
+		return len(p), nil
+	}
+	if b.Len()+len(p) > b.limit {
+		b.overflowed = true
+		b.Reset()
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
@@ -0,0 +1,119 @@
+package response_cache
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/util"
+)
+
+// listeningStore is always listening and never reports a change
+type listeningStore struct{}
+
+func (listeningStore) ListenRowChanges(ctx context.Context, onListening func(), onChange func(table string)) error {
+	onListening()
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestCacheNotModified(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	svc := NewService(listeningStore{}, 10)
+	go svc.Listen(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	for _, generation, _ := svc.Get("/", nil); generation == 0; _, generation, _ = svc.Get("/", nil) {
+		time.Sleep(time.Millisecond)
+	}
+
+	calls := 0
+	handler := Cache(svc, "team")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		util.WriteCacheableJSON(w, r, map[string]string{"team": "wolves"}, util.CacheControlRevalidate)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/teams", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusOK)
+	}
+	etag := rec.Header().Get("ETag")
+
+	tests := []struct {
+		name   string
+		cached bool
+	}{
+		{name: "cold cache", cached: false},
+		{name: "cached", cached: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.cached {
+				svc.(*service).responses.Purge()
+			}
+			calls = 0
+
+			req := httptest.NewRequest(http.MethodGet, "/teams", nil)
+			req.Header.Set("If-None-Match", etag)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusNotModified {
+				t.Errorf("got status %d, want %d", rec.Code, http.StatusNotModified)
+			}
+			if rec.Body.Len() != 0 {
+				t.Errorf("got body %q, want none", rec.Body.String())
+			}
+			if wantCalls := map[bool]int{false: 1, true: 0}[tt.cached]; calls != wantCalls {
+				t.Errorf("handler called %d times, want %d", calls, wantCalls)
+			}
+		})
+	}
+
+	// the cold cache request still filled the cache with the full response
+	response, _, ok := svc.Get("/teams", []string{"team"})
+	if !ok || len(response.Body) == 0 {
+		t.Errorf("response wasn't cached")
+	}
+}
+
+func TestInvalidateTable(t *testing.T) {
+	svc := NewService(listeningStore{}, 10).(*service)
+	svc.startListening()
+
+	teams, players := []string{"team", "franchise"}, []string{"player"}
+	for key, tables := range map[string][]string{"/teams": teams, "/players": players} {
+		_, generation, _ := svc.Get(key, tables)
+		svc.Add(key, generation, tables, Response{ETag: `"` + key + `"`})
+	}
+
+	// a response read before the change is stale by the time it's added
+	_, generation, _ := svc.Get("/franchises", teams)
+
+	svc.tableChanged("franchise")
+	svc.Add("/franchises", generation, teams, Response{ETag: `"/franchises"`})
+
+	tests := []struct {
+		key    string
+		tables []string
+		cached bool
+	}{
+		{key: "/teams", tables: teams, cached: false},
+		{key: "/franchises", tables: teams, cached: false},
+		{key: "/players", tables: players, cached: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if _, _, ok := svc.Get(tt.key, tt.tables); ok != tt.cached {
+				t.Errorf("got cached %t, want %t", ok, tt.cached)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package response_cache
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/pkg/lru"
+)
+
+const (
+	// maxBodySize is the largest response that's cached
+	maxBodySize = 1 << 20
+	// listenRetryWait is how long to wait before listening again after the connection fails
+	listenRetryWait = 5 * time.Second
+)
+
+// Response is a cached response with its validators
+type Response struct {
+	Header       http.Header
+	Body         []byte
+	ETag         string
+	LastModified time.Time
+}
+
+type Service interface {
+	// Get returns the cached response of the key unless one of the tables it was read from changed since it was cached.
+	// When there isn't one it returns the cache's generation to Add the response with, a generation of 0 means
+	// responses can't be cached right now
+	Get(key string, tables []string) (Response, uint64, bool)
+	// Add caches the response of the key read from the tables unless one of them changed since the generation
+	Add(key string, generation uint64, tables []string, response Response)
+	// Listen invalidates the responses read from a table whenever the ingest path changes one of its rows until ctx is
+	// done. The cache is bypassed while it isn't listening since it wouldn't know when responses go stale
+	Listen(ctx context.Context, logger *slog.Logger)
+}
+
+func NewService(responseCacheStore Store, size int) Service {
+	return &service{
+		responseCacheStore: responseCacheStore,
+		responses:          lru.New[string, cachedResponse](size),
+		tableGenerations:   map[string]uint64{},
+	}
+}
+
+// cachedResponse is a response with the tables it was read from and the generation it was read at
+type cachedResponse struct {
+	response   Response
+	tables     []string
+	generation uint64
+}
+
+type service struct {
+	responseCacheStore Store
+	responses          *lru.Cache[string, cachedResponse]
+
+	mu sync.Mutex
+	// generation goes up every time a table changes, it's 0 while not listening
+	generation     uint64
+	lastGeneration uint64
+	// listenGeneration is the generation it started listening at, responses read before it could have missed changes
+	listenGeneration uint64
+	// tableGenerations are the generation of each table's last change, a response is stale once one of its tables
+	// changed after the generation it was read at
+	tableGenerations map[string]uint64
+}
+
+func (s *service) Get(key string, tables []string) (Response, uint64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.generation == 0 {
+		return Response{}, 0, false
+	}
+
+	if cached, ok := s.responses.Get(key); ok && !s.changedSince(cached.tables, cached.generation) {
+		return cached.response, s.generation, true
+	}
+
+	return Response{}, s.generation, false
+}
+
+func (s *service) Add(key string, generation uint64, tables []string, response Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// holding the lock so a change can't be missed between the check and the add
+	if generation == 0 || s.generation == 0 || generation < s.listenGeneration || s.changedSince(tables, generation) {
+		return
+	}
+
+	s.responses.Add(key, cachedResponse{response: response, tables: tables, generation: generation})
+}
+
+// changedSince reports whether one of the tables changed after the generation, callers hold mu
+func (s *service) changedSince(tables []string, generation uint64) bool {
+	for _, table := range tables {
+		if s.tableGenerations[table] > generation {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *service) Listen(ctx context.Context, logger *slog.Logger) {
+	for {
+		err := s.responseCacheStore.ListenRowChanges(ctx, s.startListening, s.tableChanged)
+		s.stopListening()
+
+		if ctx.Err() != nil {
+			return
+		}
+		logger.ErrorContext(ctx, "stopped listening for row changes, responses aren't cached until it listens again", slog.Any("error", err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryWait):
+		}
+	}
+}
+
+// startListening purges the cache since rows could have changed while it wasn't listening
+func (s *service) startListening() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses.Purge()
+	clear(s.tableGenerations)
+	s.lastGeneration++
+	s.generation = s.lastGeneration
+	s.listenGeneration = s.generation
+}
+
+func (s *service) stopListening() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses.Purge()
+	s.generation = 0
+}
+
+// tableChanged starts a new generation that the responses read from the table are stale after
+func (s *service) tableChanged(table string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastGeneration++
+	s.generation = s.lastGeneration
+	s.tableGenerations[table] = s.generation
+}
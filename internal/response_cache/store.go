@@ -0,0 +1,9 @@
+package response_cache
+
+import "context"
+
+type Store interface {
+	// ListenRowChanges calls onListening once it's listening then onChange with the table's name after every committed
+	// change to a table responses are cached from, until ctx is done or the connection fails
+	ListenRowChanges(ctx context.Context, onListening func(), onChange func(table string)) error
+}
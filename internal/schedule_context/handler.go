@@ -0,0 +1,63 @@
+package schedule_context
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"go.opentelemetry.io/otel"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler interface {
+	Routes() chi.Router
+	GetTeamScheduleContexts(w http.ResponseWriter, r *http.Request)
+}
+
+func NewHandler(logger *slog.Logger, scheduleContextService Service) Handler {
+	return &handler{logger: logger, scheduleContextService: scheduleContextService}
+}
+
+type handler struct {
+	logger                 *slog.Logger
+	scheduleContextService Service
+}
+
+// Routes are mounted under a team
+func (h *handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.GetTeamScheduleContexts)
+
+	return r
+}
+
+func (h *handler) GetTeamScheduleContexts(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("schedule_context").Start(r.Context(), "schedule_context.handler.GetTeamScheduleContexts")
+	defer span.End()
+
+	teamID := chi.URLParam(r, "teamID")
+
+	var seasonStartYear *int
+	if seasonStartYearStr := r.URL.Query().Get("season-start-year"); seasonStartYearStr != "" {
+		s, err := strconv.Atoi(seasonStartYearStr)
+		if err != nil {
+			util.WriteProblem(w, r, http.StatusBadRequest, "invalid season-start-year")
+			return
+		}
+		seasonStartYear = &s
+	}
+
+	logger := h.logger.With(slog.String("team_id", teamID))
+
+	scheduleContexts, err := h.scheduleContextService.GetTeamScheduleContexts(ctx, teamID, seasonStartYear)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get team schedule contexts", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteJSON(http.StatusOK, scheduleContexts, w)
+}
@@ -0,0 +1,89 @@
+package schedule_context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/internal/season"
+	"go.opentelemetry.io/otel"
+)
+
+type Service interface {
+	GetTeamScheduleContexts(ctx context.Context, teamID string, seasonStartYear *int) ([]api.ScheduleContext, error)
+	AddScheduleContextsToGames(ctx context.Context, games []api.Game) error
+}
+
+func NewService(scheduleContextStore Store, seasonService season.Service) Service {
+	return &service{scheduleContextStore: scheduleContextStore, seasonService: seasonService}
+}
+
+type service struct {
+	scheduleContextStore Store
+
+	seasonService season.Service
+}
+
+func (s *service) GetTeamScheduleContexts(ctx context.Context, teamID string, seasonStartYear *int) ([]api.ScheduleContext, error) {
+	ctx, span := otel.Tracer("schedule_context").Start(ctx, "schedule_context.service.GetTeamScheduleContexts")
+	defer span.End()
+
+	if seasonStartYear == nil {
+		currentSeasonStartYear, err := s.seasonService.GetCurrentSeasonStartYear(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current season start year: %w", err)
+		}
+		seasonStartYear = &currentSeasonStartYear
+	}
+
+	scheduleContexts, err := s.scheduleContextStore.GetTeamScheduleContexts(ctx, teamID, *seasonStartYear)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule contexts for team: %w", err)
+	}
+
+	return scheduleContexts, nil
+}
+
+// AddScheduleContextsToGames fills in the home and away schedule context of each game in place
+func (s *service) AddScheduleContextsToGames(ctx context.Context, games []api.Game) error {
+	ctx, span := otel.Tracer("schedule_context").Start(ctx, "schedule_context.service.AddScheduleContextsToGames")
+	defer span.End()
+
+	if len(games) == 0 {
+		return nil
+	}
+
+	gameIDs := make([]string, 0, len(games))
+	for _, game := range games {
+		gameIDs = append(gameIDs, game.ID)
+	}
+
+	scheduleContexts, err := s.scheduleContextStore.GetScheduleContextsForGames(ctx, gameIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get schedule contexts for games: %w", err)
+	}
+
+	type gameTeam struct {
+		gameID string
+		teamID string
+	}
+	scheduleContextsByGameTeam := make(map[gameTeam]api.ScheduleContext, len(scheduleContexts))
+	for _, scheduleContext := range scheduleContexts {
+		scheduleContextsByGameTeam[gameTeam{gameID: scheduleContext.GameID, teamID: scheduleContext.TeamID}] = scheduleContext
+	}
+
+	for i, game := range games {
+		if game.HomeTeamID != nil {
+			if scheduleContext, ok := scheduleContextsByGameTeam[gameTeam{gameID: game.ID, teamID: *game.HomeTeamID}]; ok {
+				games[i].HomeScheduleContext = &scheduleContext
+			}
+		}
+		if game.AwayTeamID != nil {
+			if scheduleContext, ok := scheduleContextsByGameTeam[gameTeam{gameID: game.ID, teamID: *game.AwayTeamID}]; ok {
+				games[i].AwayScheduleContext = &scheduleContext
+			}
+		}
+	}
+
+	return nil
+}
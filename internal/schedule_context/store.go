@@ -0,0 +1,12 @@
+package schedule_context
+
+import (
+	"context"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+)
+
+type Store interface {
+	GetTeamScheduleContexts(ctx context.Context, teamID string, seasonStartYear int) ([]api.ScheduleContext, error)
+	GetScheduleContextsForGames(ctx context.Context, gameIDs []string) ([]api.ScheduleContext, error)
+}
@@ -2,12 +2,23 @@ package scheduler
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/internal/chart"
 	"github.com/drewthor/wolves_reddit_bot/internal/game"
+	"github.com/drewthor/wolves_reddit_bot/internal/injury_report"
+	"github.com/drewthor/wolves_reddit_bot/internal/milestone"
+	"github.com/drewthor/wolves_reddit_bot/internal/notification"
+	"github.com/drewthor/wolves_reddit_bot/internal/odds"
 	"github.com/drewthor/wolves_reddit_bot/internal/season"
+	"github.com/drewthor/wolves_reddit_bot/internal/stat_correction"
+	"github.com/drewthor/wolves_reddit_bot/internal/webhook"
 	"go.opentelemetry.io/otel"
 
 	"github.com/drewthor/wolves_reddit_bot/apis/nba"
@@ -15,6 +26,9 @@ import (
 	"github.com/go-co-op/gocron"
 )
 
+// refereeRefreshWindow is how long before tipoff the referee assignments of a game are refreshed
+const refereeRefreshWindow = 90 * time.Minute
+
 type Service interface {
 	Start(logger *slog.Logger)
 	Stop()
@@ -23,22 +37,62 @@ type Service interface {
 type service struct {
 	scheduler *gocron.Scheduler
 
-	gameService   game.Service
-	seasonService season.Service
+	chartService        chart.Service
+	gameService         game.Service
+	injuryReportService injury_report.Service
+	milestoneService    milestone.Service
+	oddsService         odds.Service
+	seasonService       season.Service
+
+	statCorrectionService stat_correction.Service
+	// statCorrectionWindow is how far back completed games are checked for stat corrections
+	statCorrectionWindow time.Duration
+
+	webhookService webhook.Service
+
+	notificationService notification.Service
+	// games are the last update of each game being followed so notifications can compare against it
+	gamesMu sync.Mutex
+	games   map[string]api.Game
 
 	nbaClient nba.Client
 }
 
-func NewService(gameService game.Service, seasonService season.Service, nbaClient nba.Client) Service {
+func NewService(
+	gameService game.Service,
+	seasonService season.Service,
+	chartService chart.Service,
+	injuryReportService injury_report.Service,
+	milestoneService milestone.Service,
+	oddsService odds.Service,
+	statCorrectionService stat_correction.Service,
+	statCorrectionWindow time.Duration,
+	webhookService webhook.Service,
+	notificationService notification.Service,
+	nbaClient nba.Client,
+) Service {
 	scheduler := gocron.NewScheduler(time.UTC)
 
 	scheduler.TagsUnique()
 
 	return &service{
-		scheduler:     scheduler,
-		gameService:   gameService,
-		seasonService: seasonService,
-		nbaClient:     nbaClient,
+		scheduler:           scheduler,
+		chartService:        chartService,
+		gameService:         gameService,
+		injuryReportService: injuryReportService,
+		milestoneService:    milestoneService,
+		oddsService:         oddsService,
+		seasonService:       seasonService,
+
+		statCorrectionService: statCorrectionService,
+		statCorrectionWindow:  statCorrectionWindow,
+
+		webhookService: webhookService,
+
+		notificationService: notificationService,
+		games:               map[string]api.Game{},
+
+		nbaClient: nbaClient,
 	}
 }
 
@@ -46,6 +100,13 @@ func (s *service) Start(logger *slog.Logger) {
 	s.scheduler.Every(5).Minutes().Do(s.getTodaysGamesAndAddToJobs, logger)
 	// 11am UTC or 3/4 am LA time
 	s.scheduler.Every(1).Day().At("11:00").Do(s.updateSeasonWeeks, logger)
+	s.scheduler.Every(15).Minutes().Do(s.updateTodaysOdds, logger)
+	// the injury report is published hourly on game days and is usually up by 45 minutes past the hour
+	s.scheduler.Cron("45 * * * *").Do(s.updateInjuryReport, logger)
+	// 10am UTC is after the previous night's games have ended in every time zone
+	s.scheduler.Every(1).Day().At("10:00").Do(s.sweepStatCorrections, logger)
+	// singleton so a slow batch of deliveries isn't claimed again by the next run
+	s.scheduler.Every(10).Seconds().SingletonMode().Do(s.deliverWebhooks, logger)
 
 	s.scheduler.StartAsync()
 }
@@ -63,6 +124,59 @@ func (s *service) updateSeasonWeeks(logger *slog.Logger) {
 	}
 }
 
+func (s *service) updateInjuryReport(logger *slog.Logger) {
+	ctx := context.Background()
+	ctx, span := otel.Tracer("scheduler").Start(ctx, "scheduler.service.updateInjuryReport")
+	defer span.End()
+
+	if err := s.injuryReportService.UpdateInjuryReport(ctx, logger, time.Now()); err != nil {
+		if errors.Is(err, nba.ErrNotFound) {
+			// no report is published on days without games
+			logger.InfoContext(ctx, "no injury report published for the current hour")
+			return
+		}
+		logger.ErrorContext(ctx, "failed to update injury report during scheduled job", slog.Any("error", err))
+	}
+}
+
+func (s *service) sweepStatCorrections(logger *slog.Logger) {
+	ctx := context.Background()
+	ctx, span := otel.Tracer("scheduler").Start(ctx, "scheduler.service.sweepStatCorrections")
+	defer span.End()
+
+	corrections, err := s.statCorrectionService.SweepCorrections(ctx, logger, s.statCorrectionWindow)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to sweep stat corrections during scheduled job", slog.Any("error", err))
+		return
+	}
+
+	logger.InfoContext(ctx, "swept completed games for stat corrections", slog.Duration("window", s.statCorrectionWindow), slog.Int("corrections", len(corrections)))
+}
+
+func (s *service) deliverWebhooks(logger *slog.Logger) {
+	ctx := context.Background()
+	ctx, span := otel.Tracer("scheduler").Start(ctx, "scheduler.service.deliverWebhooks")
+	defer span.End()
+
+	delivered, err := s.webhookService.DeliverPending(ctx, logger)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to deliver webhooks during scheduled job", slog.Any("error", err))
+	}
+	if delivered > 0 {
+		logger.InfoContext(ctx, "delivered webhooks", slog.Int("delivered", delivered))
+	}
+}
+
+func (s *service) updateTodaysOdds(logger *slog.Logger) {
+	ctx := context.Background()
+	ctx, span := otel.Tracer("scheduler").Start(ctx, "scheduler.service.updateTodaysOdds")
+	defer span.End()
+
+	if err := s.oddsService.UpdateTodaysOdds(ctx, logger); err != nil {
+		logger.ErrorContext(ctx, "failed to update todays odds during scheduled job", slog.Any("error", err))
+	}
+}
+
 func (s *service) getTodaysGamesAndAddToJobs(logger *slog.Logger) {
 	ctx := context.Background()
 	ctx, span := otel.Tracer("scheduler").Start(ctx, "scheduler.service.getTodaysGamesAndAddToJobs")
@@ -101,6 +215,14 @@ func (s *service) getTodaysGamesAndAddToJobs(logger *slog.Logger) {
 			// job already exists; just update the start time in case it has changed
 			job := jobs[0]
 			s.scheduler.Job(job).StartAt(startTimeUTC).Update()
+
+			// store the referee assignments of games about to tip off as soon as the boxscore lists them instead of
+			// waiting for the game job to start
+			if untilStart := time.Until(startTimeUTC); untilStart > 0 && untilStart <= refereeRefreshWindow {
+				if err := s.gameService.UpdateGameReferees(ctx, logger, gameID, seasonStartYear); err != nil {
+					logger.ErrorContext(ctx, "failed to refresh game referees before start via getTodaysGamesAndAddToJobs", slog.String("game_id", gameID), slog.Any("error", err))
+				}
+			}
 			continue
 		}
 
@@ -145,6 +267,20 @@ func (s *service) updateGame(logger *slog.Logger, gameID string) {
 		return
 	}
 
+	var previous *api.Game
+	s.gamesMu.Lock()
+	if previousGame, ok := s.games[gameID]; ok {
+		previous = &previousGame
+	}
+	if g.EndTime != nil {
+		delete(s.games, gameID)
+	} else {
+		s.games[gameID] = g
+	}
+	s.gamesMu.Unlock()
+
+	s.notificationService.NotifyGameUpdate(ctx, logger, previous, g)
+
 	if g.EndTime != nil {
 		jobs := []gocron.Job{}
 		for _, job := range s.scheduler.Jobs() {
@@ -154,5 +290,17 @@ func (s *service) updateGame(logger *slog.Logger, gameID string) {
 		if err != nil {
 			slog.Error("could not remove scheduled job", slog.String("tag", gameID), slog.Any("error", err))
 		}
+
+		if err := s.chartService.ArchiveGameCharts(ctx, logger, gameID); err != nil {
+			logger.ErrorContext(ctx, "failed to archive charts for completed game", slog.Any("error", err))
+		}
+
+		milestones, err := s.milestoneService.ListMilestones(ctx, milestone.ListFilter{GameID: sql.NullString{String: g.ID, Valid: true}})
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to get milestones for completed game", slog.Any("error", err))
+		}
+		for _, m := range milestones {
+			logger.InfoContext(ctx, "milestone reached in completed game", slog.String("type", m.Type), slog.String("stat", m.Stat), slog.Int("value", m.Value), slog.String("description", m.Description))
+		}
 	}
 }
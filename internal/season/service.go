@@ -15,6 +15,7 @@ type Service interface {
 	GetCurrentSeasonStartYear(ctx context.Context) (int, error)
 	UpdateSeasonForLeague(ctx context.Context, leagueID string, seasonStartYear int) (string, error)
 	UpdateSeasonWeeks(ctx context.Context) ([]SeasonWeek, error)
+	EnsureSeasonExistsForLeague(ctx context.Context, nbaLeagueID string, seasonStartYear int) error
 }
 
 func NewService(seasonStore Store, nbaClient nba.Client) Service {
@@ -57,6 +58,24 @@ func (s service) UpdateSeasonForLeague(ctx context.Context, leagueID string, sea
 	return "", nil
 }
 
+// EnsureSeasonExistsForLeague adds the season for the league if it doesn't exist yet, seasons are otherwise only seeded for
+// the seasons covered by the cdn
+func (s service) EnsureSeasonExistsForLeague(ctx context.Context, nbaLeagueID string, seasonStartYear int) error {
+	ctx, span := otel.Tracer("season").Start(ctx, "season.service.EnsureSeasonExistsForLeague")
+	defer span.End()
+
+	leagueID, err := strconv.Atoi(nbaLeagueID)
+	if err != nil {
+		return fmt.Errorf("failed to convert nba league id %s to int: %w", nbaLeagueID, err)
+	}
+
+	if err := s.seasonStore.EnsureSeasonExistsForNBALeague(ctx, leagueID, seasonStartYear); err != nil {
+		return fmt.Errorf("failed to ensure season %d exists for league %s: %w", seasonStartYear, nbaLeagueID, err)
+	}
+
+	return nil
+}
+
 func (s service) UpdateSeasonWeeks(ctx context.Context) ([]SeasonWeek, error) {
 	ctx, span := otel.Tracer("season").Start(ctx, "season.service.UpdateSeasonWeeks")
 	defer span.End()
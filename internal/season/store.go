@@ -34,4 +34,5 @@ type SeasonWeek struct {
 type Store interface {
 	UpdateSeasons(ctx context.Context, seasonUpdates []SeasonUpdate) ([]Season, error)
 	UpdateSeasonWeeks(ctx context.Context, seasonWeekUpdates []SeasonWeekUpdate) ([]SeasonWeek, error)
+	EnsureSeasonExistsForNBALeague(ctx context.Context, nbaLeagueID int, seasonStartYear int) error
 }
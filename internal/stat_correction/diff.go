@@ -0,0 +1,72 @@
+package stat_correction
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+)
+
+type statRowKey struct {
+	entity       string
+	teamID       string
+	playerID     string
+	actionNumber int64
+}
+
+func (r StatRow) key() statRowKey {
+	k := statRowKey{entity: r.Entity, actionNumber: r.ActionNumber.Int64}
+	// a player line is keyed by its team and player but a play can be reassigned to a different team or player
+	if r.Entity != EntityPlayByPlay {
+		k.teamID = r.TeamID.String
+		k.playerID = r.PlayerID.String
+	}
+	return k
+}
+
+// diffStatRows returns a correction for every value that changed between the rows stored before and after a game was
+// re-ingested. Rows that are new after re-ingesting have every value compared against null
+func diffStatRows(nbaGameID string, before []StatRow, after []StatRow) []StatCorrectionUpdate {
+	beforeRows := make(map[statRowKey]StatRow, len(before))
+	for _, row := range before {
+		beforeRows[row.key()] = row
+	}
+
+	var updates []StatCorrectionUpdate
+	for _, row := range after {
+		beforeValues := beforeRows[row.key()].Values
+
+		fields := make([]string, 0, len(row.Values))
+		for field := range row.Values {
+			fields = append(fields, field)
+		}
+		slices.Sort(fields)
+
+		for _, field := range fields {
+			oldValue := statValue(beforeValues[field])
+			newValue := statValue(row.Values[field])
+			if oldValue == newValue {
+				continue
+			}
+
+			updates = append(updates, StatCorrectionUpdate{
+				NBAGameID:    nbaGameID,
+				Entity:       row.Entity,
+				TeamID:       row.TeamID,
+				PlayerID:     row.PlayerID,
+				ActionNumber: row.ActionNumber,
+				Field:        field,
+				OldValue:     oldValue,
+				NewValue:     newValue,
+			})
+		}
+	}
+
+	return updates
+}
+
+func statValue(v any) sql.NullString {
+	if v == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: fmt.Sprint(v), Valid: true}
+}
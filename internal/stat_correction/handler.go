@@ -0,0 +1,103 @@
+package stat_correction
+
+import (
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/internal/api_key"
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"go.opentelemetry.io/otel"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler interface {
+	Routes() chi.Router
+	List(w http.ResponseWriter, r *http.Request)
+	Sweep(w http.ResponseWriter, r *http.Request)
+}
+
+func NewHandler(logger *slog.Logger, statCorrectionService Service) Handler {
+	return &handler{logger: logger, statCorrectionService: statCorrectionService}
+}
+
+type handler struct {
+	logger                *slog.Logger
+	statCorrectionService Service
+}
+
+func (h *handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.List)
+	r.With(api_key.RequireScope(api_key.ScopeIngest)).Post("/sweep", h.Sweep)
+
+	return r
+}
+
+func (h *handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("stat_correction").Start(r.Context(), "stat_correction.handler.List")
+	defer span.End()
+
+	filter := ListFilter{}
+	if gameID := r.URL.Query().Get("game-id"); gameID != "" {
+		filter.GameID = sql.NullString{String: gameID, Valid: true}
+	}
+	if entity := r.URL.Query().Get("entity"); entity != "" {
+		filter.Entity = sql.NullString{String: entity, Valid: true}
+	}
+	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
+		since, err := time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			util.WriteProblem(w, r, http.StatusBadRequest, "invalid since, expected an RFC 3339 time")
+			return
+		}
+		filter.Since = sql.NullTime{Time: since, Valid: true}
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			util.WriteProblem(w, r, http.StatusBadRequest, "invalid limit, expected a positive number")
+			return
+		}
+		filter.Limit = limit
+	}
+
+	corrections, err := h.statCorrectionService.ListStatCorrections(ctx, filter)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list stat corrections", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteJSON(http.StatusOK, corrections, w)
+}
+
+func (h *handler) Sweep(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("stat_correction").Start(r.Context(), "stat_correction.handler.Sweep")
+	defer span.End()
+
+	window := DefaultWindow
+	if windowHoursStr := r.URL.Query().Get("window-hours"); windowHoursStr != "" {
+		windowHours, err := strconv.Atoi(windowHoursStr)
+		if err != nil || windowHours <= 0 {
+			util.WriteProblem(w, r, http.StatusBadRequest, "invalid window-hours, expected a positive number")
+			return
+		}
+		window = time.Duration(windowHours) * time.Hour
+	}
+
+	logger := h.logger.With(slog.Duration("window", window))
+
+	corrections, err := h.statCorrectionService.SweepCorrections(ctx, logger, window)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to sweep stat corrections", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteJSON(http.StatusOK, corrections, w)
+}
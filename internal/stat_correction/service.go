@@ -0,0 +1,110 @@
+package stat_correction
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/internal/game"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	// DefaultWindow is how long after a game ends it is checked for corrections, the league usually finishes revising a
+	// boxscore within a couple days
+	DefaultWindow = 72 * time.Hour
+
+	defaultListLimit = 100
+	maxListLimit     = 500
+)
+
+type Service interface {
+	SweepCorrections(ctx context.Context, logger *slog.Logger, window time.Duration) ([]api.StatCorrection, error)
+	CorrectGame(ctx context.Context, logger *slog.Logger, nbaGameID string, seasonStartYear int) ([]api.StatCorrection, error)
+	ListStatCorrections(ctx context.Context, filter ListFilter) ([]api.StatCorrection, error)
+}
+
+func NewService(statCorrectionStore Store, gameService game.Service) Service {
+	return &service{statCorrectionStore: statCorrectionStore, gameService: gameService}
+}
+
+type service struct {
+	statCorrectionStore Store
+
+	gameService game.Service
+}
+
+// SweepCorrections re-ingests the games completed within the window and records what the league changed since each game
+// was last stored
+func (s *service) SweepCorrections(ctx context.Context, logger *slog.Logger, window time.Duration) ([]api.StatCorrection, error) {
+	ctx, span := otel.Tracer("stat_correction").Start(ctx, "stat_correction.service.SweepCorrections")
+	defer span.End()
+
+	games, err := s.statCorrectionStore.GetCompletedGamesSince(ctx, time.Now().Add(-window))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get completed games to check for corrections: %w", err)
+	}
+
+	corrections := []api.StatCorrection{}
+	for _, g := range games {
+		gameCorrections, err := s.CorrectGame(ctx, logger, g.NBAGameID, g.SeasonStartYear)
+		if err != nil {
+			// keep sweeping the rest of the games, this game is checked again on the next sweep
+			logger.ErrorContext(ctx, "failed to check game for corrections", slog.String("game_id", g.NBAGameID), slog.Any("error", err))
+			continue
+		}
+		corrections = append(corrections, gameCorrections...)
+	}
+
+	return corrections, nil
+}
+
+// CorrectGame re-ingests a completed game and records every stored value that changed
+func (s *service) CorrectGame(ctx context.Context, logger *slog.Logger, nbaGameID string, seasonStartYear int) ([]api.StatCorrection, error) {
+	ctx, span := otel.Tracer("stat_correction").Start(ctx, "stat_correction.service.CorrectGame")
+	defer span.End()
+
+	before, err := s.statCorrectionStore.GetGameStatRows(ctx, nbaGameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored stats before re-ingesting game: %w", err)
+	}
+
+	if _, err := s.gameService.UpdateGame(ctx, logger, nbaGameID, seasonStartYear); err != nil {
+		return nil, fmt.Errorf("failed to re-ingest game: %w", err)
+	}
+
+	after, err := s.statCorrectionStore.GetGameStatRows(ctx, nbaGameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored stats after re-ingesting game: %w", err)
+	}
+
+	statCorrectionUpdates := diffStatRows(nbaGameID, before, after)
+	if len(statCorrectionUpdates) == 0 {
+		return []api.StatCorrection{}, nil
+	}
+
+	corrections, err := s.statCorrectionStore.UpdateStatCorrections(ctx, statCorrectionUpdates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update stat corrections: %w", err)
+	}
+
+	logger.InfoContext(ctx, "recorded stat corrections for game", slog.String("game_id", nbaGameID), slog.Int("corrections", len(corrections)))
+
+	return corrections, nil
+}
+
+func (s *service) ListStatCorrections(ctx context.Context, filter ListFilter) ([]api.StatCorrection, error) {
+	ctx, span := otel.Tracer("stat_correction").Start(ctx, "stat_correction.service.ListStatCorrections")
+	defer span.End()
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+
+	return s.statCorrectionStore.ListStatCorrections(ctx, filter)
+}
@@ -0,0 +1,54 @@
+package stat_correction
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+)
+
+const (
+	EntityTeamGameStatsTotal   = "team_game_stats_total"
+	EntityPlayerGameStatsTotal = "player_game_stats_total"
+	EntityPlayByPlay           = "play_by_play"
+)
+
+type Store interface {
+	GetCompletedGamesSince(ctx context.Context, since time.Time) ([]CompletedGame, error)
+	GetGameStatRows(ctx context.Context, nbaGameID string) ([]StatRow, error)
+	UpdateStatCorrections(ctx context.Context, statCorrectionUpdates []StatCorrectionUpdate) ([]api.StatCorrection, error)
+	ListStatCorrections(ctx context.Context, filter ListFilter) ([]api.StatCorrection, error)
+}
+
+type ListFilter struct {
+	GameID sql.NullString
+	Entity sql.NullString
+	Since  sql.NullTime
+	Limit  int
+}
+
+type CompletedGame struct {
+	NBAGameID       string
+	SeasonStartYear int
+}
+
+// StatRow is a stored team total, player line or play of a game with the values that can be corrected by column name
+type StatRow struct {
+	Entity       string
+	TeamID       sql.NullString
+	PlayerID     sql.NullString
+	ActionNumber sql.NullInt64
+	Values       map[string]any
+}
+
+type StatCorrectionUpdate struct {
+	NBAGameID    string
+	Entity       string
+	TeamID       sql.NullString
+	PlayerID     sql.NullString
+	ActionNumber sql.NullInt64
+	Field        string
+	OldValue     sql.NullString
+	NewValue     sql.NullString
+}
@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/internal/api_key"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+const apiKeyColumns = `ak.id, ak.name, ak.prefix, ak.scopes, ak.rate_limit, ak.burst, ak.last_used_at, ak.revoked_at, ak.created_at, ak.updated_at`
+
+func (d DB) CreateAPIKey(ctx context.Context, keyCreate api_key.KeyCreate) (api.APIKey, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.CreateAPIKey")
+	defer span.End()
+
+	query := `
+		INSERT INTO nba.api_key
+			AS ak (name, prefix, key_hash, scopes, rate_limit, burst)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + apiKeyColumns
+
+	row := d.pgxPool.QueryRow(ctx, query,
+		keyCreate.Name,
+		keyCreate.Prefix,
+		keyCreate.KeyHash,
+		keyCreate.Scopes,
+		keyCreate.RateLimit,
+		keyCreate.Burst)
+
+	return scanAPIKey(row)
+}
+
+func (d DB) ListAPIKeys(ctx context.Context) ([]api.APIKey, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.ListAPIKeys")
+	defer span.End()
+
+	query := `SELECT ` + apiKeyColumns + ` FROM nba.api_key ak ORDER BY ak.created_at`
+
+	rows, err := d.pgxPool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	apiKeys := []api.APIKey{}
+
+	for rows.Next() {
+		apiKey, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read api keys: %w", err)
+	}
+
+	return apiKeys, nil
+}
+
+func (d DB) RevokeAPIKey(ctx context.Context, id string) (api.APIKey, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.RevokeAPIKey")
+	defer span.End()
+
+	// revoking a revoked key keeps when it was first revoked
+	query := `
+		UPDATE nba.api_key ak
+		SET revoked_at = coalesce(ak.revoked_at, now())
+		WHERE ak.id = $1
+		RETURNING ` + apiKeyColumns
+
+	return scanAPIKey(d.pgxPool.QueryRow(ctx, query, id))
+}
+
+func (d DB) GetAPIKeyWithHash(ctx context.Context, keyHash string) (api.APIKey, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetAPIKeyWithHash")
+	defer span.End()
+
+	query := `SELECT ` + apiKeyColumns + ` FROM nba.api_key ak WHERE ak.key_hash = $1 AND ak.revoked_at IS NULL`
+
+	return scanAPIKey(d.pgxPool.QueryRow(ctx, query, keyHash))
+}
+
+func (d DB) UpdateAPIKeysLastUsed(ctx context.Context, lastUsed map[string]time.Time) error {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.UpdateAPIKeysLastUsed")
+	defer span.End()
+
+	ids := make([]string, 0, len(lastUsed))
+	usedAts := make([]time.Time, 0, len(lastUsed))
+	for id, usedAt := range lastUsed {
+		ids = append(ids, id)
+		usedAts = append(usedAts, usedAt)
+	}
+
+	// a key used by more than one process keeps the latest use
+	query := `
+		UPDATE nba.api_key ak
+		SET last_used_at = u.last_used_at
+		FROM unnest($1::uuid[], $2::timestamptz[]) AS u(id, last_used_at)
+		WHERE ak.id = u.id AND (ak.last_used_at IS NULL OR ak.last_used_at < u.last_used_at)`
+
+	if _, err := d.pgxPool.Exec(ctx, query, ids, usedAts); err != nil {
+		return fmt.Errorf("failed to update api keys last used: %w", err)
+	}
+
+	return nil
+}
+
+func scanAPIKey(row pgx.Row) (api.APIKey, error) {
+	apiKey := api.APIKey{}
+
+	err := row.Scan(
+		&apiKey.ID,
+		&apiKey.Name,
+		&apiKey.Prefix,
+		&apiKey.Scopes,
+		&apiKey.RateLimit,
+		&apiKey.Burst,
+		&apiKey.LastUsedAt,
+		&apiKey.RevokedAt,
+		&apiKey.CreatedAt,
+		&apiKey.UpdatedAt)
+	if err != nil {
+		return api.APIKey{}, fmt.Errorf("failed to scan api key: %w", err)
+	}
+
+	return apiKey, nil
+}
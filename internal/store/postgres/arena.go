@@ -14,7 +14,7 @@ func (d DB) UpdateArenas(ctx context.Context, arenas []arena.ArenaUpdate) ([]api
 	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.UpdateArenas")
 	defer span.End()
 
-	tx, err := d.pgxPool.Begin(ctx)
+	tx, err := d.begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start db transaction: %w", err)
 	}
@@ -22,16 +22,19 @@ func (d DB) UpdateArenas(ctx context.Context, arenas []arena.ArenaUpdate) ([]api
 
 	insertArena := `
 		INSERT INTO nba.arena
-			as a(name, city, state, country, nba_arena_id)
-		VALUES ($1, $2, $3, $4, $5)
+			as a(name, city, state, country, timezone, latitude, longitude, nba_arena_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		ON CONFLICT (nba_arena_id) DO UPDATE
 		SET
 			name = coalesce(excluded.name, a.name),
 			city = coalesce(excluded.city, a.city),
 			state = coalesce(excluded.state, a.state),
 			country = coalesce(excluded.country, a.country),
+			timezone = coalesce(excluded.timezone, a.timezone),
+			latitude = coalesce(excluded.latitude, a.latitude),
+			longitude = coalesce(excluded.longitude, a.longitude),
 			nba_arena_id = coalesce(excluded.nba_arena_id, a.nba_arena_id)
-		RETURNING a.id, a.name, a.city, a.state, a.country, a.nba_arena_id, a.created_at, a.updated_at`
+		RETURNING a.id, a.name, a.city, a.state, a.country, a.timezone, a.latitude, a.longitude, a.nba_arena_id, a.created_at, a.updated_at`
 
 	bp := &pgx.Batch{}
 
@@ -41,6 +44,9 @@ func (d DB) UpdateArenas(ctx context.Context, arenas []arena.ArenaUpdate) ([]api
 			arena.City,
 			arena.State,
 			arena.Country,
+			arena.Timezone,
+			arena.Latitude,
+			arena.Longitude,
 			arena.NBAArenaID)
 	}
 
@@ -56,6 +62,9 @@ func (d DB) UpdateArenas(ctx context.Context, arenas []arena.ArenaUpdate) ([]api
 			&a.City,
 			&a.State,
 			&a.Country,
+			&a.Timezone,
+			&a.Latitude,
+			&a.Longitude,
 			&a.NBAArenaID,
 			&a.CreatedAt,
 			&a.UpdatedAt)
@@ -80,3 +89,44 @@ func (d DB) UpdateArenas(ctx context.Context, arenas []arena.ArenaUpdate) ([]api
 
 	return insertedArenas, nil
 }
+
+func (d DB) GetArenasWithIDs(ctx context.Context, ids []string) ([]api.Arena, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetArenasWithIDs")
+	defer span.End()
+
+	query := `
+		SELECT a.id, a.name, a.city, a.state, a.country, a.timezone, a.latitude, a.longitude, a.nba_arena_id, a.created_at, a.updated_at
+		FROM nba.arena a
+		WHERE a.id = ANY($1)`
+
+	rows, err := d.pgxPool.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get arenas: %w", err)
+	}
+	defer rows.Close()
+
+	arenas := []api.Arena{}
+
+	for rows.Next() {
+		a := api.Arena{}
+		err := rows.Scan(
+			&a.ID,
+			&a.Name,
+			&a.City,
+			&a.State,
+			&a.Country,
+			&a.Timezone,
+			&a.Latitude,
+			&a.Longitude,
+			&a.NBAArenaID,
+			&a.CreatedAt,
+			&a.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan arena: %w", err)
+		}
+
+		arenas = append(arenas, a)
+	}
+
+	return arenas, rows.Err()
+}
@@ -0,0 +1,192 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/internal/broadcast"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+func (d DB) UpdateBroadcasters(ctx context.Context, broadcasterUpdates []broadcast.BroadcasterUpdate) ([]api.Broadcaster, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.UpdateBroadcasters")
+	defer span.End()
+
+	tx, err := d.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start db transaction to update broadcasters: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	insertBroadcaster := `
+		INSERT INTO nba.broadcaster
+			as b(display, abbreviation, region_id, nba_broadcaster_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (nba_broadcaster_id) DO UPDATE
+		SET
+			display = coalesce(excluded.display, b.display),
+			abbreviation = coalesce(excluded.abbreviation, b.abbreviation),
+			region_id = coalesce(excluded.region_id, b.region_id)
+		RETURNING b.id, b.display, b.abbreviation, b.region_id, b.nba_broadcaster_id, b.created_at, b.updated_at`
+
+	bp := &pgx.Batch{}
+
+	for _, broadcasterUpdate := range broadcasterUpdates {
+		bp.Queue(insertBroadcaster,
+			broadcasterUpdate.Display,
+			broadcasterUpdate.Abbreviation,
+			broadcasterUpdate.RegionID,
+			broadcasterUpdate.NBABroadcasterID)
+	}
+
+	batchResults := tx.SendBatch(ctx, bp)
+
+	insertedBroadcasters := []api.Broadcaster{}
+
+	for range broadcasterUpdates {
+		b := api.Broadcaster{}
+
+		err := batchResults.QueryRow().Scan(
+			&b.ID,
+			&b.Display,
+			&b.Abbreviation,
+			&b.RegionID,
+			&b.NBABroadcasterID,
+			&b.CreatedAt,
+			&b.UpdatedAt)
+		if err != nil {
+			batchResults.Close()
+			return nil, fmt.Errorf("failed to scan upserted broadcaster: %w", err)
+		}
+
+		insertedBroadcasters = append(insertedBroadcasters, b)
+	}
+
+	err = batchResults.Close()
+	if err != nil {
+		return nil, fmt.Errorf("could not close batchResults when updating broadcasters: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not commit transaction when updating broadcasters: %w", err)
+	}
+
+	return insertedBroadcasters, nil
+}
+
+func (d DB) UpdateGameBroadcasts(ctx context.Context, gameBroadcastUpdates []broadcast.GameBroadcastUpdate) error {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.UpdateGameBroadcasts")
+	defer span.End()
+
+	tx, err := d.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start db transaction to update game broadcasts: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// broadcasts whose game or broadcaster isn't stored yet are skipped rather than failing the whole batch
+	insertGameBroadcast := `
+		INSERT INTO nba.game_broadcast
+			as gb(game_id, broadcaster_id, scope, media, tape_delay_comments)
+		SELECT g.id, b.id, $4, $5, $6
+		FROM nba.game g,
+		LATERAL (
+			SELECT id
+			FROM nba.broadcaster
+			WHERE nba_broadcaster_id = $2 OR ($2::integer IS NULL AND upper(abbreviation) = upper($3))
+			ORDER BY created_at
+			LIMIT 1
+		) b
+		WHERE g.nba_game_id = $1
+		ON CONFLICT (game_id, broadcaster_id, scope, media) DO UPDATE
+		SET
+			tape_delay_comments = coalesce(excluded.tape_delay_comments, gb.tape_delay_comments)`
+
+	bp := &pgx.Batch{}
+
+	for _, gameBroadcastUpdate := range gameBroadcastUpdates {
+		bp.Queue(insertGameBroadcast,
+			gameBroadcastUpdate.NBAGameID,
+			gameBroadcastUpdate.NBABroadcasterID,
+			gameBroadcastUpdate.BroadcasterAbbreviation,
+			string(gameBroadcastUpdate.Scope),
+			string(gameBroadcastUpdate.Media),
+			gameBroadcastUpdate.TapeDelayComments)
+	}
+
+	batchResults := tx.SendBatch(ctx, bp)
+
+	for range gameBroadcastUpdates {
+		if _, err := batchResults.Exec(); err != nil {
+			batchResults.Close()
+			return fmt.Errorf("failed to upsert game broadcast: %w", err)
+		}
+	}
+
+	err = batchResults.Close()
+	if err != nil {
+		return fmt.Errorf("could not close batchResults when updating game broadcasts: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("could not commit transaction when updating game broadcasts: %w", err)
+	}
+
+	return nil
+}
+
+func (d DB) GetGameBroadcasts(ctx context.Context, gameID string) ([]api.GameBroadcast, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetGameBroadcasts")
+	defer span.End()
+
+	query := `
+		SELECT gb.id, gb.game_id, gb.scope, gb.media, gb.tape_delay_comments, gb.created_at, gb.updated_at,
+			b.id, b.display, b.abbreviation, b.region_id, b.nba_broadcaster_id, b.created_at, b.updated_at
+		FROM nba.game_broadcast gb
+		JOIN nba.broadcaster b ON b.id = gb.broadcaster_id
+		WHERE gb.game_id = $1
+		ORDER BY gb.scope, gb.media, b.display`
+
+	rows, err := d.pgxPool.Query(ctx, query, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game broadcasts: %w", err)
+	}
+	defer rows.Close()
+
+	gameBroadcasts := []api.GameBroadcast{}
+
+	for rows.Next() {
+		gameBroadcast := api.GameBroadcast{}
+
+		err := rows.Scan(
+			&gameBroadcast.ID,
+			&gameBroadcast.GameID,
+			&gameBroadcast.Scope,
+			&gameBroadcast.Media,
+			&gameBroadcast.TapeDelayComments,
+			&gameBroadcast.CreatedAt,
+			&gameBroadcast.UpdatedAt,
+			&gameBroadcast.Broadcaster.ID,
+			&gameBroadcast.Broadcaster.Display,
+			&gameBroadcast.Broadcaster.Abbreviation,
+			&gameBroadcast.Broadcaster.RegionID,
+			&gameBroadcast.Broadcaster.NBABroadcasterID,
+			&gameBroadcast.Broadcaster.CreatedAt,
+			&gameBroadcast.Broadcaster.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan game broadcast: %w", err)
+		}
+
+		gameBroadcasts = append(gameBroadcasts, gameBroadcast)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read game broadcasts: %w", err)
+	}
+
+	return gameBroadcasts, nil
+}
@@ -1,6 +1,13 @@
 package postgres
 
-import "github.com/jackc/pgx/v5/pgxpool"
+import (
+	"context"
+	"fmt"
+
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
 
 //pgxpool"
 
@@ -11,3 +18,22 @@ type DB struct {
 func NewDB(pgxpool *pgxpool.Pool) DB {
 	return DB{pgxPool: pgxpool}
 }
+
+// begin starts a transaction tagged with the ingest run in the context if there is one so the history triggers can
+// record which run made each change
+func (d DB) begin(ctx context.Context) (pgx.Tx, error) {
+	tx, err := d.pgxPool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if run, ok := util.IngestRunFromContext(ctx); ok {
+		_, err := tx.Exec(ctx, `SELECT set_config('nba.ingest_run_id', $1, true), set_config('nba.source_key', $2, true)`, run.ID, run.SourceKey)
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, fmt.Errorf("failed to set ingest run for transaction: %w", err)
+		}
+	}
+
+	return tx, nil
+}
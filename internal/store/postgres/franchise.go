@@ -14,7 +14,7 @@ func (d DB) UpdateFranchises(ctx context.Context, franchiseUpdates []franchise.F
 	ctx, span := otel.Tracer("team").Start(ctx, "postgres.DB.UpdateFranchises")
 	defer span.End()
 
-	tx, err := d.pgxPool.Begin(ctx)
+	tx, err := d.begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("could not start db transaction to update franchises: %w", err)
 	}
@@ -119,3 +119,216 @@ func (d DB) UpdateFranchises(ctx context.Context, franchiseUpdates []franchise.F
 
 	return franchises, nil
 }
+
+const franchiseColumns = `f.id, f.created_at, f.updated_at, f.name, f.nickname, f.city, f.state, f.country, f.league_id, f.nba_team_id, f.start_year, f.end_year, f.years, f.games, f.wins, f.losses, f.playoff_appearances, f.division_titles, f.conference_titles, f.league_titles, f.active`
+
+func scanFranchise(row pgx.Row) (api.Franchise, error) {
+	var fr api.Franchise
+	err := row.Scan(
+		&fr.ID,
+		&fr.CreatedAt,
+		&fr.UpdatedAt,
+		&fr.Name,
+		&fr.Nickname,
+		&fr.City,
+		&fr.State,
+		&fr.Country,
+		&fr.LeagueID,
+		&fr.NBATeamID,
+		&fr.StartYear,
+		&fr.EndYear,
+		&fr.Years,
+		&fr.Games,
+		&fr.Wins,
+		&fr.Losses,
+		&fr.PlayoffAppearances,
+		&fr.DivisionTitles,
+		&fr.ConferenceTitles,
+		&fr.LeagueTitles,
+		&fr.Active,
+	)
+	return fr, err
+}
+
+func (d DB) ListFranchises(ctx context.Context) ([]api.Franchise, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.ListFranchises")
+	defer span.End()
+
+	query := `
+		SELECT ` + franchiseColumns + `
+		FROM nba.franchise f
+		ORDER BY f.city, f.name`
+
+	rows, err := d.pgxPool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list franchises: %w", err)
+	}
+	defer rows.Close()
+
+	franchises := []api.Franchise{}
+
+	for rows.Next() {
+		fr, err := scanFranchise(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan franchise: %w", err)
+		}
+
+		franchises = append(franchises, fr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read franchises: %w", err)
+	}
+
+	return franchises, nil
+}
+
+func (d DB) GetFranchisesWithIDs(ctx context.Context, ids []string) ([]api.Franchise, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetFranchisesWithIDs")
+	defer span.End()
+
+	query := `
+		SELECT ` + franchiseColumns + `
+		FROM nba.franchise f
+		WHERE f.id = ANY($1)`
+
+	rows, err := d.pgxPool.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get franchises: %w", err)
+	}
+	defer rows.Close()
+
+	franchises := []api.Franchise{}
+
+	for rows.Next() {
+		fr, err := scanFranchise(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan franchise: %w", err)
+		}
+
+		franchises = append(franchises, fr)
+	}
+
+	return franchises, rows.Err()
+}
+
+func (d DB) GetFranchiseWithTeamID(ctx context.Context, teamID string) (api.Franchise, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetFranchiseWithTeamID")
+	defer span.End()
+
+	query := `
+		SELECT ` + franchiseColumns + `
+		FROM nba.franchise f
+		JOIN nba.team t ON t.franchise_id = f.id
+		WHERE t.id = $1`
+
+	return scanFranchise(d.pgxPool.QueryRow(ctx, query, teamID))
+}
+
+func (d DB) GetFranchiseWithNBATeamID(ctx context.Context, nbaTeamID int) (api.Franchise, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetFranchiseWithNBATeamID")
+	defer span.End()
+
+	query := `
+		SELECT ` + franchiseColumns + `
+		FROM nba.franchise f
+		JOIN nba.team t ON t.franchise_id = f.id
+		WHERE t.nba_team_id = $1`
+
+	return scanFranchise(d.pgxPool.QueryRow(ctx, query, nbaTeamID))
+}
+
+func (d DB) GetFranchiseTeamSeasons(ctx context.Context, franchiseID string) ([]franchise.FranchiseTeamSeason, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetFranchiseTeamSeasons")
+	defer span.End()
+
+	query := `
+		SELECT ts.team_id, s.start_year, ts.city, ts.name
+		FROM nba.team_season ts
+		JOIN nba.team t ON t.id = ts.team_id
+		JOIN nba.season s ON s.id = ts.season_id
+		WHERE t.franchise_id = $1
+		ORDER BY s.start_year`
+
+	rows, err := d.pgxPool.Query(ctx, query, franchiseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get franchise team seasons: %w", err)
+	}
+	defer rows.Close()
+
+	teamSeasons := []franchise.FranchiseTeamSeason{}
+
+	for rows.Next() {
+		teamSeason := franchise.FranchiseTeamSeason{}
+
+		err := rows.Scan(
+			&teamSeason.TeamID,
+			&teamSeason.SeasonStartYear,
+			&teamSeason.City,
+			&teamSeason.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan franchise team season: %w", err)
+		}
+
+		teamSeasons = append(teamSeasons, teamSeason)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read franchise team seasons: %w", err)
+	}
+
+	return teamSeasons, nil
+}
+
+func (d DB) GetFranchiseGames(ctx context.Context, franchiseID string) ([]franchise.FranchiseGame, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetFranchiseGames")
+	defer span.End()
+
+	query := `
+		SELECT fg.nba_game_id, fg.start_year, fg.team_id, coalesce(fg.city, ''), fg.name, fg.team_points > fg.opponent_points
+		FROM (
+			SELECT g.nba_game_id, s.start_year, t.id AS team_id, t.city, t.name,
+				CASE WHEN t.id = g.home_team_id THEN coalesce(g.home_team_points, hts.points) ELSE coalesce(g.away_team_points, ats.points) END AS team_points,
+				CASE WHEN t.id = g.home_team_id THEN coalesce(g.away_team_points, ats.points) ELSE coalesce(g.home_team_points, hts.points) END AS opponent_points
+			FROM nba.game g
+			JOIN nba.team t ON t.id = g.home_team_id OR t.id = g.away_team_id
+			JOIN nba.season s ON s.id = g.season_id
+			JOIN nba.game_status gs ON gs.id = g.game_status_id
+			LEFT JOIN nba.team_game_stats_total hts ON hts.game_id = g.id AND hts.team_id = g.home_team_id
+			LEFT JOIN nba.team_game_stats_total ats ON ats.game_id = g.id AND ats.team_id = g.away_team_id
+			WHERE t.franchise_id = $1 AND gs.name = 'completed'
+		) fg
+		WHERE fg.team_points IS NOT NULL AND fg.opponent_points IS NOT NULL
+		ORDER BY fg.nba_game_id`
+
+	rows, err := d.pgxPool.Query(ctx, query, franchiseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get franchise games: %w", err)
+	}
+	defer rows.Close()
+
+	games := []franchise.FranchiseGame{}
+
+	for rows.Next() {
+		game := franchise.FranchiseGame{}
+
+		err := rows.Scan(
+			&game.NBAGameID,
+			&game.SeasonStartYear,
+			&game.TeamID,
+			&game.City,
+			&game.Name,
+			&game.Won)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan franchise game: %w", err)
+		}
+
+		games = append(games, game)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read franchise games: %w", err)
+	}
+
+	return games, nil
+}
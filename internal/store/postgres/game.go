@@ -2,20 +2,73 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/drewthor/wolves_reddit_bot/api"
 	"github.com/drewthor/wolves_reddit_bot/internal/game"
+	"github.com/drewthor/wolves_reddit_bot/util"
 	"github.com/jackc/pgx/v5"
 	"go.opentelemetry.io/otel"
 )
 
-func (d DB) List(ctx context.Context) ([]api.Game, error) {
+// gameSortColumns are the columns games can be listed by
+var gameSortColumns = map[string]sortColumn{
+	game.SortStartTime: {expr: "g.start_time", cast: "timestamptz"},
+}
+
+func (d DB) List(ctx context.Context, filter game.ListFilter) ([]api.Game, error) {
 	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.List")
 	defer span.End()
 
+	sortColumn, ok := gameSortColumns[filter.Sort.Field]
+	if !ok {
+		return nil, fmt.Errorf("unknown game sort %s", filter.Sort.Field)
+	}
+
+	q := listQuery{}
+	if filter.Broadcaster.Valid {
+		broadcaster := q.arg(filter.Broadcaster.String)
+		q.and(`EXISTS (
+				SELECT 1
+				FROM nba.game_broadcast gb
+				JOIN nba.broadcaster b ON b.id = gb.broadcaster_id
+				WHERE gb.game_id = g.id AND (upper(b.abbreviation) = upper(` + broadcaster + `) OR upper(b.display) = upper(` + broadcaster + `))
+			)`)
+	}
+	if filter.StartDate.Valid {
+		q.and("(g.start_time AT TIME ZONE 'America/New_York')::date >= " + q.arg(filter.StartDate.Time.Format(util.DateLayout)) + "::date")
+	}
+	if filter.EndDate.Valid {
+		q.and("(g.start_time AT TIME ZONE 'America/New_York')::date <= " + q.arg(filter.EndDate.Time.Format(util.DateLayout)) + "::date")
+	}
+	if filter.SeasonStartYear.Valid {
+		q.and("g.season_id IN (SELECT id FROM nba.season WHERE start_year = " + q.arg(filter.SeasonStartYear.Int64) + ")")
+	}
+	if filter.SeasonStageName.Valid {
+		q.and("g.season_stage_id = (SELECT id FROM nba.season_stage WHERE name = " + q.arg(filter.SeasonStageName.String) + ")")
+	}
+	if filter.TeamID.Valid {
+		teamID := q.arg(filter.TeamID.String)
+		switch filter.TeamSide {
+		case game.TeamSideHome:
+			q.and("g.home_team_id = " + teamID + "::uuid")
+		case game.TeamSideAway:
+			q.and("g.away_team_id = " + teamID + "::uuid")
+		default:
+			q.and(teamID + "::uuid IN (g.home_team_id, g.away_team_id)")
+		}
+	}
+	if filter.StatusName.Valid {
+		q.and("g.game_status_id = (SELECT id FROM nba.game_status WHERE name = " + q.arg(filter.StatusName.String) + ")")
+	}
+	if filter.ArenaID.Valid {
+		q.and("g.arena_id = " + q.arg(filter.ArenaID.String) + "::uuid")
+	}
+	q.after(sortColumn, "g.id", filter.Sort, filter.Cursor)
+
 	query := `
-		SELECT id, home_team_id, away_team_id, home_team_points, away_team_points, game_status.name, arena_id, attendance, season.name, season_stage.name, period, period_time_remaining_tenth_seconds, duration_seconds, start_time, end_time, nba_game_id, created_at, updated_at
+		SELECT g.id, home_team_id, away_team_id, home_team_points, away_team_points, game_status.name, arena_id, attendance, season.name, season_stage.name, period, period_time_remaining_tenth_seconds, duration_seconds, start_time, end_time, nba_game_id, source, created_at, updated_at
 		FROM nba.game g, 
 		LATERAL (
 		        SELECT name
@@ -31,14 +84,18 @@ func (d DB) List(ctx context.Context) ([]api.Game, error) {
 		        SELECT name
 				FROM nba.season_stage ss
 				WHERE ss.id = g.season_stage_id
-        ) season_stage`
+        ) season_stage
+		` + q.where() + `
+		` + q.orderBy(sortColumn, "g.id", filter.Sort) + `
+		` + q.limit(filter.Limit)
 
-	rows, err := d.pgxPool.Query(ctx, query)
+	rows, err := d.pgxPool.Query(ctx, query, q.args...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	var games []api.Game
+	games := []api.Game{}
 	for rows.Next() {
 		g := api.Game{}
 		err = rows.Scan(
@@ -58,6 +115,7 @@ func (d DB) List(ctx context.Context) ([]api.Game, error) {
 			&g.StartTime,
 			&g.EndTime,
 			&g.NBAGameID,
+			&g.Source,
 			&g.CreatedAt,
 			&g.UpdatedAt)
 		if err != nil {
@@ -67,8 +125,11 @@ func (d DB) List(ctx context.Context) ([]api.Game, error) {
 		games = append(games, g)
 	}
 
-	return games, nil
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
+	return games, nil
 }
 
 func (d DB) GetGameWithID(ctx context.Context, id string) (api.Game, error) {
@@ -76,7 +137,7 @@ func (d DB) GetGameWithID(ctx context.Context, id string) (api.Game, error) {
 	defer span.End()
 
 	query := `
-		SELECT id, home_team_id, away_team_id, home_team_points, away_team_points, game_status.name, arena_id, attendance, season.name, season_stage.name, period, period_time_remaining_tenth_seconds, duration_seconds, start_time, end_time, nba_game_id, created_at, updated_at
+		SELECT id, home_team_id, away_team_id, home_team_points, away_team_points, game_status.name, arena_id, attendance, season.name, season_stage.name, period, period_time_remaining_tenth_seconds, duration_seconds, start_time, end_time, nba_game_id, source, created_at, updated_at
 		FROM nba.game g, 
 		LATERAL (
 		        SELECT name
@@ -114,6 +175,7 @@ func (d DB) GetGameWithID(ctx context.Context, id string) (api.Game, error) {
 		&g.StartTime,
 		&g.EndTime,
 		&g.NBAGameID,
+		&g.Source,
 		&g.CreatedAt,
 		&g.UpdatedAt)
 	if err != nil {
@@ -128,7 +190,7 @@ func (d DB) GetGamesWithIDs(ctx context.Context, ids []string) ([]api.Game, erro
 	defer span.End()
 
 	query := `
-		SELECT id, home_team_id, away_team_id, home_team_points, away_team_points, game_status.name, arena_id, attendance, season.name, season_stage.name, period, period_time_remaining_tenth_seconds, duration_seconds, start_time, end_time, nba_game_id, created_at, updated_at
+		SELECT id, home_team_id, away_team_id, home_team_points, away_team_points, game_status.name, arena_id, attendance, season.name, season_stage.name, period, period_time_remaining_tenth_seconds, duration_seconds, start_time, end_time, nba_game_id, source, created_at, updated_at
 		FROM nba.game g, 
 		LATERAL (
 		        SELECT name
@@ -172,6 +234,7 @@ func (d DB) GetGamesWithIDs(ctx context.Context, ids []string) ([]api.Game, erro
 			&g.StartTime,
 			&g.EndTime,
 			&g.NBAGameID,
+			&g.Source,
 			&g.CreatedAt,
 			&g.UpdatedAt)
 		if err != nil {
@@ -184,12 +247,28 @@ func (d DB) GetGamesWithIDs(ctx context.Context, ids []string) ([]api.Game, erro
 	return games, nil
 }
 
+// GetGameRegulationPeriods returns the number of regulation periods for the game which is nil when the boxscore
+// hasn't been ingested yet
+func (d DB) GetGameRegulationPeriods(ctx context.Context, nbaGameID string) (*int, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetGameRegulationPeriods")
+	defer span.End()
+
+	query := `SELECT regulation_periods FROM nba.game WHERE nba_game_id = $1`
+
+	var regulationPeriods *int
+	if err := d.pgxPool.QueryRow(ctx, query, nbaGameID).Scan(&regulationPeriods); err != nil {
+		return nil, err
+	}
+
+	return regulationPeriods, nil
+}
+
 func (d DB) GetGameWithNBAID(ctx context.Context, nbaID string) (api.Game, error) {
 	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetGameWithNBAID")
 	defer span.End()
 
 	query := `
-		SELECT id, home_team_id, away_team_id, home_team_points, away_team_points, game_status.name, arena_id, attendance, season.name, season_stage.name, period, period_time_remaining_tenth_seconds, duration_seconds, start_time, end_time, nba_game_id, created_at, updated_at
+		SELECT id, home_team_id, away_team_id, home_team_points, away_team_points, game_status.name, arena_id, attendance, season.name, season_stage.name, period, period_time_remaining_tenth_seconds, duration_seconds, start_time, end_time, nba_game_id, source, created_at, updated_at
 		FROM nba.game g, 
 		LATERAL (
 		        SELECT name
@@ -227,6 +306,7 @@ func (d DB) GetGameWithNBAID(ctx context.Context, nbaID string) (api.Game, error
 		&g.StartTime,
 		&g.EndTime,
 		&g.NBAGameID,
+		&g.Source,
 		&g.CreatedAt,
 		&g.UpdatedAt)
 	if err != nil {
@@ -240,7 +320,7 @@ func (d DB) UpdateGamesSummary(ctx context.Context, gameUpdates []game.GameSumma
 	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.UpdateGamesSummary")
 	defer span.End()
 
-	tx, err := d.pgxPool.Begin(ctx)
+	tx, err := d.begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("could not start db transaction to update games old with error: %w", err)
 	}
@@ -319,7 +399,7 @@ func (d DB) UpdateGames(ctx context.Context, gameUpdates []game.GameUpdate) ([]a
 	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.UpdateGames")
 	defer span.End()
 
-	tx, err := d.pgxPool.Begin(ctx)
+	tx, err := d.begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("could not start db transaction to update games with error: %w", err)
 	}
@@ -337,7 +417,7 @@ func (d DB) UpdateGames(ctx context.Context, gameUpdates []game.GameUpdate) ([]a
 			(SELECT id FROM nba.arena WHERE nba_arena_id = $6),
 			$7,
 			(SELECT nba.season.id FROM nba.season WHERE nba.season.start_year = $8),
-			(SELECT id FROM nba.season_stage WHERE name = 'regular'),
+			(SELECT id FROM nba.season_stage WHERE name = $17),
 			$9,
 			$10,
 			$11,
@@ -365,7 +445,8 @@ func (d DB) UpdateGames(ctx context.Context, gameUpdates []game.GameUpdate) ([]a
 			start_time = excluded.start_time,
 			end_time = coalesce(excluded.end_time, nba.game.end_time),
 			regulation_periods = coalesce(excluded.regulation_periods, nba.game.regulation_periods),
-			nba_game_id = coalesce(excluded.nba_game_id, nba.game.nba_game_id)
+			nba_game_id = coalesce(excluded.nba_game_id, nba.game.nba_game_id),
+			source = excluded.source
 		RETURNING nba.game.id`
 
 	bp := &pgx.Batch{}
@@ -387,7 +468,8 @@ func (d DB) UpdateGames(ctx context.Context, gameUpdates []game.GameUpdate) ([]a
 			gameUpdate.StartTime,
 			gameUpdate.EndTime,
 			gameUpdate.RegulationPeriods,
-			gameUpdate.NBAGameID)
+			gameUpdate.NBAGameID,
+			gameUpdate.SeasonStageName)
 	}
 
 	batchResults := tx.SendBatch(ctx, bp)
@@ -422,7 +504,7 @@ func (d DB) UpdateScheduledGames(ctx context.Context, gameUpdates []game.GameSch
 	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.UpdateGames")
 	defer span.End()
 
-	tx, err := d.pgxPool.Begin(ctx)
+	tx, err := d.begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("could not start db transaction to update games with error: %w", err)
 	}
@@ -430,18 +512,21 @@ func (d DB) UpdateScheduledGames(ctx context.Context, gameUpdates []game.GameSch
 
 	insertGame := `
 		INSERT INTO nba.game
-			(home_team_id, away_team_id, home_team_points, away_team_points, game_status_id, arena_id, season_id, season_stage_id, start_time, nba_game_id)
+			(home_team_id, away_team_id, home_team_points, away_team_points, game_status_id, arena_id, season_id, season_stage_id, start_time, nba_game_id, home_team_seed, away_team_seed, if_necessary)
 		VALUES (
 			(SELECT id FROM nba.team WHERE nba_team_id = $1),
 			(SELECT id FROM nba.team WHERE nba_team_id = $2),
 		    $3,
 		    $4,
 			(SELECT id FROM nba.game_status WHERE name = $5),
-			(SELECT id FROM nba.arena WHERE name = $6),
+			(SELECT id FROM nba.arena WHERE name = $6 OR (city = $10 AND state = $11) ORDER BY name = $6 DESC LIMIT 1),
 			(SELECT nba.season.id FROM nba.season WHERE nba.season.start_year = $7),
-			(SELECT id FROM nba.season_stage WHERE name = 'regular'),
+			(SELECT id FROM nba.season_stage WHERE name = $12),
 			$8,
-			$9
+			$9,
+			$13,
+			$14,
+			$15
 		)
 		ON CONFLICT (nba_game_id) DO UPDATE
 		SET
@@ -454,7 +539,10 @@ func (d DB) UpdateScheduledGames(ctx context.Context, gameUpdates []game.GameSch
 			season_id = coalesce(excluded.season_id, nba.game.season_id),
 			season_stage_id = coalesce(excluded.season_stage_id, nba.game.season_stage_id),
 			start_time = excluded.start_time,
-			nba_game_id = coalesce(excluded.nba_game_id, nba.game.nba_game_id)
+			nba_game_id = coalesce(excluded.nba_game_id, nba.game.nba_game_id),
+			home_team_seed = coalesce(excluded.home_team_seed, nba.game.home_team_seed),
+			away_team_seed = coalesce(excluded.away_team_seed, nba.game.away_team_seed),
+			if_necessary = excluded.if_necessary
 		RETURNING nba.game.id`
 
 	bp := &pgx.Batch{}
@@ -469,7 +557,13 @@ func (d DB) UpdateScheduledGames(ctx context.Context, gameUpdates []game.GameSch
 			gameUpdate.NBAArenaName,
 			gameUpdate.SeasonStartYear,
 			gameUpdate.StartTime,
-			gameUpdate.NBAGameID)
+			gameUpdate.NBAGameID,
+			gameUpdate.NBAArenaCity,
+			gameUpdate.NBAArenaState,
+			gameUpdate.SeasonStageName,
+			gameUpdate.HomeTeamSeed,
+			gameUpdate.AwayTeamSeed,
+			gameUpdate.IfNecessary)
 	}
 
 	batchResults := tx.SendBatch(ctx, bp)
@@ -499,3 +593,98 @@ func (d DB) UpdateScheduledGames(ctx context.Context, gameUpdates []game.GameSch
 
 	return d.GetGamesWithIDs(ctx, insertedGameIDs)
 }
+
+// UpdateHistoricalGames upserts games from the stats.nba.com game logs, games that already came from the cdn are left
+// as is and aren't returned
+func (d DB) UpdateHistoricalGames(ctx context.Context, gameUpdates []game.GameHistoricalUpdate) ([]api.Game, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.UpdateHistoricalGames")
+	defer span.End()
+
+	tx, err := d.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not start db transaction to update historical games with error: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	insertGame := `
+		INSERT INTO nba.game
+			(home_team_id, away_team_id, home_team_points, away_team_points, game_status_id, season_id, season_stage_id, duration_seconds, start_time, regulation_periods, nba_game_id, source)
+		VALUES (
+			(SELECT id FROM nba.team WHERE nba_team_id = $1),
+			(SELECT id FROM nba.team WHERE nba_team_id = $2),
+			$3,
+			$4,
+			(SELECT id FROM nba.game_status WHERE name = $5),
+			(SELECT s.id FROM nba.season s JOIN nba.league l ON l.id = s.league_id WHERE s.start_year = $6 AND l.nba_league_id = 0),
+			(SELECT id FROM nba.season_stage WHERE name = $7),
+			$8,
+			$9,
+			$10,
+			$11,
+			$12
+		)
+		ON CONFLICT (nba_game_id) DO UPDATE
+		SET
+			home_team_id = excluded.home_team_id,
+			away_team_id = excluded.away_team_id,
+			home_team_points = excluded.home_team_points,
+			away_team_points = excluded.away_team_points,
+			game_status_id = excluded.game_status_id,
+			season_id = coalesce(excluded.season_id, nba.game.season_id),
+			season_stage_id = excluded.season_stage_id,
+			duration_seconds = coalesce(excluded.duration_seconds, nba.game.duration_seconds),
+			start_time = excluded.start_time,
+			regulation_periods = coalesce(nba.game.regulation_periods, excluded.regulation_periods),
+			source = excluded.source
+		WHERE nba.game.source <> 'cdn'
+		RETURNING nba.game.id`
+
+	bp := &pgx.Batch{}
+
+	for _, gameUpdate := range gameUpdates {
+		bp.Queue(insertGame,
+			gameUpdate.NBAHomeTeamID,
+			gameUpdate.NBAAwayTeamID,
+			gameUpdate.HomeTeamPoints,
+			gameUpdate.AwayTeamPoints,
+			gameUpdate.GameStatusName,
+			gameUpdate.SeasonStartYear,
+			gameUpdate.SeasonStageName,
+			gameUpdate.DurationSeconds,
+			gameUpdate.StartTime,
+			gameUpdate.RegulationPeriods,
+			gameUpdate.NBAGameID,
+			gameUpdate.Source)
+	}
+
+	batchResults := tx.SendBatch(ctx, bp)
+
+	var insertedGameIDs []string
+
+	for range gameUpdates {
+		id := ""
+		err := batchResults.QueryRow().Scan(&id)
+		if err != nil {
+			// the game already came from the cdn
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			batchResults.Close()
+			return nil, err
+		}
+
+		insertedGameIDs = append(insertedGameIDs, id)
+	}
+
+	err = batchResults.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.GetGamesWithIDs(ctx, insertedGameIDs)
+}
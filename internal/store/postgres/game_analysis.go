@@ -0,0 +1,288 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/internal/game_analysis"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+func (d DB) UpdateGameAnalysis(ctx context.Context, gameAnalysisUpdate game_analysis.GameAnalysisUpdate) error {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.UpdateGameAnalysis")
+	defer span.End()
+
+	tx, err := d.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("could not start db transaction to update game analysis: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	bp := &pgx.Batch{}
+
+	bp.Queue(`DELETE FROM nba.game_scoring_run WHERE game_id = $1`, gameAnalysisUpdate.GameID)
+	bp.Queue(`DELETE FROM nba.game_score_event WHERE game_id = $1`, gameAnalysisUpdate.GameID)
+	bp.Queue(`DELETE FROM nba.game_clutch_segment WHERE game_id = $1`, gameAnalysisUpdate.GameID)
+	bp.Queue(`DELETE FROM nba.player_game_clutch_stats WHERE game_id = $1`, gameAnalysisUpdate.GameID)
+
+	insertScoringRun := `
+		INSERT INTO nba.game_scoring_run
+			(game_id, team_id, points, start_action_number, start_period, start_clock_tenth_seconds, end_action_number, end_period, end_clock_tenth_seconds)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	for _, scoringRun := range gameAnalysisUpdate.ScoringRuns {
+		bp.Queue(insertScoringRun,
+			scoringRun.GameID,
+			scoringRun.TeamID,
+			scoringRun.Points,
+			scoringRun.StartActionNumber,
+			scoringRun.StartPeriod,
+			scoringRun.StartClockTenthSeconds,
+			scoringRun.EndActionNumber,
+			scoringRun.EndPeriod,
+			scoringRun.EndClockTenthSeconds)
+	}
+
+	insertScoreEvent := `
+		INSERT INTO nba.game_score_event
+			(game_id, type, action_number, period, clock_tenth_seconds, home_score, away_score, leading_team_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	for _, scoreEvent := range gameAnalysisUpdate.ScoreEvents {
+		bp.Queue(insertScoreEvent,
+			scoreEvent.GameID,
+			scoreEvent.Type,
+			scoreEvent.ActionNumber,
+			scoreEvent.Period,
+			scoreEvent.ClockTenthSeconds,
+			scoreEvent.HomeScore,
+			scoreEvent.AwayScore,
+			scoreEvent.LeadingTeamID)
+	}
+
+	insertClutchSegment := `
+		INSERT INTO nba.game_clutch_segment
+			(game_id, period, start_action_number, start_clock_tenth_seconds, end_action_number, end_clock_tenth_seconds)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	for _, clutchSegment := range gameAnalysisUpdate.ClutchSegments {
+		bp.Queue(insertClutchSegment,
+			clutchSegment.GameID,
+			clutchSegment.Period,
+			clutchSegment.StartActionNumber,
+			clutchSegment.StartClockTenthSeconds,
+			clutchSegment.EndActionNumber,
+			clutchSegment.EndClockTenthSeconds)
+	}
+
+	insertPlayerClutchStats := `
+		INSERT INTO nba.player_game_clutch_stats
+			(game_id, player_id, team_id, points, field_goals_made, field_goals_attempted, three_pointers_made, three_pointers_attempted, free_throws_made, free_throws_attempted, rebounds, turnovers)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	for _, playerClutchStats := range gameAnalysisUpdate.PlayerClutchStats {
+		bp.Queue(insertPlayerClutchStats,
+			playerClutchStats.GameID,
+			playerClutchStats.PlayerID,
+			playerClutchStats.TeamID,
+			playerClutchStats.Points,
+			playerClutchStats.FieldGoalsMade,
+			playerClutchStats.FieldGoalsAttempted,
+			playerClutchStats.ThreePointersMade,
+			playerClutchStats.ThreePointersAttempted,
+			playerClutchStats.FreeThrowsMade,
+			playerClutchStats.FreeThrowsAttempted,
+			playerClutchStats.Rebounds,
+			playerClutchStats.Turnovers)
+	}
+
+	err = tx.SendBatch(ctx, bp).Close()
+	if err != nil {
+		return fmt.Errorf("failed to replace game analysis: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (d DB) GetScoringRunsForGame(ctx context.Context, gameID string) ([]api.ScoringRun, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetScoringRunsForGame")
+	defer span.End()
+
+	query := `
+		SELECT id, game_id, team_id, points, start_action_number, start_period, start_clock_tenth_seconds, end_action_number, end_period, end_clock_tenth_seconds, created_at, updated_at
+		FROM nba.game_scoring_run
+		WHERE game_id = $1
+		ORDER BY start_action_number`
+
+	rows, err := d.pgxPool.Query(ctx, query, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scoring runs for game: %w", err)
+	}
+	defer rows.Close()
+
+	scoringRuns := []api.ScoringRun{}
+
+	for rows.Next() {
+		scoringRun := api.ScoringRun{}
+
+		err := rows.Scan(
+			&scoringRun.ID,
+			&scoringRun.GameID,
+			&scoringRun.TeamID,
+			&scoringRun.Points,
+			&scoringRun.StartActionNumber,
+			&scoringRun.StartPeriod,
+			&scoringRun.StartClockTenthSeconds,
+			&scoringRun.EndActionNumber,
+			&scoringRun.EndPeriod,
+			&scoringRun.EndClockTenthSeconds,
+			&scoringRun.CreatedAt,
+			&scoringRun.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan scoring run: %w", err)
+		}
+
+		scoringRuns = append(scoringRuns, scoringRun)
+	}
+
+	return scoringRuns, rows.Err()
+}
+
+func (d DB) GetScoreEventsForGame(ctx context.Context, gameID string) ([]api.GameScoreEvent, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetScoreEventsForGame")
+	defer span.End()
+
+	query := `
+		SELECT id, game_id, type, action_number, period, clock_tenth_seconds, home_score, away_score, leading_team_id, created_at, updated_at
+		FROM nba.game_score_event
+		WHERE game_id = $1
+		ORDER BY action_number`
+
+	rows, err := d.pgxPool.Query(ctx, query, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get score events for game: %w", err)
+	}
+	defer rows.Close()
+
+	scoreEvents := []api.GameScoreEvent{}
+
+	for rows.Next() {
+		scoreEvent := api.GameScoreEvent{}
+
+		err := rows.Scan(
+			&scoreEvent.ID,
+			&scoreEvent.GameID,
+			&scoreEvent.Type,
+			&scoreEvent.ActionNumber,
+			&scoreEvent.Period,
+			&scoreEvent.ClockTenthSeconds,
+			&scoreEvent.HomeScore,
+			&scoreEvent.AwayScore,
+			&scoreEvent.LeadingTeamID,
+			&scoreEvent.CreatedAt,
+			&scoreEvent.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan score event: %w", err)
+		}
+
+		scoreEvents = append(scoreEvents, scoreEvent)
+	}
+
+	return scoreEvents, rows.Err()
+}
+
+func (d DB) GetClutchSegmentsForGame(ctx context.Context, gameID string) ([]api.ClutchSegment, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetClutchSegmentsForGame")
+	defer span.End()
+
+	query := `
+		SELECT id, game_id, period, start_action_number, start_clock_tenth_seconds, end_action_number, end_clock_tenth_seconds, created_at, updated_at
+		FROM nba.game_clutch_segment
+		WHERE game_id = $1
+		ORDER BY start_action_number`
+
+	rows, err := d.pgxPool.Query(ctx, query, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clutch segments for game: %w", err)
+	}
+	defer rows.Close()
+
+	clutchSegments := []api.ClutchSegment{}
+
+	for rows.Next() {
+		clutchSegment := api.ClutchSegment{}
+
+		err := rows.Scan(
+			&clutchSegment.ID,
+			&clutchSegment.GameID,
+			&clutchSegment.Period,
+			&clutchSegment.StartActionNumber,
+			&clutchSegment.StartClockTenthSeconds,
+			&clutchSegment.EndActionNumber,
+			&clutchSegment.EndClockTenthSeconds,
+			&clutchSegment.CreatedAt,
+			&clutchSegment.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan clutch segment: %w", err)
+		}
+
+		clutchSegments = append(clutchSegments, clutchSegment)
+	}
+
+	return clutchSegments, rows.Err()
+}
+
+func (d DB) GetPlayerGameClutchStats(ctx context.Context, playerID string) ([]api.PlayerGameClutchStats, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetPlayerGameClutchStats")
+	defer span.End()
+
+	query := `
+		SELECT pgcs.id, pgcs.game_id, pgcs.player_id, pgcs.team_id, pgcs.points, pgcs.field_goals_made, pgcs.field_goals_attempted, pgcs.three_pointers_made, pgcs.three_pointers_attempted, pgcs.free_throws_made, pgcs.free_throws_attempted, pgcs.rebounds, pgcs.turnovers, pgcs.created_at, pgcs.updated_at
+		FROM nba.player_game_clutch_stats pgcs
+		JOIN nba.game g ON g.id = pgcs.game_id
+		WHERE pgcs.player_id = $1
+		ORDER BY g.start_time DESC`
+
+	rows, err := d.pgxPool.Query(ctx, query, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player game clutch stats: %w", err)
+	}
+	defer rows.Close()
+
+	playerGameClutchStats := []api.PlayerGameClutchStats{}
+
+	for rows.Next() {
+		clutchStats := api.PlayerGameClutchStats{}
+
+		err := rows.Scan(
+			&clutchStats.ID,
+			&clutchStats.GameID,
+			&clutchStats.PlayerID,
+			&clutchStats.TeamID,
+			&clutchStats.Points,
+			&clutchStats.FieldGoalsMade,
+			&clutchStats.FieldGoalsAttempted,
+			&clutchStats.ThreePointersMade,
+			&clutchStats.ThreePointersAttempted,
+			&clutchStats.FreeThrowsMade,
+			&clutchStats.FreeThrowsAttempted,
+			&clutchStats.Rebounds,
+			&clutchStats.Turnovers,
+			&clutchStats.CreatedAt,
+			&clutchStats.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan player game clutch stats: %w", err)
+		}
+
+		playerGameClutchStats = append(playerGameClutchStats, clutchStats)
+	}
+
+	return playerGameClutchStats, rows.Err()
+}
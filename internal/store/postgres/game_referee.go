@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/drewthor/wolves_reddit_bot/api"
 	"github.com/drewthor/wolves_reddit_bot/internal/game_referee"
 	"github.com/jackc/pgx/v5"
 	"go.opentelemetry.io/otel"
@@ -13,7 +14,7 @@ func (d DB) UpdateGameReferees(ctx context.Context, gameRefereeUpdates []game_re
 	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.UpdateGameReferees")
 	defer span.End()
 
-	tx, err := d.pgxPool.Begin(ctx)
+	tx, err := d.begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("could not start db transaction when updating game referees: %w", err)
 	}
@@ -43,7 +44,7 @@ func (d DB) UpdateGameReferees(ctx context.Context, gameRefereeUpdates []game_re
 
 	insertedGameReferees := []game_referee.GameReferee{}
 
-	for _, _ = range insertedGameReferees {
+	for range gameRefereeUpdates {
 		gameReferee := game_referee.GameReferee{}
 
 		err := batchResults.QueryRow().Scan(
@@ -73,3 +74,34 @@ func (d DB) UpdateGameReferees(ctx context.Context, gameRefereeUpdates []game_re
 
 	return insertedGameReferees, nil
 }
+
+func (d DB) GetGameOfficials(ctx context.Context, gameIDs []string) ([]api.GameOfficial, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetGameOfficials")
+	defer span.End()
+
+	// crew chief first then referee and umpire
+	query := `
+		SELECT gr.game_id, gr.referee_id, gr.assignment
+		FROM nba.game_referee gr
+		WHERE gr.game_id = ANY($1)
+		ORDER BY gr.game_id, CASE lower(gr.assignment) WHEN 'crew chief' THEN 0 WHEN 'referee' THEN 1 WHEN 'umpire' THEN 2 ELSE 3 END, gr.created_at`
+
+	rows, err := d.pgxPool.Query(ctx, query, gameIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game officials: %w", err)
+	}
+	defer rows.Close()
+
+	officials := []api.GameOfficial{}
+
+	for rows.Next() {
+		official := api.GameOfficial{}
+		if err := rows.Scan(&official.GameID, &official.RefereeID, &official.Assignment); err != nil {
+			return nil, fmt.Errorf("failed to scan game official: %w", err)
+		}
+
+		officials = append(officials, official)
+	}
+
+	return officials, rows.Err()
+}
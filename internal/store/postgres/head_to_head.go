@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/drewthor/wolves_reddit_bot/internal/head_to_head"
+	"go.opentelemetry.io/otel"
+)
+
+func (d DB) GetTeamFranchiseID(ctx context.Context, teamID string) (string, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetTeamFranchiseID")
+	defer span.End()
+
+	franchiseID := ""
+	if err := d.pgxPool.QueryRow(ctx, `SELECT franchise_id FROM nba.team WHERE id = $1`, teamID).Scan(&franchiseID); err != nil {
+		return "", err
+	}
+
+	return franchiseID, nil
+}
+
+func (d DB) GetFranchiseMatchupGames(ctx context.Context, franchiseID string, opponentFranchiseID string) ([]head_to_head.MatchupGame, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetFranchiseMatchupGames")
+	defer span.End()
+
+	// teams are matched by franchise so games played before a relocation or rename are included
+	query := `
+		SELECT
+			g.id,
+			g.nba_game_id,
+			s.start_year,
+			g.start_time,
+			gs.name,
+			ht.franchise_id = $1,
+			CASE WHEN ht.franchise_id = $1 THEN coalesce(g.home_team_points, hts.points) ELSE coalesce(g.away_team_points, ats.points) END,
+			CASE WHEN ht.franchise_id = $1 THEN coalesce(g.away_team_points, ats.points) ELSE coalesce(g.home_team_points, hts.points) END
+		FROM nba.game g
+		JOIN nba.team ht ON ht.id = g.home_team_id
+		JOIN nba.team at ON at.id = g.away_team_id
+		JOIN nba.season s ON s.id = g.season_id
+		JOIN nba.game_status gs ON gs.id = g.game_status_id
+		LEFT JOIN nba.team_game_stats_total hts ON hts.game_id = g.id AND hts.team_id = g.home_team_id
+		LEFT JOIN nba.team_game_stats_total ats ON ats.game_id = g.id AND ats.team_id = g.away_team_id
+		WHERE (ht.franchise_id = $1 AND at.franchise_id = $2) OR (ht.franchise_id = $2 AND at.franchise_id = $1)
+		ORDER BY g.start_time`
+
+	rows, err := d.pgxPool.Query(ctx, query, franchiseID, opponentFranchiseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get franchise matchup games: %w", err)
+	}
+	defer rows.Close()
+
+	matchupGames := []head_to_head.MatchupGame{}
+
+	for rows.Next() {
+		matchupGame := head_to_head.MatchupGame{}
+
+		err := rows.Scan(
+			&matchupGame.GameID,
+			&matchupGame.NBAGameID,
+			&matchupGame.SeasonStartYear,
+			&matchupGame.StartTime,
+			&matchupGame.GameStatusName,
+			&matchupGame.Home,
+			&matchupGame.TeamPoints,
+			&matchupGame.OpponentPoints)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan franchise matchup game: %w", err)
+		}
+
+		matchupGames = append(matchupGames, matchupGame)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read franchise matchup games: %w", err)
+	}
+
+	return matchupGames, nil
+}
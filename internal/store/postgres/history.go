@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/internal/history"
+	"go.opentelemetry.io/otel"
+)
+
+func (d DB) ListHistory(ctx context.Context, filter history.ListFilter) ([]api.History, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.ListHistory")
+	defer span.End()
+
+	query := `
+		SELECT h.id, h.table_name, h.operation, h.row_id, h.game_id, h.team_id, h.player_id, h.old_values, h.new_values, h.source_key, h.ingest_run_id, h.created_at
+		FROM nba.history h
+		WHERE ($1::uuid IS NULL OR h.game_id = $1)
+			AND ($2::uuid IS NULL OR h.team_id = $2)
+			AND ($3::uuid IS NULL OR h.player_id = $3)
+			AND ($4::text IS NULL OR h.table_name = $4)
+			AND ($5::uuid IS NULL OR h.ingest_run_id = $5)
+			AND ($6::timestamptz IS NULL OR h.created_at < $6)
+		ORDER BY h.created_at DESC
+		LIMIT $7`
+
+	rows, err := d.pgxPool.Query(ctx, query, filter.GameID, filter.TeamID, filter.PlayerID, filter.TableName, filter.IngestRunID, filter.Before, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list history: %w", err)
+	}
+	defer rows.Close()
+
+	historyRows := []api.History{}
+
+	for rows.Next() {
+		h := api.History{}
+		err := rows.Scan(
+			&h.ID,
+			&h.TableName,
+			&h.Operation,
+			&h.RowID,
+			&h.GameID,
+			&h.TeamID,
+			&h.PlayerID,
+			&h.OldValues,
+			&h.NewValues,
+			&h.SourceKey,
+			&h.IngestRunID,
+			&h.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan history: %w", err)
+		}
+
+		historyRows = append(historyRows, h)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	return historyRows, nil
+}
@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/internal/injury_report"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+func (d DB) UpdateInjuryReport(ctx context.Context, injuryReportUpdate injury_report.InjuryReportUpdate) (int, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.UpdateInjuryReport")
+	defer span.End()
+
+	tx, err := d.begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to start db transaction to update injury report: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	insertInjuryReport := `
+		INSERT INTO nba.injury_report
+			as ir(report_time)
+		VALUES ($1)
+		ON CONFLICT (report_time) DO UPDATE
+		SET
+			report_time = excluded.report_time
+		RETURNING ir.id`
+
+	injuryReportID := ""
+	if err := tx.QueryRow(ctx, insertInjuryReport, injuryReportUpdate.ReportTime).Scan(&injuryReportID); err != nil {
+		return 0, fmt.Errorf("failed to upsert injury report: %w", err)
+	}
+
+	// a report can be republished with corrections so its entries are replaced
+	if _, err := tx.Exec(ctx, `DELETE FROM nba.injury_report_entry WHERE injury_report_id = $1`, injuryReportID); err != nil {
+		return 0, fmt.Errorf("failed to delete previous injury report entries: %w", err)
+	}
+
+	insertInjuryReportEntry := `
+		INSERT INTO nba.injury_report_entry
+			(injury_report_id, game_id, team_id, player_id, game_date, matchup, team_name, player_name, status, reason)
+		VALUES (
+			$1,
+			(
+				SELECT g.id
+				FROM nba.game g
+				JOIN nba.team t ON t.id = g.home_team_id OR t.id = g.away_team_id
+				WHERE concat(t.city, ' ', t.name) = $4 AND (g.start_time AT TIME ZONE 'America/New_York')::date = $2
+				ORDER BY g.start_time
+				LIMIT 1
+			),
+			( SELECT id FROM nba.team WHERE concat(city, ' ', name) = $4 LIMIT 1 ),
+			(
+				SELECT id
+				FROM nba.player
+				WHERE lower(first_name) = lower($6) AND lower(last_name) = lower($7)
+				ORDER BY active DESC
+				LIMIT 1
+			),
+			$2,
+			$3,
+			$4,
+			$5,
+			$8,
+			$9)
+		ON CONFLICT (injury_report_id, team_name, player_name) DO UPDATE
+		SET
+			status = excluded.status,
+			reason = excluded.reason`
+
+	bp := &pgx.Batch{}
+
+	for _, entry := range injuryReportUpdate.Entries {
+		bp.Queue(insertInjuryReportEntry,
+			injuryReportID,
+			entry.GameDate.Format("2006-01-02"),
+			entry.Matchup,
+			entry.TeamName,
+			entry.PlayerName,
+			entry.PlayerFirstName,
+			entry.PlayerLastName,
+			entry.Status,
+			entry.Reason)
+	}
+
+	batchResults := tx.SendBatch(ctx, bp)
+
+	for range injuryReportUpdate.Entries {
+		if _, err := batchResults.Exec(); err != nil {
+			batchResults.Close()
+			return 0, fmt.Errorf("failed to insert injury report entry: %w", err)
+		}
+	}
+
+	err = batchResults.Close()
+	if err != nil {
+		return 0, fmt.Errorf("could not close batchResults when updating injury report: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("could not commit transaction when updating injury report: %w", err)
+	}
+
+	return len(injuryReportUpdate.Entries), nil
+}
+
+func (d DB) GetInjuryReportEntriesForGame(ctx context.Context, gameID string) ([]api.InjuryReportEntry, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetInjuryReportEntriesForGame")
+	defer span.End()
+
+	query := `
+		SELECT ire.id, ire.injury_report_id, ir.report_time, ire.game_id, ire.team_id, ire.player_id, ire.game_date, ire.matchup, ire.team_name, ire.player_name, ire.status, ire.reason, ire.created_at, ire.updated_at
+		FROM nba.injury_report_entry ire
+		JOIN nba.injury_report ir ON ir.id = ire.injury_report_id
+		WHERE ire.game_id = $1
+		ORDER BY ir.report_time, ire.team_name, ire.player_name`
+
+	rows, err := d.pgxPool.Query(ctx, query, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get injury report entries for game: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []api.InjuryReportEntry{}
+
+	for rows.Next() {
+		entry := api.InjuryReportEntry{}
+
+		err := rows.Scan(
+			&entry.ID,
+			&entry.InjuryReportID,
+			&entry.ReportTime,
+			&entry.GameID,
+			&entry.TeamID,
+			&entry.PlayerID,
+			&entry.GameDate,
+			&entry.Matchup,
+			&entry.TeamName,
+			&entry.PlayerName,
+			&entry.Status,
+			&entry.Reason,
+			&entry.CreatedAt,
+			&entry.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan injury report entry: %w", err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read injury report entries for game: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,323 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/internal/milestone"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+const milestoneColumns = `m.id, m.game_id, m.team_id, m.player_id, m.play_by_play_id, m.type, m.stat, m.value, m.previous_value, m.description, g.start_time, m.created_at, m.updated_at`
+
+// preseason and all-star games don't count toward totals or records
+const milestoneCountedGame = `substr(xg.nba_game_id, 3, 1) NOT IN ('1', '3')`
+
+func (d DB) GetPlayerGameLineHistories(ctx context.Context, gameIDs []string) ([]milestone.PlayerGameLineHistory, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetPlayerGameLineHistories")
+	defer span.End()
+
+	// every history only includes games that started before the game so re-ingesting a game gives the same result
+	query := `
+		SELECT
+			g.id,
+			ptgst.team_id,
+			ptgst.player_id,
+			concat(p.first_name, ' ', p.last_name),
+			concat(t.city, ' ', t.name),
+			s.start_year,
+			coalesce(ptgst.points, 0), coalesce(ptgst.rebounds_total, 0), coalesce(ptgst.assists, 0), coalesce(ptgst.steals, 0), coalesce(ptgst.blocks, 0),
+			ft.points, ft.rebounds, ft.assists, ft.steals, ft.blocks,
+			ct.points, ct.rebounds, ct.assists, ct.steals, ct.blocks,
+			sh.games, sh.points, sh.rebounds, sh.assists, sh.steals, sh.blocks,
+			fr.games, fr.points, fr.rebounds, fr.assists, fr.steals, fr.blocks
+		FROM nba.player_team_game_stats_total ptgst
+		JOIN nba.game g ON g.id = ptgst.game_id
+		JOIN nba.game_status gs ON gs.id = g.game_status_id
+		JOIN nba.season s ON s.id = g.season_id
+		JOIN nba.player p ON p.id = ptgst.player_id
+		JOIN nba.team t ON t.id = ptgst.team_id
+		CROSS JOIN LATERAL (
+			SELECT coalesce(sum(x.points), 0) AS points, coalesce(sum(x.rebounds_total), 0) AS rebounds, coalesce(sum(x.assists), 0) AS assists, coalesce(sum(x.steals), 0) AS steals, coalesce(sum(x.blocks), 0) AS blocks
+			FROM nba.player_team_game_stats_total x
+			JOIN nba.game xg ON xg.id = x.game_id
+			JOIN nba.team xt ON xt.id = x.team_id
+			WHERE x.player_id = ptgst.player_id AND xt.franchise_id = t.franchise_id AND xg.start_time < g.start_time AND ` + milestoneCountedGame + `
+		) ft
+		CROSS JOIN LATERAL (
+			SELECT coalesce(sum(x.points), 0) AS points, coalesce(sum(x.rebounds_total), 0) AS rebounds, coalesce(sum(x.assists), 0) AS assists, coalesce(sum(x.steals), 0) AS steals, coalesce(sum(x.blocks), 0) AS blocks
+			FROM nba.player_team_game_stats_total x
+			JOIN nba.game xg ON xg.id = x.game_id
+			WHERE x.player_id = ptgst.player_id AND xg.start_time < g.start_time AND ` + milestoneCountedGame + `
+		) ct
+		CROSS JOIN LATERAL (
+			SELECT count(*) AS games, coalesce(max(x.points), 0) AS points, coalesce(max(x.rebounds_total), 0) AS rebounds, coalesce(max(x.assists), 0) AS assists, coalesce(max(x.steals), 0) AS steals, coalesce(max(x.blocks), 0) AS blocks
+			FROM nba.player_team_game_stats_total x
+			JOIN nba.game xg ON xg.id = x.game_id
+			WHERE x.player_id = ptgst.player_id AND xg.season_id = g.season_id AND xg.start_time < g.start_time AND ` + milestoneCountedGame + `
+		) sh
+		CROSS JOIN LATERAL (
+			SELECT count(*) AS games, coalesce(max(x.points), 0) AS points, coalesce(max(x.rebounds_total), 0) AS rebounds, coalesce(max(x.assists), 0) AS assists, coalesce(max(x.steals), 0) AS steals, coalesce(max(x.blocks), 0) AS blocks
+			FROM nba.player_team_game_stats_total x
+			JOIN nba.game xg ON xg.id = x.game_id
+			JOIN nba.team xt ON xt.id = x.team_id
+			WHERE xt.franchise_id = t.franchise_id AND xg.start_time < g.start_time AND ` + milestoneCountedGame + `
+		) fr
+		WHERE g.id = ANY($1) AND gs.name = 'completed' AND substr(g.nba_game_id, 3, 1) NOT IN ('1', '3')`
+
+	rows, err := d.pgxPool.Query(ctx, query, gameIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player game line histories: %w", err)
+	}
+	defer rows.Close()
+
+	playerLines := []milestone.PlayerGameLineHistory{}
+
+	for rows.Next() {
+		l := milestone.PlayerGameLineHistory{}
+
+		err := rows.Scan(
+			&l.GameID,
+			&l.TeamID,
+			&l.PlayerID,
+			&l.PlayerName,
+			&l.TeamName,
+			&l.SeasonStartYear,
+			&l.Line.Points, &l.Line.Rebounds, &l.Line.Assists, &l.Line.Steals, &l.Line.Blocks,
+			&l.FranchiseTotals.Points, &l.FranchiseTotals.Rebounds, &l.FranchiseTotals.Assists, &l.FranchiseTotals.Steals, &l.FranchiseTotals.Blocks,
+			&l.CareerTotals.Points, &l.CareerTotals.Rebounds, &l.CareerTotals.Assists, &l.CareerTotals.Steals, &l.CareerTotals.Blocks,
+			&l.SeasonGames, &l.SeasonHighs.Points, &l.SeasonHighs.Rebounds, &l.SeasonHighs.Assists, &l.SeasonHighs.Steals, &l.SeasonHighs.Blocks,
+			&l.FranchiseRecordGames, &l.FranchiseRecords.Points, &l.FranchiseRecords.Rebounds, &l.FranchiseRecords.Assists, &l.FranchiseRecords.Steals, &l.FranchiseRecords.Blocks)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan player game line history: %w", err)
+		}
+
+		playerLines = append(playerLines, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read player game line histories: %w", err)
+	}
+
+	return playerLines, nil
+}
+
+func (d DB) GetTeamGameLineHistories(ctx context.Context, gameIDs []string) ([]milestone.TeamGameLineHistory, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetTeamGameLineHistories")
+	defer span.End()
+
+	query := `
+		SELECT
+			g.id,
+			tgst.team_id,
+			concat(t.city, ' ', t.name),
+			coalesce(tgst.points, 0), coalesce(tgst.total_rebounds, 0), coalesce(tgst.assists, 0), coalesce(tgst.steals, 0), coalesce(tgst.blocks, 0),
+			fr.games, fr.points, fr.rebounds, fr.assists, fr.steals, fr.blocks
+		FROM nba.team_game_stats_total tgst
+		JOIN nba.game g ON g.id = tgst.game_id
+		JOIN nba.game_status gs ON gs.id = g.game_status_id
+		JOIN nba.team t ON t.id = tgst.team_id
+		CROSS JOIN LATERAL (
+			SELECT count(*) AS games, coalesce(max(x.points), 0) AS points, coalesce(max(x.total_rebounds), 0) AS rebounds, coalesce(max(x.assists), 0) AS assists, coalesce(max(x.steals), 0) AS steals, coalesce(max(x.blocks), 0) AS blocks
+			FROM nba.team_game_stats_total x
+			JOIN nba.game xg ON xg.id = x.game_id
+			JOIN nba.team xt ON xt.id = x.team_id
+			WHERE xt.franchise_id = t.franchise_id AND xg.start_time < g.start_time AND ` + milestoneCountedGame + `
+		) fr
+		WHERE g.id = ANY($1) AND gs.name = 'completed' AND substr(g.nba_game_id, 3, 1) NOT IN ('1', '3')`
+
+	rows, err := d.pgxPool.Query(ctx, query, gameIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team game line histories: %w", err)
+	}
+	defer rows.Close()
+
+	teamLines := []milestone.TeamGameLineHistory{}
+
+	for rows.Next() {
+		l := milestone.TeamGameLineHistory{}
+
+		err := rows.Scan(
+			&l.GameID,
+			&l.TeamID,
+			&l.TeamName,
+			&l.Line.Points, &l.Line.Rebounds, &l.Line.Assists, &l.Line.Steals, &l.Line.Blocks,
+			&l.FranchiseRecordGames, &l.FranchiseRecords.Points, &l.FranchiseRecords.Rebounds, &l.FranchiseRecords.Assists, &l.FranchiseRecords.Steals, &l.FranchiseRecords.Blocks)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan team game line history: %w", err)
+		}
+
+		teamLines = append(teamLines, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read team game line histories: %w", err)
+	}
+
+	return teamLines, nil
+}
+
+func (d DB) GetPlayerPointsPlayByPlayID(ctx context.Context, gameID string, playerID string, points int) (sql.NullString, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetPlayerPointsPlayByPlayID")
+	defer span.End()
+
+	// the first made shot where the player's running point total for the game reaches points
+	query := `
+		SELECT running.id
+		FROM (
+			SELECT
+				pbp.id,
+				pbp.action_number,
+				sum(CASE pbp.action_type WHEN '3pt' THEN 3 WHEN '2pt' THEN 2 ELSE 1 END) OVER (ORDER BY pbp.action_number) AS points
+			FROM nba.play_by_play pbp
+			WHERE pbp.game_id = $1 AND pbp.player_id = $2 AND pbp.action_type IN ('2pt', '3pt', 'freethrow') AND pbp.shot_result = 'Made'
+		) running
+		WHERE running.points >= $3
+		ORDER BY running.action_number
+		LIMIT 1`
+
+	playByPlayID := sql.NullString{}
+	if err := d.pgxPool.QueryRow(ctx, query, gameID, playerID, points).Scan(&playByPlayID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sql.NullString{}, nil
+		}
+		return sql.NullString{}, fmt.Errorf("failed to get play by play player reached points on: %w", err)
+	}
+
+	return playByPlayID, nil
+}
+
+func (d DB) UpdateMilestones(ctx context.Context, milestoneUpdates []milestone.MilestoneUpdate) ([]api.Milestone, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.UpdateMilestones")
+	defer span.End()
+
+	tx, err := d.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start db transaction to update milestones: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// milestones already recorded for the game are left alone and not returned
+	insertMilestone := `
+		WITH m AS (
+			INSERT INTO nba.milestone
+				(game_id, team_id, player_id, play_by_play_id, type, stat, value, previous_value, description)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			ON CONFLICT DO NOTHING
+			RETURNING *
+		)
+		SELECT ` + milestoneColumns + `
+		FROM m
+		JOIN nba.game g ON g.id = m.game_id`
+
+	bp := &pgx.Batch{}
+
+	for _, milestoneUpdate := range milestoneUpdates {
+		bp.Queue(insertMilestone,
+			milestoneUpdate.GameID,
+			milestoneUpdate.TeamID,
+			milestoneUpdate.PlayerID,
+			milestoneUpdate.PlayByPlayID,
+			milestoneUpdate.Type,
+			milestoneUpdate.Stat,
+			milestoneUpdate.Value,
+			milestoneUpdate.PreviousValue,
+			milestoneUpdate.Description)
+	}
+
+	batchResults := tx.SendBatch(ctx, bp)
+
+	milestones := []api.Milestone{}
+
+	for range milestoneUpdates {
+		m, err := scanMilestone(batchResults.QueryRow())
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			batchResults.Close()
+			return nil, fmt.Errorf("failed to insert milestone: %w", err)
+		}
+
+		milestones = append(milestones, m)
+	}
+
+	err = batchResults.Close()
+	if err != nil {
+		return nil, fmt.Errorf("could not close batchResults when updating milestones: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not commit transaction when updating milestones: %w", err)
+	}
+
+	return milestones, nil
+}
+
+func (d DB) ListMilestones(ctx context.Context, filter milestone.ListFilter) ([]api.Milestone, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.ListMilestones")
+	defer span.End()
+
+	query := `
+		SELECT ` + milestoneColumns + `
+		FROM nba.milestone m
+		JOIN nba.game g ON g.id = m.game_id
+		WHERE ($1::uuid IS NULL OR m.game_id = $1)
+			AND ($2::uuid IS NULL OR m.team_id = $2)
+			AND ($3::uuid IS NULL OR m.player_id = $3)
+			AND ($4::text IS NULL OR m.type = $4)
+		ORDER BY g.start_time DESC, m.created_at DESC
+		LIMIT $5`
+
+	rows, err := d.pgxPool.Query(ctx, query, filter.GameID, filter.TeamID, filter.PlayerID, filter.Type, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list milestones: %w", err)
+	}
+	defer rows.Close()
+
+	milestones := []api.Milestone{}
+
+	for rows.Next() {
+		m, err := scanMilestone(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan milestone: %w", err)
+		}
+
+		milestones = append(milestones, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read milestones: %w", err)
+	}
+
+	return milestones, nil
+}
+
+func scanMilestone(row pgx.Row) (api.Milestone, error) {
+	m := api.Milestone{}
+
+	err := row.Scan(
+		&m.ID,
+		&m.GameID,
+		&m.TeamID,
+		&m.PlayerID,
+		&m.PlayByPlayID,
+		&m.Type,
+		&m.Stat,
+		&m.Value,
+		&m.PreviousValue,
+		&m.Description,
+		&m.GameStartTime,
+		&m.CreatedAt,
+		&m.UpdatedAt)
+	if err != nil {
+		return api.Milestone{}, err
+	}
+
+	return m, nil
+}
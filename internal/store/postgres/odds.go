@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/internal/odds"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+func (d DB) UpdateGameOdds(ctx context.Context, gameOddsUpdates []odds.GameOddsUpdate) (int, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.UpdateGameOdds")
+	defer span.End()
+
+	tx, err := d.begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to start db transaction to update game odds: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// only keep a snapshot when the odds or line moved since the last one captured for the outcome
+	insertGameOdds := `
+		INSERT INTO nba.game_odds
+			(game_id, nba_bookmaker_id, bookmaker, market, outcome, odds, line, opening_odds, opening_line, captured_at)
+		SELECT g.id, $2, $3, $4, $5, $6, $7, $8, $9, $10
+		FROM nba.game g
+		WHERE g.nba_game_id = $1 AND NOT EXISTS (
+			SELECT 1
+			FROM (
+				SELECT o.odds, o.line
+				FROM nba.game_odds o
+				WHERE o.game_id = g.id AND o.nba_bookmaker_id = $2 AND o.market = $4 AND o.outcome = $5
+				ORDER BY o.captured_at DESC
+				LIMIT 1
+			) latest
+			WHERE latest.odds IS NOT DISTINCT FROM $6::double precision AND latest.line IS NOT DISTINCT FROM $7::double precision
+		)
+		ON CONFLICT (game_id, nba_bookmaker_id, market, outcome, captured_at) DO NOTHING`
+
+	bp := &pgx.Batch{}
+
+	for _, gameOddsUpdate := range gameOddsUpdates {
+		bp.Queue(insertGameOdds,
+			gameOddsUpdate.NBAGameID,
+			gameOddsUpdate.NBABookmakerID,
+			gameOddsUpdate.Bookmaker,
+			gameOddsUpdate.Market,
+			gameOddsUpdate.Outcome,
+			gameOddsUpdate.Odds,
+			gameOddsUpdate.Line,
+			gameOddsUpdate.OpeningOdds,
+			gameOddsUpdate.OpeningLine,
+			gameOddsUpdate.CapturedAt)
+	}
+
+	batchResults := tx.SendBatch(ctx, bp)
+
+	inserted := 0
+	for range gameOddsUpdates {
+		commandTag, err := batchResults.Exec()
+		if err != nil {
+			batchResults.Close()
+			return 0, fmt.Errorf("failed to insert game odds: %w", err)
+		}
+		inserted += int(commandTag.RowsAffected())
+	}
+
+	err = batchResults.Close()
+	if err != nil {
+		return 0, fmt.Errorf("could not close batchResults when updating game odds: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("could not commit transaction when updating game odds: %w", err)
+	}
+
+	return inserted, nil
+}
+
+func (d DB) GetGameOddsSnapshots(ctx context.Context, gameID string) ([]api.GameOddsSnapshot, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetGameOddsSnapshots")
+	defer span.End()
+
+	query := `
+		SELECT id, game_id, nba_bookmaker_id, bookmaker, market, outcome, odds, line, opening_odds, opening_line, captured_at, created_at, updated_at
+		FROM nba.game_odds
+		WHERE game_id = $1
+		ORDER BY nba_bookmaker_id, market, outcome, captured_at`
+
+	rows, err := d.pgxPool.Query(ctx, query, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game odds snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots := []api.GameOddsSnapshot{}
+
+	for rows.Next() {
+		snapshot := api.GameOddsSnapshot{}
+
+		err := rows.Scan(
+			&snapshot.ID,
+			&snapshot.GameID,
+			&snapshot.NBABookmakerID,
+			&snapshot.Bookmaker,
+			&snapshot.Market,
+			&snapshot.Outcome,
+			&snapshot.Odds,
+			&snapshot.Line,
+			&snapshot.OpeningOdds,
+			&snapshot.OpeningLine,
+			&snapshot.CapturedAt,
+			&snapshot.CreatedAt,
+			&snapshot.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan game odds snapshot: %w", err)
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read game odds snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/drewthor/wolves_reddit_bot/api"
 	"github.com/drewthor/wolves_reddit_bot/internal/playbyplay"
+	"github.com/jackc/pgx/v5"
 	"go.opentelemetry.io/otel"
 )
 
@@ -13,101 +14,161 @@ func (d DB) UpdatePlayByPlays(ctx context.Context, playByPlayUpdates []playbypla
 	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.UpdatePlayByPlays")
 	defer span.End()
 
-	tx, err := d.pgxPool.Begin(ctx)
+	tx, err := d.begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("could not start db transaction to update play by plays with error: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
-	/*
-		insertGame := `
-			INSERT INTO nba.game
-				as g(home_team_id, away_team_id, home_team_points, away_team_points, game_status_id, arena_id, attendance, season_id, season_stage_id, sellout, period, period_time_remaining_tenth_seconds, duration_seconds, start_time, end_time, regulation_periods, nba_game_id)
-			SELECT
-				( SELECT id FROM nba.team WHERE nba_team_id = $1 ) as home_team_id,
-				( SELECT id FROM nba.team WHERE nba_team_id = $2 ) as away_team_id,
-			    $3,
-			    $4,
-				( SELECT id FROM nba.game_status WHERE name = $5 ) as game_status_id,
-				( SELECT id FROM nba.arena WHERE nba_arena_id = $6 ) as arena_id,
-				$7,
-				( SELECT nba.season.id FROM nba.season JOIN nba.season_week ON nba.season.id = nba.season_week.season_id WHERE nba.season_week.start_date <= $8 AND nba.season_week.end_date > $8) as season_id,
-				( SELECT id FROM nba.season_stage WHERE name = 'regular' ) as season_stage_id,
-				$9,
-				$10,
-				$11,
-				$12,
-				$13,
-				$14,
-				$15,
-				$16
-			ON CONFLICT (nba_game_id) DO UPDATE
-			SET
-				home_team_id = coalesce(excluded.home_team_id, g.home_team_id),
-				away_team_id = coalesce(excluded.away_team_id, g.away_team_id),
-				home_team_points = coalesce(excluded.home_team_points, g.home_team_points),
-				away_team_points = coalesce(excluded.away_team_points, g.away_team_points),
-				game_status_id = coalesce(excluded.game_status_id, g.game_status_id),
-				arena_id = coalesce(excluded.arena_id, g.arena_id),
-				season_id = coalesce(excluded.season_id, g.season_id),
-				season_stage_id = coalesce(excluded.season_stage_id, g.season_stage_id),
-				attendance = coalesce(excluded.attendance, g.attendance),
-				sellout = coalesce(excluded.sellout, g.sellout),
-				period = coalesce(excluded.period, g.period),
-				period_time_remaining_tenth_seconds = coalesce(excluded.period_time_remaining_tenth_seconds, g.period_time_remaining_tenth_seconds),
-				duration_seconds = coalesce(excluded.duration_seconds, g.duration_seconds),
-				start_time = g.start_time,
-				end_time = coalesce(excluded.end_time, g.end_time),
-				regulation_periods = coalesce(excluded.regulation_periods, g.regulation_periods),
-				nba_game_id = coalesce(excluded.nba_game_id, g.nba_game_id)
-			RETURNING g.id`
-
-		bp := &pgxutil.Batch{}
-
-		for _, gameUpdate := range gameUpdates {
-			bp.Queue(insertGame,
-				gameUpdate.NBAHomeTeamID,
-				gameUpdate.NBAAwayTeamID,
-				gameUpdate.HomeTeamPoints,
-				gameUpdate.AwayTeamPoints,
-				gameUpdate.GameStatusName,
-				gameUpdate.NBAArenaID,
-				gameUpdate.Attendance,
-				gameUpdate.StartTime,
-				gameUpdate.Sellout,
-				gameUpdate.Period,
-				gameUpdate.PeriodTimeRemainingTenthSeconds,
-				gameUpdate.DurationSeconds,
-				gameUpdate.StartTime,
-				gameUpdate.EndTime,
-				gameUpdate.RegulationPeriods,
-				gameUpdate.NBAGameID)
-		}
+	insertPlayByPlay := `
+		INSERT INTO nba.play_by_play
+			as pbp(game_id, team_id, player_id, period, action_number, clock_tenth_seconds, action_type, sub_type, description, shot_result, home_score, away_score, possession_team_id, home_win_probability)
+		VALUES (
+			( SELECT id FROM nba.game WHERE nba_game_id = $1 ),
+			( SELECT id FROM nba.team WHERE nba_team_id = $2 ),
+			( SELECT id FROM nba.player WHERE nba_player_id = $3 ),
+			$4,
+			$5,
+			$6,
+			$7,
+			$8,
+			$9,
+			$10,
+			$11,
+			$12,
+			( SELECT id FROM nba.team WHERE nba_team_id = $13 ),
+			$14)
+		ON CONFLICT (game_id, action_number) DO UPDATE
+		SET
+			team_id = coalesce(excluded.team_id, pbp.team_id),
+			player_id = coalesce(excluded.player_id, pbp.player_id),
+			period = coalesce(excluded.period, pbp.period),
+			clock_tenth_seconds = coalesce(excluded.clock_tenth_seconds, pbp.clock_tenth_seconds),
+			action_type = coalesce(excluded.action_type, pbp.action_type),
+			sub_type = coalesce(excluded.sub_type, pbp.sub_type),
+			description = coalesce(excluded.description, pbp.description),
+			shot_result = coalesce(excluded.shot_result, pbp.shot_result),
+			home_score = coalesce(excluded.home_score, pbp.home_score),
+			away_score = coalesce(excluded.away_score, pbp.away_score),
+			possession_team_id = coalesce(excluded.possession_team_id, pbp.possession_team_id),
+			home_win_probability = coalesce(excluded.home_win_probability, pbp.home_win_probability)
+		RETURNING id, game_id, team_id, player_id, period, action_number, clock_tenth_seconds, action_type, sub_type, description, shot_result, home_score, away_score, possession_team_id, home_win_probability, created_at, updated_at`
+
+	bp := &pgx.Batch{}
 
-		batchResults := tx.SendBatch(ctx, bp)
+	for _, playByPlayUpdate := range playByPlayUpdates {
+		bp.Queue(insertPlayByPlay,
+			playByPlayUpdate.NBAGameID,
+			playByPlayUpdate.NBATeamID,
+			playByPlayUpdate.NBAPlayerID,
+			playByPlayUpdate.Period,
+			playByPlayUpdate.ActionNumber,
+			playByPlayUpdate.ClockTenthSeconds,
+			playByPlayUpdate.ActionType,
+			playByPlayUpdate.SubType,
+			playByPlayUpdate.Description,
+			playByPlayUpdate.ShotResult,
+			playByPlayUpdate.HomeScore,
+			playByPlayUpdate.AwayScore,
+			playByPlayUpdate.NBAPossessionTeamID,
+			playByPlayUpdate.HomeWinProbability)
+	}
 
-		insertedGameIDs := []string{}
+	batchResults := tx.SendBatch(ctx, bp)
 
-		for _, _ = range gameUpdates {
-			id := ""
-			err := batchResults.QueryRow().Scan(&id)
-			if err != nil {
-				batchResults.Close()
-				return nil, err
-			}
+	insertedPlayByPlays := []api.PlayByPlay{}
 
-			insertedGameIDs = append(insertedGameIDs, id)
-		}
+	for range playByPlayUpdates {
+		playByPlay := api.PlayByPlay{}
 
-		err = batchResults.Close()
+		err := batchResults.QueryRow().Scan(
+			&playByPlay.ID,
+			&playByPlay.GameID,
+			&playByPlay.TeamID,
+			&playByPlay.PlayerID,
+			&playByPlay.Period,
+			&playByPlay.ActionNumber,
+			&playByPlay.ClockTenthSeconds,
+			&playByPlay.ActionType,
+			&playByPlay.SubType,
+			&playByPlay.Description,
+			&playByPlay.ShotResult,
+			&playByPlay.HomeScore,
+			&playByPlay.AwayScore,
+			&playByPlay.PossessionTeamID,
+			&playByPlay.HomeWinProbability,
+			&playByPlay.CreatedAt,
+			&playByPlay.UpdatedAt)
 		if err != nil {
-			return nil, err
+			batchResults.Close()
+			return nil, fmt.Errorf("failed to scan upserted play by play: %w", err)
 		}
 
-		err = tx.Commit(ctx)
+		insertedPlayByPlays = append(insertedPlayByPlays, playByPlay)
+	}
+
+	err = batchResults.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return insertedPlayByPlays, nil
+}
+
+func (d DB) GetPlayByPlaysForGame(ctx context.Context, gameID string) ([]api.PlayByPlay, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetPlayByPlaysForGame")
+	defer span.End()
+
+	query := `
+		SELECT id, game_id, team_id, player_id, period, action_number, clock_tenth_seconds, action_type, sub_type, description, shot_result, home_score, away_score, possession_team_id, home_win_probability, created_at, updated_at
+		FROM nba.play_by_play
+		WHERE game_id = $1
+		ORDER BY action_number`
+
+	rows, err := d.pgxPool.Query(ctx, query, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get play by plays for game: %w", err)
+	}
+	defer rows.Close()
+
+	playByPlays := []api.PlayByPlay{}
+
+	for rows.Next() {
+		playByPlay := api.PlayByPlay{}
+
+		err := rows.Scan(
+			&playByPlay.ID,
+			&playByPlay.GameID,
+			&playByPlay.TeamID,
+			&playByPlay.PlayerID,
+			&playByPlay.Period,
+			&playByPlay.ActionNumber,
+			&playByPlay.ClockTenthSeconds,
+			&playByPlay.ActionType,
+			&playByPlay.SubType,
+			&playByPlay.Description,
+			&playByPlay.ShotResult,
+			&playByPlay.HomeScore,
+			&playByPlay.AwayScore,
+			&playByPlay.PossessionTeamID,
+			&playByPlay.HomeWinProbability,
+			&playByPlay.CreatedAt,
+			&playByPlay.UpdatedAt)
 		if err != nil {
-			return nil, err
-		}*/
+			return nil, fmt.Errorf("failed to scan play by play: %w", err)
+		}
+
+		playByPlays = append(playByPlays, playByPlay)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read play by plays for game: %w", err)
+	}
 
-	return nil, nil
+	return playByPlays, nil
 }
@@ -165,7 +165,7 @@ func (d DB) UpdatePlayers(ctx context.Context, players []api.Player) ([]api.Play
 	ctx, span := otel.Tracer("nba").Start(ctx, "postgres.DB.UpdatePlayers")
 	defer span.End()
 
-	tx, err := d.pgxPool.Begin(ctx)
+	tx, err := d.begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("could not start db transaction with error: %w", err)
 	}
@@ -273,3 +273,55 @@ func (d DB) UpdatePlayers(ctx context.Context, players []api.Player) ([]api.Play
 
 	return d.GetPlayersWithIDs(ctx, insertedPlayerIDs)
 }
+
+func (d DB) InsertMissingPlayers(ctx context.Context, players []api.Player) (int, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.InsertMissingPlayers")
+	defer span.End()
+
+	tx, err := d.begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("could not start db transaction to insert missing players: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	insertPlayer := `
+		INSERT INTO nba.player
+			(first_name, last_name, active, years_pro, nba_player_id)
+		VALUES ($1, $2, $3, coalesce($4, 0), $5)
+		ON CONFLICT (nba_player_id) DO NOTHING`
+
+	bp := &pgx.Batch{}
+
+	for _, player := range players {
+		bp.Queue(insertPlayer,
+			player.FirstName,
+			player.LastName,
+			player.Active,
+			player.YearsPro,
+			player.NBAPlayerID)
+	}
+
+	batchResults := tx.SendBatch(ctx, bp)
+
+	inserted := 0
+	for range players {
+		commandTag, err := batchResults.Exec()
+		if err != nil {
+			batchResults.Close()
+			return 0, fmt.Errorf("failed to insert missing player: %w", err)
+		}
+		inserted += int(commandTag.RowsAffected())
+	}
+
+	err = batchResults.Close()
+	if err != nil {
+		return 0, fmt.Errorf("could not close batchResults when inserting missing players: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("could not commit transaction when inserting missing players: %w", err)
+	}
+
+	return inserted, nil
+}
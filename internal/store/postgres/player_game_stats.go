@@ -0,0 +1,249 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/internal/player_game_stats"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+func (d DB) UpdatePlayerGameStatsTotals(ctx context.Context, playerGameStatsTotalUpdates []player_game_stats.PlayerGameStatsTotalUpdate) (int, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.UpdatePlayerGameStatsTotals")
+	defer span.End()
+
+	tx, err := d.begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to start db transaction to update player game stats totals: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// players that have not been stored yet are skipped instead of failing the whole game
+	insertPlayerGameStatsTotal := `
+		INSERT INTO nba.player_team_game_stats_total
+			AS ptgst (game_id, team_id, player_id, time_played_seconds, points, assists, turnovers, steals, three_pointers_attempted, three_pointers_made, three_point_percentage, field_goals_attempted, field_goals_made, field_goal_percentage, free_throws_attempted, free_throws_made, free_throw_percentage, blocks, rebounds_offensive, rebounds_defensive, rebounds_total, fouls_personal, plus_minus)
+		SELECT g.id, t.id, p.id, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23
+		FROM nba.game g, nba.team t, nba.player p
+		WHERE g.nba_game_id = $1 AND t.nba_team_id = $2 AND p.nba_player_id = $3
+		ON CONFLICT (game_id, team_id, player_id) DO UPDATE
+		SET
+			time_played_seconds = excluded.time_played_seconds,
+			points = excluded.points,
+			assists = excluded.assists,
+			turnovers = excluded.turnovers,
+			steals = excluded.steals,
+			three_pointers_attempted = excluded.three_pointers_attempted,
+			three_pointers_made = excluded.three_pointers_made,
+			three_point_percentage = excluded.three_point_percentage,
+			field_goals_attempted = excluded.field_goals_attempted,
+			field_goals_made = excluded.field_goals_made,
+			field_goal_percentage = excluded.field_goal_percentage,
+			free_throws_attempted = excluded.free_throws_attempted,
+			free_throws_made = excluded.free_throws_made,
+			free_throw_percentage = excluded.free_throw_percentage,
+			blocks = excluded.blocks,
+			rebounds_offensive = excluded.rebounds_offensive,
+			rebounds_defensive = excluded.rebounds_defensive,
+			rebounds_total = excluded.rebounds_total,
+			fouls_personal = excluded.fouls_personal,
+			plus_minus = excluded.plus_minus,
+			source = excluded.source`
+
+	bp := &pgx.Batch{}
+
+	for _, update := range playerGameStatsTotalUpdates {
+		bp.Queue(insertPlayerGameStatsTotal,
+			update.NBAGameID,
+			update.NBATeamID,
+			update.NBAPlayerID,
+			update.TimePlayedSeconds,
+			update.Points,
+			update.Assists,
+			update.Turnovers,
+			update.Steals,
+			update.ThreePointersAttempted,
+			update.ThreePointersMade,
+			update.ThreePointPercentage,
+			update.FieldGoalsAttempted,
+			update.FieldGoalsMade,
+			update.FieldGoalPercentage,
+			update.FreeThrowsAttempted,
+			update.FreeThrowsMade,
+			update.FreeThrowPercentage,
+			update.Blocks,
+			update.ReboundsOffensive,
+			update.ReboundsDefensive,
+			update.ReboundsTotal,
+			update.FoulsPersonal,
+			update.PlusMinus)
+	}
+
+	batchResults := tx.SendBatch(ctx, bp)
+
+	updated := 0
+	for range playerGameStatsTotalUpdates {
+		commandTag, err := batchResults.Exec()
+		if err != nil {
+			batchResults.Close()
+			return 0, fmt.Errorf("failed to upsert player game stats total: %w", err)
+		}
+		updated += int(commandTag.RowsAffected())
+	}
+
+	err = batchResults.Close()
+	if err != nil {
+		return 0, fmt.Errorf("could not close batchResults when updating player game stats totals: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("could not commit transaction when updating player game stats totals: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (d DB) UpdateHistoricalPlayerGameStatsTotals(ctx context.Context, playerGameStatsTotalUpdates []player_game_stats.PlayerGameStatsTotalHistoricalUpdate) (int, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.UpdateHistoricalPlayerGameStatsTotals")
+	defer span.End()
+
+	tx, err := d.begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to start db transaction to update historical player game stats totals: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// lines that came from the cdn boxscore have more detail so they are never replaced by a historical line
+	insertPlayerGameStatsTotal := `
+		INSERT INTO nba.player_team_game_stats_total
+			AS ptgst (game_id, team_id, player_id, time_played_seconds, points, assists, turnovers, steals, three_pointers_attempted, three_pointers_made, field_goals_attempted, field_goals_made, free_throws_attempted, free_throws_made, blocks, rebounds_offensive, rebounds_defensive, rebounds_total, fouls_personal, plus_minus, source)
+		SELECT g.id, t.id, p.id, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21
+		FROM nba.game g, nba.team t, nba.player p
+		WHERE g.nba_game_id = $1 AND t.nba_team_id = $2 AND p.nba_player_id = $3
+		ON CONFLICT (game_id, team_id, player_id) DO UPDATE
+		SET
+			time_played_seconds = coalesce(excluded.time_played_seconds, ptgst.time_played_seconds),
+			points = excluded.points,
+			assists = coalesce(excluded.assists, ptgst.assists),
+			turnovers = coalesce(excluded.turnovers, ptgst.turnovers),
+			steals = coalesce(excluded.steals, ptgst.steals),
+			three_pointers_attempted = coalesce(excluded.three_pointers_attempted, ptgst.three_pointers_attempted),
+			three_pointers_made = coalesce(excluded.three_pointers_made, ptgst.three_pointers_made),
+			field_goals_attempted = coalesce(excluded.field_goals_attempted, ptgst.field_goals_attempted),
+			field_goals_made = excluded.field_goals_made,
+			free_throws_attempted = excluded.free_throws_attempted,
+			free_throws_made = excluded.free_throws_made,
+			blocks = coalesce(excluded.blocks, ptgst.blocks),
+			rebounds_offensive = coalesce(excluded.rebounds_offensive, ptgst.rebounds_offensive),
+			rebounds_defensive = coalesce(excluded.rebounds_defensive, ptgst.rebounds_defensive),
+			rebounds_total = coalesce(excluded.rebounds_total, ptgst.rebounds_total),
+			fouls_personal = coalesce(excluded.fouls_personal, ptgst.fouls_personal),
+			plus_minus = coalesce(excluded.plus_minus, ptgst.plus_minus),
+			source = excluded.source
+		WHERE ptgst.source <> 'cdn'`
+
+	bp := &pgx.Batch{}
+
+	for _, update := range playerGameStatsTotalUpdates {
+		bp.Queue(insertPlayerGameStatsTotal,
+			update.NBAGameID,
+			update.NBATeamID,
+			update.NBAPlayerID,
+			update.TimePlayedSeconds,
+			update.Points,
+			update.Assists,
+			update.Turnovers,
+			update.Steals,
+			update.ThreePointersAttempted,
+			update.ThreePointersMade,
+			update.FieldGoalsAttempted,
+			update.FieldGoalsMade,
+			update.FreeThrowsAttempted,
+			update.FreeThrowsMade,
+			update.Blocks,
+			update.ReboundsOffensive,
+			update.ReboundsDefensive,
+			update.ReboundsTotal,
+			update.FoulsPersonal,
+			update.PlusMinus,
+			update.Source)
+	}
+
+	batchResults := tx.SendBatch(ctx, bp)
+
+	updated := 0
+	for range playerGameStatsTotalUpdates {
+		commandTag, err := batchResults.Exec()
+		if err != nil {
+			batchResults.Close()
+			return 0, fmt.Errorf("failed to upsert historical player game stats total: %w", err)
+		}
+		updated += int(commandTag.RowsAffected())
+	}
+
+	err = batchResults.Close()
+	if err != nil {
+		return 0, fmt.Errorf("could not close batchResults when updating historical player game stats totals: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("could not commit transaction when updating historical player game stats totals: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (d DB) GetPlayerGameStatsForGames(ctx context.Context, gameIDs []string) ([]api.PlayerGameStats, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetPlayerGameStatsForGames")
+	defer span.End()
+
+	// starters and the most played first since that's how a boxscore reads
+	query := `
+		SELECT ptgst.game_id, ptgst.team_id, ptgst.player_id, ptgst.time_played_seconds, ptgst.points, ptgst.assists, ptgst.turnovers, ptgst.steals, ptgst.blocks, ptgst.three_pointers_attempted, ptgst.three_pointers_made, ptgst.field_goals_attempted, ptgst.field_goals_made, ptgst.free_throws_attempted, ptgst.free_throws_made, ptgst.rebounds_offensive, ptgst.rebounds_defensive, ptgst.rebounds_total, ptgst.fouls_personal, ptgst.plus_minus
+		FROM nba.player_team_game_stats_total ptgst
+		WHERE ptgst.game_id = ANY($1)
+		ORDER BY ptgst.game_id, ptgst.team_id, ptgst.time_played_seconds DESC NULLS LAST, ptgst.points DESC NULLS LAST`
+
+	rows, err := d.pgxPool.Query(ctx, query, gameIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player game stats: %w", err)
+	}
+	defer rows.Close()
+
+	playerGameStats := []api.PlayerGameStats{}
+
+	for rows.Next() {
+		s := api.PlayerGameStats{}
+		err := rows.Scan(
+			&s.GameID,
+			&s.TeamID,
+			&s.PlayerID,
+			&s.TimePlayedSeconds,
+			&s.Points,
+			&s.Assists,
+			&s.Turnovers,
+			&s.Steals,
+			&s.Blocks,
+			&s.ThreePointersAttempted,
+			&s.ThreePointersMade,
+			&s.FieldGoalsAttempted,
+			&s.FieldGoalsMade,
+			&s.FreeThrowsAttempted,
+			&s.FreeThrowsMade,
+			&s.ReboundsOffensive,
+			&s.ReboundsDefensive,
+			&s.ReboundsTotal,
+			&s.FoulsPersonal,
+			&s.PlusMinus)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan player game stats: %w", err)
+		}
+
+		playerGameStats = append(playerGameStats, s)
+	}
+
+	return playerGameStats, rows.Err()
+}
@@ -0,0 +1,206 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/internal/playoff"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+const playoffSeriesColumns = `ps.id, ps.season_id, ps.play_in, ps.round, ps.series_number, ps.conference, ps.high_seed_team_id, ps.low_seed_team_id, ps.high_seed, ps.low_seed, ps.high_seed_wins, ps.low_seed_wins, ps.wins_needed, ps.games_scheduled, ps.status, ps.winner_team_id, ps.created_at, ps.updated_at`
+
+func (d DB) GetPlayoffGames(ctx context.Context, seasonStartYear int) ([]playoff.PlayoffGame, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetPlayoffGames")
+	defer span.End()
+
+	// playoff game ids start with 004 and play-in game ids with 005
+	query := `
+		SELECT g.nba_game_id, g.home_team_id, g.away_team_id, g.home_team_seed, g.away_team_seed, c.name, g.home_team_points, g.away_team_points, gs.name, g.if_necessary, g.start_time
+		FROM nba.game g
+		JOIN nba.season s ON s.id = g.season_id
+		JOIN nba.game_status gs ON gs.id = g.game_status_id
+		LEFT JOIN nba.team_season ts ON ts.team_id = g.home_team_id AND ts.season_id = g.season_id
+		LEFT JOIN nba.conference c ON c.id = ts.conference_id
+		WHERE s.start_year = $1 AND (g.nba_game_id LIKE '004%' OR g.nba_game_id LIKE '005%')
+		ORDER BY g.nba_game_id`
+
+	rows, err := d.pgxPool.Query(ctx, query, seasonStartYear)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get playoff games: %w", err)
+	}
+	defer rows.Close()
+
+	playoffGames := []playoff.PlayoffGame{}
+
+	for rows.Next() {
+		playoffGame := playoff.PlayoffGame{}
+
+		err := rows.Scan(
+			&playoffGame.NBAGameID,
+			&playoffGame.HomeTeamID,
+			&playoffGame.AwayTeamID,
+			&playoffGame.HomeTeamSeed,
+			&playoffGame.AwayTeamSeed,
+			&playoffGame.HomeTeamConference,
+			&playoffGame.HomeTeamPoints,
+			&playoffGame.AwayTeamPoints,
+			&playoffGame.GameStatusName,
+			&playoffGame.IfNecessary,
+			&playoffGame.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan playoff game: %w", err)
+		}
+
+		playoffGames = append(playoffGames, playoffGame)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read playoff games: %w", err)
+	}
+
+	return playoffGames, nil
+}
+
+func (d DB) UpdatePlayoffSeries(ctx context.Context, playoffSeriesUpdates []playoff.PlayoffSeriesUpdate) ([]api.PlayoffSeries, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.UpdatePlayoffSeries")
+	defer span.End()
+
+	tx, err := d.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start db transaction to update playoff series: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	insertPlayoffSeries := `
+		INSERT INTO nba.playoff_series AS ps
+			(season_id, play_in, round, series_number, conference, high_seed_team_id, low_seed_team_id, high_seed, low_seed, high_seed_wins, low_seed_wins, wins_needed, games_scheduled, status, winner_team_id)
+		VALUES ((SELECT id FROM nba.season WHERE start_year = $1), $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		ON CONFLICT (season_id, play_in, round, series_number) DO UPDATE
+		SET
+			conference = coalesce(excluded.conference, ps.conference),
+			high_seed_team_id = coalesce(excluded.high_seed_team_id, ps.high_seed_team_id),
+			low_seed_team_id = coalesce(excluded.low_seed_team_id, ps.low_seed_team_id),
+			high_seed = coalesce(excluded.high_seed, ps.high_seed),
+			low_seed = coalesce(excluded.low_seed, ps.low_seed),
+			high_seed_wins = excluded.high_seed_wins,
+			low_seed_wins = excluded.low_seed_wins,
+			wins_needed = excluded.wins_needed,
+			games_scheduled = excluded.games_scheduled,
+			status = excluded.status,
+			winner_team_id = excluded.winner_team_id
+		RETURNING ` + playoffSeriesColumns
+
+	bp := &pgx.Batch{}
+
+	for _, playoffSeriesUpdate := range playoffSeriesUpdates {
+		bp.Queue(insertPlayoffSeries,
+			playoffSeriesUpdate.SeasonStartYear,
+			playoffSeriesUpdate.PlayIn,
+			playoffSeriesUpdate.Round,
+			playoffSeriesUpdate.SeriesNumber,
+			playoffSeriesUpdate.Conference,
+			playoffSeriesUpdate.HighSeedTeamID,
+			playoffSeriesUpdate.LowSeedTeamID,
+			playoffSeriesUpdate.HighSeed,
+			playoffSeriesUpdate.LowSeed,
+			playoffSeriesUpdate.HighSeedWins,
+			playoffSeriesUpdate.LowSeedWins,
+			playoffSeriesUpdate.WinsNeeded,
+			playoffSeriesUpdate.GamesScheduled,
+			playoffSeriesUpdate.Status,
+			playoffSeriesUpdate.WinnerTeamID)
+	}
+
+	batchResults := tx.SendBatch(ctx, bp)
+
+	playoffSeries := []api.PlayoffSeries{}
+
+	for range playoffSeriesUpdates {
+		series, err := scanPlayoffSeries(batchResults.QueryRow())
+		if err != nil {
+			batchResults.Close()
+			return nil, fmt.Errorf("failed to upsert playoff series: %w", err)
+		}
+
+		playoffSeries = append(playoffSeries, series)
+	}
+
+	err = batchResults.Close()
+	if err != nil {
+		return nil, fmt.Errorf("could not close batchResults when updating playoff series: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not commit transaction when updating playoff series: %w", err)
+	}
+
+	return playoffSeries, nil
+}
+
+func (d DB) GetPlayoffSeries(ctx context.Context, seasonStartYear int) ([]api.PlayoffSeries, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetPlayoffSeries")
+	defer span.End()
+
+	query := `
+		SELECT ` + playoffSeriesColumns + `
+		FROM nba.playoff_series ps
+		JOIN nba.season s ON s.id = ps.season_id
+		WHERE s.start_year = $1
+		ORDER BY ps.play_in DESC, ps.round, ps.series_number`
+
+	rows, err := d.pgxPool.Query(ctx, query, seasonStartYear)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get playoff series: %w", err)
+	}
+	defer rows.Close()
+
+	playoffSeries := []api.PlayoffSeries{}
+
+	for rows.Next() {
+		series, err := scanPlayoffSeries(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		playoffSeries = append(playoffSeries, series)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read playoff series: %w", err)
+	}
+
+	return playoffSeries, nil
+}
+
+func scanPlayoffSeries(row pgx.Row) (api.PlayoffSeries, error) {
+	series := api.PlayoffSeries{}
+
+	err := row.Scan(
+		&series.ID,
+		&series.SeasonID,
+		&series.PlayIn,
+		&series.Round,
+		&series.SeriesNumber,
+		&series.Conference,
+		&series.HighSeedTeamID,
+		&series.LowSeedTeamID,
+		&series.HighSeed,
+		&series.LowSeed,
+		&series.HighSeedWins,
+		&series.LowSeedWins,
+		&series.WinsNeeded,
+		&series.GamesScheduled,
+		&series.Status,
+		&series.WinnerTeamID,
+		&series.CreatedAt,
+		&series.UpdatedAt)
+	if err != nil {
+		return api.PlayoffSeries{}, fmt.Errorf("failed to scan playoff series: %w", err)
+	}
+
+	return series, nil
+}
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/drewthor/wolves_reddit_bot/util"
+)
+
+// sortColumn is the column a list sort field orders by. Cast is the column's type so cursor values, which are text, can
+// be compared to it. Keyset pagination needs the column to be not null
+type sortColumn struct {
+	expr string
+	cast string
+}
+
+// listQuery builds the where, order by and limit of a list query from the filters that are set, args are numbered in
+// the order they're added
+type listQuery struct {
+	conditions []string
+	args       []any
+}
+
+// arg adds an arg and returns its placeholder
+func (q *listQuery) arg(v any) string {
+	q.args = append(q.args, v)
+	return fmt.Sprintf("$%d", len(q.args))
+}
+
+// and adds a condition every row has to match
+func (q *listQuery) and(condition string) {
+	q.conditions = append(q.conditions, condition)
+}
+
+// after only includes the rows after the cursor for the sort, rows with the same sort value are ordered by id
+func (q *listQuery) after(column sortColumn, idExpr string, sort util.Sort, cursor *util.Cursor) {
+	if cursor == nil {
+		return
+	}
+
+	operator := ">"
+	if sort.Descending {
+		operator = "<"
+	}
+
+	q.and(fmt.Sprintf("(%s, %s) %s (%s::%s, %s::uuid)", column.expr, idExpr, operator, q.arg(cursor.Value), column.cast, q.arg(cursor.ID)))
+}
+
+func (q *listQuery) where() string {
+	if len(q.conditions) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(q.conditions, "\n\t\t\tAND ")
+}
+
+func (q *listQuery) orderBy(column sortColumn, idExpr string, sort util.Sort) string {
+	direction := "ASC"
+	if sort.Descending {
+		direction = "DESC"
+	}
+	return fmt.Sprintf("ORDER BY %s %s, %s %s", column.expr, direction, idExpr, direction)
+}
+
+func (q *listQuery) limit(limit int) string {
+	return "LIMIT " + q.arg(limit)
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/drewthor/wolves_reddit_bot/api"
@@ -14,7 +15,7 @@ func (d DB) UpdateReferees(ctx context.Context, refereeUpdates []referee.Referee
 	ctx, span := otel.Tracer("nba").Start(ctx, "postgres.DB.UpdateReferees")
 	defer span.End()
 
-	tx, err := d.pgxPool.Begin(ctx)
+	tx, err := d.begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("could not start db transaction with error: %w", err)
 	}
@@ -46,7 +47,7 @@ func (d DB) UpdateReferees(ctx context.Context, refereeUpdates []referee.Referee
 
 	insertedReferees := []api.Referee{}
 
-	for _, _ = range insertedReferees {
+	for range refereeUpdates {
 		referee := api.Referee{}
 
 		err := batchResults.QueryRow().Scan(
@@ -59,6 +60,7 @@ func (d DB) UpdateReferees(ctx context.Context, refereeUpdates []referee.Referee
 			&referee.UpdatedAt)
 
 		if err != nil {
+			batchResults.Close()
 			return nil, err
 		}
 
@@ -77,3 +79,215 @@ func (d DB) UpdateReferees(ctx context.Context, refereeUpdates []referee.Referee
 
 	return insertedReferees, nil
 }
+
+func (d DB) ListReferees(ctx context.Context) ([]api.Referee, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.ListReferees")
+	defer span.End()
+
+	query := `
+		SELECT id, first_name, last_name, jersey_number, nba_referee_id, created_at, updated_at
+		FROM nba.referee
+		ORDER BY last_name, first_name`
+
+	rows, err := d.pgxPool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list referees: %w", err)
+	}
+	defer rows.Close()
+
+	referees := []api.Referee{}
+
+	for rows.Next() {
+		referee := api.Referee{}
+
+		err := rows.Scan(
+			&referee.ID,
+			&referee.FirstName,
+			&referee.LastName,
+			&referee.JerseyNumber,
+			&referee.NBARefereeID,
+			&referee.CreatedAt,
+			&referee.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan referee: %w", err)
+		}
+
+		referees = append(referees, referee)
+	}
+
+	return referees, rows.Err()
+}
+
+func (d DB) GetRefereesWithIDs(ctx context.Context, ids []string) ([]api.Referee, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetRefereesWithIDs")
+	defer span.End()
+
+	query := `
+		SELECT id, first_name, last_name, jersey_number, nba_referee_id, created_at, updated_at
+		FROM nba.referee
+		WHERE id = ANY($1)`
+
+	rows, err := d.pgxPool.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get referees: %w", err)
+	}
+	defer rows.Close()
+
+	referees := []api.Referee{}
+
+	for rows.Next() {
+		referee := api.Referee{}
+
+		err := rows.Scan(
+			&referee.ID,
+			&referee.FirstName,
+			&referee.LastName,
+			&referee.JerseyNumber,
+			&referee.NBARefereeID,
+			&referee.CreatedAt,
+			&referee.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan referee: %w", err)
+		}
+
+		referees = append(referees, referee)
+	}
+
+	return referees, rows.Err()
+}
+
+func (d DB) GetRefereeWithID(ctx context.Context, id string) (api.Referee, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetRefereeWithID")
+	defer span.End()
+
+	query := `
+		SELECT id, first_name, last_name, jersey_number, nba_referee_id, created_at, updated_at
+		FROM nba.referee
+		WHERE id = $1`
+
+	referee := api.Referee{}
+
+	err := d.pgxPool.QueryRow(ctx, query, id).Scan(
+		&referee.ID,
+		&referee.FirstName,
+		&referee.LastName,
+		&referee.JerseyNumber,
+		&referee.NBARefereeID,
+		&referee.CreatedAt,
+		&referee.UpdatedAt)
+	if err != nil {
+		return api.Referee{}, err
+	}
+
+	return referee, nil
+}
+
+func (d DB) GetRefereeAssignments(ctx context.Context, refereeID string) ([]api.RefereeAssignment, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetRefereeAssignments")
+	defer span.End()
+
+	query := `
+		SELECT g.id, g.nba_game_id, CONCAT(s.start_year, '-', s.end_year), g.start_time, g.home_team_id, g.away_team_id, gr.assignment
+		FROM nba.game_referee gr
+		JOIN nba.game g ON g.id = gr.game_id
+		JOIN nba.season s ON s.id = g.season_id
+		WHERE gr.referee_id = $1
+		ORDER BY s.start_year DESC, g.start_time DESC`
+
+	rows, err := d.pgxPool.Query(ctx, query, refereeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get referee assignments: %w", err)
+	}
+	defer rows.Close()
+
+	assignments := []api.RefereeAssignment{}
+
+	for rows.Next() {
+		assignment := api.RefereeAssignment{}
+
+		err := rows.Scan(
+			&assignment.GameID,
+			&assignment.NBAGameID,
+			&assignment.Season,
+			&assignment.StartTime,
+			&assignment.HomeTeamID,
+			&assignment.AwayTeamID,
+			&assignment.Assignment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan referee assignment: %w", err)
+		}
+
+		assignments = append(assignments, assignment)
+	}
+
+	return assignments, rows.Err()
+}
+
+func (d DB) GetRefereeFoulTotals(ctx context.Context, refereeID string) (referee.RefereeFoulTotals, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetRefereeFoulTotals")
+	defer span.End()
+
+	query := `
+		SELECT
+			count(DISTINCT pbp.game_id),
+			count(*) FILTER (WHERE pbp.action_type = 'foul'),
+			count(*) FILTER (WHERE pbp.action_type = 'foul' AND pbp.team_id = g.home_team_id),
+			count(*) FILTER (WHERE pbp.action_type = 'foul' AND pbp.team_id = g.away_team_id),
+			count(*) FILTER (WHERE pbp.action_type = 'foul' AND pbp.sub_type = 'technical'),
+			count(*) FILTER (WHERE pbp.action_type = 'freethrow'),
+			count(*) FILTER (WHERE pbp.action_type IN ('2pt', '3pt'))
+		FROM nba.game_referee gr
+		JOIN nba.game g ON g.id = gr.game_id
+		JOIN nba.play_by_play pbp ON pbp.game_id = g.id
+		WHERE gr.referee_id = $1
+		AND g.end_time IS NOT NULL`
+
+	totals := referee.RefereeFoulTotals{}
+
+	err := d.pgxPool.QueryRow(ctx, query, refereeID).Scan(
+		&totals.Games,
+		&totals.Fouls,
+		&totals.HomeFouls,
+		&totals.AwayFouls,
+		&totals.Technicals,
+		&totals.FreeThrowsAttempted,
+		&totals.FieldGoalsAttempted)
+	if err != nil {
+		return referee.RefereeFoulTotals{}, fmt.Errorf("failed to get referee foul totals: %w", err)
+	}
+
+	return totals, nil
+}
+
+func (d DB) GetRefereeCrewChiefRecord(ctx context.Context, refereeID string, nbaTeamID int) (api.TeamRecord, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetRefereeCrewChiefRecord")
+	defer span.End()
+
+	query := `
+		SELECT
+			t.id,
+			count(g.id) FILTER (WHERE (g.home_team_id = t.id AND g.home_team_points > g.away_team_points) OR (g.away_team_id = t.id AND g.away_team_points > g.home_team_points)),
+			count(g.id) FILTER (WHERE (g.home_team_id = t.id AND g.home_team_points < g.away_team_points) OR (g.away_team_id = t.id AND g.away_team_points < g.home_team_points))
+		FROM nba.team t
+		LEFT JOIN nba.game g ON (g.home_team_id = t.id OR g.away_team_id = t.id)
+			AND g.end_time IS NOT NULL
+			AND EXISTS (SELECT 1 FROM nba.game_referee gr WHERE gr.game_id = g.id AND gr.referee_id = $1 AND gr.assignment = $3)
+		WHERE t.nba_team_id = $2
+		GROUP BY t.id`
+
+	record := api.TeamRecord{}
+
+	err := d.pgxPool.QueryRow(ctx, query, refereeID, nbaTeamID, referee.AssignmentCrewChief).Scan(
+		&record.TeamID,
+		&record.Wins,
+		&record.Losses)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// the team hasn't been ingested yet
+			return api.TeamRecord{}, nil
+		}
+		return api.TeamRecord{}, fmt.Errorf("failed to get team record with referee as crew chief: %w", err)
+	}
+
+	return record, nil
+}
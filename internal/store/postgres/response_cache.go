@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+)
+
+// rowChangeChannel is the channel the notify_row_change trigger notifies
+const rowChangeChannel = "row_change"
+
+func (d DB) ListenRowChanges(ctx context.Context, onListening func(), onChange func(table string)) error {
+	poolConn, err := d.pgxPool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection to listen for row changes: %w", err)
+	}
+	// the connection is taken out of the pool so it isn't reused while listening
+	conn := poolConn.Hijack()
+	defer conn.Close(context.WithoutCancel(ctx))
+
+	if _, err := conn.Exec(ctx, "LISTEN "+rowChangeChannel); err != nil {
+		return fmt.Errorf("failed to listen for row changes: %w", err)
+	}
+
+	onListening()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to wait for row changes: %w", err)
+		}
+
+		// the payload is the name of the changed table
+		onChange(notification.Payload)
+	}
+}
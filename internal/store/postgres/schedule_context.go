@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+func (d DB) GetTeamScheduleContexts(ctx context.Context, teamID string, seasonStartYear int) ([]api.ScheduleContext, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetTeamScheduleContexts")
+	defer span.End()
+
+	query := `
+		SELECT sc.game_id, sc.team_id, sc.start_time, sc.home, sc.arena_id, sc.previous_game_id, sc.rest_days, coalesce(sc.rest_days = 0, false), sc.miles_traveled, sc.time_zones_crossed
+		FROM nba.team_game_schedule_context sc
+		JOIN nba.season s ON s.id = sc.season_id
+		WHERE sc.team_id = $1 AND s.start_year = $2
+		ORDER BY sc.start_time`
+
+	rows, err := d.pgxPool.Query(ctx, query, teamID, seasonStartYear)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule contexts for team: %w", err)
+	}
+
+	return scanScheduleContexts(rows)
+}
+
+func (d DB) GetScheduleContextsForGames(ctx context.Context, gameIDs []string) ([]api.ScheduleContext, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetScheduleContextsForGames")
+	defer span.End()
+
+	query := `
+		SELECT sc.game_id, sc.team_id, sc.start_time, sc.home, sc.arena_id, sc.previous_game_id, sc.rest_days, coalesce(sc.rest_days = 0, false), sc.miles_traveled, sc.time_zones_crossed
+		FROM nba.team_game_schedule_context sc
+		WHERE sc.game_id = any($1)`
+
+	rows, err := d.pgxPool.Query(ctx, query, gameIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule contexts for games: %w", err)
+	}
+
+	return scanScheduleContexts(rows)
+}
+
+func scanScheduleContexts(rows pgx.Rows) ([]api.ScheduleContext, error) {
+	defer rows.Close()
+
+	scheduleContexts := []api.ScheduleContext{}
+
+	for rows.Next() {
+		scheduleContext := api.ScheduleContext{}
+
+		err := rows.Scan(
+			&scheduleContext.GameID,
+			&scheduleContext.TeamID,
+			&scheduleContext.StartTime,
+			&scheduleContext.Home,
+			&scheduleContext.ArenaID,
+			&scheduleContext.PreviousGameID,
+			&scheduleContext.RestDays,
+			&scheduleContext.BackToBack,
+			&scheduleContext.MilesTraveled,
+			&scheduleContext.TimeZonesCrossed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan schedule context: %w", err)
+		}
+
+		scheduleContexts = append(scheduleContexts, scheduleContext)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read schedule contexts: %w", err)
+	}
+
+	return scheduleContexts, nil
+}
@@ -2,10 +2,62 @@ package postgres
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/drewthor/wolves_reddit_bot/api"
 	"github.com/drewthor/wolves_reddit_bot/internal/season"
+	"go.opentelemetry.io/otel"
 )
 
 func (d DB) UpdateSeasons(ctx context.Context, seasonUpdates []season.SeasonUpdate) ([]season.Season, error) {
 	return nil, nil
 }
+
+func (d DB) EnsureSeasonExistsForNBALeague(ctx context.Context, nbaLeagueID int, seasonStartYear int) error {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.EnsureSeasonExistsForNBALeague")
+	defer span.End()
+
+	insertSeason := `
+		INSERT INTO nba.season
+			(start_year, end_year, league_id)
+		SELECT $2, $2 + 1, l.id
+		FROM nba.league l
+		WHERE l.nba_league_id = $1
+		ON CONFLICT (start_year, end_year, league_id) DO NOTHING`
+
+	if _, err := d.pgxPool.Exec(ctx, insertSeason, nbaLeagueID, seasonStartYear); err != nil {
+		return fmt.Errorf("failed to insert season: %w", err)
+	}
+
+	return nil
+}
+
+func (d DB) ListSeasons(ctx context.Context) ([]api.Season, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.ListSeasons")
+	defer span.End()
+
+	// seasons are stored per league, the leagues share the same years
+	query := `
+		SELECT DISTINCT CONCAT(s.start_year, '-', s.end_year), s.start_year, s.end_year
+		FROM nba.season s
+		ORDER BY s.start_year DESC`
+
+	rows, err := d.pgxPool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list seasons: %w", err)
+	}
+	defer rows.Close()
+
+	seasons := []api.Season{}
+
+	for rows.Next() {
+		s := api.Season{}
+		if err := rows.Scan(&s.Name, &s.StartYear, &s.EndYear); err != nil {
+			return nil, fmt.Errorf("failed to scan season: %w", err)
+		}
+
+		seasons = append(seasons, s)
+	}
+
+	return seasons, rows.Err()
+}
@@ -13,7 +13,7 @@ func (d DB) UpdateSeasonWeeks(ctx context.Context, seasonWeekUpdates []season.Se
 	ctx, span := otel.Tracer("nba").Start(ctx, "postgres.DB.UpdateSeasonWeeks")
 	defer span.End()
 
-	tx, err := d.pgxPool.Begin(ctx)
+	tx, err := d.begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("could not start db transaction to update season weeks with error: %w", err)
 	}
@@ -0,0 +1,216 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/internal/stat_correction"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+const statCorrectionColumns = `sc.id, sc.game_id, sc.entity, sc.team_id, sc.player_id, sc.action_number, sc.field, sc.old_value, sc.new_value, sc.created_at, sc.updated_at`
+
+func (d DB) GetCompletedGamesSince(ctx context.Context, since time.Time) ([]stat_correction.CompletedGame, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetCompletedGamesSince")
+	defer span.End()
+
+	// games backfilled from the stats.nba.com game logs don't have a cdn boxscore to re-ingest
+	query := `
+		SELECT g.nba_game_id, s.start_year
+		FROM nba.game g
+		JOIN nba.game_status gs ON gs.id = g.game_status_id
+		JOIN nba.season s ON s.id = g.season_id
+		WHERE gs.name = 'completed' AND g.source = 'cdn' AND coalesce(g.end_time, g.start_time) >= $1
+		ORDER BY g.start_time`
+
+	rows, err := d.pgxPool.Query(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get completed games: %w", err)
+	}
+	defer rows.Close()
+
+	games := []stat_correction.CompletedGame{}
+
+	for rows.Next() {
+		g := stat_correction.CompletedGame{}
+		if err := rows.Scan(&g.NBAGameID, &g.SeasonStartYear); err != nil {
+			return nil, fmt.Errorf("failed to scan completed game: %w", err)
+		}
+
+		games = append(games, g)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read completed games: %w", err)
+	}
+
+	return games, nil
+}
+
+func (d DB) GetGameStatRows(ctx context.Context, nbaGameID string) ([]stat_correction.StatRow, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetGameStatRows")
+	defer span.End()
+
+	// the values of each row leave out the columns that identify the row or are bookkeeping, win probability is left
+	// out of plays since it's modeled by us and not something the league corrects
+	query := `
+		SELECT '` + stat_correction.EntityTeamGameStatsTotal + `', tgst.team_id, NULL::uuid, NULL::integer,
+			to_jsonb(tgst) - 'id' - 'created_at' - 'updated_at' - 'game_id' - 'team_id' - 'source'
+		FROM nba.team_game_stats_total tgst
+		JOIN nba.game g ON g.id = tgst.game_id
+		WHERE g.nba_game_id = $1
+		UNION ALL
+		SELECT '` + stat_correction.EntityPlayerGameStatsTotal + `', ptgst.team_id, ptgst.player_id, NULL::integer,
+			to_jsonb(ptgst) - 'id' - 'created_at' - 'updated_at' - 'game_id' - 'team_id' - 'player_id' - 'source'
+		FROM nba.player_team_game_stats_total ptgst
+		JOIN nba.game g ON g.id = ptgst.game_id
+		WHERE g.nba_game_id = $1
+		UNION ALL
+		SELECT '` + stat_correction.EntityPlayByPlay + `', pbp.team_id, pbp.player_id, pbp.action_number,
+			to_jsonb(pbp) - 'id' - 'created_at' - 'updated_at' - 'game_id' - 'action_number' - 'home_win_probability'
+		FROM nba.play_by_play pbp
+		JOIN nba.game g ON g.id = pbp.game_id
+		WHERE g.nba_game_id = $1`
+
+	rows, err := d.pgxPool.Query(ctx, query, nbaGameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game stat rows: %w", err)
+	}
+	defer rows.Close()
+
+	statRows := []stat_correction.StatRow{}
+
+	for rows.Next() {
+		r := stat_correction.StatRow{}
+		if err := rows.Scan(&r.Entity, &r.TeamID, &r.PlayerID, &r.ActionNumber, &r.Values); err != nil {
+			return nil, fmt.Errorf("failed to scan game stat row: %w", err)
+		}
+
+		statRows = append(statRows, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read game stat rows: %w", err)
+	}
+
+	return statRows, nil
+}
+
+func (d DB) UpdateStatCorrections(ctx context.Context, statCorrectionUpdates []stat_correction.StatCorrectionUpdate) ([]api.StatCorrection, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.UpdateStatCorrections")
+	defer span.End()
+
+	tx, err := d.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not start db transaction to update stat corrections: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	insertStatCorrection := `
+		INSERT INTO nba.stat_correction AS sc
+			(game_id, entity, team_id, player_id, action_number, field, old_value, new_value)
+		VALUES ((SELECT id FROM nba.game WHERE nba_game_id = $1), $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + statCorrectionColumns
+
+	bp := &pgx.Batch{}
+
+	for _, update := range statCorrectionUpdates {
+		bp.Queue(insertStatCorrection,
+			update.NBAGameID,
+			update.Entity,
+			update.TeamID,
+			update.PlayerID,
+			update.ActionNumber,
+			update.Field,
+			update.OldValue,
+			update.NewValue)
+	}
+
+	batchResults := tx.SendBatch(ctx, bp)
+
+	corrections := []api.StatCorrection{}
+
+	for range statCorrectionUpdates {
+		c, err := scanStatCorrection(batchResults.QueryRow())
+		if err != nil {
+			batchResults.Close()
+			return nil, fmt.Errorf("failed to insert stat correction: %w", err)
+		}
+
+		corrections = append(corrections, c)
+	}
+
+	err = batchResults.Close()
+	if err != nil {
+		return nil, fmt.Errorf("could not close batchResults when updating stat corrections: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not commit transaction when updating stat corrections: %w", err)
+	}
+
+	return corrections, nil
+}
+
+func (d DB) ListStatCorrections(ctx context.Context, filter stat_correction.ListFilter) ([]api.StatCorrection, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.ListStatCorrections")
+	defer span.End()
+
+	query := `
+		SELECT ` + statCorrectionColumns + `
+		FROM nba.stat_correction sc
+		WHERE ($1::uuid IS NULL OR sc.game_id = $1)
+			AND ($2::text IS NULL OR sc.entity = $2)
+			AND ($3::timestamptz IS NULL OR sc.created_at >= $3)
+		ORDER BY sc.created_at DESC, sc.entity, sc.field
+		LIMIT $4`
+
+	rows, err := d.pgxPool.Query(ctx, query, filter.GameID, filter.Entity, filter.Since, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stat corrections: %w", err)
+	}
+	defer rows.Close()
+
+	corrections := []api.StatCorrection{}
+
+	for rows.Next() {
+		c, err := scanStatCorrection(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stat correction: %w", err)
+		}
+
+		corrections = append(corrections, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read stat corrections: %w", err)
+	}
+
+	return corrections, nil
+}
+
+func scanStatCorrection(row pgx.Row) (api.StatCorrection, error) {
+	c := api.StatCorrection{}
+
+	err := row.Scan(
+		&c.ID,
+		&c.GameID,
+		&c.Entity,
+		&c.TeamID,
+		&c.PlayerID,
+		&c.ActionNumber,
+		&c.Field,
+		&c.OldValue,
+		&c.NewValue,
+		&c.CreatedAt,
+		&c.UpdatedAt)
+	if err != nil {
+		return api.StatCorrection{}, err
+	}
+
+	return c, nil
+}
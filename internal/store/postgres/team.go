@@ -192,9 +192,9 @@ func (d DB) UpdateTeams(ctx context.Context, teams []team.TeamUpdate) ([]api.Tea
 	ctx, span := otel.Tracer("nba").Start(ctx, "postgres.DB.UpdateTeams")
 	defer span.End()
 
-	tx, err := d.pgxPool.Begin(ctx)
+	tx, err := d.begin(ctx)
 	if err != nil {
-		slog.Error("could not start db transaction with error: %v", err)
+		slog.Error("could not start db transaction", slog.Any("error", err))
 		return nil, err
 	}
 	defer tx.Rollback(ctx)
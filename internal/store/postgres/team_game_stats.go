@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/drewthor/wolves_reddit_bot/api"
 	"github.com/drewthor/wolves_reddit_bot/internal/team_game_stats"
 	"github.com/jackc/pgx/v5"
 	"go.opentelemetry.io/otel"
@@ -13,7 +14,7 @@ func (d DB) UpdateTeamGameStatsTotals(ctx context.Context, teamGameStatsTotalsUp
 	ctx, span := otel.Tracer("nba").Start(ctx, "postgres.DB.UpdateTeamGameStatsTotals")
 	defer span.End()
 
-	tx, err := d.pgxPool.Begin(ctx)
+	tx, err := d.begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("could not start db transaction to update team game stats totals: %w", err)
 	}
@@ -140,7 +141,8 @@ func (d DB) UpdateTeamGameStatsTotals(ctx context.Context, teamGameStatsTotalsUp
 			times_tied = coalesce(excluded.times_tied, tgst.times_tied),
 			true_shooting_attempts = coalesce(excluded.true_shooting_attempts, tgst.true_shooting_attempts),
 			true_shooting_percentage = coalesce(excluded.true_shooting_percentage, tgst.true_shooting_percentage),
-			bench_points = coalesce(excluded.bench_points, tgst.bench_points)
+			bench_points = coalesce(excluded.bench_points, tgst.bench_points),
+			source = excluded.source
 		RETURNING tgst.*`
 
 	bp := &pgx.Batch{}
@@ -278,6 +280,7 @@ func (d DB) UpdateTeamGameStatsTotals(ctx context.Context, teamGameStatsTotalsUp
 			&t.TrueShootingAttempts,
 			&t.TrueShootingPercentage,
 			&t.BenchPoints,
+			&t.Source,
 		)
 
 		if err != nil {
@@ -305,7 +308,7 @@ func (d DB) UpdateTeamGameStatsTotalsOld(ctx context.Context, teamGameStatsTotal
 	ctx, span := otel.Tracer("nba").Start(ctx, "postgres.DB.UpdateTeamGameStatsTotalsOld")
 	defer span.End()
 
-	tx, err := d.pgxPool.Begin(ctx)
+	tx, err := d.begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("could not start db transaction to update team game stats totals: %w", err)
 	}
@@ -486,6 +489,7 @@ func (d DB) UpdateTeamGameStatsTotalsOld(ctx context.Context, teamGameStatsTotal
 			&t.TrueShootingAttempts,
 			&t.TrueShootingPercentage,
 			&t.BenchPoints,
+			&t.Source,
 		)
 
 		if err != nil {
@@ -508,3 +512,148 @@ func (d DB) UpdateTeamGameStatsTotalsOld(ctx context.Context, teamGameStatsTotal
 
 	return insertedTeamGameStatsTotals, nil
 }
+
+func (d DB) UpdateHistoricalTeamGameStatsTotals(ctx context.Context, teamGameStatsTotalsUpdates []team_game_stats.TeamGameStatsTotalHistoricalUpdate) (int, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.UpdateHistoricalTeamGameStatsTotals")
+	defer span.End()
+
+	tx, err := d.begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("could not start db transaction to update historical team game stats totals: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// lines that came from the cdn boxscore have more detail so they are never replaced by a game log line
+	insertTeamGameStatsTotal := `
+		INSERT INTO nba.team_game_stats_total
+			AS tgst (game_id, team_id, game_time_played_seconds, total_player_time_played_seconds, points, points_against, assists, total_turnovers, steals, three_pointers_attempted, three_pointers_made, field_goals_attempted, field_goals_made, free_throws_attempted, free_throws_made, blocks, total_offensive_rebounds, total_defensive_rebounds, total_rebounds, personal_fouls, source)
+		SELECT g.id, t.id, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21
+		FROM nba.game g, nba.team t
+		WHERE g.nba_game_id = $1 AND t.nba_team_id = $2
+		ON CONFLICT (game_id, team_id) DO UPDATE
+		SET
+			game_time_played_seconds = coalesce(excluded.game_time_played_seconds, tgst.game_time_played_seconds),
+			total_player_time_played_seconds = coalesce(excluded.total_player_time_played_seconds, tgst.total_player_time_played_seconds),
+			points = excluded.points,
+			points_against = excluded.points_against,
+			assists = coalesce(excluded.assists, tgst.assists),
+			total_turnovers = coalesce(excluded.total_turnovers, tgst.total_turnovers),
+			steals = coalesce(excluded.steals, tgst.steals),
+			three_pointers_attempted = coalesce(excluded.three_pointers_attempted, tgst.three_pointers_attempted),
+			three_pointers_made = coalesce(excluded.three_pointers_made, tgst.three_pointers_made),
+			field_goals_attempted = coalesce(excluded.field_goals_attempted, tgst.field_goals_attempted),
+			field_goals_made = excluded.field_goals_made,
+			free_throws_attempted = excluded.free_throws_attempted,
+			free_throws_made = excluded.free_throws_made,
+			blocks = coalesce(excluded.blocks, tgst.blocks),
+			total_offensive_rebounds = coalesce(excluded.total_offensive_rebounds, tgst.total_offensive_rebounds),
+			total_defensive_rebounds = coalesce(excluded.total_defensive_rebounds, tgst.total_defensive_rebounds),
+			total_rebounds = coalesce(excluded.total_rebounds, tgst.total_rebounds),
+			personal_fouls = coalesce(excluded.personal_fouls, tgst.personal_fouls),
+			source = excluded.source
+		WHERE tgst.source <> 'cdn'`
+
+	bp := &pgx.Batch{}
+
+	for _, update := range teamGameStatsTotalsUpdates {
+		bp.Queue(insertTeamGameStatsTotal,
+			update.NBAGameID,
+			update.NBATeamID,
+			update.GameTimePlayedSeconds,
+			update.TotalPlayerTimePlayedSeconds,
+			update.Points,
+			update.PointsAgainst,
+			update.Assists,
+			update.TotalTurnovers,
+			update.Steals,
+			update.ThreePointersAttempted,
+			update.ThreePointersMade,
+			update.FieldGoalsAttempted,
+			update.FieldGoalsMade,
+			update.FreeThrowsAttempted,
+			update.FreeThrowsMade,
+			update.Blocks,
+			update.TotalOffensiveRebounds,
+			update.TotalDefensiveRebounds,
+			update.TotalRebounds,
+			update.PersonalFouls,
+			update.Source)
+	}
+
+	batchResults := tx.SendBatch(ctx, bp)
+
+	updated := 0
+	for range teamGameStatsTotalsUpdates {
+		commandTag, err := batchResults.Exec()
+		if err != nil {
+			batchResults.Close()
+			return 0, fmt.Errorf("failed to upsert historical team game stats total: %w", err)
+		}
+		updated += int(commandTag.RowsAffected())
+	}
+
+	err = batchResults.Close()
+	if err != nil {
+		return 0, fmt.Errorf("could not close batchResults when updating historical team game stats totals: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("could not commit transaction when updating historical team game stats totals: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (d DB) GetTeamGameStatsForGames(ctx context.Context, gameIDs []string) ([]api.TeamGameStats, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetTeamGameStatsForGames")
+	defer span.End()
+
+	query := `
+		SELECT tgst.game_id, tgst.team_id, tgst.points, tgst.points_against, tgst.assists, tgst.total_turnovers, tgst.steals, tgst.blocks, tgst.three_pointers_attempted, tgst.three_pointers_made, tgst.field_goals_attempted, tgst.field_goals_made, tgst.free_throws_attempted, tgst.free_throws_made, tgst.total_offensive_rebounds, tgst.total_defensive_rebounds, tgst.total_rebounds, tgst.personal_fouls, tgst.fast_break_points, tgst.points_in_paint, tgst.second_chance_points, tgst.points_off_turnovers, tgst.biggest_lead
+		FROM nba.team_game_stats_total tgst
+		WHERE tgst.game_id = ANY($1)`
+
+	rows, err := d.pgxPool.Query(ctx, query, gameIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team game stats: %w", err)
+	}
+	defer rows.Close()
+
+	teamGameStats := []api.TeamGameStats{}
+
+	for rows.Next() {
+		s := api.TeamGameStats{}
+		err := rows.Scan(
+			&s.GameID,
+			&s.TeamID,
+			&s.Points,
+			&s.PointsAgainst,
+			&s.Assists,
+			&s.Turnovers,
+			&s.Steals,
+			&s.Blocks,
+			&s.ThreePointersAttempted,
+			&s.ThreePointersMade,
+			&s.FieldGoalsAttempted,
+			&s.FieldGoalsMade,
+			&s.FreeThrowsAttempted,
+			&s.FreeThrowsMade,
+			&s.ReboundsOffensive,
+			&s.ReboundsDefensive,
+			&s.ReboundsTotal,
+			&s.FoulsPersonal,
+			&s.FastBreakPoints,
+			&s.PointsInPaint,
+			&s.SecondChancePoints,
+			&s.PointsOffTurnovers,
+			&s.BiggestLead)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan team game stats: %w", err)
+		}
+
+		teamGameStats = append(teamGameStats, s)
+	}
+
+	return teamGameStats, rows.Err()
+}
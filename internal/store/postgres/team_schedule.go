@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/drewthor/wolves_reddit_bot/internal/team_schedule"
+	"go.opentelemetry.io/otel"
+)
+
+func (d DB) GetTeamScheduleGames(ctx context.Context, teamID string, seasonStartYear int) ([]team_schedule.ScheduleGame, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetTeamScheduleGames")
+	defer span.End()
+
+	// season weeks are dates in eastern time stored as midnight utc and include their end date. only broadcasts the
+	// team's fans can see are included, national ones and those for the team's side
+	query := `
+		SELECT
+			g.id,
+			g.nba_game_id,
+			g.start_time,
+			ss.name,
+			gs.name,
+			w.week,
+			g.home_team_id = $1,
+			o.id,
+			o.name,
+			o.nickname,
+			o.city,
+			o.nba_short_name,
+			a.id,
+			a.name,
+			a.city,
+			a.state,
+			a.timezone,
+			CASE WHEN g.home_team_id = $1 THEN coalesce(g.home_team_points, hts.points) ELSE coalesce(g.away_team_points, ats.points) END,
+			CASE WHEN g.home_team_id = $1 THEN coalesce(g.away_team_points, ats.points) ELSE coalesce(g.home_team_points, hts.points) END,
+			sc.rest_days,
+			coalesce(b.broadcasters, '[]'::jsonb)
+		FROM nba.game g
+		JOIN nba.season s ON s.id = g.season_id
+		JOIN nba.season_stage ss ON ss.id = g.season_stage_id
+		JOIN nba.game_status gs ON gs.id = g.game_status_id
+		JOIN nba.team o ON o.id = CASE WHEN g.home_team_id = $1 THEN g.away_team_id ELSE g.home_team_id END
+		LEFT JOIN nba.arena a ON a.id = g.arena_id
+		LEFT JOIN nba.team_game_stats_total hts ON hts.game_id = g.id AND hts.team_id = g.home_team_id
+		LEFT JOIN nba.team_game_stats_total ats ON ats.game_id = g.id AND ats.team_id = g.away_team_id
+		LEFT JOIN nba.team_game_schedule_context sc ON sc.game_id = g.id AND sc.team_id = $1
+		LEFT JOIN LATERAL (
+			SELECT sw.week
+			FROM (
+				SELECT start_date, end_date, row_number() OVER (ORDER BY start_date) AS week
+				FROM nba.season_week
+				WHERE season_id = g.season_id
+			) sw
+			WHERE (g.start_time AT TIME ZONE 'America/New_York')::date
+				BETWEEN (sw.start_date AT TIME ZONE 'UTC')::date AND (sw.end_date AT TIME ZONE 'UTC')::date
+		) w ON true
+		LEFT JOIN LATERAL (
+			SELECT jsonb_agg(
+				jsonb_build_object('display', br.display, 'abbreviation', br.abbreviation, 'scope', gb.scope, 'media', gb.media)
+				ORDER BY gb.scope <> 'national', gb.media DESC, br.display) AS broadcasters
+			FROM nba.game_broadcast gb
+			JOIN nba.broadcaster br ON br.id = gb.broadcaster_id
+			WHERE gb.game_id = g.id
+				AND gb.scope = ANY (ARRAY['national', CASE WHEN g.home_team_id = $1 THEN 'home' ELSE 'away' END])
+		) b ON true
+		WHERE (g.home_team_id = $1 OR g.away_team_id = $1) AND s.start_year = $2
+		ORDER BY g.start_time, g.id`
+
+	rows, err := d.pgxPool.Query(ctx, query, teamID, seasonStartYear)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team schedule games: %w", err)
+	}
+	defer rows.Close()
+
+	scheduleGames := []team_schedule.ScheduleGame{}
+
+	for rows.Next() {
+		scheduleGame := team_schedule.ScheduleGame{}
+
+		err := rows.Scan(
+			&scheduleGame.GameID,
+			&scheduleGame.NBAGameID,
+			&scheduleGame.StartTime,
+			&scheduleGame.SeasonStageName,
+			&scheduleGame.GameStatusName,
+			&scheduleGame.SeasonWeek,
+			&scheduleGame.Home,
+			&scheduleGame.Opponent.ID,
+			&scheduleGame.Opponent.Name,
+			&scheduleGame.Opponent.Nickname,
+			&scheduleGame.Opponent.City,
+			&scheduleGame.Opponent.NBAShortName,
+			&scheduleGame.ArenaID,
+			&scheduleGame.ArenaName,
+			&scheduleGame.ArenaCity,
+			&scheduleGame.ArenaState,
+			&scheduleGame.ArenaTimezone,
+			&scheduleGame.TeamPoints,
+			&scheduleGame.OpponentPoints,
+			&scheduleGame.RestDays,
+			&scheduleGame.Broadcasters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan team schedule game: %w", err)
+		}
+
+		scheduleGames = append(scheduleGames, scheduleGame)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read team schedule games: %w", err)
+	}
+
+	return scheduleGames, nil
+}
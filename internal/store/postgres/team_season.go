@@ -50,7 +50,7 @@ func (d DB) UpdateTeamSeasons(ctx context.Context, teamSeasonUpdates []team_seas
 	ctx, span := otel.Tracer("nba").Start(ctx, "postgres.DB.UpdateTeamSeasons")
 	defer span.End()
 
-	tx, err := d.pgxPool.Begin(ctx)
+	tx, err := d.begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("could not start db transaction with error: %w", err)
 	}
@@ -0,0 +1,424 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/internal/webhook"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+const webhookSubscriptionColumns = `ws.id, ws.url, ws.event_types, ws.team_ids, ws.league_ids, ws.active, ws.created_at, ws.updated_at`
+
+func (d DB) CreateWebhookSubscription(ctx context.Context, subscriptionCreate webhook.SubscriptionCreate) (api.WebhookSubscription, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.CreateWebhookSubscription")
+	defer span.End()
+
+	query := `
+		INSERT INTO nba.webhook_subscription
+			AS ws (url, secret, event_types, team_ids, league_ids)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + webhookSubscriptionColumns
+
+	row := d.pgxPool.QueryRow(ctx, query,
+		subscriptionCreate.URL,
+		subscriptionCreate.Secret,
+		subscriptionCreate.EventTypes,
+		subscriptionCreate.TeamIDs,
+		subscriptionCreate.LeagueIDs)
+
+	return scanWebhookSubscription(row)
+}
+
+func (d DB) GetWebhookSubscription(ctx context.Context, id string) (api.WebhookSubscription, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetWebhookSubscription")
+	defer span.End()
+
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM nba.webhook_subscription ws WHERE ws.id = $1`
+
+	return scanWebhookSubscription(d.pgxPool.QueryRow(ctx, query, id))
+}
+
+func (d DB) ListWebhookSubscriptions(ctx context.Context) ([]api.WebhookSubscription, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.ListWebhookSubscriptions")
+	defer span.End()
+
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM nba.webhook_subscription ws ORDER BY ws.created_at`
+
+	rows, err := d.pgxPool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subscriptions := []api.WebhookSubscription{}
+
+	for rows.Next() {
+		subscription, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read webhook subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func scanWebhookSubscription(row pgx.Row) (api.WebhookSubscription, error) {
+	subscription := api.WebhookSubscription{}
+
+	err := row.Scan(
+		&subscription.ID,
+		&subscription.URL,
+		&subscription.EventTypes,
+		&subscription.TeamIDs,
+		&subscription.LeagueIDs,
+		&subscription.Active,
+		&subscription.CreatedAt,
+		&subscription.UpdatedAt)
+	if err != nil {
+		return api.WebhookSubscription{}, fmt.Errorf("failed to scan webhook subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+func (d DB) DeleteWebhookSubscription(ctx context.Context, id string) error {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.DeleteWebhookSubscription")
+	defer span.End()
+
+	commandTag, err := d.pgxPool.Exec(ctx, `DELETE FROM nba.webhook_subscription WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func (d DB) GetWebhookGameStates(ctx context.Context, gameIDs []string) (map[string]webhook.GameState, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetWebhookGameStates")
+	defer span.End()
+
+	rows, err := d.pgxPool.Query(ctx, `SELECT game_id, status, period FROM nba.webhook_game_state WHERE game_id = any($1)`, gameIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook game states: %w", err)
+	}
+	defer rows.Close()
+
+	gameStates := map[string]webhook.GameState{}
+
+	for rows.Next() {
+		gameState := webhook.GameState{}
+
+		if err := rows.Scan(&gameState.GameID, &gameState.Status, &gameState.Period); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook game state: %w", err)
+		}
+
+		gameStates[gameState.GameID] = gameState
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read webhook game states: %w", err)
+	}
+
+	return gameStates, nil
+}
+
+// EnqueueWebhookEvents fans each event out to the matching subscriptions and records the game states the events came
+// from in one transaction so a failure doesn't lose or repeat transitions
+func (d DB) EnqueueWebhookEvents(ctx context.Context, events []webhook.Event, gameStates []webhook.GameState) (int, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.EnqueueWebhookEvents")
+	defer span.End()
+
+	tx, err := d.begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("could not start db transaction to enqueue webhook events: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// empty filters match everything
+	insertDelivery := `
+		INSERT INTO nba.webhook_delivery
+			(subscription_id, event_key, event_id, event_type, game_id, payload)
+		SELECT ws.id, $1, $2, $3, g.id, $5
+		FROM nba.webhook_subscription ws
+		JOIN nba.game g ON g.id = $4
+		LEFT JOIN nba.season s ON s.id = g.season_id
+		WHERE ws.active
+			AND $3 = ANY (ws.event_types)
+			AND (cardinality(ws.team_ids) = 0 OR g.home_team_id = ANY (ws.team_ids) OR g.away_team_id = ANY (ws.team_ids))
+			AND (cardinality(ws.league_ids) = 0 OR s.league_id = ANY (ws.league_ids))
+		ON CONFLICT (subscription_id, event_key) DO NOTHING`
+
+	upsertGameState := `
+		INSERT INTO nba.webhook_game_state
+			AS wgs (game_id, status, period)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (game_id) DO UPDATE
+		SET
+			status = excluded.status,
+			period = excluded.period,
+			updated_at = now()`
+
+	bp := &pgx.Batch{}
+
+	for _, event := range events {
+		bp.Queue(insertDelivery,
+			event.Key,
+			event.Payload.ID,
+			event.Payload.Type,
+			event.Payload.Game.ID,
+			event.Payload)
+	}
+
+	for _, gameState := range gameStates {
+		bp.Queue(upsertGameState,
+			gameState.GameID,
+			gameState.Status,
+			gameState.Period)
+	}
+
+	batchResults := tx.SendBatch(ctx, bp)
+
+	queued := 0
+	for range events {
+		commandTag, err := batchResults.Exec()
+		if err != nil {
+			batchResults.Close()
+			return 0, fmt.Errorf("failed to enqueue webhook event: %w", err)
+		}
+		queued += int(commandTag.RowsAffected())
+	}
+
+	for range gameStates {
+		if _, err := batchResults.Exec(); err != nil {
+			batchResults.Close()
+			return 0, fmt.Errorf("failed to update webhook game state: %w", err)
+		}
+	}
+
+	err = batchResults.Close()
+	if err != nil {
+		return 0, fmt.Errorf("could not close batchResults when enqueueing webhook events: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("could not commit transaction when enqueueing webhook events: %w", err)
+	}
+
+	return queued, nil
+}
+
+// ClaimWebhookDeliveries pushes the next attempt of due deliveries out by the lease so other workers skip them while
+// they are being sent
+func (d DB) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]webhook.Delivery, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.ClaimWebhookDeliveries")
+	defer span.End()
+
+	query := `
+		WITH claimed AS (
+			SELECT wd.id
+			FROM nba.webhook_delivery wd
+			JOIN nba.webhook_subscription ws ON ws.id = wd.subscription_id
+			WHERE wd.status = 'pending' AND wd.next_attempt_at <= now() AND ws.active
+			ORDER BY wd.next_attempt_at
+			LIMIT $1
+			FOR UPDATE OF wd SKIP LOCKED
+		)
+		UPDATE nba.webhook_delivery wd
+		SET next_attempt_at = now() + make_interval(secs => $2)
+		FROM claimed, nba.webhook_subscription ws
+		WHERE wd.id = claimed.id AND ws.id = wd.subscription_id
+		RETURNING wd.id, wd.subscription_id, ws.url, ws.secret, wd.event_id, wd.event_type, wd.attempts, wd.payload`
+
+	rows, err := d.pgxPool.Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []webhook.Delivery{}
+
+	for rows.Next() {
+		delivery := webhook.Delivery{}
+
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.SubscriptionID,
+			&delivery.URL,
+			&delivery.Secret,
+			&delivery.EventID,
+			&delivery.EventType,
+			&delivery.Attempts,
+			&delivery.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan claimed webhook delivery: %w", err)
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read claimed webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (d DB) UpdateWebhookDelivery(ctx context.Context, deliveryUpdate webhook.DeliveryUpdate) error {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.UpdateWebhookDelivery")
+	defer span.End()
+
+	query := `
+		UPDATE nba.webhook_delivery
+		SET
+			status = $2,
+			attempts = $3,
+			next_attempt_at = coalesce($4, next_attempt_at),
+			last_attempt_at = now(),
+			last_response_status = $5,
+			last_error = $6,
+			delivered_at = CASE WHEN $2 = 'delivered' THEN now() END
+		WHERE id = $1`
+
+	_, err := d.pgxPool.Exec(ctx, query,
+		deliveryUpdate.ID,
+		deliveryUpdate.Status,
+		deliveryUpdate.Attempts,
+		deliveryUpdate.NextAttemptAt,
+		deliveryUpdate.ResponseStatus,
+		deliveryUpdate.Error)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+const webhookDeliveryColumns = `wd.id, wd.subscription_id, ws.url, wd.event_id, wd.event_type, wd.game_id, wd.status, wd.attempts, wd.next_attempt_at, wd.last_attempt_at, wd.last_response_status, wd.last_error, wd.delivered_at, wd.payload, wd.created_at`
+
+func (d DB) ListWebhookDeliveries(ctx context.Context, filter webhook.DeliveryListFilter) ([]api.WebhookDelivery, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.ListWebhookDeliveries")
+	defer span.End()
+
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM nba.webhook_delivery wd
+		JOIN nba.webhook_subscription ws ON ws.id = wd.subscription_id
+		WHERE ($1::uuid IS NULL OR wd.subscription_id = $1) AND ($2::text IS NULL OR wd.status = $2)
+		ORDER BY wd.created_at DESC
+		LIMIT $3`
+
+	rows, err := d.pgxPool.Query(ctx, query, filter.SubscriptionID, filter.Status, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return scanWebhookDeliveries(rows)
+}
+
+// ListWebhookDeadLetters reads from the dead letter view so the deliveries needing attention are the same ones seen
+// when querying the database directly
+func (d DB) ListWebhookDeadLetters(ctx context.Context, filter webhook.DeliveryListFilter) ([]api.WebhookDelivery, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.ListWebhookDeadLetters")
+	defer span.End()
+
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM nba.webhook_dead_letter dl
+		JOIN nba.webhook_delivery wd ON wd.id = dl.id
+		JOIN nba.webhook_subscription ws ON ws.id = wd.subscription_id
+		WHERE $1::uuid IS NULL OR dl.subscription_id = $1
+		ORDER BY dl.last_attempt_at DESC NULLS LAST
+		LIMIT $2`
+
+	rows, err := d.pgxPool.Query(ctx, query, filter.SubscriptionID, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook dead letters: %w", err)
+	}
+
+	return scanWebhookDeliveries(rows)
+}
+
+func (d DB) RetryWebhookDelivery(ctx context.Context, id string) (api.WebhookDelivery, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.RetryWebhookDelivery")
+	defer span.End()
+
+	query := `
+		WITH retried AS (
+			UPDATE nba.webhook_delivery
+			SET status = 'pending', attempts = 0, next_attempt_at = now()
+			WHERE id = $1 AND status = 'dead'
+			RETURNING *
+		)
+		SELECT ` + webhookDeliveryColumns + `
+		FROM retried wd
+		JOIN nba.webhook_subscription ws ON ws.id = wd.subscription_id`
+
+	rows, err := d.pgxPool.Query(ctx, query, id)
+	if err != nil {
+		return api.WebhookDelivery{}, fmt.Errorf("failed to retry webhook delivery: %w", err)
+	}
+
+	deliveries, err := scanWebhookDeliveries(rows)
+	if err != nil {
+		return api.WebhookDelivery{}, err
+	}
+
+	if len(deliveries) == 0 {
+		return api.WebhookDelivery{}, pgx.ErrNoRows
+	}
+
+	return deliveries[0], nil
+}
+
+func scanWebhookDeliveries(rows pgx.Rows) ([]api.WebhookDelivery, error) {
+	defer rows.Close()
+
+	deliveries := []api.WebhookDelivery{}
+
+	for rows.Next() {
+		delivery := api.WebhookDelivery{}
+
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.SubscriptionID,
+			&delivery.URL,
+			&delivery.EventID,
+			&delivery.EventType,
+			&delivery.GameID,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&delivery.LastAttemptAt,
+			&delivery.LastResponseStatus,
+			&delivery.LastError,
+			&delivery.DeliveredAt,
+			&delivery.Payload,
+			&delivery.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
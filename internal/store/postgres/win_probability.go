@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/drewthor/wolves_reddit_bot/internal/win_probability"
+	"go.opentelemetry.io/otel"
+)
+
+func (d DB) GetPreGameTeamRecords(ctx context.Context, nbaGameID string) (win_probability.PreGameTeamRecords, error) {
+	ctx, span := otel.Tracer("postgres").Start(ctx, "postgres.DB.GetPreGameTeamRecords")
+	defer span.End()
+
+	query := `
+		WITH g AS (
+			SELECT id, home_team_id, away_team_id, season_id, season_stage_id, start_time, regulation_periods
+			FROM nba.game
+			WHERE nba_game_id = $1
+		),
+		prior_results AS (
+			SELECT
+				pg.home_team_id as team_id,
+				pg.home_team_points > pg.away_team_points as won
+			FROM nba.game pg, g
+			WHERE pg.season_id = g.season_id
+			AND pg.season_stage_id = g.season_stage_id
+			AND pg.start_time < g.start_time
+			AND pg.end_time IS NOT NULL
+			UNION ALL
+			SELECT
+				pg.away_team_id as team_id,
+				pg.away_team_points > pg.home_team_points as won
+			FROM nba.game pg, g
+			WHERE pg.season_id = g.season_id
+			AND pg.season_stage_id = g.season_stage_id
+			AND pg.start_time < g.start_time
+			AND pg.end_time IS NOT NULL
+		)
+		SELECT
+			ht.nba_team_id,
+			at.nba_team_id,
+			g.regulation_periods,
+			( SELECT count(*) FROM prior_results WHERE team_id = g.home_team_id AND won ),
+			( SELECT count(*) FROM prior_results WHERE team_id = g.home_team_id AND NOT won ),
+			( SELECT count(*) FROM prior_results WHERE team_id = g.away_team_id AND won ),
+			( SELECT count(*) FROM prior_results WHERE team_id = g.away_team_id AND NOT won )
+		FROM g
+		JOIN nba.team ht ON ht.id = g.home_team_id
+		JOIN nba.team at ON at.id = g.away_team_id`
+
+	records := win_probability.PreGameTeamRecords{}
+
+	err := d.pgxPool.QueryRow(ctx, query, nbaGameID).Scan(
+		&records.NBAHomeTeamID,
+		&records.NBAAwayTeamID,
+		&records.RegulationPeriods,
+		&records.HomeWins,
+		&records.HomeLosses,
+		&records.AwayWins,
+		&records.AwayLosses)
+	if err != nil {
+		return win_probability.PreGameTeamRecords{}, fmt.Errorf("failed to get pre-game team records: %w", err)
+	}
+
+	return records, nil
+}
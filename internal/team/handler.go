@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/drewthor/wolves_reddit_bot/internal/api_key"
 	"github.com/drewthor/wolves_reddit_bot/util"
 	"go.opentelemetry.io/otel"
 
@@ -34,7 +35,7 @@ func (h *handler) Routes() chi.Router {
 
 	r.Get("/{teamID}", h.Get)
 
-	r.Post("/update", h.UpdateTeams)
+	r.With(api_key.RequireScope(api_key.ScopeIngest)).Post("/update", h.UpdateTeams)
 
 	return r
 }
@@ -47,11 +48,11 @@ func (h *handler) List(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		h.logger.ErrorContext(ctx, "failed to list teams", slog.Any("error", err))
-		util.WriteJSON(http.StatusInternalServerError, err, w)
+		util.WriteError(w, r, err)
 		return
 	}
 
-	util.WriteJSON(http.StatusOK, teams, w)
+	util.WriteCacheableListJSON(w, r, teams, util.CacheControlRevalidate)
 }
 
 func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
@@ -65,11 +66,11 @@ func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		logger.ErrorContext(ctx, "failed to get team", slog.Any("error", err))
-		util.WriteJSON(http.StatusInternalServerError, err, w)
+		util.WriteError(w, r, err)
 		return
 	}
 
-	util.WriteJSON(http.StatusOK, team, w)
+	util.WriteCacheableJSON(w, r, team, util.CacheControlRevalidate)
 }
 
 func (h *handler) UpdateTeams(w http.ResponseWriter, r *http.Request) {
@@ -78,7 +79,7 @@ func (h *handler) UpdateTeams(w http.ResponseWriter, r *http.Request) {
 
 	seasonStartYear, err := strconv.Atoi(r.URL.Query().Get("season-start-year"))
 	if err != nil {
-		util.WriteJSON(http.StatusBadRequest, "invalid required season-start-year", w)
+		util.WriteProblem(w, r, http.StatusBadRequest, "invalid required season-start-year")
 		return
 	}
 
@@ -88,7 +89,7 @@ func (h *handler) UpdateTeams(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		logger.ErrorContext(ctx, "failed to update teams", slog.Any("error", err))
-		util.WriteJSON(http.StatusInternalServerError, err, w)
+		util.WriteError(w, r, err)
 		return
 	}
 
@@ -2,12 +2,16 @@ package team
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
 	"github.com/drewthor/wolves_reddit_bot/api"
 	"github.com/drewthor/wolves_reddit_bot/apis/nba"
 	"github.com/drewthor/wolves_reddit_bot/internal/team_season"
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"go.opentelemetry.io/otel"
 )
 
@@ -35,8 +39,15 @@ type service struct {
 }
 
 func (s service) Get(ctx context.Context, teamID string) (api.Team, error) {
+	if _, err := uuid.Parse(teamID); err != nil {
+		return api.Team{}, util.NewError(util.ErrNotFound, "team not found")
+	}
+
 	team, err := s.teamStore.GetTeamWithID(ctx, teamID)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.Team{}, util.NewError(util.ErrNotFound, "team not found")
+		}
 		return api.Team{}, err
 	}
 
@@ -8,6 +8,7 @@ import (
 
 type Service interface {
 	UpdateTeamGameStatsTotals(ctx context.Context, teamGameStatsTotalsUpdates []TeamGameStatsTotalUpdate) ([]TeamGameStatsTotal, error)
+	UpdateHistoricalTeamGameStatsTotals(ctx context.Context, teamGameStatsTotalsUpdates []TeamGameStatsTotalHistoricalUpdate) (int, error)
 }
 
 func NewService(teamGameStatsStore Store) Service {
@@ -31,3 +32,14 @@ func (s service) UpdateTeamGameStatsTotalsOld(ctx context.Context, teamGameStats
 
 	return s.TeamGameStatsStore.UpdateTeamGameStatsTotalsOld(ctx, teamGameStatsTotalsUpdates)
 }
+
+func (s service) UpdateHistoricalTeamGameStatsTotals(ctx context.Context, teamGameStatsTotalsUpdates []TeamGameStatsTotalHistoricalUpdate) (int, error) {
+	ctx, span := otel.Tracer("team_game_stats").Start(ctx, "team_game_stats.service.UpdateHistoricalTeamGameStatsTotals")
+	defer span.End()
+
+	if len(teamGameStatsTotalsUpdates) == 0 {
+		return 0, nil
+	}
+
+	return s.TeamGameStatsStore.UpdateHistoricalTeamGameStatsTotals(ctx, teamGameStatsTotalsUpdates)
+}
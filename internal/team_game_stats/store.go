@@ -9,6 +9,7 @@ import (
 type Store interface {
 	UpdateTeamGameStatsTotals(ctx context.Context, teamGameStatsTotalsUpdates []TeamGameStatsTotalUpdate) ([]TeamGameStatsTotal, error)
 	UpdateTeamGameStatsTotalsOld(ctx context.Context, teamGameStatsTotalsUpdates []TeamGameStatsTotalUpdateOld) ([]TeamGameStatsTotal, error)
+	UpdateHistoricalTeamGameStatsTotals(ctx context.Context, teamGameStatsTotalsUpdates []TeamGameStatsTotalHistoricalUpdate) (int, error)
 }
 
 type TeamGameStatsTotalUpdate struct {
@@ -105,6 +106,32 @@ type TeamGameStatsTotalUpdateOld struct {
 	TimesTied                    int
 }
 
+// TeamGameStatsTotalHistoricalUpdate is a team's line from the stats.nba.com league game log. Stats the league didn't
+// track for the season are null
+type TeamGameStatsTotalHistoricalUpdate struct {
+	NBAGameID                    string
+	NBATeamID                    int
+	GameTimePlayedSeconds        sql.NullInt64
+	TotalPlayerTimePlayedSeconds sql.NullInt64
+	Points                       int
+	PointsAgainst                int
+	Assists                      sql.NullInt64
+	TotalTurnovers               sql.NullInt64
+	Steals                       sql.NullInt64
+	ThreePointersAttempted       sql.NullInt64
+	ThreePointersMade            sql.NullInt64
+	FieldGoalsAttempted          sql.NullInt64
+	FieldGoalsMade               int
+	FreeThrowsAttempted          int
+	FreeThrowsMade               int
+	Blocks                       sql.NullInt64
+	TotalOffensiveRebounds       sql.NullInt64
+	TotalDefensiveRebounds       sql.NullInt64
+	TotalRebounds                sql.NullInt64
+	PersonalFouls                sql.NullInt64
+	Source                       string
+}
+
 type TeamGameStatsTotal struct {
 	ID                           string
 	GameID                       string
@@ -167,4 +194,5 @@ type TeamGameStatsTotal struct {
 	TrueShootingAttempts         float64
 	TrueShootingPercentage       float64
 	BenchPoints                  int
+	Source                       string
 }
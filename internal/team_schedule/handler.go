@@ -0,0 +1,115 @@
+package team_schedule
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"go.opentelemetry.io/otel"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler interface {
+	Routes() chi.Router
+	CalendarRoutes() chi.Router
+	GetTeamSchedule(w http.ResponseWriter, r *http.Request)
+	GetTeamScheduleCalendar(w http.ResponseWriter, r *http.Request)
+}
+
+func NewHandler(logger *slog.Logger, teamScheduleService Service) Handler {
+	return &handler{logger: logger, teamScheduleService: teamScheduleService}
+}
+
+type handler struct {
+	logger              *slog.Logger
+	teamScheduleService Service
+}
+
+// Routes are mounted under a team's games
+func (h *handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.GetTeamSchedule)
+
+	return r
+}
+
+// CalendarRoutes are mounted under a team's calendar feed
+func (h *handler) CalendarRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.GetTeamScheduleCalendar)
+
+	return r
+}
+
+func (h *handler) GetTeamSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("team_schedule").Start(r.Context(), "team_schedule.handler.GetTeamSchedule")
+	defer span.End()
+
+	teamID := chi.URLParam(r, "teamID")
+
+	seasonStartYear, err := parseSeason(r)
+	if err != nil {
+		util.WriteProblem(w, r, http.StatusBadRequest, "invalid season, expected a start year ex. 2024 or 2024-25")
+		return
+	}
+
+	logger := h.logger.With(slog.String("team_id", teamID))
+
+	schedule, err := h.teamScheduleService.GetTeamSchedule(ctx, teamID, seasonStartYear)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get team schedule", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteJSON(http.StatusOK, schedule, w)
+}
+
+func (h *handler) GetTeamScheduleCalendar(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("team_schedule").Start(r.Context(), "team_schedule.handler.GetTeamScheduleCalendar")
+	defer span.End()
+
+	teamID := chi.URLParam(r, "teamID")
+
+	seasonStartYear, err := parseSeason(r)
+	if err != nil {
+		util.WriteProblem(w, r, http.StatusBadRequest, "invalid season, expected a start year ex. 2024 or 2024-25")
+		return
+	}
+
+	logger := h.logger.With(slog.String("team_id", teamID))
+
+	calendar, err := h.teamScheduleService.GetTeamScheduleCalendar(ctx, teamID, seasonStartYear)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get team schedule calendar", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(calendar); err != nil {
+		logger.ErrorContext(ctx, "failed to write team schedule calendar", slog.Any("error", err))
+	}
+}
+
+// parseSeason reads the optional season query param as a start year, the nba's 2024-25 format is also accepted
+func parseSeason(r *http.Request) (*int, error) {
+	season := r.URL.Query().Get("season")
+	if season == "" {
+		return nil, nil
+	}
+
+	startYear, _, _ := strings.Cut(season, "-")
+	seasonStartYear, err := strconv.Atoi(startYear)
+	if err != nil {
+		return nil, err
+	}
+
+	return &seasonStartYear, nil
+}
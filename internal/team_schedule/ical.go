@@ -0,0 +1,231 @@
+package team_schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+)
+
+const (
+	icalDateTimeLayout = "20060102T150405"
+	// icalLineLength is the maximum number of octets in a content line before it must be folded
+	icalLineLength = 75
+	// gameDuration is roughly how long an nba game takes including breaks
+	gameDuration = "PT2H30M"
+)
+
+// marshalCalendar writes the schedule as an RFC 5545 calendar. start times are in the arena's time zone so calendar
+// apps show the local tip off for road games, games at arenas without a known time zone are written in utc
+func marshalCalendar(t api.Team, schedule api.TeamSchedule, now time.Time) []byte {
+	c := &calendarWriter{}
+
+	teamName := strings.TrimSpace(fmt.Sprintf("%s %s", t.City, t.Name))
+
+	c.line("BEGIN:VCALENDAR")
+	c.line("VERSION:2.0")
+	c.line("PRODID:-//wolves_reddit_bot//team schedule//EN")
+	c.line("CALSCALE:GREGORIAN")
+	c.line("METHOD:PUBLISH")
+	c.line("X-WR-CALNAME:" + escapeText(fmt.Sprintf("%s %d-%02d", teamName, schedule.SeasonStartYear, (schedule.SeasonStartYear+1)%100)))
+	c.line("REFRESH-INTERVAL;VALUE=DURATION:PT6H")
+	c.line("X-PUBLISHED-TTL:PT6H")
+
+	locations := map[string]*time.Location{}
+	timezones := []string{}
+	for _, game := range schedule.Games {
+		if game.Arena == nil || game.Arena.Timezone == nil {
+			continue
+		}
+		if _, ok := locations[*game.Arena.Timezone]; ok {
+			continue
+		}
+		location, err := time.LoadLocation(*game.Arena.Timezone)
+		if err != nil {
+			location = nil
+		}
+		locations[*game.Arena.Timezone] = location
+		if location != nil {
+			timezones = append(timezones, *game.Arena.Timezone)
+		}
+	}
+
+	if len(schedule.Games) > 0 {
+		rangeStart := schedule.Games[0].StartTime.Add(-24 * time.Hour)
+		rangeEnd := schedule.Games[len(schedule.Games)-1].StartTime.Add(24 * time.Hour)
+		for _, timezone := range timezones {
+			c.timezone(timezone, locations[timezone], rangeStart, rangeEnd)
+		}
+	}
+
+	for _, game := range schedule.Games {
+		var location *time.Location
+		if game.Arena != nil && game.Arena.Timezone != nil {
+			location = locations[*game.Arena.Timezone]
+		}
+		c.event(t, game, location, now)
+	}
+
+	c.line("END:VCALENDAR")
+
+	return []byte(c.b.String())
+}
+
+type calendarWriter struct {
+	b strings.Builder
+}
+
+// line writes a content line folding it so no line is longer than icalLineLength octets without splitting a utf-8
+// character
+func (c *calendarWriter) line(s string) {
+	limit := icalLineLength
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && s[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		c.b.WriteString(s[:cut])
+		c.b.WriteString("\r\n ")
+		s = s[cut:]
+		// continuation lines start with a space which counts toward the limit
+		limit = icalLineLength - 1
+	}
+	c.b.WriteString(s)
+	c.b.WriteString("\r\n")
+}
+
+// timezone writes a VTIMEZONE with an observance for each utc offset change between start and end which is enough for
+// calendar apps to resolve the events without relying on their own time zone database
+func (c *calendarWriter) timezone(tzid string, location *time.Location, start time.Time, end time.Time) {
+	c.line("BEGIN:VTIMEZONE")
+	c.line("TZID:" + tzid)
+
+	start = start.In(location)
+	name, offset := start.Zone()
+	c.observance(start, start.IsDST(), name, offset, offset)
+
+	for t := start; t.Before(end); {
+		next := t.Add(24 * time.Hour)
+		if _, nextOffset := next.Zone(); nextOffset != offset {
+			transition := findOffsetTransition(t, next)
+			transitionName, transitionOffset := transition.Zone()
+			c.observance(transition, transition.IsDST(), transitionName, offset, transitionOffset)
+			offset = transitionOffset
+		}
+		t = next
+	}
+
+	c.line("END:VTIMEZONE")
+}
+
+func (c *calendarWriter) observance(t time.Time, dst bool, name string, offsetFrom int, offsetTo int) {
+	component := "STANDARD"
+	if dst {
+		component = "DAYLIGHT"
+	}
+
+	c.line("BEGIN:" + component)
+	// the observance starts at the local time before the change
+	c.line("DTSTART:" + t.In(time.FixedZone("", offsetFrom)).Format(icalDateTimeLayout))
+	c.line("TZOFFSETFROM:" + formatUTCOffset(offsetFrom))
+	c.line("TZOFFSETTO:" + formatUTCOffset(offsetTo))
+	c.line("TZNAME:" + escapeText(name))
+	c.line("END:" + component)
+}
+
+func (c *calendarWriter) event(t api.Team, game api.TeamScheduleGame, location *time.Location, now time.Time) {
+	c.line("BEGIN:VEVENT")
+	c.line("UID:" + game.GameID)
+	c.line("DTSTAMP:" + now.UTC().Format(icalDateTimeLayout) + "Z")
+	if location != nil {
+		c.line(fmt.Sprintf("DTSTART;TZID=%s:%s", location.String(), game.StartTime.In(location).Format(icalDateTimeLayout)))
+	} else {
+		c.line("DTSTART:" + game.StartTime.UTC().Format(icalDateTimeLayout) + "Z")
+	}
+	c.line("DURATION:" + gameDuration)
+
+	matchup := "@"
+	if game.Home {
+		matchup = "vs"
+	}
+	summary := fmt.Sprintf("%s %s %s", t.Name, matchup, game.Opponent.Name)
+	if game.Result != nil {
+		summary = fmt.Sprintf("%s (%s %d-%d)", summary, *game.Result, *game.TeamPoints, *game.OpponentPoints)
+	}
+	c.line("SUMMARY:" + escapeText(summary))
+
+	if game.Arena != nil && game.Arena.Name != "" {
+		locationParts := []string{game.Arena.Name}
+		if game.Arena.City != nil {
+			locationParts = append(locationParts, *game.Arena.City)
+		}
+		if game.Arena.State != nil {
+			locationParts = append(locationParts, *game.Arena.State)
+		}
+		c.line("LOCATION:" + escapeText(strings.Join(locationParts, ", ")))
+	}
+
+	if description := eventDescription(game); description != "" {
+		c.line("DESCRIPTION:" + escapeText(description))
+	}
+	c.line("END:VEVENT")
+}
+
+func eventDescription(game api.TeamScheduleGame) string {
+	lines := []string{}
+
+	if game.Result != nil {
+		result := fmt.Sprintf("Final: %s %d-%d, %d-%d", *game.Result, *game.TeamPoints, *game.OpponentPoints, game.Wins, game.Losses)
+		if game.Streak != nil {
+			result = fmt.Sprintf("%s (%s)", result, *game.Streak)
+		}
+		lines = append(lines, result)
+	}
+
+	if game.SeasonWeek != nil {
+		lines = append(lines, fmt.Sprintf("Week %d", *game.SeasonWeek))
+	}
+
+	broadcastersByMedia := map[string][]string{}
+	for _, broadcaster := range game.Broadcasters {
+		broadcastersByMedia[broadcaster.Media] = append(broadcastersByMedia[broadcaster.Media], broadcaster.Display)
+	}
+	if tv := broadcastersByMedia["tv"]; len(tv) > 0 {
+		lines = append(lines, "TV: "+strings.Join(tv, ", "))
+	}
+	if radio := broadcastersByMedia["radio"]; len(radio) > 0 {
+		lines = append(lines, "Radio: "+strings.Join(radio, ", "))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// findOffsetTransition returns the first second after start with the utc offset of end
+func findOffsetTransition(start time.Time, end time.Time) time.Time {
+	_, endOffset := end.Zone()
+	for end.Sub(start) > time.Second {
+		mid := start.Add(end.Sub(start) / 2)
+		if _, offset := mid.Zone(); offset == endOffset {
+			end = mid
+		} else {
+			start = mid
+		}
+	}
+	return end.Truncate(time.Second)
+}
+
+func formatUTCOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset%3600/60)
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
@@ -0,0 +1,183 @@
+package team_schedule
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/internal/season"
+	"github.com/drewthor/wolves_reddit_bot/internal/team"
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	gameStatusCompleted = "completed"
+
+	resultWin  = "W"
+	resultLoss = "L"
+)
+
+type Service interface {
+	GetTeamSchedule(ctx context.Context, teamID string, seasonStartYear *int) (api.TeamSchedule, error)
+	GetTeamScheduleCalendar(ctx context.Context, teamID string, seasonStartYear *int) ([]byte, error)
+}
+
+func NewService(teamScheduleStore Store, teamService team.Service, seasonService season.Service) Service {
+	return &service{teamScheduleStore: teamScheduleStore, teamService: teamService, seasonService: seasonService}
+}
+
+type service struct {
+	teamScheduleStore Store
+
+	teamService   team.Service
+	seasonService season.Service
+}
+
+func (s *service) GetTeamSchedule(ctx context.Context, teamID string, seasonStartYear *int) (api.TeamSchedule, error) {
+	ctx, span := otel.Tracer("team_schedule").Start(ctx, "team_schedule.service.GetTeamSchedule")
+	defer span.End()
+
+	_, schedule, err := s.getTeamSchedule(ctx, teamID, seasonStartYear)
+	if err != nil {
+		return api.TeamSchedule{}, err
+	}
+
+	return schedule, nil
+}
+
+// GetTeamScheduleCalendar returns the team's schedule as an iCalendar feed
+func (s *service) GetTeamScheduleCalendar(ctx context.Context, teamID string, seasonStartYear *int) ([]byte, error) {
+	ctx, span := otel.Tracer("team_schedule").Start(ctx, "team_schedule.service.GetTeamScheduleCalendar")
+	defer span.End()
+
+	t, schedule, err := s.getTeamSchedule(ctx, teamID, seasonStartYear)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalCalendar(t, schedule, time.Now()), nil
+}
+
+func (s *service) getTeamSchedule(ctx context.Context, teamID string, seasonStartYear *int) (api.Team, api.TeamSchedule, error) {
+	t, err := s.teamService.Get(ctx, teamID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.Team{}, api.TeamSchedule{}, util.NewError(util.ErrNotFound, "team not found")
+		}
+		return api.Team{}, api.TeamSchedule{}, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	if seasonStartYear == nil {
+		currentSeasonStartYear, err := s.seasonService.GetCurrentSeasonStartYear(ctx)
+		if err != nil {
+			return api.Team{}, api.TeamSchedule{}, fmt.Errorf("failed to get current season start year: %w", err)
+		}
+		seasonStartYear = &currentSeasonStartYear
+	}
+
+	scheduleGames, err := s.teamScheduleStore.GetTeamScheduleGames(ctx, teamID, *seasonStartYear)
+	if err != nil {
+		return api.Team{}, api.TeamSchedule{}, fmt.Errorf("failed to get team schedule games: %w", err)
+	}
+
+	return t, teamSchedule(teamID, *seasonStartYear, scheduleGames), nil
+}
+
+// teamSchedule fills in results along with the running record and streak which are kept separately for each season
+// stage so preseason games don't count toward the regular season record
+func teamSchedule(teamID string, seasonStartYear int, scheduleGames []ScheduleGame) api.TeamSchedule {
+	type stageRecord struct {
+		wins, losses int
+		streakResult string
+		streakLength int
+	}
+	recordsByStage := map[string]*stageRecord{}
+
+	schedule := api.TeamSchedule{TeamID: teamID, SeasonStartYear: seasonStartYear, Games: make([]api.TeamScheduleGame, 0, len(scheduleGames))}
+
+	for _, g := range scheduleGames {
+		record, ok := recordsByStage[g.SeasonStageName]
+		if !ok {
+			record = &stageRecord{}
+			recordsByStage[g.SeasonStageName] = record
+		}
+
+		scheduleGame := api.TeamScheduleGame{
+			GameID:          g.GameID,
+			NBAGameID:       g.NBAGameID,
+			StartTime:       g.StartTime,
+			SeasonStageName: g.SeasonStageName,
+			GameStatusName:  g.GameStatusName,
+			SeasonWeek:      intPointer(g.SeasonWeek),
+			Home:            g.Home,
+			Opponent:        g.Opponent,
+			TeamPoints:      intPointer(g.TeamPoints),
+			OpponentPoints:  intPointer(g.OpponentPoints),
+			RestDays:        intPointer(g.RestDays),
+			Broadcasters:    g.Broadcasters,
+		}
+		if scheduleGame.Broadcasters == nil {
+			scheduleGame.Broadcasters = []api.TeamScheduleBroadcast{}
+		}
+
+		if g.ArenaID.Valid {
+			scheduleGame.Arena = &api.TeamScheduleArena{
+				ID:       g.ArenaID.String,
+				Name:     g.ArenaName.String,
+				City:     stringPointer(g.ArenaCity),
+				State:    stringPointer(g.ArenaState),
+				Timezone: stringPointer(g.ArenaTimezone),
+			}
+		}
+
+		if g.GameStatusName == gameStatusCompleted && g.TeamPoints.Valid && g.OpponentPoints.Valid && g.TeamPoints.Int64 != g.OpponentPoints.Int64 {
+			result := resultLoss
+			if g.TeamPoints.Int64 > g.OpponentPoints.Int64 {
+				result = resultWin
+				record.wins++
+			} else {
+				record.losses++
+			}
+
+			if record.streakResult == result {
+				record.streakLength++
+			} else {
+				record.streakResult = result
+				record.streakLength = 1
+			}
+
+			scheduleGame.Result = &result
+		}
+
+		scheduleGame.Wins = record.wins
+		scheduleGame.Losses = record.losses
+		if record.streakLength > 0 {
+			streak := fmt.Sprintf("%s%d", record.streakResult, record.streakLength)
+			scheduleGame.Streak = &streak
+		}
+
+		schedule.Games = append(schedule.Games, scheduleGame)
+	}
+
+	return schedule
+}
+
+func intPointer(i sql.NullInt64) *int {
+	if !i.Valid {
+		return nil
+	}
+	v := int(i.Int64)
+	return &v
+}
+
+func stringPointer(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
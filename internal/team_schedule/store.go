@@ -0,0 +1,34 @@
+package team_schedule
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+)
+
+type Store interface {
+	GetTeamScheduleGames(ctx context.Context, teamID string, seasonStartYear int) ([]ScheduleGame, error)
+}
+
+// ScheduleGame is a game from the perspective of the team whose schedule it is part of
+type ScheduleGame struct {
+	GameID          string
+	NBAGameID       string
+	StartTime       time.Time
+	SeasonStageName string
+	GameStatusName  string
+	SeasonWeek      sql.NullInt64
+	Home            bool
+	Opponent        api.TeamScheduleOpponent
+	ArenaID         sql.NullString
+	ArenaName       sql.NullString
+	ArenaCity       sql.NullString
+	ArenaState      sql.NullString
+	ArenaTimezone   sql.NullString
+	TeamPoints      sql.NullInt64
+	OpponentPoints  sql.NullInt64
+	RestDays        sql.NullInt64
+	Broadcasters    []api.TeamScheduleBroadcast
+}
@@ -0,0 +1,225 @@
+package webhook
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// maxSubscriptionBodySize is plenty for a url and a few filters
+const maxSubscriptionBodySize = 64 << 10
+
+type Handler interface {
+	Routes() chi.Router
+	CreateSubscription(w http.ResponseWriter, r *http.Request)
+	ListSubscriptions(w http.ResponseWriter, r *http.Request)
+	GetSubscription(w http.ResponseWriter, r *http.Request)
+	DeleteSubscription(w http.ResponseWriter, r *http.Request)
+	ListDeliveries(w http.ResponseWriter, r *http.Request)
+	ListDeadLetters(w http.ResponseWriter, r *http.Request)
+	RetryDelivery(w http.ResponseWriter, r *http.Request)
+}
+
+func NewHandler(logger *slog.Logger, webhookService Service) Handler {
+	return &handler{logger: logger, webhookService: webhookService}
+}
+
+type handler struct {
+	logger         *slog.Logger
+	webhookService Service
+}
+
+func (h *handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/", h.CreateSubscription)
+	r.Get("/", h.ListSubscriptions)
+	r.Get("/dead-letters", h.ListDeadLetters)
+	r.Post("/deliveries/{deliveryID}/retry", h.RetryDelivery)
+	r.Get("/{subscriptionID}", h.GetSubscription)
+	r.Delete("/{subscriptionID}", h.DeleteSubscription)
+	r.Get("/{subscriptionID}/deliveries", h.ListDeliveries)
+
+	return r
+}
+
+func (h *handler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("webhook").Start(r.Context(), "webhook.handler.CreateSubscription")
+	defer span.End()
+
+	subscriptionCreate := SubscriptionCreate{}
+
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSubscriptionBodySize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&subscriptionCreate); err != nil {
+		util.WriteProblem(w, r, http.StatusBadRequest, "invalid webhook subscription body: "+err.Error())
+		return
+	}
+
+	subscription, err := h.webhookService.CreateSubscription(ctx, subscriptionCreate)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to create webhook subscription", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteJSON(http.StatusCreated, subscription, w)
+}
+
+func (h *handler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("webhook").Start(r.Context(), "webhook.handler.ListSubscriptions")
+	defer span.End()
+
+	subscriptions, err := h.webhookService.ListSubscriptions(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list webhook subscriptions", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteJSON(http.StatusOK, subscriptions, w)
+}
+
+func (h *handler) GetSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("webhook").Start(r.Context(), "webhook.handler.GetSubscription")
+	defer span.End()
+
+	subscriptionID := chi.URLParam(r, "subscriptionID")
+	if _, err := uuid.Parse(subscriptionID); err != nil {
+		util.WriteProblem(w, r, http.StatusNotFound, "webhook subscription not found")
+		return
+	}
+
+	subscription, err := h.webhookService.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to get webhook subscription", slog.String("subscription_id", subscriptionID), slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteJSON(http.StatusOK, subscription, w)
+}
+
+func (h *handler) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("webhook").Start(r.Context(), "webhook.handler.DeleteSubscription")
+	defer span.End()
+
+	subscriptionID := chi.URLParam(r, "subscriptionID")
+	if _, err := uuid.Parse(subscriptionID); err != nil {
+		util.WriteProblem(w, r, http.StatusNotFound, "webhook subscription not found")
+		return
+	}
+
+	if err := h.webhookService.DeleteSubscription(ctx, subscriptionID); err != nil {
+		h.logger.ErrorContext(ctx, "failed to delete webhook subscription", slog.String("subscription_id", subscriptionID), slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("webhook").Start(r.Context(), "webhook.handler.ListDeliveries")
+	defer span.End()
+
+	subscriptionID := chi.URLParam(r, "subscriptionID")
+	if _, err := uuid.Parse(subscriptionID); err != nil {
+		util.WriteProblem(w, r, http.StatusNotFound, "webhook subscription not found")
+		return
+	}
+
+	filter, ok := parseDeliveryListFilter(w, r)
+	if !ok {
+		return
+	}
+	filter.SubscriptionID = sql.NullString{String: subscriptionID, Valid: true}
+
+	if status := r.URL.Query().Get("status"); status != "" {
+		if !slices.Contains([]string{DeliveryStatusPending, DeliveryStatusDelivered, DeliveryStatusDead}, status) {
+			util.WriteProblem(w, r, http.StatusBadRequest, "invalid status, expected pending, delivered or dead")
+			return
+		}
+		filter.Status = sql.NullString{String: status, Valid: true}
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(ctx, filter)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list webhook deliveries", slog.String("subscription_id", subscriptionID), slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteJSON(http.StatusOK, deliveries, w)
+}
+
+func (h *handler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("webhook").Start(r.Context(), "webhook.handler.ListDeadLetters")
+	defer span.End()
+
+	filter, ok := parseDeliveryListFilter(w, r)
+	if !ok {
+		return
+	}
+
+	if subscriptionID := r.URL.Query().Get("subscription-id"); subscriptionID != "" {
+		if _, err := uuid.Parse(subscriptionID); err != nil {
+			util.WriteProblem(w, r, http.StatusBadRequest, "invalid subscription-id")
+			return
+		}
+		filter.SubscriptionID = sql.NullString{String: subscriptionID, Valid: true}
+	}
+
+	deadLetters, err := h.webhookService.ListDeadLetters(ctx, filter)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list webhook dead letters", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteJSON(http.StatusOK, deadLetters, w)
+}
+
+func parseDeliveryListFilter(w http.ResponseWriter, r *http.Request) (DeliveryListFilter, bool) {
+	filter := DeliveryListFilter{}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			util.WriteProblem(w, r, http.StatusBadRequest, "invalid limit, expected a positive number")
+			return DeliveryListFilter{}, false
+		}
+		filter.Limit = limit
+	}
+
+	return filter, true
+}
+
+func (h *handler) RetryDelivery(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("webhook").Start(r.Context(), "webhook.handler.RetryDelivery")
+	defer span.End()
+
+	deliveryID := chi.URLParam(r, "deliveryID")
+	if _, err := uuid.Parse(deliveryID); err != nil {
+		util.WriteProblem(w, r, http.StatusNotFound, "dead lettered webhook delivery not found")
+		return
+	}
+
+	delivery, err := h.webhookService.RetryDelivery(ctx, deliveryID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to retry webhook delivery", slog.String("delivery_id", deliveryID), slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteJSON(http.StatusOK, delivery, w)
+}
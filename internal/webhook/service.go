@@ -0,0 +1,511 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	mathrand "math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	EventTypeGameScheduled = "game_scheduled"
+	EventTypeTipoff        = "tipoff"
+	EventTypePeriodEnd     = "period_end"
+	EventTypeFinal         = "final"
+	EventTypeMilestone     = "milestone"
+)
+
+var EventTypes = []string{EventTypeGameScheduled, EventTypeTipoff, EventTypePeriodEnd, EventTypeFinal, EventTypeMilestone}
+
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	// DeliveryStatusDead deliveries ran out of attempts and show up in the dead letters until they are retried
+	DeliveryStatusDead = "dead"
+)
+
+// headers sent with every delivery, the signature is an hmac-sha256 of the timestamp and body, see Sign
+const (
+	HeaderEventID   = "X-Webhook-Event-ID"
+	HeaderEventType = "X-Webhook-Event-Type"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+const (
+	gameStatusScheduled = "scheduled"
+	gameStatusStarted   = "started"
+	gameStatusCompleted = "completed"
+)
+
+const (
+	DefaultListLimit = 100
+	MaxListLimit     = 1000
+
+	// MaxAttempts is how many times a delivery is tried before it is dead lettered, with the backoff that's about a day
+	MaxAttempts    = 10
+	initialBackoff = 30 * time.Second
+	maxBackoff     = 6 * time.Hour
+
+	deliveryBatchSize   = 50
+	deliveryConcurrency = 8
+	deliveryTimeout     = 10 * time.Second
+	// claimLease keeps a delivery from being claimed again while it is in flight, it is longer than deliveryTimeout
+	claimLease = 2 * time.Minute
+	// maxErrorLength keeps large error pages from receivers out of the database
+	maxErrorLength = 512
+)
+
+var ErrInvalidSubscription = util.NewError(util.ErrValidation, "invalid webhook subscription")
+
+type Service interface {
+	CreateSubscription(ctx context.Context, subscriptionCreate SubscriptionCreate) (api.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, id string) (api.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]api.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id string) error
+	ListDeliveries(ctx context.Context, filter DeliveryListFilter) ([]api.WebhookDelivery, error)
+	ListDeadLetters(ctx context.Context, filter DeliveryListFilter) ([]api.WebhookDelivery, error)
+	RetryDelivery(ctx context.Context, id string) (api.WebhookDelivery, error)
+	EnqueueGameEvents(ctx context.Context, games []api.Game, milestones []api.Milestone) (int, error)
+	DeliverPending(ctx context.Context, logger *slog.Logger) (int, error)
+}
+
+func NewService(webhookStore Store, httpClient *http.Client) Service {
+	return &service{webhookStore: webhookStore, httpClient: httpClient}
+}
+
+type service struct {
+	webhookStore Store
+
+	httpClient *http.Client
+}
+
+func (s *service) CreateSubscription(ctx context.Context, subscriptionCreate SubscriptionCreate) (api.WebhookSubscription, error) {
+	ctx, span := otel.Tracer("webhook").Start(ctx, "webhook.service.CreateSubscription")
+	defer span.End()
+
+	if err := validateSubscription(subscriptionCreate); err != nil {
+		return api.WebhookSubscription{}, err
+	}
+
+	if subscriptionCreate.TeamIDs == nil {
+		subscriptionCreate.TeamIDs = []string{}
+	}
+	if subscriptionCreate.LeagueIDs == nil {
+		subscriptionCreate.LeagueIDs = []string{}
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return api.WebhookSubscription{}, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	subscriptionCreate.Secret = hex.EncodeToString(secret)
+
+	subscription, err := s.webhookStore.CreateWebhookSubscription(ctx, subscriptionCreate)
+	if err != nil {
+		return api.WebhookSubscription{}, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	// the secret is only ever shown here
+	subscription.Secret = &subscriptionCreate.Secret
+
+	return subscription, nil
+}
+
+func validateSubscription(subscriptionCreate SubscriptionCreate) error {
+	u, err := url.Parse(subscriptionCreate.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https url", ErrInvalidSubscription)
+	}
+
+	if len(subscriptionCreate.EventTypes) == 0 {
+		return fmt.Errorf("%w: at least one event type is required", ErrInvalidSubscription)
+	}
+	for _, eventType := range subscriptionCreate.EventTypes {
+		if !slices.Contains(EventTypes, eventType) {
+			return fmt.Errorf("%w: unknown event type %s", ErrInvalidSubscription, eventType)
+		}
+	}
+
+	for _, id := range slices.Concat(subscriptionCreate.TeamIDs, subscriptionCreate.LeagueIDs) {
+		if _, err := uuid.Parse(id); err != nil {
+			return fmt.Errorf("%w: invalid team or league id %s", ErrInvalidSubscription, id)
+		}
+	}
+
+	return nil
+}
+
+func (s *service) GetSubscription(ctx context.Context, id string) (api.WebhookSubscription, error) {
+	ctx, span := otel.Tracer("webhook").Start(ctx, "webhook.service.GetSubscription")
+	defer span.End()
+
+	subscription, err := s.webhookStore.GetWebhookSubscription(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.WebhookSubscription{}, util.NewError(util.ErrNotFound, "webhook subscription not found")
+		}
+		return api.WebhookSubscription{}, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+func (s *service) ListSubscriptions(ctx context.Context) ([]api.WebhookSubscription, error) {
+	ctx, span := otel.Tracer("webhook").Start(ctx, "webhook.service.ListSubscriptions")
+	defer span.End()
+
+	return s.webhookStore.ListWebhookSubscriptions(ctx)
+}
+
+func (s *service) DeleteSubscription(ctx context.Context, id string) error {
+	ctx, span := otel.Tracer("webhook").Start(ctx, "webhook.service.DeleteSubscription")
+	defer span.End()
+
+	if err := s.webhookStore.DeleteWebhookSubscription(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return util.NewError(util.ErrNotFound, "webhook subscription not found")
+		}
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) ListDeliveries(ctx context.Context, filter DeliveryListFilter) ([]api.WebhookDelivery, error) {
+	ctx, span := otel.Tracer("webhook").Start(ctx, "webhook.service.ListDeliveries")
+	defer span.End()
+
+	filter.Limit = listLimit(filter.Limit)
+
+	return s.webhookStore.ListWebhookDeliveries(ctx, filter)
+}
+
+func (s *service) ListDeadLetters(ctx context.Context, filter DeliveryListFilter) ([]api.WebhookDelivery, error) {
+	ctx, span := otel.Tracer("webhook").Start(ctx, "webhook.service.ListDeadLetters")
+	defer span.End()
+
+	filter.Limit = listLimit(filter.Limit)
+
+	return s.webhookStore.ListWebhookDeadLetters(ctx, filter)
+}
+
+func listLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultListLimit
+	}
+	return min(limit, MaxListLimit)
+}
+
+// RetryDelivery puts a dead lettered delivery back in the queue with a fresh set of attempts
+func (s *service) RetryDelivery(ctx context.Context, id string) (api.WebhookDelivery, error) {
+	ctx, span := otel.Tracer("webhook").Start(ctx, "webhook.service.RetryDelivery")
+	defer span.End()
+
+	delivery, err := s.webhookStore.RetryWebhookDelivery(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.WebhookDelivery{}, util.NewError(util.ErrNotFound, "dead lettered webhook delivery not found")
+		}
+		return api.WebhookDelivery{}, fmt.Errorf("failed to retry webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// EnqueueGameEvents compares freshly ingested games with the state events were last generated from and queues a
+// delivery of each new event for every matching subscription
+func (s *service) EnqueueGameEvents(ctx context.Context, games []api.Game, milestones []api.Milestone) (int, error) {
+	ctx, span := otel.Tracer("webhook").Start(ctx, "webhook.service.EnqueueGameEvents")
+	defer span.End()
+
+	if len(games) == 0 {
+		return 0, nil
+	}
+
+	gameIDs := make([]string, 0, len(games))
+	for _, g := range games {
+		gameIDs = append(gameIDs, g.ID)
+	}
+
+	previousGameStates, err := s.webhookStore.GetWebhookGameStates(ctx, gameIDs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get webhook game states: %w", err)
+	}
+
+	now := time.Now().UTC()
+
+	events := []Event{}
+	gameStates := make([]GameState, 0, len(games))
+	gamesByID := make(map[string]api.Game, len(games))
+	for _, g := range games {
+		gamesByID[g.ID] = g
+
+		previousGameState, ok := previousGameStates[g.ID]
+		events = append(events, gameEvents(g, previousGameState, ok, now)...)
+
+		gameState := GameState{GameID: g.ID, Status: g.Status}
+		if g.Period != nil {
+			gameState.Period = sql.NullInt64{Int64: int64(*g.Period), Valid: true}
+		}
+		gameStates = append(gameStates, gameState)
+	}
+
+	for _, m := range milestones {
+		g, ok := gamesByID[m.GameID]
+		if !ok {
+			continue
+		}
+		milestone := m
+		events = append(events, Event{
+			Key:     fmt.Sprintf("%s:%s", EventTypeMilestone, m.ID),
+			Payload: api.WebhookEvent{ID: uuid.NewString(), Type: EventTypeMilestone, Time: now, Game: webhookGame(g), Milestone: &milestone},
+		})
+	}
+
+	queued, err := s.webhookStore.EnqueueWebhookEvents(ctx, events, gameStates)
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue webhook events: %w", err)
+	}
+
+	return queued, nil
+}
+
+// gameEvents returns the events for every transition between the game's previous state and its current one, several
+// can happen between polls ex. two periods ending. games seen for the first time only get a game_scheduled event
+func gameEvents(g api.Game, previous GameState, known bool, now time.Time) []Event {
+	newEvent := func(eventType string, key string, period *int) Event {
+		return Event{
+			Key:     key,
+			Payload: api.WebhookEvent{ID: uuid.NewString(), Type: eventType, Time: now, Game: webhookGame(g), Period: period},
+		}
+	}
+
+	events := []Event{}
+
+	if !known {
+		if g.Status == gameStatusScheduled {
+			events = append(events, newEvent(EventTypeGameScheduled, fmt.Sprintf("%s:%s", EventTypeGameScheduled, g.ID), nil))
+		}
+		return events
+	}
+
+	if previous.Status == gameStatusScheduled && g.Status != gameStatusScheduled {
+		events = append(events, newEvent(EventTypeTipoff, fmt.Sprintf("%s:%s", EventTypeTipoff, g.ID), nil))
+	}
+
+	previousPeriod := 0
+	if previous.Period.Valid {
+		previousPeriod = int(previous.Period.Int64)
+	}
+	period := 0
+	if g.Period != nil {
+		period = *g.Period
+	}
+
+	for p := endedPeriods(previous.Status, previousPeriod) + 1; p <= endedPeriods(g.Status, period); p++ {
+		endedPeriod := p
+		events = append(events, newEvent(EventTypePeriodEnd, fmt.Sprintf("%s:%s:%d", EventTypePeriodEnd, g.ID, p), &endedPeriod))
+	}
+
+	if g.Status == gameStatusCompleted && previous.Status != gameStatusCompleted {
+		events = append(events, newEvent(EventTypeFinal, fmt.Sprintf("%s:%s", EventTypeFinal, g.ID), nil))
+	}
+
+	return events
+}
+
+// endedPeriods is how many periods are over, the current period of a game in progress hasn't ended yet
+func endedPeriods(status string, period int) int {
+	switch status {
+	case gameStatusStarted:
+		return max(period-1, 0)
+	case gameStatusCompleted:
+		return period
+	default:
+		return 0
+	}
+}
+
+func webhookGame(g api.Game) api.WebhookGame {
+	return api.WebhookGame{
+		ID:             g.ID,
+		NBAGameID:      g.NBAGameID,
+		HomeTeamID:     g.HomeTeamID,
+		AwayTeamID:     g.AwayTeamID,
+		HomeTeamPoints: g.HomeTeamPoints,
+		AwayTeamPoints: g.AwayTeamPoints,
+		Status:         g.Status,
+		Period:         g.Period,
+		SeasonStage:    g.SeasonStage,
+		StartTime:      g.StartTime,
+		EndTime:        g.EndTime,
+	}
+}
+
+// DeliverPending sends the deliveries that are due and records the results, failures are retried with exponential
+// backoff until MaxAttempts when they are dead lettered
+func (s *service) DeliverPending(ctx context.Context, logger *slog.Logger) (int, error) {
+	ctx, span := otel.Tracer("webhook").Start(ctx, "webhook.service.DeliverPending")
+	defer span.End()
+
+	deliveries, err := s.webhookStore.ClaimWebhookDeliveries(ctx, deliveryBatchSize, claimLease)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		delivered int
+		errs      []error
+	)
+	sem := make(chan struct{}, deliveryConcurrency)
+
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(delivery Delivery) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			deliveryUpdate := s.deliver(ctx, delivery, time.Now())
+			if deliveryUpdate.Status != DeliveryStatusDelivered {
+				logger.WarnContext(ctx, "failed to deliver webhook",
+					slog.String("delivery_id", delivery.ID),
+					slog.String("subscription_id", delivery.SubscriptionID),
+					slog.Int("attempts", deliveryUpdate.Attempts),
+					slog.String("status", deliveryUpdate.Status),
+					slog.String("error", deliveryUpdate.Error.String))
+			}
+
+			err := s.webhookStore.UpdateWebhookDelivery(ctx, deliveryUpdate)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to update webhook delivery %s: %w", delivery.ID, err))
+				return
+			}
+			if deliveryUpdate.Status == DeliveryStatusDelivered {
+				delivered++
+			}
+		}(delivery)
+	}
+
+	wg.Wait()
+
+	return delivered, errors.Join(errs...)
+}
+
+func (s *service) deliver(ctx context.Context, delivery Delivery, now time.Time) DeliveryUpdate {
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+
+	deliveryUpdate := DeliveryUpdate{ID: delivery.ID, Attempts: delivery.Attempts + 1}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return failedDelivery(deliveryUpdate, err.Error(), now)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "wolves_reddit_bot-webhooks")
+	req.Header.Set(HeaderEventID, delivery.EventID)
+	req.Header.Set(HeaderEventType, delivery.EventType)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, now, delivery.Payload))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return failedDelivery(deliveryUpdate, err.Error(), now)
+	}
+	defer resp.Body.Close()
+
+	deliveryUpdate.ResponseStatus = sql.NullInt64{Int64: int64(resp.StatusCode), Valid: true}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorLength))
+		return failedDelivery(deliveryUpdate, fmt.Sprintf("unexpected status %d: %s", resp.StatusCode, body), now)
+	}
+
+	// drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorLength))
+
+	deliveryUpdate.Status = DeliveryStatusDelivered
+	return deliveryUpdate
+}
+
+func failedDelivery(deliveryUpdate DeliveryUpdate, message string, now time.Time) DeliveryUpdate {
+	if len(message) > maxErrorLength {
+		message = message[:maxErrorLength]
+	}
+	deliveryUpdate.Error = sql.NullString{String: message, Valid: true}
+
+	if deliveryUpdate.Attempts >= MaxAttempts {
+		deliveryUpdate.Status = DeliveryStatusDead
+		return deliveryUpdate
+	}
+
+	deliveryUpdate.Status = DeliveryStatusPending
+	deliveryUpdate.NextAttemptAt = sql.NullTime{Time: now.Add(Backoff(deliveryUpdate.Attempts)), Valid: true}
+	return deliveryUpdate
+}
+
+// Backoff is how long to wait before the next attempt after the given number of failed ones. it doubles each time up
+// to maxBackoff with up to 20% jitter so a receiver coming back up isn't hit by every retry at once
+func Backoff(attempts int) time.Duration {
+	backoff := time.Duration(float64(initialBackoff) * math.Pow(2, float64(max(attempts-1, 0))))
+	if backoff > maxBackoff || backoff <= 0 {
+		backoff = maxBackoff
+	}
+
+	jitter := time.Duration(mathrand.Int64N(int64(backoff) / 5))
+	return backoff - jitter
+}
+
+// Sign returns the signature sent in the X-Webhook-Signature header, an hmac-sha256 of the unix timestamp sent in
+// X-Webhook-Timestamp, a period and the body keyed with the subscription's secret
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a delivery's signature for receivers written in go, tolerance limits how old a delivery can be to stop
+// replays
+func Verify(secret string, timestampHeader string, signatureHeader string, body []byte, tolerance time.Duration, now time.Time) bool {
+	unix, err := strconv.ParseInt(timestampHeader, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	timestamp := time.Unix(unix, 0)
+	if now.Sub(timestamp).Abs() > tolerance {
+		return false
+	}
+
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signatureHeader))
+}
@@ -0,0 +1,220 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+)
+
+// memoryStore queues deliveries for a single subscription and hands out every pending one when claimed
+type memoryStore struct {
+	Store
+
+	mu         sync.Mutex
+	deliveries map[string]*Delivery
+	updates    map[string]DeliveryUpdate
+}
+
+func (m *memoryStore) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	claimed := []Delivery{}
+	for id, delivery := range m.deliveries {
+		if update, ok := m.updates[id]; ok && update.Status != DeliveryStatusPending {
+			continue
+		}
+		claimed = append(claimed, *delivery)
+	}
+	return claimed, nil
+}
+
+func (m *memoryStore) UpdateWebhookDelivery(ctx context.Context, deliveryUpdate DeliveryUpdate) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.updates[deliveryUpdate.ID] = deliveryUpdate
+	m.deliveries[deliveryUpdate.ID].Attempts = deliveryUpdate.Attempts
+	return nil
+}
+
+func newMemoryStore(url string, secret string, payload []byte) *memoryStore {
+	return &memoryStore{
+		deliveries: map[string]*Delivery{
+			"delivery": {ID: "delivery", SubscriptionID: "subscription", URL: url, Secret: secret, EventID: "event", EventType: EventTypeFinal, Payload: payload},
+		},
+		updates: map[string]DeliveryUpdate{},
+	}
+}
+
+func TestDeliverPendingSignsDeliveries(t *testing.T) {
+	secret := "secret"
+	payload := []byte(`{"id":"event","type":"final"}`)
+
+	var received []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !Verify(secret, r.Header.Get(HeaderTimestamp), r.Header.Get(HeaderSignature), body, time.Minute, time.Now()) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get(HeaderEventID) != "event" || r.Header.Get(HeaderEventType) != EventTypeFinal {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = body
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	store := newMemoryStore(receiver.URL, secret, payload)
+	s := NewService(store, receiver.Client())
+
+	delivered, err := s.DeliverPending(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("failed to deliver pending webhooks: %v", err)
+	}
+	if delivered != 1 {
+		t.Fatalf("expected 1 delivery, got %d", delivered)
+	}
+	if string(received) != string(payload) {
+		t.Errorf("receiver got %s, want %s", received, payload)
+	}
+	if update := store.updates["delivery"]; update.Status != DeliveryStatusDelivered || update.Attempts != 1 || update.ResponseStatus.Int64 != http.StatusNoContent {
+		t.Errorf("unexpected delivery update %+v", update)
+	}
+}
+
+func TestDeliverPendingRetriesThenDeadLetters(t *testing.T) {
+	attempts := 0
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		http.Error(w, "down for maintenance", http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	store := newMemoryStore(receiver.URL, "secret", []byte(`{}`))
+	s := NewService(store, receiver.Client())
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	for i := 1; i <= MaxAttempts; i++ {
+		before := time.Now()
+		if _, err := s.DeliverPending(context.Background(), logger); err != nil {
+			t.Fatalf("failed to deliver pending webhooks: %v", err)
+		}
+
+		update := store.updates["delivery"]
+		if update.Attempts != i {
+			t.Fatalf("expected %d attempts, got %d", i, update.Attempts)
+		}
+		if update.ResponseStatus.Int64 != http.StatusServiceUnavailable || !update.Error.Valid {
+			t.Fatalf("expected the failed response to be recorded, got %+v", update)
+		}
+
+		if i < MaxAttempts {
+			if update.Status != DeliveryStatusPending {
+				t.Fatalf("expected attempt %d to be retried, got status %s", i, update.Status)
+			}
+			// the backoff doubles from 30 seconds with up to 20% jitter taken off
+			wantBackoff := min(initialBackoff<<(i-1), maxBackoff)
+			backoff := update.NextAttemptAt.Time.Sub(before)
+			if backoff > wantBackoff+time.Second || backoff < wantBackoff*4/5-time.Second {
+				t.Errorf("attempt %d backed off %s, want about %s", i, backoff, wantBackoff)
+			}
+		} else if update.Status != DeliveryStatusDead {
+			t.Fatalf("expected the last attempt to be dead lettered, got status %s", update.Status)
+		}
+	}
+
+	if attempts != MaxAttempts {
+		t.Errorf("receiver got %d attempts, want %d", attempts, MaxAttempts)
+	}
+
+	if _, err := s.DeliverPending(context.Background(), logger); err != nil {
+		t.Fatalf("failed to deliver pending webhooks: %v", err)
+	}
+	if attempts != MaxAttempts {
+		t.Errorf("dead lettered delivery was attempted again")
+	}
+}
+
+func TestGameEvents(t *testing.T) {
+	intPointer := func(i int) *int { return &i }
+
+	tests := []struct {
+		name     string
+		game     api.Game
+		previous GameState
+		known    bool
+		want     []string
+	}{
+		{
+			name: "new scheduled game",
+			game: api.Game{ID: "game", Status: gameStatusScheduled},
+			want: []string{"game_scheduled:game"},
+		},
+		{
+			name: "new game already underway is only a baseline",
+			game: api.Game{ID: "game", Status: gameStatusStarted, Period: intPointer(2)},
+			want: []string{},
+		},
+		{
+			name:     "tipoff",
+			game:     api.Game{ID: "game", Status: gameStatusStarted, Period: intPointer(1)},
+			previous: GameState{GameID: "game", Status: gameStatusScheduled},
+			known:    true,
+			want:     []string{"tipoff:game"},
+		},
+		{
+			name:     "periods ending between polls",
+			game:     api.Game{ID: "game", Status: gameStatusStarted, Period: intPointer(4)},
+			previous: GameState{GameID: "game", Status: gameStatusStarted, Period: sql.NullInt64{Int64: 2, Valid: true}},
+			known:    true,
+			want:     []string{"period_end:game:2", "period_end:game:3"},
+		},
+		{
+			name:     "final after overtime",
+			game:     api.Game{ID: "game", Status: gameStatusCompleted, Period: intPointer(5)},
+			previous: GameState{GameID: "game", Status: gameStatusStarted, Period: sql.NullInt64{Int64: 5, Valid: true}},
+			known:    true,
+			want:     []string{"period_end:game:5", "final:game"},
+		},
+		{
+			name:     "unchanged",
+			game:     api.Game{ID: "game", Status: gameStatusCompleted, Period: intPointer(4)},
+			previous: GameState{GameID: "game", Status: gameStatusCompleted, Period: sql.NullInt64{Int64: 4, Valid: true}},
+			known:    true,
+			want:     []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := gameEvents(tt.game, tt.previous, tt.known, time.Now())
+
+			keys := []string{}
+			for _, event := range events {
+				keys = append(keys, event.Key)
+				if event.Payload.Game.ID != tt.game.ID || event.Payload.ID == "" {
+					t.Errorf("event %s has an unexpected payload %+v", event.Key, event.Payload)
+				}
+				if _, err := json.Marshal(event.Payload); err != nil {
+					t.Errorf("failed to marshal event %s: %v", event.Key, err)
+				}
+			}
+
+			if !reflect.DeepEqual(keys, tt.want) {
+				t.Errorf("gameEvents() = %v, want %v", keys, tt.want)
+			}
+		})
+	}
+}
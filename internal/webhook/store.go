@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+)
+
+type Store interface {
+	CreateWebhookSubscription(ctx context.Context, subscriptionCreate SubscriptionCreate) (api.WebhookSubscription, error)
+	GetWebhookSubscription(ctx context.Context, id string) (api.WebhookSubscription, error)
+	ListWebhookSubscriptions(ctx context.Context) ([]api.WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, id string) error
+	GetWebhookGameStates(ctx context.Context, gameIDs []string) (map[string]GameState, error)
+	EnqueueWebhookEvents(ctx context.Context, events []Event, gameStates []GameState) (int, error)
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]Delivery, error)
+	UpdateWebhookDelivery(ctx context.Context, deliveryUpdate DeliveryUpdate) error
+	ListWebhookDeliveries(ctx context.Context, filter DeliveryListFilter) ([]api.WebhookDelivery, error)
+	ListWebhookDeadLetters(ctx context.Context, filter DeliveryListFilter) ([]api.WebhookDelivery, error)
+	RetryWebhookDelivery(ctx context.Context, id string) (api.WebhookDelivery, error)
+}
+
+type SubscriptionCreate struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	TeamIDs    []string `json:"team_ids"`
+	LeagueIDs  []string `json:"league_ids"`
+	// Secret is generated when the subscription is created
+	Secret string `json:"-"`
+}
+
+// GameState is what a game looked like the last time events were generated for it
+type GameState struct {
+	GameID string
+	Status string
+	Period sql.NullInt64
+}
+
+// Event is queued for every active subscription that wants its type and whose filters match its game. Key identifies
+// the event itself ex. the end of a game's second period so it is only queued once per subscription
+type Event struct {
+	Key     string
+	Payload api.WebhookEvent
+}
+
+// Delivery is a claimed delivery attempt, Payload is the exact body that is signed and sent
+type Delivery struct {
+	ID             string
+	SubscriptionID string
+	URL            string
+	Secret         string
+	EventID        string
+	EventType      string
+	Attempts       int
+	Payload        []byte
+}
+
+type DeliveryUpdate struct {
+	ID             string
+	Status         string
+	Attempts       int
+	NextAttemptAt  sql.NullTime
+	ResponseStatus sql.NullInt64
+	Error          sql.NullString
+}
+
+type DeliveryListFilter struct {
+	SubscriptionID sql.NullString
+	Status         sql.NullString
+	Limit          int
+}
@@ -0,0 +1,160 @@
+package win_probability
+
+import (
+	"errors"
+	"math"
+)
+
+type Sample struct {
+	State   GameState
+	HomeWon bool
+}
+
+// ridge keeps the fit stable when a feature barely varies, like possession in older archives that don't track it
+const ridge = 1e-3
+
+// Fit fits the model to the samples using newton's method on the log likelihood
+func Fit(samples []Sample, iterations int) (Model, error) {
+	if len(samples) == 0 {
+		return Model{}, errors.New("no samples to fit win probability model to")
+	}
+
+	b := [4]float64{}
+	for iter := 0; iter < iterations; iter++ {
+		gradient := [4]float64{}
+		hessian := [4][4]float64{}
+		for _, sample := range samples {
+			// samples at the buzzer are decided by the score alone and say nothing about the coefficients
+			if sample.State.Remaining <= 0 {
+				continue
+			}
+
+			x := features(sample.State)
+			z := 0.0
+			for i := range x {
+				z += x[i] * b[i]
+			}
+			p := sigmoid(z)
+
+			y := 0.0
+			if sample.HomeWon {
+				y = 1
+			}
+			for i := range x {
+				gradient[i] += (y - p) * x[i]
+				for j := range x {
+					hessian[i][j] += p * (1 - p) * x[i] * x[j]
+				}
+			}
+		}
+
+		for i := range b {
+			gradient[i] -= ridge * b[i]
+			hessian[i][i] += ridge
+		}
+
+		step, err := solve(hessian, gradient)
+		if err != nil {
+			return Model{}, err
+		}
+
+		maxStep := 0.0
+		for i := range b {
+			b[i] += step[i]
+			maxStep = max(maxStep, math.Abs(step[i]))
+		}
+		if maxStep < 1e-8 {
+			break
+		}
+	}
+
+	return Model{Intercept: b[0], Margin: b[1], Possession: b[2], Strength: b[3]}, nil
+}
+
+// solve solves ax = y with gaussian elimination and partial pivoting
+func solve(a [4][4]float64, y [4]float64) ([4]float64, error) {
+	n := len(y)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return [4]float64{}, errors.New("win probability model fit is singular")
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		y[col], y[pivot] = y[pivot], y[col]
+
+		for row := col + 1; row < n; row++ {
+			factor := a[row][col] / a[col][col]
+			for k := col; k < n; k++ {
+				a[row][k] -= factor * a[col][k]
+			}
+			y[row] -= factor * y[col]
+		}
+	}
+
+	x := [4]float64{}
+	for row := n - 1; row >= 0; row-- {
+		sum := y[row]
+		for k := row + 1; k < n; k++ {
+			sum -= a[row][k] * x[k]
+		}
+		x[row] = sum / a[row][row]
+	}
+
+	return x, nil
+}
+
+// Metrics are how well a model predicts the winner of the samples it's evaluated on, BaseRateLogLoss is the log loss of
+// always predicting how often the home team won so a useful model's LogLoss is well below it
+type Metrics struct {
+	Samples         int     `json:"samples"`
+	LogLoss         float64 `json:"log_loss"`
+	BaseRateLogLoss float64 `json:"base_rate_log_loss"`
+	Brier           float64 `json:"brier"`
+	Accuracy        float64 `json:"accuracy"`
+}
+
+// Evaluate scores the model on the samples, samples at the buzzer are left out like they are when fitting
+func Evaluate(m Model, samples []Sample) Metrics {
+	metrics := Metrics{}
+	homeWins := 0
+	correct := 0
+	for _, sample := range samples {
+		if sample.State.Remaining <= 0 {
+			continue
+		}
+
+		// keep a confident miss from making the log loss infinite
+		p := min(max(m.HomeWinProbability(sample.State), 1e-15), 1-1e-15)
+		y := 0.0
+		if sample.HomeWon {
+			y = 1
+			homeWins++
+		}
+
+		metrics.Samples++
+		metrics.LogLoss -= y*math.Log(p) + (1-y)*math.Log(1-p)
+		metrics.Brier += (p - y) * (p - y)
+		if (p > 0.5) == sample.HomeWon {
+			correct++
+		}
+	}
+	if metrics.Samples == 0 {
+		return metrics
+	}
+
+	n := float64(metrics.Samples)
+	metrics.LogLoss /= n
+	metrics.Brier /= n
+	metrics.Accuracy = float64(correct) / n
+
+	if baseRate := float64(homeWins) / n; baseRate > 0 && baseRate < 1 {
+		metrics.BaseRateLogLoss = -(baseRate*math.Log(baseRate) + (1-baseRate)*math.Log(1-baseRate))
+	}
+
+	return metrics
+}
@@ -0,0 +1,51 @@
+package win_probability
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"go.opentelemetry.io/otel"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler interface {
+	Routes() chi.Router
+	Get(w http.ResponseWriter, r *http.Request)
+}
+
+func NewHandler(logger *slog.Logger, winProbabilityService Service) Handler {
+	return &handler{logger: logger, winProbabilityService: winProbabilityService}
+}
+
+type handler struct {
+	logger                *slog.Logger
+	winProbabilityService Service
+}
+
+func (h *handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.Get)
+
+	return r
+}
+
+func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("win_probability").Start(r.Context(), "win_probability.handler.Get")
+	defer span.End()
+
+	gameID := chi.URLParam(r, "gameID")
+
+	logger := h.logger.With(slog.String("game_id", gameID))
+
+	winProbability, err := h.winProbabilityService.GetGameWinProbability(ctx, gameID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get game win probability", slog.Any("error", err))
+		util.WriteError(w, r, err)
+		return
+	}
+
+	util.WriteJSON(http.StatusOK, winProbability, w)
+}
@@ -0,0 +1,98 @@
+package win_probability
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+)
+
+// model.json holds hand picked placeholder coefficients until cmd/fit_win_probability is run against the archived
+// play by play in r2 and its output replaces them
+//
+//go:embed model.json
+var modelJSON []byte
+
+// Model is a logistic regression on the state of the game that gives the probability the home team wins
+type Model struct {
+	Intercept  float64 `json:"intercept"`
+	Margin     float64 `json:"margin"`
+	Possession float64 `json:"possession"`
+	Strength   float64 `json:"strength"`
+}
+
+// GameState is the state of a game at a single play by play action from the home team's point of view
+type GameState struct {
+	Margin           int
+	Remaining        time.Duration
+	RegulationLength time.Duration
+	HomePossession   int // 1 if the home team has the ball, -1 if the away team does, 0 if neither
+	StrengthDiff     float64
+}
+
+// DefaultModel is the embedded placeholder model, its probabilities are rough until the coefficients are fitted
+func DefaultModel() (Model, error) {
+	m := Model{}
+	if err := json.Unmarshal(modelJSON, &m); err != nil {
+		return Model{}, fmt.Errorf("failed to unmarshal embedded win probability model: %w", err)
+	}
+
+	return m, nil
+}
+
+// features scales the margin and possession by the time left since both matter more the closer the game is to
+// ending, while the pre-game strength of the teams matters less
+func features(state GameState) [4]float64 {
+	minutesRemaining := state.Remaining.Minutes()
+	timeScale := 1 / math.Sqrt(minutesRemaining+1)
+
+	fractionRemaining := 0.0
+	if state.RegulationLength > 0 {
+		fractionRemaining = min(state.Remaining.Seconds()/state.RegulationLength.Seconds(), 1)
+	}
+
+	return [4]float64{
+		1,
+		float64(state.Margin) * timeScale,
+		float64(state.HomePossession) * timeScale,
+		state.StrengthDiff * fractionRemaining,
+	}
+}
+
+func (m Model) coefficients() [4]float64 {
+	return [4]float64{m.Intercept, m.Margin, m.Possession, m.Strength}
+}
+
+// HomeWinProbability returns the probability the home team wins from the given game state
+func (m Model) HomeWinProbability(state GameState) float64 {
+	if state.Remaining <= 0 {
+		switch {
+		case state.Margin > 0:
+			return 1
+		case state.Margin < 0:
+			return 0
+		default:
+			// tied at the end of a period means overtime which is close to a coin flip
+			return 0.5
+		}
+	}
+
+	x := features(state)
+	b := m.coefficients()
+	z := 0.0
+	for i := range x {
+		z += x[i] * b[i]
+	}
+
+	return sigmoid(z)
+}
+
+// WinPercentage returns a win percentage that is pulled towards .500 when a team has only played a few games
+func WinPercentage(wins, losses int) float64 {
+	return float64(wins+1) / float64(wins+losses+2)
+}
+
+func sigmoid(z float64) float64 {
+	return 1 / (1 + math.Exp(-z))
+}
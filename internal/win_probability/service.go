@@ -0,0 +1,129 @@
+package win_probability
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/apis/nba"
+	"github.com/drewthor/wolves_reddit_bot/util"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+)
+
+const biggestSwingCount = 5
+
+type Service interface {
+	GetGameWinProbability(ctx context.Context, gameID string) (api.WinProbability, error)
+	ModelGame(ctx context.Context, nbaGameID string, actions []nba.PlayByPlayAction) ([]float64, error)
+}
+
+func NewService(winProbabilityStore Store, model Model) Service {
+	return &service{winProbabilityStore: winProbabilityStore, model: model}
+}
+
+type service struct {
+	winProbabilityStore Store
+
+	model Model
+}
+
+// ModelGame returns the home team's win probability after each of the actions
+func (s *service) ModelGame(ctx context.Context, nbaGameID string, actions []nba.PlayByPlayAction) ([]float64, error) {
+	ctx, span := otel.Tracer("win_probability").Start(ctx, "win_probability.service.ModelGame")
+	defer span.End()
+
+	records, err := s.winProbabilityStore.GetPreGameTeamRecords(ctx, nbaGameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pre-game team records to model win probability: %w", err)
+	}
+
+	regulationPeriods := nba.DefaultRegulationPeriods
+	if records.RegulationPeriods != nil {
+		regulationPeriods = *records.RegulationPeriods
+	}
+
+	strengthDiff := WinPercentage(records.HomeWins, records.HomeLosses) - WinPercentage(records.AwayWins, records.AwayLosses)
+
+	states := GameStates(actions, records.NBAHomeTeamID, records.NBAAwayTeamID, strengthDiff, regulationPeriods)
+
+	probabilities := make([]float64, len(states))
+	for i, state := range states {
+		probabilities[i] = s.model.HomeWinProbability(state)
+	}
+
+	return probabilities, nil
+}
+
+func (s *service) GetGameWinProbability(ctx context.Context, gameID string) (api.WinProbability, error) {
+	ctx, span := otel.Tracer("win_probability").Start(ctx, "win_probability.service.GetGameWinProbability")
+	defer span.End()
+
+	g, err := s.winProbabilityStore.GetGameWithID(ctx, gameID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.WinProbability{}, util.NewError(util.ErrNotFound, "game not found")
+		}
+		return api.WinProbability{}, fmt.Errorf("failed to get game for win probability: %w", err)
+	}
+
+	playByPlays, err := s.winProbabilityStore.GetPlayByPlaysForGame(ctx, gameID)
+	if err != nil {
+		return api.WinProbability{}, fmt.Errorf("failed to get play by plays for win probability: %w", err)
+	}
+
+	winProbability := api.WinProbability{
+		GameID:     g.ID,
+		HomeTeamID: g.HomeTeamID,
+		AwayTeamID: g.AwayTeamID,
+		Plays:      []api.WinProbabilityPlay{},
+	}
+
+	// a play's swing is the change in the home team's win probability from the play before it
+	for _, playByPlay := range playByPlays {
+		if playByPlay.HomeWinProbability == nil {
+			continue
+		}
+
+		play := api.WinProbabilityPlay{
+			PlayByPlayID:       playByPlay.ID,
+			ActionNumber:       playByPlay.ActionNumber,
+			Period:             playByPlay.Period,
+			ClockTenthSeconds:  playByPlay.ClockTenthSeconds,
+			Description:        playByPlay.Description,
+			HomeScore:          playByPlay.HomeScore,
+			AwayScore:          playByPlay.AwayScore,
+			HomeWinProbability: *playByPlay.HomeWinProbability,
+		}
+		if len(winProbability.Plays) > 0 {
+			play.Swing = play.HomeWinProbability - winProbability.Plays[len(winProbability.Plays)-1].HomeWinProbability
+		}
+
+		winProbability.Plays = append(winProbability.Plays, play)
+	}
+
+	swingIndexes := make([]int, len(winProbability.Plays))
+	for i := range swingIndexes {
+		swingIndexes[i] = i
+	}
+	slices.SortStableFunc(swingIndexes, func(a, b int) int {
+		return cmp.Compare(math.Abs(winProbability.Plays[b].Swing), math.Abs(winProbability.Plays[a].Swing))
+	})
+	swingIndexes = swingIndexes[:min(biggestSwingCount, len(swingIndexes))]
+	slices.Sort(swingIndexes)
+
+	winProbability.BiggestSwings = []api.WinProbabilityPlay{}
+	for _, i := range swingIndexes {
+		if winProbability.Plays[i].Swing == 0 {
+			continue
+		}
+		winProbability.Plays[i].BigSwing = true
+		winProbability.BiggestSwings = append(winProbability.BiggestSwings, winProbability.Plays[i])
+	}
+
+	return winProbability, nil
+}
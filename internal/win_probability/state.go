@@ -0,0 +1,36 @@
+package win_probability
+
+import (
+	"github.com/drewthor/wolves_reddit_bot/apis/nba"
+)
+
+// GameStates returns the game state at each action; actions without a parsable score carry the previous score forward
+func GameStates(actions []nba.PlayByPlayAction, homeTeamID, awayTeamID int, strengthDiff float64, regulationPeriods int) []GameState {
+	regulationLength := nba.GameElapsed(regulationPeriods, regulationPeriods, 0)
+
+	states := make([]GameState, 0, len(actions))
+	margin := 0
+	for _, action := range actions {
+		if home, away, err := action.Score(); err == nil {
+			margin = home - away
+		}
+
+		homePossession := 0
+		switch action.Possession {
+		case homeTeamID:
+			homePossession = 1
+		case awayTeamID:
+			homePossession = -1
+		}
+
+		states = append(states, GameState{
+			Margin:           margin,
+			Remaining:        nba.GameRemaining(action.Period, regulationPeriods, action.ClockRemaining()),
+			RegulationLength: regulationLength,
+			HomePossession:   homePossession,
+			StrengthDiff:     strengthDiff,
+		})
+	}
+
+	return states
+}
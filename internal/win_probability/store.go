@@ -0,0 +1,24 @@
+package win_probability
+
+import (
+	"context"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+)
+
+type Store interface {
+	GetGameWithID(ctx context.Context, id string) (api.Game, error)
+	GetPlayByPlaysForGame(ctx context.Context, gameID string) ([]api.PlayByPlay, error)
+	GetPreGameTeamRecords(ctx context.Context, nbaGameID string) (PreGameTeamRecords, error)
+}
+
+// PreGameTeamRecords are the records of the teams in a game from the games they played earlier in the same season
+type PreGameTeamRecords struct {
+	NBAHomeTeamID     int
+	NBAAwayTeamID     int
+	RegulationPeriods *int
+	HomeWins          int
+	HomeLosses        int
+	AwayWins          int
+	AwayLosses        int
+}
@@ -0,0 +1,862 @@
+// Code generated by cmd/openapi_client from the api's openapi spec. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/pkg/graphql"
+)
+
+type WebhookSubscriptionCreate struct {
+	EventTypes []string `json:"event_types"`
+	LeagueIDs  []string `json:"league_ids"`
+	TeamIDs    []string `json:"team_ids"`
+	URL        string   `json:"url"`
+}
+
+// BackfillSeasonGamesParams are the params of BackfillSeasonGames
+type BackfillSeasonGamesParams struct {
+	// SeasonStartYear is the season-start-year param, the season's start year
+	SeasonStartYear int
+}
+
+// BackfillSeasonGames calls POST /games/backfill to backfill a historical season's games from stats.nba.com game logs
+func (c *Client) BackfillSeasonGames(ctx context.Context, params BackfillSeasonGamesParams) ([]api.Game, error) {
+	query := url.Values{}
+	query.Set("season-start-year", fmt.Sprint(params.SeasonStartYear))
+	resp, err := c.do(ctx, http.MethodPost, "/games/backfill", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[[]api.Game](resp)
+}
+
+// CreateWebhookSubscription calls POST /webhooks to subscribe a url to game events
+//
+// The subscription's secret is only returned when it's created, deliveries are signed with it
+func (c *Client) CreateWebhookSubscription(ctx context.Context, body WebhookSubscriptionCreate) (api.WebhookSubscription, error) {
+	resp, err := c.do(ctx, http.MethodPost, "/webhooks", nil, nil, body)
+	if err != nil {
+		return api.WebhookSubscription{}, err
+	}
+	return decodeJSON[api.WebhookSubscription](resp)
+}
+
+// DeleteWebhookSubscription calls DELETE /webhooks/{subscriptionID} to delete a webhook subscription
+func (c *Client) DeleteWebhookSubscription(ctx context.Context, subscriptionID string) error {
+	resp, err := c.do(ctx, http.MethodDelete, "/webhooks/"+url.PathEscape(subscriptionID), nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	close(resp)
+	return nil
+}
+
+// ExecuteGraphQL calls POST /graphql to execute a graphql query
+//
+// The schema is served at /graphql/schema.graphql. Errors executing the query are in the response's errors
+func (c *Client) ExecuteGraphQL(ctx context.Context, body graphql.Request) (graphql.Response, error) {
+	resp, err := c.do(ctx, http.MethodPost, "/graphql", nil, nil, body)
+	if err != nil {
+		return graphql.Response{}, err
+	}
+	return decodeJSON[graphql.Response](resp)
+}
+
+// ExecuteGraphQLQueryParams are the params of ExecuteGraphQLQuery
+type ExecuteGraphQLQueryParams struct {
+	// Query is the query param, the graphql query
+	Query string
+	// OperationName is the operationName param, the operation to execute when the query has many
+	OperationName *string
+	// Variables is the variables param, the query's variables as a json object
+	Variables *string
+}
+
+// ExecuteGraphQLQuery calls GET /graphql to execute a graphql query from query params
+//
+// The schema is served at /graphql/schema.graphql. Errors executing the query are in the response's errors
+func (c *Client) ExecuteGraphQLQuery(ctx context.Context, params ExecuteGraphQLQueryParams) (graphql.Response, error) {
+	query := url.Values{}
+	query.Set("query", params.Query)
+	if params.OperationName != nil {
+		query.Set("operationName", *params.OperationName)
+	}
+	if params.Variables != nil {
+		query.Set("variables", *params.Variables)
+	}
+	resp, err := c.do(ctx, http.MethodGet, "/graphql", query, nil, nil)
+	if err != nil {
+		return graphql.Response{}, err
+	}
+	return decodeJSON[graphql.Response](resp)
+}
+
+// GetBoxscoreParams are the params of GetBoxscore
+type GetBoxscoreParams struct {
+	// GameDate is the game_date param, the game's date ex. 20240102
+	GameDate string
+}
+
+// GetBoxscore calls GET /games/{gameID}/boxscore to get a game's boxscore
+func (c *Client) GetBoxscore(ctx context.Context, gameID string, params GetBoxscoreParams) (api.Boxscore, error) {
+	query := url.Values{}
+	query.Set("game_date", params.GameDate)
+	resp, err := c.do(ctx, http.MethodGet, "/games/"+url.PathEscape(gameID)+"/boxscore", query, nil, nil)
+	if err != nil {
+		return api.Boxscore{}, err
+	}
+	return decodeJSON[api.Boxscore](resp)
+}
+
+// GetBoxscoreWithoutGameParams are the params of GetBoxscoreWithoutGame
+type GetBoxscoreWithoutGameParams struct {
+	// GameDate is the game_date param, the game's date ex. 20240102
+	GameDate string
+}
+
+// GetBoxscoreWithoutGame calls GET /boxscores to get a boxscore without a game
+//
+// Deprecated: The route has no game id so it always responds with a bad request, use /games/{gameID}/boxscore
+func (c *Client) GetBoxscoreWithoutGame(ctx context.Context, params GetBoxscoreWithoutGameParams) (api.Boxscore, error) {
+	query := url.Values{}
+	query.Set("game_date", params.GameDate)
+	resp, err := c.do(ctx, http.MethodGet, "/boxscores", query, nil, nil)
+	if err != nil {
+		return api.Boxscore{}, err
+	}
+	return decodeJSON[api.Boxscore](resp)
+}
+
+// GetFranchise calls GET /franchises/{teamID} to get a team's franchise
+func (c *Client) GetFranchise(ctx context.Context, teamID string) (api.Franchise, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/franchises/"+url.PathEscape(teamID), nil, nil, nil)
+	if err != nil {
+		return api.Franchise{}, err
+	}
+	return decodeJSON[api.Franchise](resp)
+}
+
+// GetFranchiseTimeline calls GET /franchises/{teamID}/timeline to get a franchise's eras and seasons
+func (c *Client) GetFranchiseTimeline(ctx context.Context, teamID string) (api.FranchiseTimeline, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/franchises/"+url.PathEscape(teamID)+"/timeline", nil, nil, nil)
+	if err != nil {
+		return api.FranchiseTimeline{}, err
+	}
+	return decodeJSON[api.FranchiseTimeline](resp)
+}
+
+// GetGameAvailability calls GET /games/{gameID}/availability to get the latest injury report availability of a game's players
+func (c *Client) GetGameAvailability(ctx context.Context, gameID string) (api.GameAvailability, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/games/"+url.PathEscape(gameID)+"/availability", nil, nil, nil)
+	if err != nil {
+		return api.GameAvailability{}, err
+	}
+	return decodeJSON[api.GameAvailability](resp)
+}
+
+// GetGameChartPNG calls GET /games/{gameID}/charts/{kind}.png to render a game chart as PNG
+func (c *Client) GetGameChartPNG(ctx context.Context, gameID string, kind string) (io.ReadCloser, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/games/"+url.PathEscape(gameID)+"/charts/"+url.PathEscape(kind)+".png", nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// GetGameChartSVG calls GET /games/{gameID}/charts/{kind}.svg to render a game chart as SVG
+func (c *Client) GetGameChartSVG(ctx context.Context, gameID string, kind string) (io.ReadCloser, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/games/"+url.PathEscape(gameID)+"/charts/"+url.PathEscape(kind)+".svg", nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// GetGameOdds calls GET /games/{gameID}/odds to get a game's betting lines
+func (c *Client) GetGameOdds(ctx context.Context, gameID string) (api.GameOdds, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/games/"+url.PathEscape(gameID)+"/odds", nil, nil, nil)
+	if err != nil {
+		return api.GameOdds{}, err
+	}
+	return decodeJSON[api.GameOdds](resp)
+}
+
+// GetGameRuns calls GET /games/{gameID}/runs to get a game's scoring runs
+func (c *Client) GetGameRuns(ctx context.Context, gameID string) (api.GameRuns, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/games/"+url.PathEscape(gameID)+"/runs", nil, nil, nil)
+	if err != nil {
+		return api.GameRuns{}, err
+	}
+	return decodeJSON[api.GameRuns](resp)
+}
+
+// GetGameWinProbability calls GET /games/{gameID}/win-probability to get the home team's win probability after every play of a game
+func (c *Client) GetGameWinProbability(ctx context.Context, gameID string) (api.WinProbability, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/games/"+url.PathEscape(gameID)+"/win-probability", nil, nil, nil)
+	if err != nil {
+		return api.WinProbability{}, err
+	}
+	return decodeJSON[api.WinProbability](resp)
+}
+
+// GetGraphQLSchema calls GET /graphql/schema.graphql to get the graphql schema
+func (c *Client) GetGraphQLSchema(ctx context.Context) (io.ReadCloser, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/graphql/schema.graphql", nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// GetHeadToHead calls GET /teams/{teamID}/vs/{opponentID} to get a team's record and games against an opponent
+func (c *Client) GetHeadToHead(ctx context.Context, teamID string, opponentID string) (api.HeadToHead, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/teams/"+url.PathEscape(teamID)+"/vs/"+url.PathEscape(opponentID), nil, nil, nil)
+	if err != nil {
+		return api.HeadToHead{}, err
+	}
+	return decodeJSON[api.HeadToHead](resp)
+}
+
+// GetOpenAPISpec calls GET /openapi.json to get this OpenAPI spec
+func (c *Client) GetOpenAPISpec(ctx context.Context) (map[string]any, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/openapi.json", nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[map[string]any](resp)
+}
+
+// GetPlayer calls GET /players/{id} to get a player
+func (c *Client) GetPlayer(ctx context.Context, id string) (api.Player, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/players/"+url.PathEscape(id), nil, nil, nil)
+	if err != nil {
+		return api.Player{}, err
+	}
+	return decodeJSON[api.Player](resp)
+}
+
+// GetPlayerClutchStats calls GET /players/{playerID}/clutch to get a player's stats in clutch time
+func (c *Client) GetPlayerClutchStats(ctx context.Context, playerID string) (api.PlayerClutchStats, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/players/"+url.PathEscape(playerID)+"/clutch", nil, nil, nil)
+	if err != nil {
+		return api.PlayerClutchStats{}, err
+	}
+	return decodeJSON[api.PlayerClutchStats](resp)
+}
+
+// GetPlayoffBracket calls GET /playoffs/{season}/bracket to get a season's playoff bracket
+func (c *Client) GetPlayoffBracket(ctx context.Context, season int) (api.PlayoffBracket, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/playoffs/"+url.PathEscape(fmt.Sprint(season))+"/bracket", nil, nil, nil)
+	if err != nil {
+		return api.PlayoffBracket{}, err
+	}
+	return decodeJSON[api.PlayoffBracket](resp)
+}
+
+// GetRefereeParams are the params of GetReferee
+type GetRefereeParams struct {
+	// NBATeamID is the nba-team-id param, the nba's id of a team to get the crew chief record of
+	NBATeamID *int
+}
+
+// GetReferee calls GET /referees/{id} to get a referee's seasons, tendencies and crew chief record
+func (c *Client) GetReferee(ctx context.Context, id string, params GetRefereeParams) (api.RefereeDetails, error) {
+	query := url.Values{}
+	if params.NBATeamID != nil {
+		query.Set("nba-team-id", fmt.Sprint(*params.NBATeamID))
+	}
+	resp, err := c.do(ctx, http.MethodGet, "/referees/"+url.PathEscape(id), query, nil, nil)
+	if err != nil {
+		return api.RefereeDetails{}, err
+	}
+	return decodeJSON[api.RefereeDetails](resp)
+}
+
+// GetTeam calls GET /teams/{teamID} to get a team
+func (c *Client) GetTeam(ctx context.Context, teamID string) (api.Team, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/teams/"+url.PathEscape(teamID), nil, nil, nil)
+	if err != nil {
+		return api.Team{}, err
+	}
+	return decodeJSON[api.Team](resp)
+}
+
+// GetTeamScheduleParams are the params of GetTeamSchedule
+type GetTeamScheduleParams struct {
+	// Season is the season param, the season's start year or the nba's format ex. 2024-25, defaults to the current season
+	Season *string
+}
+
+// GetTeamSchedule calls GET /teams/{teamID}/games to get a team's schedule with its running record
+func (c *Client) GetTeamSchedule(ctx context.Context, teamID string, params GetTeamScheduleParams) (api.TeamSchedule, error) {
+	query := url.Values{}
+	if params.Season != nil {
+		query.Set("season", *params.Season)
+	}
+	resp, err := c.do(ctx, http.MethodGet, "/teams/"+url.PathEscape(teamID)+"/games", query, nil, nil)
+	if err != nil {
+		return api.TeamSchedule{}, err
+	}
+	return decodeJSON[api.TeamSchedule](resp)
+}
+
+// GetTeamScheduleCalendarParams are the params of GetTeamScheduleCalendar
+type GetTeamScheduleCalendarParams struct {
+	// Season is the season param, the season's start year or the nba's format ex. 2024-25, defaults to the current season
+	Season *string
+}
+
+// GetTeamScheduleCalendar calls GET /teams/{teamID}/schedule.ics to get a team's schedule as an iCalendar feed
+func (c *Client) GetTeamScheduleCalendar(ctx context.Context, teamID string, params GetTeamScheduleCalendarParams) (io.ReadCloser, error) {
+	query := url.Values{}
+	if params.Season != nil {
+		query.Set("season", *params.Season)
+	}
+	resp, err := c.do(ctx, http.MethodGet, "/teams/"+url.PathEscape(teamID)+"/schedule.ics", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// GetWebhookSubscription calls GET /webhooks/{subscriptionID} to get a webhook subscription
+func (c *Client) GetWebhookSubscription(ctx context.Context, subscriptionID string) (api.WebhookSubscription, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/webhooks/"+url.PathEscape(subscriptionID), nil, nil, nil)
+	if err != nil {
+		return api.WebhookSubscription{}, err
+	}
+	return decodeJSON[api.WebhookSubscription](resp)
+}
+
+// ListFranchises calls GET /franchises to list franchises
+func (c *Client) ListFranchises(ctx context.Context) ([]api.Franchise, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/franchises", nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[[]api.Franchise](resp)
+}
+
+// ListGameBroadcasts calls GET /games/{gameID}/broadcasts to list a game's broadcasts
+func (c *Client) ListGameBroadcasts(ctx context.Context, gameID string) ([]api.GameBroadcast, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/games/"+url.PathEscape(gameID)+"/broadcasts", nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[[]api.GameBroadcast](resp)
+}
+
+// ListGameHistoryParams are the params of ListGameHistory
+type ListGameHistoryParams struct {
+	// Table is the table param, only changes to rows of the table
+	Table *string
+	// IngestRunID is the ingest-run-id param, only changes made by the ingest run
+	IngestRunID *string
+	// Before is the before param, only changes made before the time
+	Before *string
+	// Limit is the limit param, the most changes to return, at most 1000
+	Limit *int
+}
+
+// ListGameHistory calls GET /games/{gameID}/history to list changes to a Game's rows, newest first
+func (c *Client) ListGameHistory(ctx context.Context, gameID string, params ListGameHistoryParams) ([]api.History, error) {
+	query := url.Values{}
+	if params.Table != nil {
+		query.Set("table", *params.Table)
+	}
+	if params.IngestRunID != nil {
+		query.Set("ingest-run-id", *params.IngestRunID)
+	}
+	if params.Before != nil {
+		query.Set("before", *params.Before)
+	}
+	if params.Limit != nil {
+		query.Set("limit", fmt.Sprint(*params.Limit))
+	}
+	resp, err := c.do(ctx, http.MethodGet, "/games/"+url.PathEscape(gameID)+"/history", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[[]api.History](resp)
+}
+
+// ListGamesParams are the params of ListGames
+type ListGamesParams struct {
+	// Sort is the sort param, the field to sort by, prefixed with - for descending
+	Sort *string
+	// Cursor is the cursor param, the X-Next-Cursor of the previous page
+	Cursor *string
+	// Limit is the limit param, the most games to return, at most 1000
+	Limit *int
+	// Broadcaster is the broadcaster param, only games broadcast by the broadcaster
+	Broadcaster *string
+	// Date is the date param, only games on the day in eastern time
+	Date *string
+	// StartDate is the start-date param, only games on or after the day in eastern time
+	StartDate *string
+	// EndDate is the end-date param, only games on or before the day in eastern time
+	EndDate *string
+	// SeasonStartYear is the season-start-year param, only games in the season starting in the year
+	SeasonStartYear *int
+	// SeasonStage is the season-stage param, only games in the stage of the season
+	SeasonStage *string
+	// TeamID is the team-id param, only games the team played in
+	TeamID *string
+	// TeamSide is the team-side param, which side of the game team-id has to be on, only allowed with team-id
+	TeamSide *string
+	// Status is the status param, only games with the status
+	Status *string
+	// ArenaID is the arena-id param, only games played in the arena
+	ArenaID *string
+}
+
+// ListGamesResponse is the body and headers of ListGames's response
+type ListGamesResponse struct {
+	Body []api.Game
+	// NextCursor is the X-Next-Cursor header, the cursor of the next page, left out on the last page
+	NextCursor string
+}
+
+// ListGames calls GET /games to list games
+//
+// Games are listed a page at a time, the next page is listed by passing the X-Next-Cursor header as the cursor with the same sort
+func (c *Client) ListGames(ctx context.Context, params ListGamesParams) (ListGamesResponse, error) {
+	query := url.Values{}
+	if params.Sort != nil {
+		query.Set("sort", *params.Sort)
+	}
+	if params.Cursor != nil {
+		query.Set("cursor", *params.Cursor)
+	}
+	if params.Limit != nil {
+		query.Set("limit", fmt.Sprint(*params.Limit))
+	}
+	if params.Broadcaster != nil {
+		query.Set("broadcaster", *params.Broadcaster)
+	}
+	if params.Date != nil {
+		query.Set("date", *params.Date)
+	}
+	if params.StartDate != nil {
+		query.Set("start-date", *params.StartDate)
+	}
+	if params.EndDate != nil {
+		query.Set("end-date", *params.EndDate)
+	}
+	if params.SeasonStartYear != nil {
+		query.Set("season-start-year", fmt.Sprint(*params.SeasonStartYear))
+	}
+	if params.SeasonStage != nil {
+		query.Set("season-stage", *params.SeasonStage)
+	}
+	if params.TeamID != nil {
+		query.Set("team-id", *params.TeamID)
+	}
+	if params.TeamSide != nil {
+		query.Set("team-side", *params.TeamSide)
+	}
+	if params.Status != nil {
+		query.Set("status", *params.Status)
+	}
+	if params.ArenaID != nil {
+		query.Set("arena-id", *params.ArenaID)
+	}
+	resp, err := c.do(ctx, http.MethodGet, "/games", query, nil, nil)
+	if err != nil {
+		return ListGamesResponse{}, err
+	}
+	body, err := decodeJSON[[]api.Game](resp)
+	if err != nil {
+		return ListGamesResponse{}, err
+	}
+	return ListGamesResponse{
+		Body:       body,
+		NextCursor: resp.Header.Get("X-Next-Cursor"),
+	}, nil
+}
+
+// ListMilestonesParams are the params of ListMilestones
+type ListMilestonesParams struct {
+	// GameID is the game-id param, only milestones reached in the game
+	GameID *string
+	// TeamID is the team-id param, only milestones reached by the team
+	TeamID *string
+	// PlayerID is the player-id param, only milestones reached by the player
+	PlayerID *string
+	// Type is the type param, only milestones of the type
+	Type *string
+	// Limit is the limit param, the most milestones to return, at most 500
+	Limit *int
+}
+
+// ListMilestones calls GET /milestones to list milestones reached in games, newest first
+func (c *Client) ListMilestones(ctx context.Context, params ListMilestonesParams) ([]api.Milestone, error) {
+	query := url.Values{}
+	if params.GameID != nil {
+		query.Set("game-id", *params.GameID)
+	}
+	if params.TeamID != nil {
+		query.Set("team-id", *params.TeamID)
+	}
+	if params.PlayerID != nil {
+		query.Set("player-id", *params.PlayerID)
+	}
+	if params.Type != nil {
+		query.Set("type", *params.Type)
+	}
+	if params.Limit != nil {
+		query.Set("limit", fmt.Sprint(*params.Limit))
+	}
+	resp, err := c.do(ctx, http.MethodGet, "/milestones", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[[]api.Milestone](resp)
+}
+
+// ListPlayerHistoryParams are the params of ListPlayerHistory
+type ListPlayerHistoryParams struct {
+	// Table is the table param, only changes to rows of the table
+	Table *string
+	// IngestRunID is the ingest-run-id param, only changes made by the ingest run
+	IngestRunID *string
+	// Before is the before param, only changes made before the time
+	Before *string
+	// Limit is the limit param, the most changes to return, at most 1000
+	Limit *int
+}
+
+// ListPlayerHistory calls GET /players/{playerID}/history to list changes to a Player's rows, newest first
+func (c *Client) ListPlayerHistory(ctx context.Context, playerID string, params ListPlayerHistoryParams) ([]api.History, error) {
+	query := url.Values{}
+	if params.Table != nil {
+		query.Set("table", *params.Table)
+	}
+	if params.IngestRunID != nil {
+		query.Set("ingest-run-id", *params.IngestRunID)
+	}
+	if params.Before != nil {
+		query.Set("before", *params.Before)
+	}
+	if params.Limit != nil {
+		query.Set("limit", fmt.Sprint(*params.Limit))
+	}
+	resp, err := c.do(ctx, http.MethodGet, "/players/"+url.PathEscape(playerID)+"/history", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[[]api.History](resp)
+}
+
+// ListPlayers calls GET /players to list players
+func (c *Client) ListPlayers(ctx context.Context) ([]api.Player, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/players", nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[[]api.Player](resp)
+}
+
+// ListReferees calls GET /referees to list referees
+func (c *Client) ListReferees(ctx context.Context) ([]api.Referee, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/referees", nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[[]api.Referee](resp)
+}
+
+// ListStatCorrectionsParams are the params of ListStatCorrections
+type ListStatCorrectionsParams struct {
+	// GameID is the game-id param, only corrections to the game
+	GameID *string
+	// Entity is the entity param, only corrections to the entity
+	Entity *string
+	// Since is the since param, only corrections detected at or after the time
+	Since *string
+	// Limit is the limit param, the most corrections to return, at most 500
+	Limit *int
+}
+
+// ListStatCorrections calls GET /stat-corrections to list stat corrections, newest first
+func (c *Client) ListStatCorrections(ctx context.Context, params ListStatCorrectionsParams) ([]api.StatCorrection, error) {
+	query := url.Values{}
+	if params.GameID != nil {
+		query.Set("game-id", *params.GameID)
+	}
+	if params.Entity != nil {
+		query.Set("entity", *params.Entity)
+	}
+	if params.Since != nil {
+		query.Set("since", *params.Since)
+	}
+	if params.Limit != nil {
+		query.Set("limit", fmt.Sprint(*params.Limit))
+	}
+	resp, err := c.do(ctx, http.MethodGet, "/stat-corrections", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[[]api.StatCorrection](resp)
+}
+
+// ListTeamHistoryParams are the params of ListTeamHistory
+type ListTeamHistoryParams struct {
+	// Table is the table param, only changes to rows of the table
+	Table *string
+	// IngestRunID is the ingest-run-id param, only changes made by the ingest run
+	IngestRunID *string
+	// Before is the before param, only changes made before the time
+	Before *string
+	// Limit is the limit param, the most changes to return, at most 1000
+	Limit *int
+}
+
+// ListTeamHistory calls GET /teams/{teamID}/history to list changes to a Team's rows, newest first
+func (c *Client) ListTeamHistory(ctx context.Context, teamID string, params ListTeamHistoryParams) ([]api.History, error) {
+	query := url.Values{}
+	if params.Table != nil {
+		query.Set("table", *params.Table)
+	}
+	if params.IngestRunID != nil {
+		query.Set("ingest-run-id", *params.IngestRunID)
+	}
+	if params.Before != nil {
+		query.Set("before", *params.Before)
+	}
+	if params.Limit != nil {
+		query.Set("limit", fmt.Sprint(*params.Limit))
+	}
+	resp, err := c.do(ctx, http.MethodGet, "/teams/"+url.PathEscape(teamID)+"/history", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[[]api.History](resp)
+}
+
+// ListTeamScheduleContextsParams are the params of ListTeamScheduleContexts
+type ListTeamScheduleContextsParams struct {
+	// SeasonStartYear is the season-start-year param, only games in the season starting in the year
+	SeasonStartYear *int
+}
+
+// ListTeamScheduleContexts calls GET /teams/{teamID}/schedule-context to list the rest and travel context of a team's games
+func (c *Client) ListTeamScheduleContexts(ctx context.Context, teamID string, params ListTeamScheduleContextsParams) ([]api.ScheduleContext, error) {
+	query := url.Values{}
+	if params.SeasonStartYear != nil {
+		query.Set("season-start-year", fmt.Sprint(*params.SeasonStartYear))
+	}
+	resp, err := c.do(ctx, http.MethodGet, "/teams/"+url.PathEscape(teamID)+"/schedule-context", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[[]api.ScheduleContext](resp)
+}
+
+// ListTeams calls GET /teams to list teams
+func (c *Client) ListTeams(ctx context.Context) ([]api.Team, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/teams", nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[[]api.Team](resp)
+}
+
+// ListWebhookDeadLettersParams are the params of ListWebhookDeadLetters
+type ListWebhookDeadLettersParams struct {
+	// SubscriptionID is the subscription-id param, only deliveries to the subscription
+	SubscriptionID *string
+	// Limit is the limit param, the most deliveries to return, at most 1000
+	Limit *int
+}
+
+// ListWebhookDeadLetters calls GET /webhooks/dead-letters to list deliveries that ran out of attempts
+func (c *Client) ListWebhookDeadLetters(ctx context.Context, params ListWebhookDeadLettersParams) ([]api.WebhookDelivery, error) {
+	query := url.Values{}
+	if params.SubscriptionID != nil {
+		query.Set("subscription-id", *params.SubscriptionID)
+	}
+	if params.Limit != nil {
+		query.Set("limit", fmt.Sprint(*params.Limit))
+	}
+	resp, err := c.do(ctx, http.MethodGet, "/webhooks/dead-letters", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[[]api.WebhookDelivery](resp)
+}
+
+// ListWebhookDeliveriesParams are the params of ListWebhookDeliveries
+type ListWebhookDeliveriesParams struct {
+	// Status is the status param, only deliveries with the status
+	Status *string
+	// Limit is the limit param, the most deliveries to return, at most 1000
+	Limit *int
+}
+
+// ListWebhookDeliveries calls GET /webhooks/{subscriptionID}/deliveries to list a subscription's deliveries, newest first
+func (c *Client) ListWebhookDeliveries(ctx context.Context, subscriptionID string, params ListWebhookDeliveriesParams) ([]api.WebhookDelivery, error) {
+	query := url.Values{}
+	if params.Status != nil {
+		query.Set("status", *params.Status)
+	}
+	if params.Limit != nil {
+		query.Set("limit", fmt.Sprint(*params.Limit))
+	}
+	resp, err := c.do(ctx, http.MethodGet, "/webhooks/"+url.PathEscape(subscriptionID)+"/deliveries", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[[]api.WebhookDelivery](resp)
+}
+
+// ListWebhookSubscriptions calls GET /webhooks to list webhook subscriptions
+func (c *Client) ListWebhookSubscriptions(ctx context.Context) ([]api.WebhookSubscription, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/webhooks", nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[[]api.WebhookSubscription](resp)
+}
+
+// RetryWebhookDelivery calls POST /webhooks/deliveries/{deliveryID}/retry to queue a dead delivery to be attempted again
+func (c *Client) RetryWebhookDelivery(ctx context.Context, deliveryID string) (api.WebhookDelivery, error) {
+	resp, err := c.do(ctx, http.MethodPost, "/webhooks/deliveries/"+url.PathEscape(deliveryID)+"/retry", nil, nil, nil)
+	if err != nil {
+		return api.WebhookDelivery{}, err
+	}
+	return decodeJSON[api.WebhookDelivery](resp)
+}
+
+// StreamGameParams are the params of StreamGame
+type StreamGameParams struct {
+	// LastEventID is the last-event-id param, the id of the last event received to resume a stream from, takes precedence over the Last-Event-ID header
+	LastEventID *string
+	// LastEventIDHeader is the Last-Event-ID header, the id of the last event received, sent by browsers when reconnecting
+	LastEventIDHeader *string
+}
+
+// StreamGame calls GET /games/{gameID}/live to stream a game's events as they happen
+//
+// Events are sent as server-sent events, or over a websocket when the request asks to upgrade. Each event's data is a LiveGameEvent
+func (c *Client) StreamGame(ctx context.Context, gameID string, params StreamGameParams) (io.ReadCloser, error) {
+	query := url.Values{}
+	if params.LastEventID != nil {
+		query.Set("last-event-id", *params.LastEventID)
+	}
+	header := http.Header{}
+	if params.LastEventIDHeader != nil {
+		header.Set("Last-Event-ID", *params.LastEventIDHeader)
+	}
+	resp, err := c.do(ctx, http.MethodGet, "/games/"+url.PathEscape(gameID)+"/live", query, header, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// SweepStatCorrectionsParams are the params of SweepStatCorrections
+type SweepStatCorrectionsParams struct {
+	// WindowHours is the window-hours param, how many hours back finished games are re-ingested
+	WindowHours *int
+}
+
+// SweepStatCorrections calls POST /stat-corrections/sweep to re-ingest recently finished games to detect stat corrections
+func (c *Client) SweepStatCorrections(ctx context.Context, params SweepStatCorrectionsParams) ([]api.StatCorrection, error) {
+	query := url.Values{}
+	if params.WindowHours != nil {
+		query.Set("window-hours", fmt.Sprint(*params.WindowHours))
+	}
+	resp, err := c.do(ctx, http.MethodPost, "/stat-corrections/sweep", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[[]api.StatCorrection](resp)
+}
+
+// UpdateFranchises calls POST /franchises/update to ingest franchises from the nba
+func (c *Client) UpdateFranchises(ctx context.Context) ([]api.Franchise, error) {
+	resp, err := c.do(ctx, http.MethodPost, "/franchises/update", nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[[]api.Franchise](resp)
+}
+
+// UpdateGameParams are the params of UpdateGame
+type UpdateGameParams struct {
+	// GameID is the game-id param, the nba's id of the game
+	GameID string
+	// SeasonStartYear is the season-start-year param, the game's season start year
+	SeasonStartYear int
+}
+
+// UpdateGame calls POST /games/updateGame to ingest a game from the nba
+func (c *Client) UpdateGame(ctx context.Context, params UpdateGameParams) (api.Game, error) {
+	query := url.Values{}
+	query.Set("game-id", params.GameID)
+	query.Set("season-start-year", fmt.Sprint(params.SeasonStartYear))
+	resp, err := c.do(ctx, http.MethodPost, "/games/updateGame", query, nil, nil)
+	if err != nil {
+		return api.Game{}, err
+	}
+	return decodeJSON[api.Game](resp)
+}
+
+// UpdatePlayersParams are the params of UpdatePlayers
+type UpdatePlayersParams struct {
+	// SeasonStartYear is the season-start-year param, the season's start year
+	SeasonStartYear int
+}
+
+// UpdatePlayers calls POST /players/update to ingest a season's players from the nba
+func (c *Client) UpdatePlayers(ctx context.Context, params UpdatePlayersParams) ([]api.Player, error) {
+	query := url.Values{}
+	query.Set("season-start-year", fmt.Sprint(params.SeasonStartYear))
+	resp, err := c.do(ctx, http.MethodPost, "/players/update", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[[]api.Player](resp)
+}
+
+// UpdateSeasonGamesParams are the params of UpdateSeasonGames
+type UpdateSeasonGamesParams struct {
+	// SeasonStartYear is the season-start-year param, the season's start year
+	SeasonStartYear int
+}
+
+// UpdateSeasonGames calls POST /games/update to ingest every game of a season from the nba
+func (c *Client) UpdateSeasonGames(ctx context.Context, params UpdateSeasonGamesParams) ([]api.Game, error) {
+	query := url.Values{}
+	query.Set("season-start-year", fmt.Sprint(params.SeasonStartYear))
+	resp, err := c.do(ctx, http.MethodPost, "/games/update", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[[]api.Game](resp)
+}
+
+// UpdateTeamsParams are the params of UpdateTeams
+type UpdateTeamsParams struct {
+	// SeasonStartYear is the season-start-year param, the season's start year
+	SeasonStartYear int
+}
+
+// UpdateTeams calls POST /teams/update to ingest a season's teams from the nba
+func (c *Client) UpdateTeams(ctx context.Context, params UpdateTeamsParams) ([]api.Team, error) {
+	query := url.Values{}
+	query.Set("season-start-year", fmt.Sprint(params.SeasonStartYear))
+	resp, err := c.do(ctx, http.MethodPost, "/teams/update", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON[[]api.Team](resp)
+}
@@ -0,0 +1,131 @@
+// Package client is a Go client of the api. The methods are generated from the api's openapi spec, run go generate
+// after changing a route
+package client
+
+//go:generate go run ../../cmd/openapi_client -out client.gen.go -package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+)
+
+// maxErrorBodySize bounds how much of an error response is read into the error's message
+const maxErrorBodySize = 64 << 10
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	apiKey     string
+}
+
+type ClientOption func(c *Client)
+
+// WithAPIKey authenticates every request with the api key, every route but the spec requires one
+func WithAPIKey(apiKey string) ClientOption {
+	return func(c *Client) {
+		c.apiKey = apiKey
+	}
+}
+
+// NewClient creates a client of the api served at baseURL ex. http://localhost:3333
+func NewClient(baseURL string, httpClient *http.Client, options ...ClientOption) *Client {
+	c := &Client{baseURL: strings.TrimSuffix(baseURL, "/"), httpClient: httpClient}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// Error is returned when the api responds with a status that isn't successful
+type Error struct {
+	StatusCode int
+	// Problem is the problem+json body of the response, responses that aren't one have their body as its detail
+	Problem api.Problem
+}
+
+func (e *Error) Error() string {
+	if e.Problem.TraceID != "" {
+		return fmt.Sprintf("api responded with %d: %s (trace id %s)", e.StatusCode, e.Problem.Detail, e.Problem.TraceID)
+	}
+	return fmt.Sprintf("api responded with %d: %s", e.StatusCode, e.Problem.Detail)
+}
+
+// do sends a request returning the response when it's successful, otherwise an *Error
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, header http.Header, body any) (*http.Response, error) {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var bodyReader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send %s %s: %w", method, path, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer close(resp)
+
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		problem := api.Problem{}
+		if err := json.Unmarshal(b, &problem); err != nil || problem.Status == 0 {
+			problem = api.Problem{Title: http.StatusText(resp.StatusCode), Status: resp.StatusCode, Detail: string(b)}
+		}
+
+		return nil, &Error{StatusCode: resp.StatusCode, Problem: problem}
+	}
+
+	return resp, nil
+}
+
+func decodeJSON[T any](resp *http.Response) (T, error) {
+	defer close(resp)
+
+	var v T
+	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+		return v, fmt.Errorf("failed to decode %s %s response: %w", resp.Request.Method, resp.Request.URL.Path, err)
+	}
+
+	return v, nil
+}
+
+// close drains and closes the body so the connection can be reused
+func close(resp *http.Response) {
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
+
+// Ptr returns a pointer to v for setting optional params ex. ListGamesParams{Limit: client.Ptr(10)}
+func Ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,173 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned by Load when the batch func didn't return a value for the key
+var ErrNotFound = errors.New("no value loaded for key")
+
+const (
+	DefaultWait     = 2 * time.Millisecond
+	DefaultMaxBatch = 500
+)
+
+// BatchFunc loads the values of many keys at once, keys without a value are left out of the map
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+type result[V any] struct {
+	value V
+	err   error
+	// done is closed once value and err are set
+	done chan struct{}
+}
+
+type batch[K comparable, V any] struct {
+	keys    []K
+	results map[K]*result[V]
+	timer   *time.Timer
+}
+
+// Loader batches the keys loaded within a short window into one call to its batch func and caches each key's result.
+// A loader is meant to live for a single request so its cache never goes stale
+type Loader[K comparable, V any] struct {
+	batchFunc BatchFunc[K, V]
+	wait      time.Duration
+	maxBatch  int
+
+	mu    sync.Mutex
+	cache map[K]*result[V]
+	batch *batch[K, V]
+}
+
+// New creates a loader that waits up to wait after the first key of a batch for more keys, dispatching early once
+// maxBatch keys are waiting
+func New[K comparable, V any](batchFunc BatchFunc[K, V], wait time.Duration, maxBatch int) *Loader[K, V] {
+	return &Loader[K, V]{
+		batchFunc: batchFunc,
+		wait:      wait,
+		maxBatch:  maxBatch,
+		cache:     map[K]*result[V]{},
+	}
+}
+
+// Load returns the value for a key, waiting for the batch it's in to be loaded
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	r := l.enqueue(ctx, key)
+
+	select {
+	case <-r.done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// LoadMany returns the values for keys in the same order, keys without a value are left out
+func (l *Loader[K, V]) LoadMany(ctx context.Context, keys []K) ([]V, error) {
+	results := make([]*result[V], 0, len(keys))
+	for _, key := range keys {
+		results = append(results, l.enqueue(ctx, key))
+	}
+
+	values := make([]V, 0, len(keys))
+	for _, r := range results {
+		select {
+		case <-r.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		if errors.Is(r.err, ErrNotFound) {
+			continue
+		}
+		if r.err != nil {
+			return nil, r.err
+		}
+		values = append(values, r.value)
+	}
+
+	return values, nil
+}
+
+func (l *Loader[K, V]) enqueue(ctx context.Context, key K) *result[V] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if r, ok := l.cache[key]; ok {
+		return r
+	}
+
+	r := &result[V]{done: make(chan struct{})}
+	l.cache[key] = r
+
+	if l.batch == nil {
+		b := &batch[K, V]{results: map[K]*result[V]{}}
+		// the batch is loaded without the caller's cancellation since other callers are waiting on it too
+		batchCtx := context.WithoutCancel(ctx)
+		b.timer = time.AfterFunc(l.wait, func() { l.dispatch(batchCtx, b) })
+		l.batch = b
+	}
+
+	l.batch.keys = append(l.batch.keys, key)
+	l.batch.results[key] = r
+
+	if len(l.batch.keys) >= l.maxBatch {
+		b := l.batch
+		l.batch = nil
+		// when the timer already fired its dispatch is waiting on the lock and will load the batch
+		if b.timer.Stop() {
+			go l.dispatch(context.WithoutCancel(ctx), b)
+		}
+	}
+
+	return r
+}
+
+func (l *Loader[K, V]) dispatch(ctx context.Context, b *batch[K, V]) {
+	l.mu.Lock()
+	if l.batch == b {
+		l.batch = nil
+	}
+	l.mu.Unlock()
+
+	values, err := l.load(ctx, b.keys)
+
+	for key, r := range b.results {
+		if err != nil {
+			r.err = err
+		} else if v, ok := values[key]; ok {
+			r.value = v
+		} else {
+			r.err = ErrNotFound
+		}
+		close(r.done)
+	}
+
+	// a failed batch isn't cached so the keys can be loaded again
+	if err != nil {
+		l.mu.Lock()
+		for key, r := range b.results {
+			if l.cache[key] == r {
+				delete(l.cache, key)
+			}
+		}
+		l.mu.Unlock()
+	}
+}
+
+// load calls the batch func, turning a panic into an error so the keys waiting on the batch are still released
+func (l *Loader[K, V]) load(ctx context.Context, keys []K) (values map[K]V, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			values, err = nil, fmt.Errorf("dataloader batch func panicked: %v", p)
+		}
+	}()
+
+	return l.batchFunc(ctx, keys)
+}
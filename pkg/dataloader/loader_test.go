@@ -0,0 +1,122 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLoaderBatchesKeys(t *testing.T) {
+	var mu sync.Mutex
+	batches := [][]int{}
+	l := New(func(ctx context.Context, keys []int) (map[int]string, error) {
+		mu.Lock()
+		batches = append(batches, keys)
+		mu.Unlock()
+
+		values := map[int]string{}
+		for _, key := range keys {
+			if key != 3 {
+				values[key] = strings.Repeat("x", key)
+			}
+		}
+		return values, nil
+	}, 10*time.Millisecond, 100)
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for key := range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := l.Load(ctx, key)
+			if key == 3 {
+				if !errors.Is(err, ErrNotFound) {
+					t.Errorf("key 3 got error %v, want ErrNotFound", err)
+				}
+				return
+			}
+			if err != nil || v != strings.Repeat("x", key) {
+				t.Errorf("key %d got %q, %v", key, v, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	values, err := l.LoadMany(ctx, []int{4, 3, 1})
+	if err != nil {
+		t.Fatalf("failed to load many: %v", err)
+	}
+	if len(values) != 2 || values[0] != "xxxx" || values[1] != "x" {
+		t.Errorf("got %q, want the values of keys 4 and 1", values)
+	}
+
+	// the cached keys are never loaded again
+	if len(batches) != 1 || len(batches[0]) != 5 {
+		t.Errorf("got batches %v, want one batch of 5 keys", batches)
+	}
+}
+
+func TestLoaderMaxBatch(t *testing.T) {
+	var mu sync.Mutex
+	sizes := []int{}
+	l := New(func(ctx context.Context, keys []int) (map[int]int, error) {
+		mu.Lock()
+		sizes = append(sizes, len(keys))
+		mu.Unlock()
+
+		values := map[int]int{}
+		for _, key := range keys {
+			values[key] = key
+		}
+		return values, nil
+	}, time.Hour, 3)
+
+	keys := []int{1, 2, 3, 4, 5, 6}
+	values, err := l.LoadMany(context.Background(), keys)
+	if err != nil {
+		t.Fatalf("failed to load many: %v", err)
+	}
+	if len(values) != len(keys) {
+		t.Errorf("got %v, want %v", values, keys)
+	}
+	if len(sizes) != 2 || sizes[0] != 3 || sizes[1] != 3 {
+		t.Errorf("got batch sizes %v, want two batches of 3", sizes)
+	}
+}
+
+func TestLoaderBatchFuncPanic(t *testing.T) {
+	calls := 0
+	l := New(func(ctx context.Context, keys []int) (map[int]int, error) {
+		calls++
+		if calls == 1 {
+			panic("boom")
+		}
+		return map[int]int{1: 1}, nil
+	}, time.Millisecond, 100)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for key := range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := l.Load(ctx, key)
+			if err == nil || !strings.Contains(err.Error(), "panicked: boom") {
+				t.Errorf("key %d got error %v, want the panic", key, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// the failed batch isn't cached so the key loads once the batch func recovers
+	v, err := l.Load(ctx, 1)
+	if err != nil || v != 1 {
+		t.Errorf("got %d, %v after the panic, want 1", v, err)
+	}
+}
@@ -0,0 +1,171 @@
+package graphql
+
+// Location is a 1-indexed line and column in a query document
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// document is a parsed query, only executable definitions are supported
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	operationType string
+	name          string
+	variables     []*variableDefinition
+	directives    []*directive
+	selectionSet  []selection
+	loc           Location
+}
+
+type variableDefinition struct {
+	name         string
+	typ          *typeRef
+	defaultValue value
+	loc          Location
+}
+
+// typeRef is a type written in a query, either a named type or a list of elem, optionally non null
+type typeRef struct {
+	name    string
+	elem    *typeRef
+	nonNull bool
+}
+
+func (t *typeRef) String() string {
+	s := t.name
+	if t.elem != nil {
+		s = "[" + t.elem.String() + "]"
+	}
+	if t.nonNull {
+		s += "!"
+	}
+	return s
+}
+
+type selection interface {
+	location() Location
+}
+
+type field struct {
+	alias        string
+	name         string
+	arguments    []*argument
+	directives   []*directive
+	selectionSet []selection
+	loc          Location
+}
+
+func (f *field) location() Location { return f.loc }
+
+// responseKey is the key the field's value is returned under
+func (f *field) responseKey() string {
+	if f.alias != "" {
+		return f.alias
+	}
+	return f.name
+}
+
+type fragmentSpread struct {
+	name       string
+	directives []*directive
+	loc        Location
+}
+
+func (f *fragmentSpread) location() Location { return f.loc }
+
+type inlineFragment struct {
+	typeCondition string
+	directives    []*directive
+	selectionSet  []selection
+	loc           Location
+}
+
+func (f *inlineFragment) location() Location { return f.loc }
+
+type fragment struct {
+	name          string
+	typeCondition string
+	directives    []*directive
+	selectionSet  []selection
+	loc           Location
+}
+
+type argument struct {
+	name  string
+	value value
+	loc   Location
+}
+
+type directive struct {
+	name      string
+	arguments []*argument
+	loc       Location
+}
+
+// value is an input value literal in a query
+type value interface {
+	location() Location
+}
+
+type variableValue struct {
+	name string
+	loc  Location
+}
+
+type intValue struct {
+	raw string
+	loc Location
+}
+
+type floatValue struct {
+	raw string
+	loc Location
+}
+
+type stringValue struct {
+	value string
+	loc   Location
+}
+
+type booleanValue struct {
+	value bool
+	loc   Location
+}
+
+type nullValue struct {
+	loc Location
+}
+
+type enumValue struct {
+	value string
+	loc   Location
+}
+
+type listValue struct {
+	values []value
+	loc    Location
+}
+
+type objectValue struct {
+	fields []*objectField
+	loc    Location
+}
+
+type objectField struct {
+	name  string
+	value value
+}
+
+func (v *variableValue) location() Location { return v.loc }
+func (v *intValue) location() Location      { return v.loc }
+func (v *floatValue) location() Location    { return v.loc }
+func (v *stringValue) location() Location   { return v.loc }
+func (v *booleanValue) location() Location  { return v.loc }
+func (v *nullValue) location() Location     { return v.loc }
+func (v *enumValue) location() Location     { return v.loc }
+func (v *listValue) location() Location     { return v.loc }
+func (v *objectValue) location() Location   { return v.loc }
@@ -0,0 +1,31 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Error is a graphql error as returned in a response's errors. Path is the response keys and list indexes of the field
+// the error happened resolving, it's empty for errors in the query itself
+type Error struct {
+	Message   string     `json:"message"`
+	Locations []Location `json:"locations,omitempty"`
+	Path      []any      `json:"path,omitempty"`
+}
+
+func (e *Error) Error() string {
+	var sb strings.Builder
+	sb.WriteString(e.Message)
+	for _, loc := range e.Locations {
+		fmt.Fprintf(&sb, " (%d:%d)", loc.Line, loc.Column)
+	}
+	return sb.String()
+}
+
+func syntaxError(loc Location, message string) *Error {
+	return &Error{Message: "syntax error: " + message, Locations: []Location{loc}}
+}
+
+func queryError(loc Location, format string, args ...any) *Error {
+	return &Error{Message: fmt.Sprintf(format, args...), Locations: []Location{loc}}
+}
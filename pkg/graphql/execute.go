@@ -0,0 +1,517 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Request is a graphql request as sent in a POST body or GET query params
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Response is the result of executing a request. Data is left out when the request failed before it could be executed
+// and is null when a non null field on the query type couldn't be resolved
+type Response struct {
+	Data   any      `json:"data,omitempty"`
+	Errors []*Error `json:"errors,omitempty"`
+}
+
+// maxConcurrentResolvers is how many goroutines a query resolves fields on at once, fields past the limit are resolved
+// on the goroutine that reached them
+const maxConcurrentResolvers = 64
+
+// Execute parses, validates and executes a query. Fields are resolved concurrently, up to maxConcurrentResolvers at a
+// time, so resolvers that load by key, such as dataloaders, see the keys of every item in a list together
+func (s *Schema) Execute(ctx context.Context, req Request) Response {
+	doc, err := parse(req.Query)
+	if err != nil {
+		return Response{Errors: []*Error{toError(err)}}
+	}
+
+	op, err := doc.operation(req.OperationName)
+	if err != nil {
+		return Response{Errors: []*Error{toError(err)}}
+	}
+
+	if errs := s.validate(doc, op); len(errs) > 0 {
+		return Response{Errors: errs}
+	}
+
+	variables, errs := s.coerceVariables(op, req.Variables)
+	if len(errs) > 0 {
+		return Response{Errors: errs}
+	}
+
+	e := &executor{schema: s, doc: doc, variables: variables, sem: make(chan struct{}, maxConcurrentResolvers)}
+
+	data, errored := e.executeFields(ctx, s.Query, nil, e.collectFields(s.Query, op.selectionSet), nil)
+
+	resp := Response{Data: data, Errors: e.errors}
+	if errored {
+		resp.Data = json.RawMessage("null")
+	}
+	return resp
+}
+
+func toError(err error) *Error {
+	if graphqlErr, ok := err.(*Error); ok {
+		return graphqlErr
+	}
+	return &Error{Message: err.Error()}
+}
+
+func (d *document) operation(name string) (*operation, error) {
+	if name == "" {
+		if len(d.operations) > 1 {
+			return nil, &Error{Message: "operationName is required when the document has more than one operation"}
+		}
+		return d.operations[0], nil
+	}
+
+	for _, op := range d.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, &Error{Message: fmt.Sprintf("unknown operation named %q", name)}
+}
+
+func (s *Schema) coerceVariables(op *operation, values map[string]any) (map[string]any, []*Error) {
+	variables := map[string]any{}
+	errs := []*Error{}
+
+	for _, def := range op.variables {
+		// the type was checked during validation
+		t, _ := s.inputType(def.typ)
+
+		v, ok := values[def.name]
+		if !ok {
+			if def.defaultValue != nil {
+				v, err := coerceLiteral(t, def.defaultValue, nil)
+				if err != nil {
+					errs = append(errs, queryError(def.loc, "variable $%s has an invalid default value: %s", def.name, err))
+				}
+				variables[def.name] = v
+			} else if _, nonNull := t.(*NonNull); nonNull {
+				errs = append(errs, queryError(def.loc, "variable $%s of required type %s was not provided", def.name, t))
+			}
+			continue
+		}
+
+		coerced, err := coerceInput(t, v)
+		if err != nil {
+			errs = append(errs, queryError(def.loc, "variable $%s got an invalid value: %s", def.name, err))
+			continue
+		}
+		variables[def.name] = coerced
+	}
+
+	return variables, errs
+}
+
+type executor struct {
+	schema    *Schema
+	doc       *document
+	variables map[string]any
+	// sem holds a slot for every goroutine resolving fields
+	sem chan struct{}
+
+	mu     sync.Mutex
+	errors []*Error
+}
+
+func (e *executor) addError(f *field, path []any, message string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.errors = append(e.errors, &Error{Message: message, Locations: []Location{f.loc}, Path: path})
+}
+
+// spawn runs fn on a new goroutine when a slot is free and on the calling goroutine otherwise. Running inline rather
+// than waiting for a slot means fields can't deadlock waiting on slots held by their parents
+func (e *executor) spawn(wg *sync.WaitGroup, fn func()) {
+	select {
+	case e.sem <- struct{}{}:
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-e.sem
+				wg.Done()
+			}()
+			fn()
+		}()
+	default:
+		fn()
+	}
+}
+
+// fieldGroup is the fields returned under the same response key, their selection sets are merged
+type fieldGroup struct {
+	key    string
+	fields []*field
+}
+
+// collectFields flattens fragments and drops skipped fields, keeping fields in the order they first appear
+func (e *executor) collectFields(parent *Object, selections []selection) []*fieldGroup {
+	groups := []*fieldGroup{}
+	byKey := map[string]*fieldGroup{}
+	visited := map[string]bool{}
+
+	var collect func(selections []selection)
+	collect = func(selections []selection) {
+		for _, sel := range selections {
+			switch sel := sel.(type) {
+			case *field:
+				if !e.shouldInclude(sel.directives) {
+					continue
+				}
+				key := sel.responseKey()
+				group, ok := byKey[key]
+				if !ok {
+					group = &fieldGroup{key: key}
+					byKey[key] = group
+					groups = append(groups, group)
+				}
+				group.fields = append(group.fields, sel)
+			case *fragmentSpread:
+				if visited[sel.name] || !e.shouldInclude(sel.directives) {
+					continue
+				}
+				visited[sel.name] = true
+				collect(e.doc.fragments[sel.name].selectionSet)
+			case *inlineFragment:
+				if !e.shouldInclude(sel.directives) {
+					continue
+				}
+				collect(sel.selectionSet)
+			}
+		}
+	}
+
+	collect(selections)
+
+	return groups
+}
+
+func (e *executor) shouldInclude(directives []*directive) bool {
+	for _, d := range directives {
+		// the directives and their arguments were checked during validation
+		v, _ := coerceLiteral(directiveArguments[0].Type, d.arguments[0].value, e.variables)
+		condition, _ := v.(bool)
+		if d.name == "skip" && condition || d.name == "include" && !condition {
+			return false
+		}
+	}
+	return true
+}
+
+// executeFields resolves the fields of an object. errored is true when a non null field couldn't be resolved, which
+// makes the whole object null
+func (e *executor) executeFields(ctx context.Context, parent *Object, source any, groups []*fieldGroup, path []any) (result *orderedMap, errored bool) {
+	result = &orderedMap{keys: make([]string, len(groups)), values: make([]any, len(groups))}
+	failed := make([]bool, len(groups))
+
+	var wg sync.WaitGroup
+	for i, group := range groups {
+		result.keys[i] = group.key
+
+		def := parent.Field(group.fields[0].name)
+		if def == nil || def.Resolve == nil {
+			// typename and fields read from the source are cheap enough to not need their own goroutine
+			result.values[i], failed[i] = e.executeField(ctx, parent, def, source, group, path)
+			continue
+		}
+
+		e.spawn(&wg, func() {
+			result.values[i], failed[i] = e.executeField(ctx, parent, def, source, group, path)
+		})
+	}
+	wg.Wait()
+
+	if slices.Contains(failed, true) {
+		return nil, true
+	}
+	return result, false
+}
+
+// executeField resolves a field and completes its value, failed is true when the field is null but non null
+func (e *executor) executeField(ctx context.Context, parent *Object, def *Field, source any, group *fieldGroup, path []any) (v any, failed bool) {
+	f := group.fields[0]
+	fieldPath := append(slices.Clip(path), group.key)
+
+	if f.name == "__typename" {
+		return parent.Name, false
+	}
+
+	args, err := e.coerceArguments(def.Args, f.arguments)
+	if err != nil {
+		e.addError(f, fieldPath, err.Error())
+		return nil, isNonNull(def.Type)
+	}
+
+	resolved, err := e.resolve(ctx, def, source, args)
+	if err != nil {
+		e.addError(f, fieldPath, err.Error())
+		return nil, isNonNull(def.Type)
+	}
+
+	v, _ = e.completeValue(ctx, def.Type, group, resolved, fieldPath)
+	return v, v == nil && isNonNull(def.Type)
+}
+
+func (e *executor) resolve(ctx context.Context, def *Field, source any, args map[string]any) (v any, err error) {
+	if def.Resolve == nil {
+		return defaultResolve(source, def.Name)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("internal error resolving field %s: %v", def.Name, r)
+		}
+	}()
+
+	return def.Resolve(ctx, ResolveParams{Source: source, Args: args})
+}
+
+func (e *executor) coerceArguments(defs []*Argument, args []*argument) (map[string]any, error) {
+	coerced := make(map[string]any, len(defs))
+
+	for _, def := range defs {
+		i := slices.IndexFunc(args, func(arg *argument) bool { return arg.name == def.Name })
+		if i == -1 {
+			if def.DefaultValue != nil {
+				coerced[def.Name] = def.DefaultValue
+			}
+			continue
+		}
+
+		// an argument set to a variable that wasn't provided is treated as not set
+		if variable, ok := args[i].value.(*variableValue); ok {
+			if _, ok := e.variables[variable.name]; !ok {
+				if def.DefaultValue != nil {
+					coerced[def.Name] = def.DefaultValue
+				} else if isNonNull(def.Type) {
+					return nil, fmt.Errorf("argument %q of type %s is required but variable $%s was not provided", def.Name, def.Type, variable.name)
+				}
+				continue
+			}
+		}
+
+		v, err := coerceLiteral(def.Type, args[i].value, e.variables)
+		if err != nil {
+			return nil, fmt.Errorf("argument %q has an invalid value: %w", def.Name, err)
+		}
+		coerced[def.Name] = v
+	}
+
+	return coerced, nil
+}
+
+// completeValue converts a resolved value to the field's type. errored is true when the value is null because of an
+// error that was already added, so a non null type doesn't add another
+func (e *executor) completeValue(ctx context.Context, t Type, group *fieldGroup, resolved any, path []any) (v any, errored bool) {
+	if nonNull, ok := t.(*NonNull); ok {
+		v, errored := e.completeValue(ctx, nonNull.OfType, group, resolved, path)
+		if v == nil {
+			if !errored {
+				e.addError(group.fields[0], path, fmt.Sprintf("cannot return null for non null field of type %s", t))
+			}
+			return nil, true
+		}
+		return v, false
+	}
+
+	resolved, ok := deref(resolved)
+	if !ok {
+		return nil, false
+	}
+
+	switch t := t.(type) {
+	case *Scalar:
+		serialized, err := t.Serialize(resolved)
+		if err != nil {
+			e.addError(group.fields[0], path, err.Error())
+			return nil, true
+		}
+		return serialized, false
+	case *Object:
+		subfields := []selection{}
+		for _, f := range group.fields {
+			subfields = append(subfields, f.selectionSet...)
+		}
+		result, errored := e.executeFields(ctx, t, resolved, e.collectFields(t, subfields), path)
+		if errored {
+			return nil, true
+		}
+		return result, false
+	case *List:
+		return e.completeList(ctx, t, group, resolved, path)
+	default:
+		e.addError(group.fields[0], path, fmt.Sprintf("unsupported type %s", t))
+		return nil, true
+	}
+}
+
+func (e *executor) completeList(ctx context.Context, t *List, group *fieldGroup, resolved any, path []any) (any, bool) {
+	list := reflect.ValueOf(resolved)
+	if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+		e.addError(group.fields[0], path, fmt.Sprintf("expected a list for field of type %s, got %T", t, resolved))
+		return nil, true
+	}
+
+	items := make([]any, list.Len())
+	failed := make([]bool, list.Len())
+
+	complete := func(i int) {
+		itemPath := append(slices.Clip(path), i)
+		items[i], _ = e.completeValue(ctx, t.OfType, group, list.Index(i).Interface(), itemPath)
+		failed[i] = items[i] == nil && isNonNull(t.OfType)
+	}
+
+	// objects are completed concurrently so the fields of every item are resolved together
+	if _, ok := namedType(t.OfType).(*Object); ok {
+		var wg sync.WaitGroup
+		for i := range items {
+			e.spawn(&wg, func() { complete(i) })
+		}
+		wg.Wait()
+	} else {
+		for i := range items {
+			complete(i)
+		}
+	}
+
+	if isNonNull(t.OfType) && slices.Contains(failed, true) {
+		return nil, true
+	}
+	return items, false
+}
+
+func isNonNull(t Type) bool {
+	_, ok := t.(*NonNull)
+	return ok
+}
+
+// deref follows pointers and interfaces, ok is false when the value is nil. nil slices are kept as empty lists
+func deref(v any) (any, bool) {
+	if v == nil {
+		return nil, false
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, false
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() == reflect.Map && rv.IsNil() {
+		return nil, false
+	}
+
+	return rv.Interface(), true
+}
+
+type structFieldKey struct {
+	t    reflect.Type
+	name string
+}
+
+// structFields caches the index of the struct field each graphql field is read from
+var structFields sync.Map
+
+// defaultResolve reads a field from the source, a map keyed by field name or a struct with a field whose json name is
+// the snake case of the field name ex. homeTeamId is read from a field tagged json:"home_team_id"
+func defaultResolve(source any, name string) (any, error) {
+	source, ok := deref(source)
+	if !ok {
+		return nil, nil
+	}
+
+	if m, ok := source.(map[string]any); ok {
+		return m[name], nil
+	}
+
+	rv := reflect.ValueOf(source)
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("can't read field %s from %T", name, source)
+	}
+
+	key := structFieldKey{t: rv.Type(), name: name}
+	index, ok := structFields.Load(key)
+	if !ok {
+		index = structFieldIndex(rv.Type(), name)
+		structFields.Store(key, index)
+	}
+
+	if index.([]int) == nil {
+		return nil, fmt.Errorf("%T has no field for %s", source, name)
+	}
+	return rv.FieldByIndex(index.([]int)).Interface(), nil
+}
+
+func structFieldIndex(t reflect.Type, name string) []int {
+	jsonName := snakeCase(name)
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		tagName, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if tagName == jsonName || (tagName == "" && strings.EqualFold(f.Name, name)) {
+			return f.Index
+		}
+	}
+	return nil
+}
+
+func snakeCase(name string) string {
+	var sb strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				sb.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// orderedMap is an object in the response, json objects are written with their fields in the order they were queried
+type orderedMap struct {
+	keys   []string
+	values []any
+}
+
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		v, err := json.Marshal(m.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,318 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testTeam struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	City string `json:"city"`
+	Wins int    `json:"wins"`
+}
+
+type testPlayer struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	TeamID string `json:"team_id"`
+}
+
+var testTeams = map[string]testTeam{
+	"1": {ID: "1", Name: "Timberwolves", City: "Minnesota", Wins: 56},
+	"2": {ID: "2", Name: "Nuggets", City: "Denver", Wins: 57},
+}
+
+var testPlayers = []testPlayer{
+	{ID: "10", Name: "Anthony Edwards", TeamID: "1"},
+	{ID: "11", Name: "Rudy Gobert", TeamID: "1"},
+	{ID: "20", Name: "Nikola Jokic", TeamID: "2"},
+}
+
+// newTestSchema is a small schema of teams and players with fields that fail in each of the ways a resolver can
+func newTestSchema(t *testing.T) *Schema {
+	t.Helper()
+
+	team := &Object{Name: "Team"}
+	player := &Object{Name: "Player"}
+
+	team.Fields = []*Field{
+		{Name: "id", Type: &NonNull{OfType: ID}},
+		{Name: "name", Type: &NonNull{OfType: String}},
+		{Name: "city", Type: String},
+		{Name: "wins", Type: Int},
+		{
+			Name: "players",
+			Type: &NonNull{OfType: &List{OfType: &NonNull{OfType: player}}},
+			Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+				teamID := p.Source.(testTeam).ID
+				players := []testPlayer{}
+				for _, player := range testPlayers {
+					if player.TeamID == teamID {
+						players = append(players, player)
+					}
+				}
+				return players, nil
+			},
+		},
+	}
+	player.Fields = []*Field{
+		{Name: "id", Type: &NonNull{OfType: ID}},
+		{Name: "name", Type: &NonNull{OfType: String}},
+		{
+			Name: "team",
+			Type: team,
+			Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+				t, ok := testTeams[p.Source.(testPlayer).TeamID]
+				if !ok {
+					return nil, nil
+				}
+				return t, nil
+			},
+		},
+	}
+
+	query := &Object{
+		Name: "Query",
+		Fields: []*Field{
+			{
+				Name: "team",
+				Type: team,
+				Args: []*Argument{{Name: "id", Type: &NonNull{OfType: ID}}},
+				Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+					t, ok := testTeams[p.Args["id"].(string)]
+					if !ok {
+						return nil, nil
+					}
+					return t, nil
+				},
+			},
+			{
+				Name: "players",
+				Type: &NonNull{OfType: &List{OfType: &NonNull{OfType: player}}},
+				Args: []*Argument{{Name: "limit", Type: Int, DefaultValue: 10}},
+				Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+					limit := p.Args["limit"].(int)
+					return testPlayers[:min(limit, len(testPlayers))], nil
+				},
+			},
+			{
+				Name: "failing",
+				Type: String,
+				Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+					return nil, errors.New("failed to load")
+				},
+			},
+			{
+				Name: "failingNonNull",
+				Type: &NonNull{OfType: String},
+				Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+					return nil, errors.New("failed to load")
+				},
+			},
+			{
+				Name: "panicking",
+				Type: String,
+				Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+					panic("boom")
+				},
+			},
+		},
+	}
+
+	schema, err := NewSchema(query)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	return schema
+}
+
+func execute(t *testing.T, schema *Schema, req Request) string {
+	t.Helper()
+
+	b, err := json.Marshal(schema.Execute(context.Background(), req))
+	if err != nil {
+		t.Fatalf("failed to marshal response: %v", err)
+	}
+	return string(b)
+}
+
+func TestExecute(t *testing.T) {
+	schema := newTestSchema(t)
+
+	tests := []struct {
+		name string
+		req  Request
+		want string
+	}{
+		{
+			name: "fields in query order",
+			req:  Request{Query: `{ team(id: "1") { name id city } }`},
+			want: `{"data":{"team":{"name":"Timberwolves","id":"1","city":"Minnesota"}}}`,
+		},
+		{
+			name: "aliases and typename",
+			req:  Request{Query: `{ wolves: team(id: "1") { __typename name } nuggets: team(id: "2") { name } }`},
+			want: `{"data":{"wolves":{"__typename":"Team","name":"Timberwolves"},"nuggets":{"name":"Nuggets"}}}`,
+		},
+		{
+			name: "nested lists",
+			req:  Request{Query: `{ team(id: "2") { players { name team { city } } } }`},
+			want: `{"data":{"team":{"players":[{"name":"Nikola Jokic","team":{"city":"Denver"}}]}}}`,
+		},
+		{
+			name: "argument default",
+			req:  Request{Query: `{ players { id } }`},
+			want: `{"data":{"players":[{"id":"10"},{"id":"11"},{"id":"20"}]}}`,
+		},
+		{
+			name: "variables",
+			req: Request{
+				Query:     `query Players($limit: Int) { players(limit: $limit) { name } }`,
+				Variables: map[string]any{"limit": json.Number("1")},
+			},
+			want: `{"data":{"players":[{"name":"Anthony Edwards"}]}}`,
+		},
+		{
+			name: "fragments and directives",
+			req: Request{
+				Query: `query ($withCity: Boolean!) { team(id: "1") { ...names city @include(if: $withCity) wins @skip(if: true) } }
+					fragment names on Team { name }`,
+				Variables: map[string]any{"withCity": false},
+			},
+			want: `{"data":{"team":{"name":"Timberwolves"}}}`,
+		},
+		{
+			name: "operation name picks the operation",
+			req: Request{
+				Query:         `query A { team(id: "1") { name } } query B { team(id: "2") { name } }`,
+				OperationName: "B",
+			},
+			want: `{"data":{"team":{"name":"Nuggets"}}}`,
+		},
+		{
+			name: "missing object is null",
+			req:  Request{Query: `{ team(id: "99") { name } }`},
+			want: `{"data":{"team":null}}`,
+		},
+		{
+			name: "resolver error nulls the field",
+			req:  Request{Query: `{ failing team(id: "1") { name } }`},
+			want: `{"data":{"failing":null,"team":{"name":"Timberwolves"}},"errors":[{"message":"failed to load","locations":[{"line":1,"column":3}],"path":["failing"]}]}`,
+		},
+		{
+			name: "non null resolver error nulls the data",
+			req:  Request{Query: `{ team(id: "1") { name } failingNonNull }`},
+			want: `{"data":null,"errors":[{"message":"failed to load","locations":[{"line":1,"column":26}],"path":["failingNonNull"]}]}`,
+		},
+		{
+			name: "resolver panic is an error",
+			req:  Request{Query: `{ panicking }`},
+			want: `{"data":{"panicking":null},"errors":[{"message":"internal error resolving field panicking: boom","locations":[{"line":1,"column":3}],"path":["panicking"]}]}`,
+		},
+		{
+			name: "missing required variable",
+			req:  Request{Query: `query ($id: ID!) { team(id: $id) { name } }`},
+			want: `{"errors":[{"message":"variable $id of required type ID! was not provided","locations":[{"line":1,"column":8}]}]}`,
+		},
+		{
+			name: "invalid variable",
+			req: Request{
+				Query:     `query ($limit: Int) { players(limit: $limit) { name } }`,
+				Variables: map[string]any{"limit": "ten"},
+			},
+			want: `{"errors":[{"message":"variable $limit got an invalid value: Int cannot represent a non integer value ten","locations":[{"line":1,"column":8}]}]}`,
+		},
+		{
+			name: "ambiguous operation",
+			req:  Request{Query: `query A { players { id } } query B { players { id } }`},
+			want: `{"errors":[{"message":"operationName is required when the document has more than one operation"}]}`,
+		},
+		{
+			name: "unknown operation",
+			req:  Request{Query: `query A { players { id } }`, OperationName: "C"},
+			want: `{"errors":[{"message":"unknown operation named \"C\""}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := execute(t, schema, tt.req); got != tt.want {
+				t.Errorf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestExecuteLimitsConcurrency resolves a list far larger than the goroutine limit and checks no more resolvers than
+// the limit ran at once while every item was still resolved
+func TestExecuteLimitsConcurrency(t *testing.T) {
+	const items = maxConcurrentResolvers * 20
+
+	var running, maxRunning atomic.Int64
+	item := &Object{Name: "Item", Fields: []*Field{{
+		Name: "value",
+		Type: Int,
+		Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+			n := running.Add(1)
+			defer running.Add(-1)
+			// hold the slot long enough for resolvers to overlap
+			time.Sleep(time.Millisecond)
+			for {
+				m := maxRunning.Load()
+				if n <= m || maxRunning.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			return p.Source.(int), nil
+		},
+	}}}
+
+	query := &Object{Name: "Query", Fields: []*Field{{
+		Name: "items",
+		Type: &List{OfType: item},
+		Resolve: func(ctx context.Context, p ResolveParams) (any, error) {
+			values := make([]int, items)
+			for i := range values {
+				values[i] = i
+			}
+			return values, nil
+		},
+	}}}
+
+	schema, err := NewSchema(query)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	resp := schema.Execute(context.Background(), Request{Query: `{ items { value } }`})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("got errors %v", resp.Errors)
+	}
+
+	got, err := json.Marshal(resp.Data.(*orderedMap).values[0])
+	if err != nil {
+		t.Fatalf("failed to marshal items: %v", err)
+	}
+	values := []struct{ Value int }{}
+	if err := json.Unmarshal(got, &values); err != nil {
+		t.Fatalf("failed to unmarshal items: %v", err)
+	}
+	if len(values) != items {
+		t.Fatalf("got %d items, want %d", len(values), items)
+	}
+	for i, v := range values {
+		if v.Value != i {
+			t.Fatalf("item %d got value %d", i, v.Value)
+		}
+	}
+
+	// the goroutine that reached a field past the limit resolves it too, so one more can be running than there are slots
+	if n := maxRunning.Load(); n > maxConcurrentResolvers+1 {
+		t.Errorf("got %d resolvers running at once, want at most %d", n, maxConcurrentResolvers+1)
+	}
+}
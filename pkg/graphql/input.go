@@ -0,0 +1,122 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// coerceLiteral converts an input value written in the query to what resolvers receive, variables in it are taken from
+// the already coerced variables
+func coerceLiteral(t Type, val value, variables map[string]any) (any, error) {
+	if variable, ok := val.(*variableValue); ok {
+		v, ok := variables[variable.name]
+		if !ok || v == nil {
+			if _, nonNull := t.(*NonNull); nonNull {
+				return nil, fmt.Errorf("expected a value of non null type %s", t)
+			}
+		}
+		return v, nil
+	}
+
+	switch t := t.(type) {
+	case *NonNull:
+		if _, ok := val.(*nullValue); ok {
+			return nil, fmt.Errorf("expected a value of non null type %s, found null", t)
+		}
+		return coerceLiteral(t.OfType, val, variables)
+	case *List:
+		if _, ok := val.(*nullValue); ok {
+			return nil, nil
+		}
+		list, ok := val.(*listValue)
+		if !ok {
+			// a single value is coerced to a list of one
+			item, err := coerceLiteral(t.OfType, val, variables)
+			if err != nil {
+				return nil, err
+			}
+			return []any{item}, nil
+		}
+		items := make([]any, 0, len(list.values))
+		for _, itemValue := range list.values {
+			item, err := coerceLiteral(t.OfType, itemValue, variables)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case *Scalar:
+		var raw any
+		switch val := val.(type) {
+		case *nullValue:
+			return nil, nil
+		case *intValue:
+			raw = json.Number(val.raw)
+		case *floatValue:
+			raw = json.Number(val.raw)
+		case *stringValue:
+			raw = val.value
+		case *booleanValue:
+			raw = val.value
+		default:
+			return nil, fmt.Errorf("%s cannot represent value %s", t.Name, printValue(val))
+		}
+		return t.ParseValue(raw)
+	default:
+		return nil, fmt.Errorf("%s is not an input type", t)
+	}
+}
+
+// coerceInput converts a json variable value to what resolvers receive
+func coerceInput(t Type, v any) (any, error) {
+	switch t := t.(type) {
+	case *NonNull:
+		if v == nil {
+			return nil, fmt.Errorf("expected a value of non null type %s, found null", t)
+		}
+		return coerceInput(t.OfType, v)
+	case *List:
+		if v == nil {
+			return nil, nil
+		}
+		list, ok := v.([]any)
+		if !ok {
+			item, err := coerceInput(t.OfType, v)
+			if err != nil {
+				return nil, err
+			}
+			return []any{item}, nil
+		}
+		items := make([]any, 0, len(list))
+		for i, itemValue := range list {
+			item, err := coerceInput(t.OfType, itemValue)
+			if err != nil {
+				return nil, fmt.Errorf("at index %d: %w", i, err)
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case *Scalar:
+		if v == nil {
+			return nil, nil
+		}
+		return t.ParseValue(normalizeNumber(v))
+	default:
+		return nil, fmt.Errorf("%s is not an input type", t)
+	}
+}
+
+// normalizeNumber converts numbers decoded from json without UseNumber to json.Number so scalars only handle one
+// number type
+func normalizeNumber(v any) any {
+	switch v := v.(type) {
+	case float64:
+		return json.Number(strconv.FormatFloat(v, 'f', -1, 64))
+	case int:
+		return json.Number(strconv.Itoa(v))
+	default:
+		return v
+	}
+}
@@ -0,0 +1,519 @@
+package graphql
+
+import (
+	"fmt"
+)
+
+// parser is a recursive descent parser for executable graphql documents
+type parser struct {
+	lexer *lexer
+	token token
+}
+
+func parse(query string) (*document, error) {
+	p := &parser{lexer: newLexer(query)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	doc := &document{fragments: map[string]*fragment{}}
+	for p.token.kind != tokenEOF {
+		switch {
+		case p.peek(tokenPunctuator, "{"):
+			loc := p.token.loc
+			selectionSet, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &operation{operationType: "query", selectionSet: selectionSet, loc: loc})
+		case p.peek(tokenName, "query"), p.peek(tokenName, "mutation"), p.peek(tokenName, "subscription"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case p.peek(tokenName, "fragment"):
+			frag, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.fragments[frag.name]; ok {
+				return nil, queryError(frag.loc, "there can be only one fragment named %q", frag.name)
+			}
+			doc.fragments[frag.name] = frag
+		default:
+			return nil, p.unexpected()
+		}
+	}
+
+	if len(doc.operations) == 0 {
+		return nil, &Error{Message: "document must contain an operation"}
+	}
+
+	return doc, nil
+}
+
+func (p *parser) advance() error {
+	t, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.token = t
+	return nil
+}
+
+func (p *parser) peek(kind tokenKind, value string) bool {
+	return p.token.kind == kind && p.token.value == value
+}
+
+func (p *parser) unexpected() error {
+	return syntaxError(p.token.loc, fmt.Sprintf("unexpected %s", p.token))
+}
+
+// skip advances past the token when it matches, reporting whether it did
+func (p *parser) skip(kind tokenKind, value string) (bool, error) {
+	if !p.peek(kind, value) {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+func (p *parser) expect(kind tokenKind, value string) error {
+	if !p.peek(kind, value) {
+		return syntaxError(p.token.loc, fmt.Sprintf("expected %q, found %s", value, p.token))
+	}
+	return p.advance()
+}
+
+func (p *parser) expectName() (string, error) {
+	if p.token.kind != tokenName {
+		return "", syntaxError(p.token.loc, fmt.Sprintf("expected name, found %s", p.token))
+	}
+	name := p.token.value
+	return name, p.advance()
+}
+
+func (p *parser) parseOperation() (*operation, error) {
+	op := &operation{operationType: p.token.value, loc: p.token.loc}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.token.kind == tokenName {
+		op.name = p.token.value
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.peek(tokenPunctuator, "(") {
+		variables, err := p.parseVariableDefinitions()
+		if err != nil {
+			return nil, err
+		}
+		op.variables = variables
+	}
+
+	directives, err := p.parseDirectives(false)
+	if err != nil {
+		return nil, err
+	}
+	op.directives = directives
+
+	selectionSet, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.selectionSet = selectionSet
+
+	return op, nil
+}
+
+func (p *parser) parseVariableDefinitions() ([]*variableDefinition, error) {
+	if err := p.expect(tokenPunctuator, "("); err != nil {
+		return nil, err
+	}
+
+	variables := []*variableDefinition{}
+	for {
+		if ok, err := p.skip(tokenPunctuator, ")"); err != nil || ok {
+			return variables, err
+		}
+
+		v := &variableDefinition{loc: p.token.loc}
+		if err := p.expect(tokenPunctuator, "$"); err != nil {
+			return nil, err
+		}
+
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		v.name = name
+
+		if err := p.expect(tokenPunctuator, ":"); err != nil {
+			return nil, err
+		}
+
+		typ, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		v.typ = typ
+
+		if ok, err := p.skip(tokenPunctuator, "="); err != nil {
+			return nil, err
+		} else if ok {
+			defaultValue, err := p.parseValue(true)
+			if err != nil {
+				return nil, err
+			}
+			v.defaultValue = defaultValue
+		}
+
+		if _, err := p.parseDirectives(true); err != nil {
+			return nil, err
+		}
+
+		variables = append(variables, v)
+	}
+}
+
+func (p *parser) parseType() (*typeRef, error) {
+	t := &typeRef{}
+
+	if ok, err := p.skip(tokenPunctuator, "["); err != nil {
+		return nil, err
+	} else if ok {
+		elem, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		t.elem = elem
+		if err := p.expect(tokenPunctuator, "]"); err != nil {
+			return nil, err
+		}
+	} else {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		t.name = name
+	}
+
+	nonNull, err := p.skip(tokenPunctuator, "!")
+	if err != nil {
+		return nil, err
+	}
+	t.nonNull = nonNull
+
+	return t, nil
+}
+
+func (p *parser) parseDirectives(isConst bool) ([]*directive, error) {
+	directives := []*directive{}
+	for p.peek(tokenPunctuator, "@") {
+		d := &directive{loc: p.token.loc}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		d.name = name
+
+		arguments, err := p.parseArguments(isConst)
+		if err != nil {
+			return nil, err
+		}
+		d.arguments = arguments
+
+		directives = append(directives, d)
+	}
+	return directives, nil
+}
+
+func (p *parser) parseArguments(isConst bool) ([]*argument, error) {
+	if ok, err := p.skip(tokenPunctuator, "("); err != nil || !ok {
+		return nil, err
+	}
+
+	arguments := []*argument{}
+	for {
+		if ok, err := p.skip(tokenPunctuator, ")"); err != nil || ok {
+			return arguments, err
+		}
+
+		arg := &argument{loc: p.token.loc}
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		arg.name = name
+
+		if err := p.expect(tokenPunctuator, ":"); err != nil {
+			return nil, err
+		}
+
+		v, err := p.parseValue(isConst)
+		if err != nil {
+			return nil, err
+		}
+		arg.value = v
+
+		arguments = append(arguments, arg)
+	}
+}
+
+func (p *parser) parseSelectionSet() ([]selection, error) {
+	if err := p.expect(tokenPunctuator, "{"); err != nil {
+		return nil, err
+	}
+
+	selections := []selection{}
+	for {
+		loc := p.token.loc
+		if ok, err := p.skip(tokenPunctuator, "}"); err != nil {
+			return nil, err
+		} else if ok {
+			if len(selections) == 0 {
+				return nil, syntaxError(loc, "selection set must not be empty")
+			}
+			return selections, nil
+		}
+
+		var s selection
+		var err error
+		if p.peek(tokenPunctuator, "...") {
+			s, err = p.parseFragmentSelection()
+		} else {
+			s, err = p.parseField()
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		selections = append(selections, s)
+	}
+}
+
+func (p *parser) parseField() (*field, error) {
+	f := &field{loc: p.token.loc}
+
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+
+	if ok, err := p.skip(tokenPunctuator, ":"); err != nil {
+		return nil, err
+	} else if ok {
+		f.alias = name
+		name, err = p.expectName()
+		if err != nil {
+			return nil, err
+		}
+	}
+	f.name = name
+
+	arguments, err := p.parseArguments(false)
+	if err != nil {
+		return nil, err
+	}
+	f.arguments = arguments
+
+	directives, err := p.parseDirectives(false)
+	if err != nil {
+		return nil, err
+	}
+	f.directives = directives
+
+	if p.peek(tokenPunctuator, "{") {
+		selectionSet, err := p.parseSelectionSet()
+		if err != nil {
+			return nil, err
+		}
+		f.selectionSet = selectionSet
+	}
+
+	return f, nil
+}
+
+func (p *parser) parseFragmentSelection() (selection, error) {
+	loc := p.token.loc
+	if err := p.expect(tokenPunctuator, "..."); err != nil {
+		return nil, err
+	}
+
+	if p.token.kind == tokenName && p.token.value != "on" {
+		spread := &fragmentSpread{name: p.token.value, loc: loc}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		directives, err := p.parseDirectives(false)
+		if err != nil {
+			return nil, err
+		}
+		spread.directives = directives
+
+		return spread, nil
+	}
+
+	inline := &inlineFragment{loc: loc}
+	if ok, err := p.skip(tokenName, "on"); err != nil {
+		return nil, err
+	} else if ok {
+		typeCondition, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		inline.typeCondition = typeCondition
+	}
+
+	directives, err := p.parseDirectives(false)
+	if err != nil {
+		return nil, err
+	}
+	inline.directives = directives
+
+	selectionSet, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	inline.selectionSet = selectionSet
+
+	return inline, nil
+}
+
+func (p *parser) parseFragment() (*fragment, error) {
+	frag := &fragment{loc: p.token.loc}
+	if err := p.expect(tokenName, "fragment"); err != nil {
+		return nil, err
+	}
+
+	if p.peek(tokenName, "on") {
+		return nil, p.unexpected()
+	}
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	frag.name = name
+
+	if err := p.expect(tokenName, "on"); err != nil {
+		return nil, err
+	}
+
+	typeCondition, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	frag.typeCondition = typeCondition
+
+	directives, err := p.parseDirectives(false)
+	if err != nil {
+		return nil, err
+	}
+	frag.directives = directives
+
+	selectionSet, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	frag.selectionSet = selectionSet
+
+	return frag, nil
+}
+
+// parseValue parses an input value, variables aren't allowed in const values such as variable defaults
+func (p *parser) parseValue(isConst bool) (value, error) {
+	t := p.token
+	switch t.kind {
+	case tokenPunctuator:
+		switch t.value {
+		case "$":
+			if isConst {
+				return nil, p.unexpected()
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			return &variableValue{name: name, loc: t.loc}, nil
+		case "[":
+			return p.parseList(isConst)
+		case "{":
+			return p.parseObject(isConst)
+		}
+	case tokenInt:
+		return &intValue{raw: t.value, loc: t.loc}, p.advance()
+	case tokenFloat:
+		return &floatValue{raw: t.value, loc: t.loc}, p.advance()
+	case tokenString:
+		return &stringValue{value: t.value, loc: t.loc}, p.advance()
+	case tokenName:
+		switch t.value {
+		case "true", "false":
+			return &booleanValue{value: t.value == "true", loc: t.loc}, p.advance()
+		case "null":
+			return &nullValue{loc: t.loc}, p.advance()
+		default:
+			return &enumValue{value: t.value, loc: t.loc}, p.advance()
+		}
+	}
+
+	return nil, p.unexpected()
+}
+
+func (p *parser) parseList(isConst bool) (value, error) {
+	list := &listValue{loc: p.token.loc, values: []value{}}
+	if err := p.expect(tokenPunctuator, "["); err != nil {
+		return nil, err
+	}
+
+	for {
+		if ok, err := p.skip(tokenPunctuator, "]"); err != nil || ok {
+			return list, err
+		}
+
+		v, err := p.parseValue(isConst)
+		if err != nil {
+			return nil, err
+		}
+		list.values = append(list.values, v)
+	}
+}
+
+func (p *parser) parseObject(isConst bool) (value, error) {
+	object := &objectValue{loc: p.token.loc, fields: []*objectField{}}
+	if err := p.expect(tokenPunctuator, "{"); err != nil {
+		return nil, err
+	}
+
+	for {
+		if ok, err := p.skip(tokenPunctuator, "}"); err != nil || ok {
+			return object, err
+		}
+
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(tokenPunctuator, ":"); err != nil {
+			return nil, err
+		}
+
+		v, err := p.parseValue(isConst)
+		if err != nil {
+			return nil, err
+		}
+		object.fields = append(object.fields, &objectField{name: name, value: v})
+	}
+}
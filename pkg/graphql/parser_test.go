@@ -0,0 +1,116 @@
+package graphql
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	doc, err := parse(`
+		# the wolves and their players
+		query Team($id: ID! = "1", $names: [String!]) @skip(if: false) {
+			wolves: team(id: $id) {
+				...teamFields
+				players(limit: 5, filter: {name: "Ant", active: true}, ids: [1, 2.5, null, RECENT]) @include(if: true) {
+					... on Player { name }
+				}
+			}
+		}
+
+		fragment teamFields on Team { name note(text: "line\nbreak é", block: """
+			indented
+			  block
+		""") }
+	`)
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	if len(doc.operations) != 1 {
+		t.Fatalf("got %d operations, want 1", len(doc.operations))
+	}
+	op := doc.operations[0]
+	if op.operationType != "query" || op.name != "Team" {
+		t.Errorf("got %s operation %q, want query Team", op.operationType, op.name)
+	}
+	if op.loc != (Location{Line: 3, Column: 3}) {
+		t.Errorf("got operation location %+v", op.loc)
+	}
+
+	if len(op.variables) != 2 {
+		t.Fatalf("got %d variables, want 2", len(op.variables))
+	}
+	if v := op.variables[0]; v.name != "id" || v.typ.String() != "ID!" || v.defaultValue.(*stringValue).value != "1" {
+		t.Errorf("got variable $%s: %s", v.name, v.typ)
+	}
+	if v := op.variables[1]; v.name != "names" || v.typ.String() != "[String!]" || v.defaultValue != nil {
+		t.Errorf("got variable $%s: %s", v.name, v.typ)
+	}
+	if len(op.directives) != 1 || op.directives[0].name != "skip" {
+		t.Errorf("got operation directives %+v", op.directives)
+	}
+
+	wolves := op.selectionSet[0].(*field)
+	if wolves.alias != "wolves" || wolves.name != "team" || wolves.responseKey() != "wolves" {
+		t.Errorf("got field %s: %s", wolves.alias, wolves.name)
+	}
+	if variable, ok := wolves.arguments[0].value.(*variableValue); !ok || variable.name != "id" {
+		t.Errorf("got team argument %+v", wolves.arguments[0].value)
+	}
+
+	if spread, ok := wolves.selectionSet[0].(*fragmentSpread); !ok || spread.name != "teamFields" {
+		t.Errorf("got selection %+v, want the teamFields spread", wolves.selectionSet[0])
+	}
+
+	players := wolves.selectionSet[1].(*field)
+	if got := printArguments(players.arguments); got != `filter:{name:"Ant",active:true},ids:[1,2.5,null,RECENT],limit:5` {
+		t.Errorf("got players arguments %s", got)
+	}
+	if len(players.directives) != 1 || players.directives[0].name != "include" {
+		t.Errorf("got players directives %+v", players.directives)
+	}
+	if inline, ok := players.selectionSet[0].(*inlineFragment); !ok || inline.typeCondition != "Player" {
+		t.Errorf("got selection %+v, want an inline fragment on Player", players.selectionSet[0])
+	}
+
+	frag, ok := doc.fragments["teamFields"]
+	if !ok || frag.typeCondition != "Team" {
+		t.Fatalf("got fragments %+v", doc.fragments)
+	}
+	note := frag.selectionSet[1].(*field)
+	if got := note.arguments[0].value.(*stringValue).value; got != "line\nbreak é" {
+		t.Errorf("got string %q", got)
+	}
+	if got := note.arguments[1].value.(*stringValue).value; got != "indented\n  block" {
+		t.Errorf("got block string %q", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: ``, want: "document must contain an operation"},
+		{query: `{ team { }`, want: "syntax error: selection set must not be empty (1:10)"},
+		{query: `{ team(id: ) { name } }`, want: `syntax error: unexpected punctuator ")" (1:12)`},
+		{query: `{ team(id: "1) { name } }`, want: "syntax error: unterminated string (1:12)"},
+		{query: `{ team(id: 01) { name } }`, want: "syntax error: invalid number, unexpected digit after 0 (1:12)"},
+		{query: `{ team(id: "\x") { name } }`, want: `syntax error: invalid escape sequence \x in string (1:12)`},
+		{query: `{ team ~ }`, want: `syntax error: unexpected character '~' (1:8)`},
+		{query: `query ($id ID) { team }`, want: `syntax error: expected ":", found name "ID" (1:12)`},
+		{query: `{ name } fragment a on Team { name } fragment a on Team { id }`, want: `there can be only one fragment named "a" (1:38)`},
+		{query: `type Team { name: String }`, want: `syntax error: unexpected name "type" (1:1)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := parse(tt.query)
+			if err == nil {
+				t.Fatalf("got no error, want %s", tt.want)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("got  %s\nwant %s", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// input values, from variables or literals in the query, are json values with numbers as json.Number so ints and
+// floats can be told apart
+
+var ID = &Scalar{
+	Name:        "ID",
+	Description: "A unique identifier, serialized as a string",
+	Serialize: func(v any) (any, error) {
+		switch v := v.(type) {
+		case string:
+			return v, nil
+		case fmt.Stringer:
+			return v.String(), nil
+		}
+		if i, ok := toInt64(v); ok {
+			return strconv.FormatInt(i, 10), nil
+		}
+		return nil, fmt.Errorf("ID cannot represent value %v", v)
+	},
+	ParseValue: func(v any) (any, error) {
+		switch v := v.(type) {
+		case string:
+			return v, nil
+		case json.Number:
+			if _, err := v.Int64(); err == nil {
+				return v.String(), nil
+			}
+		}
+		return nil, fmt.Errorf("ID cannot represent value %v", v)
+	},
+}
+
+var String = &Scalar{
+	Name: "String",
+	Serialize: func(v any) (any, error) {
+		switch v := v.(type) {
+		case string:
+			return v, nil
+		case fmt.Stringer:
+			return v.String(), nil
+		}
+		return nil, fmt.Errorf("String cannot represent value %v", v)
+	},
+	ParseValue: func(v any) (any, error) {
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+		return nil, fmt.Errorf("String cannot represent a non string value %v", v)
+	},
+}
+
+var Int = &Scalar{
+	Name:        "Int",
+	Description: "A signed 32 bit integer",
+	Serialize: func(v any) (any, error) {
+		i, ok := toInt64(v)
+		if !ok || i < math.MinInt32 || i > math.MaxInt32 {
+			return nil, fmt.Errorf("Int cannot represent value %v", v)
+		}
+		return i, nil
+	},
+	ParseValue: func(v any) (any, error) {
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("Int cannot represent a non integer value %v", v)
+		}
+		i, err := n.Int64()
+		if err != nil || i < math.MinInt32 || i > math.MaxInt32 {
+			return nil, fmt.Errorf("Int cannot represent value %v", v)
+		}
+		return int(i), nil
+	},
+}
+
+var Float = &Scalar{
+	Name: "Float",
+	Serialize: func(v any) (any, error) {
+		switch v := v.(type) {
+		case float64:
+			return v, nil
+		case float32:
+			return float64(v), nil
+		}
+		if i, ok := toInt64(v); ok {
+			return float64(i), nil
+		}
+		return nil, fmt.Errorf("Float cannot represent value %v", v)
+	},
+	ParseValue: func(v any) (any, error) {
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("Float cannot represent a non numeric value %v", v)
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("Float cannot represent value %v", v)
+		}
+		return f, nil
+	},
+}
+
+var Boolean = &Scalar{
+	Name: "Boolean",
+	Serialize: func(v any) (any, error) {
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+		return nil, fmt.Errorf("Boolean cannot represent value %v", v)
+	},
+	ParseValue: func(v any) (any, error) {
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+		return nil, fmt.Errorf("Boolean cannot represent a non boolean value %v", v)
+	},
+}
+
+func toInt64(v any) (int64, bool) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint:
+		if uint64(v) > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	}
+	return 0, false
+}
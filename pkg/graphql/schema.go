@@ -0,0 +1,273 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Type is a graphql output or input type, a *Scalar, *Object, *List or *NonNull
+type Type interface {
+	String() string
+	isType()
+}
+
+// Scalar is a leaf type. Serialize converts a resolved value to what is written in the response and ParseValue
+// converts an argument or variable, which come as json values, to what resolvers receive
+type Scalar struct {
+	Name        string
+	Description string
+	Serialize   func(v any) (any, error)
+	ParseValue  func(v any) (any, error)
+}
+
+func (s *Scalar) String() string { return s.Name }
+func (*Scalar) isType()          {}
+
+// Object is a type with fields, the fields are assigned after creating the object when types reference each other
+type Object struct {
+	Name        string
+	Description string
+	Fields      []*Field
+
+	fields map[string]*Field
+}
+
+func (o *Object) String() string { return o.Name }
+func (*Object) isType()          {}
+
+// Field looks up a field by name after the object has been added to a schema
+func (o *Object) Field(name string) *Field {
+	return o.fields[name]
+}
+
+type List struct {
+	OfType Type
+}
+
+func (l *List) String() string { return "[" + l.OfType.String() + "]" }
+func (*List) isType()          {}
+
+type NonNull struct {
+	OfType Type
+}
+
+func (n *NonNull) String() string { return n.OfType.String() + "!" }
+func (*NonNull) isType()          {}
+
+// ResolveParams are what a field is resolved from, Source is the value of the object the field is on and is nil for
+// query fields
+type ResolveParams struct {
+	Source any
+	Args   map[string]any
+}
+
+type ResolveFunc func(ctx context.Context, p ResolveParams) (any, error)
+
+// Field is a field on an object. Fields without a Resolve func are read from the source value, see defaultResolve
+type Field struct {
+	Name        string
+	Description string
+	Type        Type
+	Args        []*Argument
+	Resolve     ResolveFunc
+}
+
+// Argument is a field argument, only scalars and lists of them are supported as input types
+type Argument struct {
+	Name         string
+	Description  string
+	Type         Type
+	DefaultValue any
+}
+
+// Schema is the types reachable from the query type
+type Schema struct {
+	Query *Object
+	// MaxDepth is how deeply selection sets can be nested, it bounds the work a single query can ask for
+	MaxDepth int
+
+	types map[string]Type
+}
+
+const DefaultMaxDepth = 15
+
+var nameRegexp = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+var ErrInvalidSchema = errors.New("invalid graphql schema")
+
+// NewSchema checks the types reachable from query and indexes their fields
+func NewSchema(query *Object) (*Schema, error) {
+	s := &Schema{Query: query, MaxDepth: DefaultMaxDepth, types: map[string]Type{}}
+	for _, scalar := range []*Scalar{ID, String, Int, Float, Boolean} {
+		s.types[scalar.Name] = scalar
+	}
+
+	if err := s.addType(query); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *Schema) addType(t Type) error {
+	switch t := t.(type) {
+	case *List:
+		return s.addType(t.OfType)
+	case *NonNull:
+		if _, ok := t.OfType.(*NonNull); ok {
+			return fmt.Errorf("%w: %s can't be non null of a non null type", ErrInvalidSchema, t)
+		}
+		return s.addType(t.OfType)
+	case *Scalar:
+		return s.addNamedType(t.Name, t)
+	case *Object:
+		if existing, ok := s.types[t.Name]; ok {
+			if existing != Type(t) {
+				return fmt.Errorf("%w: there can be only one type named %s", ErrInvalidSchema, t.Name)
+			}
+			return nil
+		}
+		if err := s.addNamedType(t.Name, t); err != nil {
+			return err
+		}
+
+		if len(t.Fields) == 0 {
+			return fmt.Errorf("%w: %s must have at least one field", ErrInvalidSchema, t.Name)
+		}
+
+		t.fields = make(map[string]*Field, len(t.Fields))
+		for _, f := range t.Fields {
+			if !nameRegexp.MatchString(f.Name) || strings.HasPrefix(f.Name, "__") {
+				return fmt.Errorf("%w: %s.%s is not a valid field name", ErrInvalidSchema, t.Name, f.Name)
+			}
+			if _, ok := t.fields[f.Name]; ok {
+				return fmt.Errorf("%w: %s.%s is defined more than once", ErrInvalidSchema, t.Name, f.Name)
+			}
+			if f.Type == nil {
+				return fmt.Errorf("%w: %s.%s has no type", ErrInvalidSchema, t.Name, f.Name)
+			}
+			t.fields[f.Name] = f
+
+			if err := s.addType(f.Type); err != nil {
+				return err
+			}
+
+			for _, arg := range f.Args {
+				if !nameRegexp.MatchString(arg.Name) {
+					return fmt.Errorf("%w: %s.%s(%s) is not a valid argument name", ErrInvalidSchema, t.Name, f.Name, arg.Name)
+				}
+				if _, ok := namedType(arg.Type).(*Scalar); !ok {
+					return fmt.Errorf("%w: %s.%s(%s) must be a scalar or list of scalars", ErrInvalidSchema, t.Name, f.Name, arg.Name)
+				}
+				if err := s.addType(arg.Type); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: unsupported type %T", ErrInvalidSchema, t)
+	}
+}
+
+func (s *Schema) addNamedType(name string, t Type) error {
+	if !nameRegexp.MatchString(name) || strings.HasPrefix(name, "__") {
+		return fmt.Errorf("%w: %q is not a valid type name", ErrInvalidSchema, name)
+	}
+	if existing, ok := s.types[name]; ok && existing != t {
+		return fmt.Errorf("%w: there can be only one type named %s", ErrInvalidSchema, name)
+	}
+	s.types[name] = t
+	return nil
+}
+
+// SDL is the schema in the graphql schema definition language, for clients and tools that generate types from it
+func (s *Schema) SDL() string {
+	names := make([]string, 0, len(s.types))
+	for name := range s.types {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var sb strings.Builder
+	sb.WriteString("schema {\n  query: " + s.Query.Name + "\n}\n")
+
+	for _, name := range names {
+		switch t := s.types[name].(type) {
+		case *Scalar:
+			if slices.Contains([]*Scalar{ID, String, Int, Float, Boolean}, t) {
+				continue
+			}
+			sb.WriteString("\n")
+			writeDescription(&sb, "", t.Description)
+			sb.WriteString("scalar " + t.Name + "\n")
+		case *Object:
+			sb.WriteString("\n")
+			writeDescription(&sb, "", t.Description)
+			sb.WriteString("type " + t.Name + " {\n")
+			for _, f := range t.Fields {
+				writeDescription(&sb, "  ", f.Description)
+				sb.WriteString("  " + f.Name)
+				if len(f.Args) > 0 {
+					args := make([]string, 0, len(f.Args))
+					for _, arg := range f.Args {
+						a := arg.Name + ": " + arg.Type.String()
+						if arg.DefaultValue != nil {
+							a += " = " + formatDefaultValue(arg.DefaultValue)
+						}
+						args = append(args, a)
+					}
+					sb.WriteString("(" + strings.Join(args, ", ") + ")")
+				}
+				sb.WriteString(": " + f.Type.String() + "\n")
+			}
+			sb.WriteString("}\n")
+		}
+	}
+
+	return sb.String()
+}
+
+func writeDescription(sb *strings.Builder, indent string, description string) {
+	if description == "" {
+		return
+	}
+	sb.WriteString(indent + `"""` + "\n")
+	for _, line := range strings.Split(description, "\n") {
+		sb.WriteString(indent + line + "\n")
+	}
+	sb.WriteString(indent + `"""` + "\n")
+}
+
+func formatDefaultValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, formatDefaultValue(item))
+		}
+		return "[" + strings.Join(values, ", ") + "]"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// namedType unwraps lists and non nulls
+func namedType(t Type) Type {
+	for {
+		switch wrapped := t.(type) {
+		case *List:
+			t = wrapped.OfType
+		case *NonNull:
+			t = wrapped.OfType
+		default:
+			return t
+		}
+	}
+}
@@ -0,0 +1,437 @@
+package graphql
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// validator checks an operation against the schema before it's executed so a query with a mistake in it returns
+// errors instead of partial data
+type validator struct {
+	schema    *Schema
+	doc       *document
+	variables map[string]*variableDefinition
+	varTypes  map[string]Type
+	errors    []*Error
+
+	// fragmentDepths are the nesting depth of each validated fragment's selection set, fragments are validated once since
+	// their type condition is the only type they can be spread on
+	fragmentDepths map[string]int
+	visiting       map[string]bool
+}
+
+func (s *Schema) validate(doc *document, op *operation) []*Error {
+	v := &validator{
+		schema:         s,
+		doc:            doc,
+		variables:      map[string]*variableDefinition{},
+		varTypes:       map[string]Type{},
+		fragmentDepths: map[string]int{},
+		visiting:       map[string]bool{},
+	}
+
+	if op.operationType != "query" {
+		v.errorf(op.loc, "%s operations are not supported", op.operationType)
+		return v.errors
+	}
+
+	if len(op.directives) > 0 {
+		v.errorf(op.directives[0].loc, "directive @%s is not allowed on operations", op.directives[0].name)
+	}
+
+	for _, def := range op.variables {
+		v.variableDefinition(def)
+	}
+
+	depth := v.selectionSet(s.Query, op.selectionSet)
+	if s.MaxDepth > 0 && depth > s.MaxDepth {
+		v.errorf(op.loc, "query is nested %d levels deep, the maximum is %d", depth, s.MaxDepth)
+	}
+
+	return v.errors
+}
+
+func (v *validator) errorf(loc Location, format string, args ...any) {
+	v.errors = append(v.errors, queryError(loc, format, args...))
+}
+
+func (v *validator) variableDefinition(def *variableDefinition) {
+	if _, ok := v.variables[def.name]; ok {
+		v.errorf(def.loc, "there can be only one variable named $%s", def.name)
+		return
+	}
+	v.variables[def.name] = def
+
+	t, err := v.schema.inputType(def.typ)
+	if err != nil {
+		v.errorf(def.loc, "variable $%s %s", def.name, err)
+		return
+	}
+	v.varTypes[def.name] = t
+
+	if def.defaultValue != nil {
+		if _, err := coerceLiteral(t, def.defaultValue, nil); err != nil {
+			v.errorf(def.defaultValue.location(), "variable $%s has an invalid default value: %s", def.name, err)
+		}
+	}
+}
+
+// inputType converts a type written in a query to the schema type, only scalars can be used as inputs
+func (s *Schema) inputType(ref *typeRef) (Type, error) {
+	var t Type
+	if ref.elem != nil {
+		elem, err := s.inputType(ref.elem)
+		if err != nil {
+			return nil, err
+		}
+		t = &List{OfType: elem}
+	} else {
+		named, ok := s.types[ref.name]
+		if !ok {
+			return nil, fmt.Errorf("has unknown type %s", ref.name)
+		}
+		if _, ok := named.(*Scalar); !ok {
+			return nil, fmt.Errorf("type %s is not an input type", ref.name)
+		}
+		t = named
+	}
+
+	if ref.nonNull {
+		t = &NonNull{OfType: t}
+	}
+	return t, nil
+}
+
+// selectionSet validates the selections on parent and returns how deeply they are nested
+func (v *validator) selectionSet(parent *Object, selections []selection) int {
+	depth := 0
+
+	for _, sel := range selections {
+		switch sel := sel.(type) {
+		case *field:
+			depth = max(depth, v.field(parent, sel))
+		case *fragmentSpread:
+			v.directives(sel.directives)
+			frag, ok := v.doc.fragments[sel.name]
+			if !ok {
+				v.errorf(sel.loc, "unknown fragment %q", sel.name)
+				continue
+			}
+			if frag.typeCondition != parent.Name {
+				v.errorf(sel.loc, "fragment %q on %s can't be spread on %s", sel.name, frag.typeCondition, parent.Name)
+				continue
+			}
+			depth = max(depth, v.fragment(frag))
+		case *inlineFragment:
+			v.directives(sel.directives)
+			if sel.typeCondition != "" && sel.typeCondition != parent.Name {
+				v.errorf(sel.loc, "fragment on %s can't be spread on %s", sel.typeCondition, parent.Name)
+				continue
+			}
+			depth = max(depth, v.selectionSet(parent, sel.selectionSet))
+		}
+	}
+
+	v.fieldConflicts(selections)
+
+	return depth
+}
+
+func (v *validator) fragment(frag *fragment) int {
+	if depth, ok := v.fragmentDepths[frag.name]; ok {
+		return depth
+	}
+
+	if v.visiting[frag.name] {
+		v.errorf(frag.loc, "fragment %q spreads itself", frag.name)
+		// record it so the cycle is only reported once
+		v.fragmentDepths[frag.name] = 0
+		return 0
+	}
+
+	if len(frag.directives) > 0 {
+		v.errorf(frag.directives[0].loc, "directive @%s is not allowed on fragment definitions", frag.directives[0].name)
+	}
+
+	typ, ok := v.schema.types[frag.typeCondition]
+	if !ok {
+		v.errorf(frag.loc, "fragment %q is on unknown type %s", frag.name, frag.typeCondition)
+		v.fragmentDepths[frag.name] = 0
+		return 0
+	}
+	parent, ok := typ.(*Object)
+	if !ok {
+		v.errorf(frag.loc, "fragment %q can't be on non object type %s", frag.name, frag.typeCondition)
+		v.fragmentDepths[frag.name] = 0
+		return 0
+	}
+
+	v.visiting[frag.name] = true
+	depth := v.selectionSet(parent, frag.selectionSet)
+	delete(v.visiting, frag.name)
+
+	if _, ok := v.fragmentDepths[frag.name]; !ok {
+		v.fragmentDepths[frag.name] = depth
+	}
+	return depth
+}
+
+// field validates a field and returns how deeply its selection set is nested, counting the field itself
+func (v *validator) field(parent *Object, f *field) int {
+	v.directives(f.directives)
+
+	if f.name == "__typename" {
+		if len(f.arguments) > 0 {
+			v.errorf(f.arguments[0].loc, "unknown argument %q on field __typename", f.arguments[0].name)
+		}
+		if len(f.selectionSet) > 0 {
+			v.errorf(f.loc, "field __typename of type String! must not have a selection")
+		}
+		return 1
+	}
+
+	def := parent.Field(f.name)
+	if def == nil {
+		v.errorf(f.loc, "cannot query field %q on type %s", f.name, parent.Name)
+		return 1
+	}
+
+	v.arguments(fmt.Sprintf("field %s.%s", parent.Name, def.Name), def.Args, f.arguments, f.loc)
+
+	switch t := namedType(def.Type).(type) {
+	case *Object:
+		if len(f.selectionSet) == 0 {
+			v.errorf(f.loc, "field %q of type %s must have a selection of subfields", f.name, def.Type)
+			return 1
+		}
+		return 1 + v.selectionSet(t, f.selectionSet)
+	default:
+		if len(f.selectionSet) > 0 {
+			v.errorf(f.loc, "field %q must not have a selection since type %s has no subfields", f.name, def.Type)
+		}
+		return 1
+	}
+}
+
+func (v *validator) arguments(owner string, defs []*Argument, args []*argument, loc Location) {
+	seen := map[string]bool{}
+	for _, arg := range args {
+		if seen[arg.name] {
+			v.errorf(arg.loc, "there can be only one argument named %q", arg.name)
+			continue
+		}
+		seen[arg.name] = true
+
+		i := slices.IndexFunc(defs, func(def *Argument) bool { return def.Name == arg.name })
+		if i == -1 {
+			v.errorf(arg.loc, "unknown argument %q on %s", arg.name, owner)
+			continue
+		}
+		def := defs[i]
+
+		v.value(def, arg.value)
+	}
+
+	for _, def := range defs {
+		if _, ok := def.Type.(*NonNull); ok && def.DefaultValue == nil && !seen[def.Name] {
+			v.errorf(loc, "%s argument %q of type %s is required but not provided", owner, def.Name, def.Type)
+		}
+	}
+}
+
+// value checks a literal argument can be coerced to the argument's type and that variables used in it are defined
+// with a compatible type
+func (v *validator) value(def *Argument, val value) {
+	variablesOK := true
+	walkVariables(val, def.Type, func(variable *variableValue, locationType Type) {
+		varDef, ok := v.variables[variable.name]
+		if !ok {
+			v.errorf(variable.loc, "variable $%s is not defined", variable.name)
+			variablesOK = false
+			return
+		}
+
+		varType, ok := v.varTypes[variable.name]
+		if !ok {
+			// the definition already has an error
+			variablesOK = false
+			return
+		}
+
+		// a nullable variable can be used for a non null argument when either has a default
+		if nonNull, ok := locationType.(*NonNull); ok && val == value(variable) {
+			if _, varNonNull := varType.(*NonNull); !varNonNull && (varDef.defaultValue != nil || def.DefaultValue != nil) {
+				locationType = nonNull.OfType
+			}
+		}
+
+		if !typeCompatible(varType, locationType) {
+			v.errorf(variable.loc, "variable $%s of type %s can't be used where %s is expected", variable.name, varType, locationType)
+			variablesOK = false
+		}
+	})
+
+	if !variablesOK {
+		return
+	}
+
+	if !containsVariable(val) {
+		if _, err := coerceLiteral(def.Type, val, nil); err != nil {
+			v.errorf(val.location(), "argument %q has an invalid value: %s", def.Name, err)
+		}
+	}
+}
+
+func (v *validator) directives(directives []*directive) {
+	for _, d := range directives {
+		if d.name != "skip" && d.name != "include" {
+			v.errorf(d.loc, "unknown directive @%s", d.name)
+			continue
+		}
+		v.arguments("directive @"+d.name, directiveArguments, d.arguments, d.loc)
+	}
+}
+
+var directiveArguments = []*Argument{{Name: "if", Type: &NonNull{OfType: Boolean}}}
+
+// fieldConflicts reports fields returned under the same key that are different fields or have different arguments,
+// their values couldn't both be returned
+func (v *validator) fieldConflicts(selections []selection) {
+	fields := map[string]*field{}
+	visited := map[string]bool{}
+
+	var collect func(selections []selection)
+	collect = func(selections []selection) {
+		for _, sel := range selections {
+			switch sel := sel.(type) {
+			case *field:
+				key := sel.responseKey()
+				existing, ok := fields[key]
+				if !ok {
+					fields[key] = sel
+					continue
+				}
+				if existing.name != sel.name {
+					v.errorf(sel.loc, "fields %q conflict because %s and %s are different fields, use aliases on the fields to fetch both", key, existing.name, sel.name)
+				} else if printArguments(existing.arguments) != printArguments(sel.arguments) {
+					v.errorf(sel.loc, "fields %q conflict because they have differing arguments, use aliases on the fields to fetch both", key)
+				}
+			case *fragmentSpread:
+				if visited[sel.name] {
+					continue
+				}
+				visited[sel.name] = true
+				if frag, ok := v.doc.fragments[sel.name]; ok && !v.visiting[sel.name] {
+					collect(frag.selectionSet)
+				}
+			case *inlineFragment:
+				collect(sel.selectionSet)
+			}
+		}
+	}
+
+	collect(selections)
+}
+
+// typeCompatible reports whether a variable of type varType can be used where locationType is expected
+func typeCompatible(varType Type, locationType Type) bool {
+	if locationNonNull, ok := locationType.(*NonNull); ok {
+		varNonNull, ok := varType.(*NonNull)
+		if !ok {
+			return false
+		}
+		return typeCompatible(varNonNull.OfType, locationNonNull.OfType)
+	}
+
+	if varNonNull, ok := varType.(*NonNull); ok {
+		return typeCompatible(varNonNull.OfType, locationType)
+	}
+
+	if locationList, ok := locationType.(*List); ok {
+		varList, ok := varType.(*List)
+		if !ok {
+			return false
+		}
+		return typeCompatible(varList.OfType, locationList.OfType)
+	}
+
+	if _, ok := varType.(*List); ok {
+		return false
+	}
+
+	return varType == locationType
+}
+
+// walkVariables calls fn with each variable in a value and the type expected where it's used
+func walkVariables(val value, t Type, fn func(variable *variableValue, locationType Type)) {
+	switch val := val.(type) {
+	case *variableValue:
+		fn(val, t)
+	case *listValue:
+		itemType := t
+		if nonNull, ok := itemType.(*NonNull); ok {
+			itemType = nonNull.OfType
+		}
+		if list, ok := itemType.(*List); ok {
+			itemType = list.OfType
+		}
+		for _, item := range val.values {
+			walkVariables(item, itemType, fn)
+		}
+	case *objectValue:
+		for _, f := range val.fields {
+			walkVariables(f.value, nil, fn)
+		}
+	}
+}
+
+func containsVariable(val value) bool {
+	found := false
+	walkVariables(val, nil, func(*variableValue, Type) { found = true })
+	return found
+}
+
+func printArguments(args []*argument) string {
+	sorted := slices.Clone(args)
+	slices.SortFunc(sorted, func(a, b *argument) int { return strings.Compare(a.name, b.name) })
+
+	printed := make([]string, 0, len(sorted))
+	for _, arg := range sorted {
+		printed = append(printed, arg.name+":"+printValue(arg.value))
+	}
+	return strings.Join(printed, ",")
+}
+
+func printValue(val value) string {
+	switch val := val.(type) {
+	case *variableValue:
+		return "$" + val.name
+	case *intValue:
+		return val.raw
+	case *floatValue:
+		return val.raw
+	case *stringValue:
+		return fmt.Sprintf("%q", val.value)
+	case *booleanValue:
+		return fmt.Sprint(val.value)
+	case *nullValue:
+		return "null"
+	case *enumValue:
+		return val.value
+	case *listValue:
+		values := make([]string, 0, len(val.values))
+		for _, item := range val.values {
+			values = append(values, printValue(item))
+		}
+		return "[" + strings.Join(values, ",") + "]"
+	case *objectValue:
+		fields := make([]string, 0, len(val.fields))
+		for _, f := range val.fields {
+			fields = append(fields, f.name+":"+printValue(f.value))
+		}
+		return "{" + strings.Join(fields, ",") + "}"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,117 @@
+package graphql
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	schema := newTestSchema(t)
+	schema.MaxDepth = 4
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{
+			name:  "valid query",
+			query: `query ($id: ID!) { team(id: $id) { ...names players { name } } } fragment names on Team { name city }`,
+		},
+		{
+			name:  "mutation",
+			query: `mutation { team(id: "1") { name } }`,
+			want:  []string{"mutation operations are not supported"},
+		},
+		{
+			name:  "unknown field",
+			query: `{ team(id: "1") { name coach } }`,
+			want:  []string{`cannot query field "coach" on type Team`},
+		},
+		{
+			name:  "object without subfields",
+			query: `{ team(id: "1") }`,
+			want:  []string{`field "team" of type Team must have a selection of subfields`},
+		},
+		{
+			name:  "scalar with subfields",
+			query: `{ team(id: "1") { name { first } } }`,
+			want:  []string{`field "name" must not have a selection since type String! has no subfields`},
+		},
+		{
+			name:  "unknown argument",
+			query: `{ players(limit: 1, season: 2024) { name } }`,
+			want:  []string{`unknown argument "season" on field Query.players`},
+		},
+		{
+			name:  "missing required argument",
+			query: `{ team { name } }`,
+			want:  []string{`field Query.team argument "id" of type ID! is required but not provided`},
+		},
+		{
+			name:  "invalid argument value",
+			query: `{ players(limit: "ten") { name } }`,
+			want:  []string{`argument "limit" has an invalid value: Int cannot represent a non integer value ten`},
+		},
+		{
+			name:  "undefined variable",
+			query: `{ team(id: $id) { name } }`,
+			want:  []string{"variable $id is not defined"},
+		},
+		{
+			name:  "incompatible variable",
+			query: `query ($id: ID) { team(id: $id) { name } }`,
+			want:  []string{"variable $id of type ID can't be used where ID! is expected"},
+		},
+		{
+			name:  "unknown variable type",
+			query: `query ($id: TeamID!) { team(id: $id) { name } }`,
+			want:  []string{"variable $id has unknown type TeamID"},
+		},
+		{
+			name:  "unknown directive",
+			query: `{ team(id: "1") { name @cached } }`,
+			want:  []string{"unknown directive @cached"},
+		},
+		{
+			name:  "unknown fragment",
+			query: `{ team(id: "1") { ...names } }`,
+			want:  []string{`unknown fragment "names"`},
+		},
+		{
+			name:  "fragment cycle",
+			query: `{ team(id: "1") { ...a } } fragment a on Team { players { team { ...a } } }`,
+			want:  []string{`fragment "a" spreads itself`},
+		},
+		{
+			name:  "conflicting fields",
+			query: `{ team(id: "1") { name name: city } }`,
+			want:  []string{`fields "name" conflict because name and city are different fields, use aliases on the fields to fetch both`},
+		},
+		{
+			name:  "too deep",
+			query: `{ team(id: "1") { players { team { players { team { name } } } } } }`,
+			want:  []string{"query is nested 6 levels deep, the maximum is 4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parse(tt.query)
+			if err != nil {
+				t.Fatalf("failed to parse: %v", err)
+			}
+
+			got := []string{}
+			for _, err := range schema.validate(doc, doc.operations[0]) {
+				got = append(got, err.Message)
+			}
+			if len(tt.want) == 0 {
+				tt.want = []string{}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got  %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package pubsub
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrSlowSubscriber is returned by Subscription.Err when the subscription was dropped because it fell too far behind
+var ErrSlowSubscriber = errors.New("subscriber fell too far behind and was dropped")
+
+type message[T any] struct {
+	seq   uint64
+	value T
+}
+
+type topic[T any] struct {
+	seq         uint64
+	replay      []message[T]
+	subscribers map[*Subscription[T]]struct{}
+	closed      bool
+}
+
+// Broker fans messages published to a topic out to its subscribers. Publishing never blocks on subscribers, a
+// subscriber whose buffer is full is dropped so it can resubscribe from the last message it saw using the topic's
+// replay buffer
+type Broker[T any] struct {
+	mu               sync.Mutex
+	topics           map[string]*topic[T]
+	replaySize       int
+	subscriberBuffer int
+}
+
+// NewBroker creates a broker that keeps the last replaySize messages of each topic for resuming subscribers and buffers
+// up to subscriberBuffer messages for each subscriber
+func NewBroker[T any](replaySize int, subscriberBuffer int) *Broker[T] {
+	return &Broker[T]{
+		topics:           map[string]*topic[T]{},
+		replaySize:       replaySize,
+		subscriberBuffer: subscriberBuffer,
+	}
+}
+
+func (b *Broker[T]) topic(name string) *topic[T] {
+	t, ok := b.topics[name]
+	if !ok {
+		t = &topic[T]{subscribers: map[*Subscription[T]]struct{}{}}
+		b.topics[name] = t
+	}
+	return t
+}
+
+// Publish sends a message to the topic's subscribers. build is called with the message's sequence number, which starts
+// at 1 for each topic, so it can be made part of the message ex. as an event id. Messages published to a closed topic
+// are dropped
+func (b *Broker[T]) Publish(topicName string, build func(seq uint64) T) uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t := b.topic(topicName)
+	if t.closed {
+		return t.seq
+	}
+
+	t.seq++
+	m := message[T]{seq: t.seq, value: build(t.seq)}
+
+	t.replay = append(t.replay, m)
+	if len(t.replay) > b.replaySize {
+		t.replay = append(t.replay[:0], t.replay[len(t.replay)-b.replaySize:]...)
+	}
+
+	for s := range t.subscribers {
+		select {
+		case s.c <- m.value:
+		default:
+			s.err = ErrSlowSubscriber
+			delete(t.subscribers, s)
+			close(s.c)
+		}
+	}
+
+	return t.seq
+}
+
+// Seq returns the sequence number of the last message published to the topic
+func (b *Broker[T]) Seq(topicName string) uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if t, ok := b.topics[topicName]; ok {
+		return t.seq
+	}
+	return 0
+}
+
+// Subscribe starts receiving messages published to the topic. When after is set the buffered messages published after
+// that sequence number are delivered first, resumed reports whether every one of them was still buffered. Subscribing
+// to a closed topic delivers the replay and then closes the subscription
+func (b *Broker[T]) Subscribe(topicName string, after *uint64) (subscription *Subscription[T], resumed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t := b.topic(topicName)
+
+	var replay []T
+	if after != nil && *after <= t.seq {
+		oldest := t.seq + 1
+		if len(t.replay) > 0 {
+			oldest = t.replay[0].seq
+		}
+		resumed = *after+1 >= oldest
+		for _, m := range t.replay {
+			if m.seq > *after {
+				replay = append(replay, m.value)
+			}
+		}
+	}
+
+	s := &Subscription[T]{broker: b, topicName: topicName, c: make(chan T, len(replay)+b.subscriberBuffer)}
+	s.C = s.c
+	for _, value := range replay {
+		s.c <- value
+	}
+
+	if t.closed {
+		close(s.c)
+		return s, resumed
+	}
+
+	t.subscribers[s] = struct{}{}
+
+	return s, resumed
+}
+
+// Close ends every subscription to the topic once they have received the messages already published, the replay buffer
+// is kept so late subscribers can still catch up
+func (b *Broker[T]) Close(topicName string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t := b.topic(topicName)
+	if t.closed {
+		return
+	}
+	t.closed = true
+
+	for s := range t.subscribers {
+		delete(t.subscribers, s)
+		close(s.c)
+	}
+}
+
+// Remove closes the topic and drops its replay buffer
+func (b *Broker[T]) Remove(topicName string) {
+	b.Close(topicName)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.topics, topicName)
+}
+
+// Subscription receives a topic's messages on C until the topic is closed, the subscriber is dropped for being too slow
+// or Unsubscribe is called
+type Subscription[T any] struct {
+	C <-chan T
+
+	c         chan T
+	broker    *Broker[T]
+	topicName string
+	err       error
+}
+
+// Unsubscribe stops delivery and closes C, it is safe to call more than once
+func (s *Subscription[T]) Unsubscribe() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	t, ok := s.broker.topics[s.topicName]
+	if !ok {
+		return
+	}
+	if _, ok := t.subscribers[s]; ok {
+		delete(t.subscribers, s)
+		close(s.c)
+	}
+}
+
+// Err returns ErrSlowSubscriber once C is closed if the subscription was dropped for falling behind
+func (s *Subscription[T]) Err() error {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	return s.err
+}
@@ -0,0 +1,106 @@
+package pubsub
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func publish(b *Broker[uint64], topic string, n int) {
+	for range n {
+		b.Publish(topic, func(seq uint64) uint64 { return seq })
+	}
+}
+
+func drain(c <-chan uint64) []uint64 {
+	got := []uint64{}
+	for {
+		select {
+		case v, ok := <-c:
+			if !ok {
+				return got
+			}
+			got = append(got, v)
+		default:
+			return got
+		}
+	}
+}
+
+func TestBrokerReplay(t *testing.T) {
+	b := NewBroker[uint64](3, 10)
+	publish(b, "game", 5)
+
+	ptr := func(seq uint64) *uint64 { return &seq }
+
+	tests := []struct {
+		name        string
+		after       *uint64
+		want        []uint64
+		wantResumed bool
+	}{
+		{name: "no last seq", after: nil, want: []uint64{}},
+		{name: "caught up", after: ptr(5), want: []uint64{}, wantResumed: true},
+		{name: "within replay", after: ptr(3), want: []uint64{4, 5}, wantResumed: true},
+		{name: "oldest buffered", after: ptr(2), want: []uint64{3, 4, 5}, wantResumed: true},
+		{name: "older than replay", after: ptr(1), want: []uint64{3, 4, 5}},
+		{name: "ahead of topic", after: ptr(9), want: []uint64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, resumed := b.Subscribe("game", tt.after)
+			defer s.Unsubscribe()
+
+			if resumed != tt.wantResumed {
+				t.Errorf("got resumed %t, want %t", resumed, tt.wantResumed)
+			}
+			if got := drain(s.C); !slices.Equal(got, tt.want) {
+				t.Errorf("got replay %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBrokerSlowSubscriber(t *testing.T) {
+	b := NewBroker[uint64](10, 2)
+
+	slow, _ := b.Subscribe("game", nil)
+	fast, _ := b.Subscribe("game", nil)
+
+	publish(b, "game", 2)
+	if got := drain(fast.C); !slices.Equal(got, []uint64{1, 2}) {
+		t.Errorf("got %v, want [1 2]", got)
+	}
+
+	// slow's buffer is full so the next message drops it
+	publish(b, "game", 1)
+
+	if got := drain(slow.C); !slices.Equal(got, []uint64{1, 2}) {
+		t.Errorf("got %v from the slow subscriber, want the buffered [1 2]", got)
+	}
+	if _, ok := <-slow.C; ok {
+		t.Error("got the slow subscriber's channel open, want it closed")
+	}
+	if err := slow.Err(); !errors.Is(err, ErrSlowSubscriber) {
+		t.Errorf("got error %v, want ErrSlowSubscriber", err)
+	}
+
+	if got := drain(fast.C); !slices.Equal(got, []uint64{3}) {
+		t.Errorf("got %v, want [3]", got)
+	}
+	if err := fast.Err(); err != nil {
+		t.Errorf("got error %v for the fast subscriber, want nil", err)
+	}
+
+	// the dropped subscriber resumes from the last message it received
+	last := uint64(2)
+	resubscribed, resumed := b.Subscribe("game", &last)
+	defer resubscribed.Unsubscribe()
+	if !resumed {
+		t.Error("got not resumed, want resumed")
+	}
+	if got := drain(resubscribed.C); !slices.Equal(got, []uint64{3}) {
+		t.Errorf("got %v after resubscribing, want [3]", got)
+	}
+}
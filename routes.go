@@ -0,0 +1,116 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/drewthor/wolves_reddit_bot/internal/api_key"
+	"github.com/drewthor/wolves_reddit_bot/internal/boxscore"
+	"github.com/drewthor/wolves_reddit_bot/internal/broadcast"
+	"github.com/drewthor/wolves_reddit_bot/internal/chart"
+	"github.com/drewthor/wolves_reddit_bot/internal/franchise"
+	"github.com/drewthor/wolves_reddit_bot/internal/game"
+	"github.com/drewthor/wolves_reddit_bot/internal/game_analysis"
+	"github.com/drewthor/wolves_reddit_bot/internal/graph"
+	"github.com/drewthor/wolves_reddit_bot/internal/head_to_head"
+	"github.com/drewthor/wolves_reddit_bot/internal/history"
+	"github.com/drewthor/wolves_reddit_bot/internal/injury_report"
+	"github.com/drewthor/wolves_reddit_bot/internal/live"
+	"github.com/drewthor/wolves_reddit_bot/internal/milestone"
+	"github.com/drewthor/wolves_reddit_bot/internal/odds"
+	"github.com/drewthor/wolves_reddit_bot/internal/openapi"
+	"github.com/drewthor/wolves_reddit_bot/internal/player"
+	"github.com/drewthor/wolves_reddit_bot/internal/playoff"
+	"github.com/drewthor/wolves_reddit_bot/internal/referee"
+	"github.com/drewthor/wolves_reddit_bot/internal/response_cache"
+	"github.com/drewthor/wolves_reddit_bot/internal/schedule_context"
+	"github.com/drewthor/wolves_reddit_bot/internal/stat_correction"
+	"github.com/drewthor/wolves_reddit_bot/internal/team"
+	"github.com/drewthor/wolves_reddit_bot/internal/team_schedule"
+	"github.com/drewthor/wolves_reddit_bot/internal/webhook"
+	"github.com/drewthor/wolves_reddit_bot/internal/win_probability"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// services are what the route handlers are built with
+type services struct {
+	apiKey          api_key.Service
+	boxscore        boxscore.Service
+	broadcast       broadcast.Service
+	chart           chart.Service
+	franchise       franchise.Service
+	game            game.Service
+	gameAnalysis    game_analysis.Service
+	graph           graph.Service
+	headToHead      head_to_head.Service
+	history         history.Service
+	injuryReport    injury_report.Service
+	live            live.Service
+	milestone       milestone.Service
+	odds            odds.Service
+	player          player.Service
+	playoff         playoff.Service
+	referee         referee.Service
+	responseCache   response_cache.Service
+	scheduleContext schedule_context.Service
+	statCorrection  stat_correction.Service
+	team            team.Service
+	teamSchedule    team_schedule.Service
+	webhook         webhook.Service
+	winProbability  win_probability.Service
+}
+
+// mountRoutes mounts every route of the api, routes added here have to be added to the openapi spec too which
+// routes_test.go checks. Every route but the spec needs an api key with the read scope, routes that fetch from the nba or
+// change data need a higher scope
+func mountRoutes(r chi.Router, logger *slog.Logger, s services) {
+	r.Mount("/openapi.json", openapi.NewHandler(logger).Routes())
+
+	r.Group(func(r chi.Router) {
+		r.Use(api_key.Authenticate(logger, s.apiKey))
+		r.Use(api_key.RequireScope(api_key.ScopeRead))
+		mountAuthenticatedRoutes(r, logger, s)
+	})
+}
+
+// cache returns the response cache middleware for routes whose cacheable responses are read from the tables, it does
+// nothing when the optional response cache isn't configured
+func (s services) cache(tables ...string) func(http.Handler) http.Handler {
+	if s.responseCache == nil {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	return response_cache.Cache(s.responseCache, tables...)
+}
+
+func mountAuthenticatedRoutes(r chi.Router, logger *slog.Logger, s services) {
+	r.With(s.cache("game", "game_status", "season", "season_stage", "league", "team", "arena", "broadcaster", "game_broadcast")).Mount("/games", game.NewHandler(logger, s.game).Routes())
+	r.Mount("/graphql", graph.NewHandler(logger, s.graph).Routes())
+	r.With(s.cache("milestone", "game", "game_status", "season", "team", "player", "play_by_play", "player_team_game_stats_total", "team_game_stats_total")).Mount("/milestones", milestone.NewHandler(logger, s.milestone).Routes())
+	r.Mount("/games/{gameID}/broadcasts", broadcast.NewHandler(logger, s.broadcast).Routes())
+	r.Mount("/games/{gameID}/availability", injury_report.NewHandler(logger, s.injuryReport).Routes())
+	r.Mount("/games/{gameID}/odds", odds.NewHandler(logger, s.odds).Routes())
+	r.Mount("/games/{gameID}/charts", chart.NewHandler(logger, s.chart).Routes())
+	r.Mount("/games/{gameID}/live", live.NewHandler(logger, s.live).Routes())
+	r.Mount("/games/{gameID}/win-probability", win_probability.NewHandler(logger, s.winProbability).Routes())
+	historyHandler := history.NewHandler(logger, s.history)
+	r.Mount("/games/{gameID}/history", historyHandler.GameRoutes())
+	r.Mount("/players/{playerID}/history", historyHandler.PlayerRoutes())
+	r.Mount("/teams/{teamID}/history", historyHandler.TeamRoutes())
+	gameAnalysisHandler := game_analysis.NewHandler(logger, s.gameAnalysis)
+	r.Mount("/games/{gameID}/runs", gameAnalysisHandler.GameRoutes())
+	r.Mount("/players/{playerID}/clutch", gameAnalysisHandler.PlayerRoutes())
+	r.With(s.cache("player", "player_position", "position")).Mount("/players", player.NewHandler(logger, s.player).Routes())
+	r.With(s.cache("playoff_series", "game", "game_status", "season", "conference", "team_season")).Mount("/playoffs", playoff.NewHandler(logger, s.playoff).Routes())
+	r.With(s.cache("team", "franchise", "league", "conference", "division", "season")).Mount("/teams", team.NewHandler(logger, s.team).Routes())
+	r.Mount("/teams/{teamID}/schedule-context", schedule_context.NewHandler(logger, s.scheduleContext).Routes())
+	r.Mount("/teams/{teamID}/vs", head_to_head.NewHandler(logger, s.headToHead).Routes())
+	teamScheduleHandler := team_schedule.NewHandler(logger, s.teamSchedule)
+	r.Mount("/teams/{teamID}/games", teamScheduleHandler.Routes())
+	r.Mount("/teams/{teamID}/schedule.ics", teamScheduleHandler.CalendarRoutes())
+	r.Mount("/boxscores", boxscore.NewHandler(logger, s.boxscore).Routes())
+	r.With(s.cache("franchise", "team", "team_season", "season", "game", "game_status", "team_game_stats_total")).Mount("/franchises", franchise.NewHandler(logger, s.franchise).Routes())
+	r.With(s.cache("referee", "game_referee", "game", "season", "team", "play_by_play")).Mount("/referees", referee.NewHandler(logger, s.referee).Routes())
+	r.Mount("/stat-corrections", stat_correction.NewHandler(logger, s.statCorrection).Routes())
+	r.With(api_key.RequireScope(api_key.ScopeAdmin)).Mount("/webhooks", webhook.NewHandler(logger, s.webhook).Routes())
+}
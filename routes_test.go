@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/internal/api_key"
+	"github.com/drewthor/wolves_reddit_bot/internal/openapi"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestRoutesMatchOpenAPISpec(t *testing.T) {
+	r := chi.NewRouter()
+	mountRoutes(r, slog.New(slog.NewTextHandler(io.Discard, nil)), services{})
+
+	routes := []string{}
+	err := chi.Walk(r, func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		// mounted routers serve their root with a trailing slash
+		if route != "/" {
+			route = strings.TrimSuffix(route, "/")
+		}
+		routes = append(routes, method+" "+route)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk routes: %v", err)
+	}
+
+	operations := []string{}
+	for path, pathItem := range openapi.Spec().Paths {
+		for method := range pathItem.Operations() {
+			operations = append(operations, method+" "+path)
+		}
+	}
+
+	for _, route := range routes {
+		if !slices.Contains(operations, route) {
+			t.Errorf("route %s is missing from the openapi spec", route)
+		}
+	}
+	for _, operation := range operations {
+		if !slices.Contains(routes, operation) {
+			t.Errorf("openapi spec has %s but no route serves it", operation)
+		}
+	}
+}
+
+// scopedAPIKeyService authenticates keys as having the scopes after the key prefix ex. nba_read,ingest
+type scopedAPIKeyService struct {
+	api_key.Service
+}
+
+func (scopedAPIKeyService) Authenticate(ctx context.Context, key string) (api.APIKey, error) {
+	scopes, ok := strings.CutPrefix(key, "nba_")
+	if !ok {
+		return api.APIKey{}, api_key.ErrUnauthorized
+	}
+	return api.APIKey{ID: key, Scopes: strings.Split(scopes, ",")}, nil
+}
+
+func (scopedAPIKeyService) Allow(key api.APIKey) (bool, time.Duration) {
+	return true, 0
+}
+
+var pathParameterPattern = regexp.MustCompile(`\{[^}]+\}`)
+
+func TestRoutesRequireOpenAPISpecScopes(t *testing.T) {
+	r := chi.NewRouter()
+	mountRoutes(r, slog.New(slog.NewTextHandler(io.Discard, nil)), services{apiKey: scopedAPIKeyService{}})
+
+	for path, pathItem := range openapi.Spec().Paths {
+		for method, operation := range pathItem.Operations() {
+			if operation.Security != nil {
+				continue
+			}
+
+			scope := api_key.Scope(operation.RequiredScope)
+			scopeIndex := slices.Index(api_key.Scopes, scope)
+			if scopeIndex == -1 {
+				t.Errorf("%s %s requires unknown scope %s", method, path, scope)
+				continue
+			}
+
+			target := pathParameterPattern.ReplaceAllString(path, "x")
+
+			req := httptest.NewRequest(method, target, nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != http.StatusUnauthorized {
+				t.Errorf("%s %s without a key responded %d, expected %d", method, path, w.Code, http.StatusUnauthorized)
+			}
+
+			// a key with every scope below the required one isn't enough
+			req = httptest.NewRequest(method, target, nil)
+			lowerScopes := []string{}
+			for _, lowerScope := range api_key.Scopes[:scopeIndex] {
+				lowerScopes = append(lowerScopes, string(lowerScope))
+			}
+			req.Header.Set("Authorization", "Bearer nba_"+strings.Join(lowerScopes, ","))
+			w = httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != http.StatusForbidden {
+				t.Errorf("%s %s without the %s scope responded %d, expected %d", method, path, scope, w.Code, http.StatusForbidden)
+			}
+		}
+	}
+}
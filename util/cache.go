@@ -0,0 +1,136 @@
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	// CacheControlFinal is for responses of data that's done changing ex. games past the stat correction window
+	CacheControlFinal = "private, max-age=86400"
+	// CacheControlRevalidate is for responses of data that can change at any time, clients revalidate them every use
+	// which the ETag and Last-Modified validators make cheap
+	CacheControlRevalidate = "private, no-cache"
+)
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// WriteCacheableJSON writes obj like WriteJSON with ETag and Last-Modified validators, responding not modified when the
+// request's conditions match. The last modified time is the latest created_at or updated_at in obj
+func WriteCacheableJSON(w http.ResponseWriter, r *http.Request, obj any, cacheControl string) {
+	writeCacheableJSON(w, r, obj, cacheControl, LastModified(obj))
+}
+
+// WriteCacheableListJSON writes obj like WriteCacheableJSON with only an ETag validator. It's for lists and responses
+// aggregated from many rows, whose latest created_at or updated_at can't tell when a row was deleted
+func WriteCacheableListJSON(w http.ResponseWriter, r *http.Request, obj any, cacheControl string) {
+	writeCacheableJSON(w, r, obj, cacheControl, time.Time{})
+}
+
+func writeCacheableJSON(w http.ResponseWriter, r *http.Request, obj any, cacheControl string, lastModified time.Time) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	sum := sha256.Sum256(b)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Set("ETag", etag)
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if NotModified(r, etag, lastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(b)
+}
+
+// NotModified reports whether a GET or HEAD request's If-None-Match or If-Modified-Since matches a response's
+// validators, If-None-Match takes precedence when both are sent
+func NotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, tag := range strings.Split(ifNoneMatch, ",") {
+			tag = strings.TrimSpace(tag)
+			// a weak comparison since the response is the same either way
+			if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ifModifiedSince := r.Header.Get("If-Modified-Since"); ifModifiedSince != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(ifModifiedSince)
+		if err != nil {
+			return false
+		}
+		// the header only has second precision
+		return !lastModified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
+
+// LastModified returns the latest CreatedAt or UpdatedAt time of v and the values it holds, the columns every table has
+// maintained by the set_timestamp trigger
+func LastModified(v any) time.Time {
+	var latest time.Time
+
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		switch v.Kind() {
+		case reflect.Pointer, reflect.Interface:
+			if !v.IsNil() {
+				walk(v.Elem())
+			}
+		case reflect.Slice, reflect.Array:
+			for i := range v.Len() {
+				walk(v.Index(i))
+			}
+		case reflect.Map:
+			for _, key := range v.MapKeys() {
+				walk(v.MapIndex(key))
+			}
+		case reflect.Struct:
+			if v.Type() == timeType {
+				return
+			}
+			for _, field := range []string{"CreatedAt", "UpdatedAt"} {
+				f := v.FieldByName(field)
+				if f.Kind() == reflect.Pointer && !f.IsNil() {
+					f = f.Elem()
+				}
+				if f.IsValid() && f.Type() == timeType {
+					if t := f.Interface().(time.Time); t.After(latest) {
+						latest = t
+					}
+				}
+			}
+			for i := range v.NumField() {
+				if v.Type().Field(i).IsExported() {
+					walk(v.Field(i))
+				}
+			}
+		}
+	}
+	walk(reflect.ValueOf(v))
+
+	return latest
+}
@@ -2,4 +2,29 @@ package util
 
 import "errors"
 
-var ErrNotFound = errors.New("not found")
+// The kinds of errors the api responds with. Errors are classified by matching a kind with errors.Is so wrap one with
+// %w or create a domain error of the kind with NewError
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrValidation = errors.New("invalid input")
+	ErrConflict   = errors.New("conflict")
+)
+
+// Error is a domain error of a kind, it matches its kind with errors.Is without the kind being part of its message
+type Error struct {
+	kind    error
+	message string
+}
+
+// NewError creates a domain error of a kind ex. NewError(ErrValidation, "invalid season")
+func NewError(kind error, message string) *Error {
+	return &Error{kind: kind, message: message}
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+func (e *Error) Is(target error) bool {
+	return target == e.kind
+}
@@ -2,9 +2,21 @@ package util
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+
+	"github.com/drewthor/wolves_reddit_bot/api"
+	"github.com/drewthor/wolves_reddit_bot/apis/nba"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// ContentTypeProblemJSON is the content type of error responses
+const ContentTypeProblemJSON = "application/problem+json"
+
+// pgUniqueViolation is the postgres error code of inserting a row that conflicts with a unique constraint
+const pgUniqueViolation = "23505"
+
 func WriteJSON(statusCode int, obj interface{}, w http.ResponseWriter) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -15,3 +27,56 @@ func WriteJSON(statusCode int, obj interface{}, w http.ResponseWriter) {
 
 	w.Write(b)
 }
+
+// WriteProblem responds with a problem of the status, detail is shown to the caller so it shouldn't have internal details
+func WriteProblem(w http.ResponseWriter, r *http.Request, statusCode int, detail string) {
+	problem := api.Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(statusCode),
+		Status:   statusCode,
+		Detail:   detail,
+		Instance: r.URL.Path,
+	}
+	if spanContext := trace.SpanContextFromContext(r.Context()); spanContext.HasTraceID() {
+		problem.TraceID = spanContext.TraceID().String()
+	}
+
+	w.Header().Set("Content-Type", ContentTypeProblemJSON)
+	w.WriteHeader(statusCode)
+	b, err := json.Marshal(problem)
+	if err != nil {
+		return
+	}
+
+	w.Write(b)
+}
+
+// WriteError responds with a problem of the status of the error's kind. The messages of errors without a kind aren't
+// shown since they're internal, the trace id finds them. Stores' no rows errors are internal too, services translate
+// them into not found errors of the resource
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	var pgErr *pgconn.PgError
+	var domainErr *Error
+
+	switch {
+	case errors.Is(err, ErrNotFound):
+		// only the not found error's own message is shown since whatever wrapped it can have internal details
+		detail := "the resource doesn't exist"
+		if errors.As(err, &domainErr) && errors.Is(domainErr, ErrNotFound) {
+			detail = domainErr.Error()
+		}
+		WriteProblem(w, r, http.StatusNotFound, detail)
+	case errors.Is(err, nba.ErrNotFound):
+		WriteProblem(w, r, http.StatusNotFound, "the resource doesn't exist")
+	case errors.Is(err, ErrValidation):
+		WriteProblem(w, r, http.StatusBadRequest, err.Error())
+	case errors.Is(err, ErrConflict):
+		WriteProblem(w, r, http.StatusConflict, err.Error())
+	case errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation:
+		WriteProblem(w, r, http.StatusConflict, "the resource already exists")
+	case errors.Is(err, nba.ErrUnavailable):
+		WriteProblem(w, r, http.StatusServiceUnavailable, "the nba's api is unavailable, try again later")
+	default:
+		WriteProblem(w, r, http.StatusInternalServerError, "the request failed")
+	}
+}
@@ -0,0 +1,31 @@
+package util
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// IngestRun is a single ingest of nba data, every change it makes to stored rows is recorded in their history with the
+// run's id
+type IngestRun struct {
+	ID string
+	// SourceKey is the object key of the payload the data came from, the key prefix of the payloads for runs over more
+	// than one ex. a whole season or the endpoint for payloads that aren't cached
+	SourceKey string
+}
+
+type ingestRunContextKey struct{}
+
+func NewIngestRun(sourceKey string) IngestRun {
+	return IngestRun{ID: uuid.NewString(), SourceKey: sourceKey}
+}
+
+func WithIngestRun(ctx context.Context, run IngestRun) context.Context {
+	return context.WithValue(ctx, ingestRunContextKey{}, run)
+}
+
+func IngestRunFromContext(ctx context.Context) (IngestRun, bool) {
+	run, ok := ctx.Value(ingestRunContextKey{}).(IngestRun)
+	return run, ok
+}
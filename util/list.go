@@ -0,0 +1,135 @@
+package util
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NextCursorHeader is the response header list endpoints put the cursor of the next page in, it's left out on the last page
+const NextCursorHeader = "X-Next-Cursor"
+
+// DateLayout is the layout of date query params
+const DateLayout = "2006-01-02"
+
+var ErrInvalidListParam = NewError(ErrValidation, "invalid list param")
+
+// Sort is the field a list is ordered by, the sort query param is the field name prefixed with - for descending ex. -start-time
+type Sort struct {
+	Field      string
+	Descending bool
+}
+
+func (s Sort) String() string {
+	if s.Descending {
+		return "-" + s.Field
+	}
+	return s.Field
+}
+
+// Cursor is the position after the last item of a page. It holds the sort the page was listed with since the value is
+// only comparable to the same sort field, every sort field is a time so the value is an RFC 3339 time
+type Cursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeCursor(s string) (Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: cursor is not base64: %w", ErrInvalidListParam, err)
+	}
+
+	c := Cursor{}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return Cursor{}, fmt.Errorf("%w: malformed cursor: %w", ErrInvalidListParam, err)
+	}
+
+	if _, err := uuid.Parse(c.ID); err != nil {
+		return Cursor{}, fmt.Errorf("%w: malformed cursor id: %w", ErrInvalidListParam, err)
+	}
+
+	if _, err := time.Parse(time.RFC3339Nano, c.Value); err != nil {
+		return Cursor{}, fmt.Errorf("%w: malformed cursor value: %w", ErrInvalidListParam, err)
+	}
+
+	return c, nil
+}
+
+// ListParams are the sort, cursor and limit shared by every list endpoint
+type ListParams struct {
+	Sort   Sort
+	Cursor *Cursor
+	Limit  int
+}
+
+// ListParamsOptions are what a list endpoint allows, SortFields are the sort query param values without the direction
+type ListParamsOptions struct {
+	SortFields   []string
+	DefaultSort  Sort
+	DefaultLimit int
+	MaxLimit     int
+}
+
+// ParseListParams reads the sort, cursor and limit query params, returning ErrInvalidListParam when one isn't valid for
+// the endpoint
+func ParseListParams(r *http.Request, options ListParamsOptions) (ListParams, error) {
+	params := ListParams{Sort: options.DefaultSort, Limit: options.DefaultLimit}
+
+	if sortStr := r.URL.Query().Get("sort"); sortStr != "" {
+		sort := Sort{Field: strings.TrimPrefix(sortStr, "-"), Descending: strings.HasPrefix(sortStr, "-")}
+		if !slices.Contains(options.SortFields, sort.Field) {
+			return ListParams{}, fmt.Errorf("%w: sort must be one of %s optionally prefixed with -", ErrInvalidListParam, strings.Join(options.SortFields, ", "))
+		}
+		params.Sort = sort
+	}
+
+	if cursorStr := r.URL.Query().Get("cursor"); cursorStr != "" {
+		cursor, err := DecodeCursor(cursorStr)
+		if err != nil {
+			return ListParams{}, err
+		}
+		if cursor.Sort != params.Sort.String() {
+			return ListParams{}, fmt.Errorf("%w: cursor is for sort %s", ErrInvalidListParam, cursor.Sort)
+		}
+		params.Cursor = &cursor
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			return ListParams{}, fmt.Errorf("%w: limit must be a positive number", ErrInvalidListParam)
+		}
+		params.Limit = min(limit, options.MaxLimit)
+	}
+
+	return params, nil
+}
+
+// ParseDateParam reads a YYYY-MM-DD query param, ok is false when the param isn't set
+func ParseDateParam(r *http.Request, name string) (date time.Time, ok bool, err error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return time.Time{}, false, nil
+	}
+
+	date, err = time.Parse(DateLayout, value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%w: %s must be a YYYY-MM-DD date", ErrInvalidListParam, name)
+	}
+
+	return date, true, nil
+}
@@ -12,6 +12,9 @@ import (
 	"github.com/drewthor/wolves_reddit_bot/apis/nba"
 )
 
+// TimberwolvesNBATeamID is the nba team id of the team this bot follows
+const TimberwolvesNBATeamID = 1610612750
+
 type SeasonStage string
 
 const (
@@ -22,6 +25,16 @@ const (
 	SeasonStagePlayIn  SeasonStage = "playin"
 )
 
+// DataSource is where a stored game, team line or player line came from. Older seasons only exist in the stats.nba.com game
+// logs and traditional boxscores which have less detail than the cdn feeds
+type DataSource string
+
+const (
+	DataSourceCDN                      DataSource = "cdn"
+	DataSourceStatsLeagueGameLog       DataSource = "stats_league_game_log"
+	DataSourceStatsBoxscoreTraditional DataSource = "stats_boxscore_traditional"
+)
+
 func NBASeasonTypeToInternal(nbaSeasonType nba.SeasonType) SeasonStage {
 	switch nbaSeasonType {
 	case nba.SeasonTypePre:
@@ -3,3 +3,7 @@ package util
 const NBAR2Bucket = "nba"
 
 const ContentTypeJSON = "application/json"
+
+const ContentTypeSVG = "image/svg+xml"
+
+const ContentTypePNG = "image/png"
@@ -7,17 +7,17 @@ type RetryableLogger struct {
 }
 
 func (r RetryableLogger) Error(msg string, keysAndValues ...interface{}) {
-	r.l.Error(msg, keysAndValues)
+	r.l.Error(msg, keysAndValues...)
 }
 
 func (r RetryableLogger) Info(msg string, keysAndValues ...interface{}) {
-	r.l.Info(msg, keysAndValues)
+	r.l.Info(msg, keysAndValues...)
 }
 
 func (r RetryableLogger) Debug(msg string, keysAndValues ...interface{}) {
-	r.l.Debug(msg, keysAndValues)
+	r.l.Debug(msg, keysAndValues...)
 }
 
 func (r RetryableLogger) Warn(msg string, keysAndValues ...interface{}) {
-	r.l.Warn(msg, keysAndValues)
+	r.l.Warn(msg, keysAndValues...)
 }